@@ -2,6 +2,7 @@ package addons
 
 import (
 	"github.com/rocket-pool/smartnode/addons/graffiti_wall_writer"
+	"github.com/rocket-pool/smartnode/addons/manifest"
 	"github.com/rocket-pool/smartnode/addons/rescue_node"
 	"github.com/rocket-pool/smartnode/shared/types/addons"
 )
@@ -13,3 +14,7 @@ func NewGraffitiWallWriter() addons.SmartnodeAddon {
 func NewRescueNode() addons.SmartnodeAddon {
 	return rescue_node.NewRescueNode()
 }
+
+func NewUserAddons(path string) ([]*manifest.UserAddon, []error) {
+	return manifest.LoadUserAddons(path)
+}
@@ -0,0 +1,249 @@
+package manifest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/types/addons"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+const (
+	templateSuffix string = ".tmpl"
+
+	// The prefix of the containers and settings sections of user addons
+	containerPrefix string = "addon_"
+	sectionPrefix   string = "addons-"
+)
+
+// An addon described by a manifest in the user addons folder
+type UserAddon struct {
+	manifest *AddonManifest
+	path     string
+	cfg      *UserAddonConfig
+}
+
+// Configuration for a user addon
+type UserAddonConfig struct {
+	Title string `yaml:"-"`
+
+	Enabled cfgtypes.Parameter `yaml:"enabled,omitempty"`
+
+	// The settings from the manifest
+	Parameters []*cfgtypes.Parameter `yaml:"parameters,omitempty"`
+
+	// The Docker Hub tag
+	ContainerTag cfgtypes.Parameter `yaml:"containerTag,omitempty"`
+}
+
+// Create the addon described by a manifest
+func newUserAddon(manifest *AddonManifest, path string) (*UserAddon, error) {
+	addon := &UserAddon{
+		manifest: manifest,
+		path:     path,
+	}
+	containerID := addon.GetContainerID()
+	enabledContainers := []cfgtypes.ContainerID{containerID}
+	if len(manifest.ValidatorFlags) > 0 {
+		enabledContainers = append(enabledContainers, cfgtypes.ContainerID_Validator)
+	}
+
+	cfg := &UserAddonConfig{
+		Title: fmt.Sprintf("%s Settings", manifest.Name),
+
+		Enabled: cfgtypes.Parameter{
+			ID:                 enabledParameterID,
+			Name:               "Enabled",
+			Description:        fmt.Sprintf("Enable %s", manifest.Name),
+			Type:               cfgtypes.ParameterType_Bool,
+			Default:            map[cfgtypes.Network]interface{}{cfgtypes.Network_All: false},
+			AffectsContainers:  enabledContainers,
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		Parameters: []*cfgtypes.Parameter{},
+
+		ContainerTag: cfgtypes.Parameter{
+			ID:                 containerTagParameterID,
+			Name:               "Container Tag",
+			Description:        "The tag name of the container you want to use on Docker Hub.",
+			Type:               cfgtypes.ParameterType_String,
+			Default:            map[cfgtypes.Network]interface{}{cfgtypes.Network_All: manifest.ContainerTag},
+			AffectsContainers:  []cfgtypes.ContainerID{containerID},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: true,
+		},
+	}
+
+	// Convert the manifest's parameters
+	for _, paramManifest := range manifest.Parameters {
+		affectedContainers := []cfgtypes.ContainerID{containerID}
+		if paramManifest.AffectsValidator {
+			affectedContainers = append(affectedContainers, cfgtypes.ContainerID_Validator)
+		}
+		options := make([]cfgtypes.ParameterOption, len(paramManifest.Options))
+		for i, option := range paramManifest.Options {
+			options[i] = cfgtypes.ParameterOption{
+				Name:        option.Name,
+				Description: option.Description,
+				Value:       option.Value,
+			}
+		}
+		param := &cfgtypes.Parameter{
+			ID:                 paramManifest.ID,
+			Name:               paramManifest.Name,
+			Description:        paramManifest.Description,
+			Type:               paramManifest.Type,
+			MaxLength:          paramManifest.MaxLength,
+			Regex:              paramManifest.Regex,
+			Advanced:           paramManifest.Advanced,
+			Sensitive:          paramManifest.Sensitive,
+			AffectsContainers:  affectedContainers,
+			CanBeBlank:         paramManifest.CanBeBlank,
+			OverwriteOnUpgrade: false,
+			Options:            options,
+		}
+
+		// Parse the default the same way a value in the settings file would be
+		defaultValue := ""
+		if paramManifest.Default != nil {
+			defaultValue = fmt.Sprint(paramManifest.Default)
+		} else if paramManifest.Type == cfgtypes.ParameterType_Choice {
+			defaultValue = paramManifest.Options[0].Value
+		}
+		err := param.ValidateSerializedValue(defaultValue)
+		if err != nil {
+			return nil, fmt.Errorf("parameter [%s] has an invalid default: %w", param.ID, err)
+		}
+		param.Default = map[cfgtypes.Network]interface{}{cfgtypes.Network_All: ""}
+		err = param.Deserialize(map[string]string{param.ID: defaultValue}, cfgtypes.Network_All)
+		if err != nil {
+			return nil, fmt.Errorf("parameter [%s] has an invalid default: %w", param.ID, err)
+		}
+		param.Default[cfgtypes.Network_All] = param.Value
+		cfg.Parameters = append(cfg.Parameters, param)
+	}
+
+	addon.cfg = cfg
+	return addon, nil
+}
+
+// Create a copy of the addon with the same settings
+func (addon *UserAddon) CreateCopy() *UserAddon {
+	// The manifest was already checked when the addon was loaded, so this can't fail
+	newAddon, _ := newUserAddon(addon.manifest, addon.path)
+	newParams := newAddon.cfg.GetParameters()
+	for i, param := range addon.cfg.GetParameters() {
+		newParams[i].Value = param.Value
+	}
+	return newAddon
+}
+
+func (addon *UserAddon) GetName() string {
+	return addon.manifest.Name
+}
+
+func (addon *UserAddon) GetDescription() string {
+	description := addon.manifest.Description
+	if addon.manifest.Author != "" {
+		description = fmt.Sprintf("%s\n\nMade by %s.", description, addon.manifest.Author)
+	}
+	return fmt.Sprintf("%s\n\nThis is a third-party addon installed from %s; it is not maintained by the Rocket Pool team.", description, addon.path)
+}
+
+func (addon *UserAddon) GetConfig() cfgtypes.Config {
+	return addon.cfg
+}
+
+func (addon *UserAddon) GetContainerName() string {
+	return fmt.Sprint(addon.GetContainerID())
+}
+
+func (addon *UserAddon) GetEnabledParameter() *cfgtypes.Parameter {
+	return &addon.cfg.Enabled
+}
+
+// Get the container tag the user configured, falling back to the manifest's until the settings are loaded
+func (addon *UserAddon) GetContainerTag() string {
+	tag, ok := addon.cfg.ContainerTag.Value.(string)
+	if !ok || tag == "" {
+		return addon.manifest.ContainerTag
+	}
+	return tag
+}
+
+// Get the addon's ID from its manifest
+func (addon *UserAddon) GetID() string {
+	return addon.manifest.ID
+}
+
+// Get the ID of the addon's container
+func (addon *UserAddon) GetContainerID() cfgtypes.ContainerID {
+	return cfgtypes.ContainerID(containerPrefix + addon.manifest.ID)
+}
+
+// Get the name of the addon's section in the settings file
+func (addon *UserAddon) GetSectionName() string {
+	return sectionPrefix + addon.manifest.ID
+}
+
+// Get the folder the addon was loaded from, which holds its manifest and compose template
+func (addon *UserAddon) GetPath() string {
+	return addon.path
+}
+
+// Get the name of the addon's compose template in its folder, without the extension
+func (addon *UserAddon) GetComposeTemplateName() string {
+	return addon.manifest.ID
+}
+
+// Get the values of the addon's parameters by ID.
+// Used by text/template to format the addon's compose template.
+func (addon *UserAddon) Params() map[string]interface{} {
+	values := map[string]interface{}{}
+	for _, param := range addon.cfg.GetParameters() {
+		values[param.ID] = param.Value
+	}
+	return values
+}
+
+// Get the flags the addon adds to the Validator Client's command line for the provided Consensus Client
+func (addon *UserAddon) GetValidatorFlags(client cfgtypes.ConsensusClient) (string, error) {
+	if addon.cfg.Enabled.Value != true {
+		return "", nil
+	}
+
+	flags := []string{}
+	for _, key := range []string{AllClientsKey, string(client)} {
+		flagTemplate, exists := addon.manifest.ValidatorFlags[key]
+		if !exists {
+			continue
+		}
+		rendered, err := renderValidatorFlags(key, flagTemplate, addon.Params())
+		if err != nil {
+			return "", fmt.Errorf("error rendering validator flags of addon %s: %w", addon.manifest.ID, err)
+		}
+		rendered = strings.TrimSpace(rendered)
+		if rendered != "" {
+			flags = append(flags, rendered)
+		}
+	}
+	return strings.Join(flags, " "), nil
+}
+
+// Get the parameters for this config
+func (cfg *UserAddonConfig) GetParameters() []*cfgtypes.Parameter {
+	params := []*cfgtypes.Parameter{&cfg.Enabled}
+	params = append(params, cfg.Parameters...)
+	return append(params, &cfg.ContainerTag)
+}
+
+// The the title for the config
+func (cfg *UserAddonConfig) GetConfigTitle() string {
+	return cfg.Title
+}
+
+// Make sure the interfaces are implemented
+var _ addons.SmartnodeAddon = (*UserAddon)(nil)
+var _ addons.ValidatorFlagsProvider = (*UserAddon)(nil)
@@ -0,0 +1,226 @@
+package manifest
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+const (
+	// The folder in the Rocket Pool directory that user addons are installed into, one subfolder per addon
+	UserAddonsFolder string = "user-addons"
+
+	// The name of the file that describes an addon
+	ManifestFile string = "manifest.yml"
+
+	// The key of validator flags that apply to every Consensus Client
+	AllClientsKey string = "all"
+
+	// The IDs of the parameters every user addon has
+	enabledParameterID      string = "enabled"
+	containerTagParameterID string = "containerTag"
+)
+
+var (
+	// Addon IDs are used in container names and folder names, so they're kept simple
+	addonIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+	// Parameter IDs are used as keys in the settings file and in templates
+	parameterIDPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+	// The IDs of the built-in addons
+	reservedAddonIDs = map[string]bool{
+		"gww":         true,
+		"rescue-node": true,
+	}
+)
+
+// The manifest that describes a user addon
+type AddonManifest struct {
+	ID             string              `yaml:"id"`
+	Name           string              `yaml:"name"`
+	Description    string              `yaml:"description"`
+	Author         string              `yaml:"author,omitempty"`
+	Version        string              `yaml:"version,omitempty"`
+	ContainerTag   string              `yaml:"containerTag"`
+	Parameters     []ParameterManifest `yaml:"parameters,omitempty"`
+	ValidatorFlags map[string]string   `yaml:"validatorFlags,omitempty"`
+}
+
+// A setting of a user addon
+type ParameterManifest struct {
+	ID               string                 `yaml:"id"`
+	Name             string                 `yaml:"name"`
+	Description      string                 `yaml:"description"`
+	Type             cfgtypes.ParameterType `yaml:"type"`
+	Default          interface{}            `yaml:"default,omitempty"`
+	MaxLength        int                    `yaml:"maxLength,omitempty"`
+	Regex            string                 `yaml:"regex,omitempty"`
+	Advanced         bool                   `yaml:"advanced,omitempty"`
+	CanBeBlank       bool                   `yaml:"canBeBlank,omitempty"`
+	Sensitive        bool                   `yaml:"sensitive,omitempty"`
+	AffectsValidator bool                   `yaml:"affectsValidator,omitempty"`
+	Options          []OptionManifest       `yaml:"options,omitempty"`
+}
+
+// A single option of a choice parameter
+type OptionManifest struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Value       string `yaml:"value"`
+}
+
+// Load every user addon in the provided folder. Addons that can't be loaded are skipped, and the reasons are returned
+// alongside the ones that could.
+func LoadUserAddons(path string) ([]*UserAddon, []error) {
+	entries, err := os.ReadDir(path)
+	if os.IsNotExist(err) {
+		return []*UserAddon{}, nil
+	}
+	if err != nil {
+		return []*UserAddon{}, []error{fmt.Errorf("error reading user addons folder %s: %w", path, err)}
+	}
+
+	// Sort them by folder name so the order is the same every time
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	addons := []*UserAddon{}
+	errs := []error{}
+	ids := map[string]string{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		addonPath := filepath.Join(path, entry.Name())
+		addon, err := LoadUserAddon(addonPath)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if otherPath, exists := ids[addon.manifest.ID]; exists {
+			errs = append(errs, fmt.Errorf("user addon in %s has the same ID (%s) as the one in %s", addonPath, addon.manifest.ID, otherPath))
+			continue
+		}
+		ids[addon.manifest.ID] = addonPath
+		addons = append(addons, addon)
+	}
+	return addons, errs
+}
+
+// Load the user addon in the provided folder
+func LoadUserAddon(path string) (*UserAddon, error) {
+	manifestPath := filepath.Join(path, ManifestFile)
+	bytes, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading user addon manifest %s: %w", manifestPath, err)
+	}
+
+	manifest := &AddonManifest{}
+	err = yaml.UnmarshalStrict(bytes, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing user addon manifest %s: %w", manifestPath, err)
+	}
+	err = manifest.validate()
+	if err != nil {
+		return nil, fmt.Errorf("user addon manifest %s is invalid: %w", manifestPath, err)
+	}
+
+	// The compose template has to be next to the manifest
+	templatePath := filepath.Join(path, manifest.ID+templateSuffix)
+	_, err = os.Stat(templatePath)
+	if err != nil {
+		return nil, fmt.Errorf("user addon %s doesn't have a compose template at %s: %w", manifest.ID, templatePath, err)
+	}
+
+	return newUserAddon(manifest, path)
+}
+
+// Check that a manifest describes a usable addon
+func (m *AddonManifest) validate() error {
+	if !addonIDPattern.MatchString(m.ID) {
+		return fmt.Errorf("id [%s] must be 1 to 32 lowercase letters, numbers, or dashes, starting with a letter or number", m.ID)
+	}
+	if reservedAddonIDs[m.ID] {
+		return fmt.Errorf("id [%s] is used by a built-in addon", m.ID)
+	}
+	if m.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if m.ContainerTag == "" {
+		return fmt.Errorf("containerTag is required")
+	}
+
+	// Check the parameters
+	ids := map[string]bool{
+		enabledParameterID:      true,
+		containerTagParameterID: true,
+	}
+	for _, param := range m.Parameters {
+		if !parameterIDPattern.MatchString(param.ID) {
+			return fmt.Errorf("parameter id [%s] must start with a letter and only contain letters, numbers, or underscores", param.ID)
+		}
+		if ids[param.ID] {
+			return fmt.Errorf("parameter id [%s] is used more than once or is reserved", param.ID)
+		}
+		ids[param.ID] = true
+		if param.Name == "" {
+			return fmt.Errorf("parameter [%s] needs a name", param.ID)
+		}
+
+		switch param.Type {
+		case cfgtypes.ParameterType_Int, cfgtypes.ParameterType_Uint, cfgtypes.ParameterType_Uint16, cfgtypes.ParameterType_Float,
+			cfgtypes.ParameterType_Bool, cfgtypes.ParameterType_String:
+		case cfgtypes.ParameterType_Choice:
+			if len(param.Options) == 0 {
+				return fmt.Errorf("choice parameter [%s] needs at least one option", param.ID)
+			}
+		default:
+			return fmt.Errorf("parameter [%s] has an unknown type [%s]", param.ID, param.Type)
+		}
+		if param.Regex != "" {
+			_, err := regexp.Compile(param.Regex)
+			if err != nil {
+				return fmt.Errorf("parameter [%s] has an invalid regex: %w", param.ID, err)
+			}
+		}
+	}
+
+	// Check the validator flag templates
+	for key, flags := range m.ValidatorFlags {
+		switch cfgtypes.ConsensusClient(key) {
+		case cfgtypes.ConsensusClient_Lighthouse, cfgtypes.ConsensusClient_Lodestar, cfgtypes.ConsensusClient_Nimbus,
+			cfgtypes.ConsensusClient_Prysm, cfgtypes.ConsensusClient_Teku, cfgtypes.ConsensusClient(AllClientsKey):
+		default:
+			return fmt.Errorf("validatorFlags has an unknown Consensus Client [%s]", key)
+		}
+		_, err := template.New(key).Option("missingkey=error").Parse(flags)
+		if err != nil {
+			return fmt.Errorf("validatorFlags for [%s] is not a valid template: %w", key, err)
+		}
+	}
+	return nil
+}
+
+// Render a validator flag template with the addon's parameter values
+func renderValidatorFlags(key string, flags string, values map[string]interface{}) (string, error) {
+	tmpl, err := template.New(key).Option("missingkey=error").Parse(flags)
+	if err != nil {
+		return "", err
+	}
+	output := &bytes.Buffer{}
+	err = tmpl.Execute(output, values)
+	if err != nil {
+		return "", err
+	}
+	return output.String(), nil
+}
@@ -0,0 +1,249 @@
+package manifest
+
+import (
+	"strings"
+	"testing"
+
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Create a manifest that passes validation, for tests to break one piece at a time
+func newTestManifest() *AddonManifest {
+	return &AddonManifest{
+		ID:           "test-addon",
+		Name:         "Test Addon",
+		Description:  "An addon for tests",
+		ContainerTag: "example/test-addon:v1.0.0",
+		Parameters: []ParameterManifest{
+			{
+				ID:      "endpoint",
+				Name:    "Endpoint",
+				Type:    cfgtypes.ParameterType_String,
+				Default: "http://localhost:9000",
+			},
+			{
+				ID:      "port",
+				Name:    "Port",
+				Type:    cfgtypes.ParameterType_Uint16,
+				Default: 9000,
+			},
+			{
+				ID:   "mode",
+				Name: "Mode",
+				Type: cfgtypes.ParameterType_Choice,
+				Options: []OptionManifest{
+					{Name: "Fast", Value: "fast"},
+					{Name: "Safe", Value: "safe"},
+				},
+			},
+		},
+		ValidatorFlags: map[string]string{
+			AllClientsKey:                         "--addon-endpoint={{.endpoint}}",
+			string(cfgtypes.ConsensusClient_Teku): "--addon-port={{.port}} --addon-mode={{.mode}}",
+		},
+	}
+}
+
+func TestAddonManifestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(m *AddonManifest)
+		err    string
+	}{
+		{
+			name:   "valid manifest",
+			modify: func(m *AddonManifest) {},
+		},
+		{
+			name:   "uppercase id",
+			modify: func(m *AddonManifest) { m.ID = "Test-Addon" },
+			err:    "must be 1 to 32 lowercase letters",
+		},
+		{
+			name:   "id starting with a dash",
+			modify: func(m *AddonManifest) { m.ID = "-test" },
+			err:    "must be 1 to 32 lowercase letters",
+		},
+		{
+			name:   "id that is too long",
+			modify: func(m *AddonManifest) { m.ID = strings.Repeat("a", 33) },
+			err:    "must be 1 to 32 lowercase letters",
+		},
+		{
+			name:   "built-in addon id",
+			modify: func(m *AddonManifest) { m.ID = "rescue-node" },
+			err:    "used by a built-in addon",
+		},
+		{
+			name:   "missing name",
+			modify: func(m *AddonManifest) { m.Name = "" },
+			err:    "name is required",
+		},
+		{
+			name:   "missing container tag",
+			modify: func(m *AddonManifest) { m.ContainerTag = "" },
+			err:    "containerTag is required",
+		},
+		{
+			name:   "invalid parameter id",
+			modify: func(m *AddonManifest) { m.Parameters[0].ID = "1endpoint" },
+			err:    "must start with a letter",
+		},
+		{
+			name:   "duplicate parameter id",
+			modify: func(m *AddonManifest) { m.Parameters[1].ID = "endpoint" },
+			err:    "used more than once or is reserved",
+		},
+		{
+			name:   "reserved parameter id",
+			modify: func(m *AddonManifest) { m.Parameters[0].ID = containerTagParameterID },
+			err:    "used more than once or is reserved",
+		},
+		{
+			name:   "parameter without a name",
+			modify: func(m *AddonManifest) { m.Parameters[0].Name = "" },
+			err:    "needs a name",
+		},
+		{
+			name:   "choice parameter without options",
+			modify: func(m *AddonManifest) { m.Parameters[2].Options = nil },
+			err:    "needs at least one option",
+		},
+		{
+			name:   "unknown parameter type",
+			modify: func(m *AddonManifest) { m.Parameters[0].Type = "bytes" },
+			err:    "unknown type",
+		},
+		{
+			name:   "invalid parameter regex",
+			modify: func(m *AddonManifest) { m.Parameters[0].Regex = "^(http" },
+			err:    "invalid regex",
+		},
+		{
+			name:   "validator flags for an unknown client",
+			modify: func(m *AddonManifest) { m.ValidatorFlags["geth"] = "--flag" },
+			err:    "unknown Consensus Client",
+		},
+		{
+			name:   "validator flags that aren't a template",
+			modify: func(m *AddonManifest) { m.ValidatorFlags[AllClientsKey] = "--addon-endpoint={{.endpoint" },
+			err:    "not a valid template",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			manifest := newTestManifest()
+			test.modify(manifest)
+			err := manifest.validate()
+			if test.err == "" {
+				if err != nil {
+					t.Fatalf("expected the manifest to be valid, got %s", err.Error())
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected an error containing [%s]", test.err)
+			}
+			if !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("expected an error containing [%s], got [%s]", test.err, err.Error())
+			}
+		})
+	}
+}
+
+func TestGetValidatorFlags(t *testing.T) {
+	addon, err := newUserAddon(newTestManifest(), t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Disabled addons don't add any flags
+	flags, err := addon.GetValidatorFlags(cfgtypes.ConsensusClient_Teku)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if flags != "" {
+		t.Fatalf("expected no flags from a disabled addon, got [%s]", flags)
+	}
+
+	addon.cfg.Enabled.Value = true
+	tests := []struct {
+		name     string
+		client   cfgtypes.ConsensusClient
+		expected string
+	}{
+		{
+			name:     "only the flags for all clients",
+			client:   cfgtypes.ConsensusClient_Lighthouse,
+			expected: "--addon-endpoint=http://localhost:9000",
+		},
+		{
+			name:     "flags for all clients and the client's own",
+			client:   cfgtypes.ConsensusClient_Teku,
+			expected: "--addon-endpoint=http://localhost:9000 --addon-port=9000 --addon-mode=fast",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flags, err := addon.GetValidatorFlags(test.client)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if flags != test.expected {
+				t.Fatalf("expected [%s], got [%s]", test.expected, flags)
+			}
+		})
+	}
+
+	// Changed settings are used in the flags
+	for _, param := range addon.cfg.Parameters {
+		if param.ID == "port" {
+			param.Value = uint16(9100)
+		}
+	}
+	flags, err = addon.GetValidatorFlags(cfgtypes.ConsensusClient_Teku)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "--addon-endpoint=http://localhost:9000 --addon-port=9100 --addon-mode=fast"
+	if flags != expected {
+		t.Fatalf("expected [%s], got [%s]", expected, flags)
+	}
+
+	// Blank renders are dropped instead of leaving stray spaces
+	addon.manifest.ValidatorFlags[AllClientsKey] = "{{if eq .mode \"safe\"}}--addon-safe{{end}}"
+	flags, err = addon.GetValidatorFlags(cfgtypes.ConsensusClient_Teku)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "--addon-port=9100 --addon-mode=fast"
+	if flags != expected {
+		t.Fatalf("expected [%s], got [%s]", expected, flags)
+	}
+
+	// Templates that reference a missing parameter fail instead of rendering "<no value>"
+	addon.manifest.ValidatorFlags[AllClientsKey] = "--addon-missing={{.missing}}"
+	_, err = addon.GetValidatorFlags(cfgtypes.ConsensusClient_Teku)
+	if err == nil {
+		t.Fatalf("expected an error for a missing parameter")
+	}
+}
+
+func TestGetContainerTag(t *testing.T) {
+	addon, err := newUserAddon(newTestManifest(), t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The manifest's tag is used until the settings are loaded
+	if tag := addon.GetContainerTag(); tag != "example/test-addon:v1.0.0" {
+		t.Fatalf("expected the manifest's tag, got [%s]", tag)
+	}
+
+	// The user's setting overrides it
+	addon.cfg.ContainerTag.Value = "example/test-addon:v1.1.0"
+	if tag := addon.GetContainerTag(); tag != "example/test-addon:v1.1.0" {
+		t.Fatalf("expected the configured tag, got [%s]", tag)
+	}
+}
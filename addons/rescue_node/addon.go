@@ -23,8 +23,36 @@ const (
 
 	soloAuthValidity = 10 * time.Hour * 24
 	rpAuthValidity   = 15 * time.Hour * 24
+
+	// How long before a credential expires to start warning about it
+	CredentialExpiryWarning = 2 * time.Hour * 24
+
+	// The message signed by the node wallet to request a credential from rescuenode.com
+	credentialRequestMessageFormat string = "Rescue Node %d"
+	credentialRequestVersion       string = "1"
 )
 
+// A signed request for a Rescue Node credential, in the format rescuenode.com expects
+type CredentialRequest struct {
+	Address   common.Address `json:"address"`
+	Message   string         `json:"msg"`
+	Signature string         `json:"sig"`
+	Version   string         `json:"version"`
+}
+
+// The details of a Rescue Node credential
+type CredentialStatus struct {
+	NodeAddress common.Address
+	Solo        bool
+	Issued      time.Time
+	Expires     time.Time
+}
+
+// Get the time left until the credential expires; this is negative if it already has
+func (s *CredentialStatus) GetTimeLeft() time.Duration {
+	return time.Until(s.Expires)
+}
+
 type credentialDetails struct {
 	solo   bool
 	issued time.Time
@@ -82,21 +110,32 @@ func (r *RescueNode) getCredentialDetails() (*credentialDetails, error) {
 		panic("getCredentialDetails() should not be called without checking if RN plugin is enabled")
 	}
 
-	password := r.cfg.Password.Value.(string)
+	details, err := parseCredentialDetails(r.cfg.Password.Value.(string))
+	if err != nil {
+		return nil, fmt.Errorf("Rescue Node enabled, but %w", err)
+	}
+	return details, nil
+}
+
+// Parse the details of a credential out of its password
+func parseCredentialDetails(password string) (*credentialDetails, error) {
 	if password == "" {
-		return nil, fmt.Errorf("Rescue Node enabled, but no Username provided")
+		return nil, fmt.Errorf("no Password provided")
 	}
 
 	protoBytes, err := base64.URLEncoding.DecodeString(password)
 	if err != nil {
-		return nil, fmt.Errorf("Rescue Node enabled, but Password is not valid - error decoding base64: %w", err)
+		return nil, fmt.Errorf("Password is not valid - error decoding base64: %w", err)
 	}
 
 	// To avoid a dependency on Rescue Node code, we will parse the protobuf by hand.
 	msg := new(pb.AuthenticatedCredential)
 	err = proto.Unmarshal(protoBytes, msg)
 	if err != nil {
-		return nil, fmt.Errorf("Rescue Node enabled, but Password is not valid - error decoding proto: %w", err)
+		return nil, fmt.Errorf("Password is not valid - error decoding proto: %w", err)
+	}
+	if msg.Credential == nil {
+		return nil, fmt.Errorf("Password is not valid - it does not contain a credential")
 	}
 
 	return &credentialDetails{
@@ -110,20 +149,77 @@ func (r *RescueNode) getCredentialNodeId() (*common.Address, error) {
 		panic("getCredentialNodeId() should not be called without checking if RN plugin is enabled")
 	}
 
-	username := r.cfg.Username.Value.(string)
+	nodeId, err := parseCredentialNodeId(r.cfg.Username.Value.(string))
+	if err != nil {
+		return nil, fmt.Errorf("Rescue Node enabled, but %w", err)
+	}
+	return nodeId, nil
+}
+
+// Parse the node address a credential was issued to out of its username
+func parseCredentialNodeId(username string) (*common.Address, error) {
 	if username == "" {
-		return nil, fmt.Errorf("Rescue Node enabled, but no Username provided")
+		return nil, fmt.Errorf("no Username provided")
 	}
 
 	addr, err := base64.URLEncoding.DecodeString(username)
 	if err != nil {
-		return nil, fmt.Errorf("Rescue Node enabled, but Username is not valid - error decoding base64: %w", err)
+		return nil, fmt.Errorf("Username is not valid - error decoding base64: %w", err)
 	}
 
 	out := common.BytesToAddress(addr)
 	return &out, nil
 }
 
+// Get the details of the configured credential, whether or not the Rescue Node is enabled
+func (r *RescueNode) GetCredentialStatus() (*CredentialStatus, error) {
+	nodeAddress, err := parseCredentialNodeId(r.cfg.Username.Value.(string))
+	if err != nil {
+		return nil, err
+	}
+	details, err := parseCredentialDetails(r.cfg.Password.Value.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	validity := rpAuthValidity
+	if details.solo {
+		validity = soloAuthValidity
+	}
+	return &CredentialStatus{
+		NodeAddress: *nodeAddress,
+		Solo:        details.solo,
+		Issued:      details.issued,
+		Expires:     details.issued.Add(validity),
+	}, nil
+}
+
+// Set the username and password of the credential to use
+func (r *RescueNode) SetCredential(username string, password string) {
+	r.cfg.Username.Value = username
+	r.cfg.Password.Value = password
+}
+
+// Enable or disable the Rescue Node
+func (r *RescueNode) SetEnabled(enabled bool) {
+	r.cfg.Enabled.Value = enabled
+}
+
+// Get the message the node wallet signs to request a new credential
+func GetCredentialRequestMessage(timestamp time.Time) string {
+	return fmt.Sprintf(credentialRequestMessageFormat, timestamp.Unix())
+}
+
+// Create a credential request from a message signed by the node wallet
+func NewCredentialRequest(nodeAddress common.Address, message string, signature string) *CredentialRequest {
+	return &CredentialRequest{
+		Address:   nodeAddress,
+		Message:   message,
+		Signature: signature,
+		Version:   credentialRequestVersion,
+	}
+}
+
 func (r *RescueNode) PrintStatusText(nodeAddr common.Address) {
 	if !r.cfg.Enabled.Value.(bool) {
 		return
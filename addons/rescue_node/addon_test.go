@@ -0,0 +1,154 @@
+package rescue_node
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/rocket-pool/smartnode/addons/rescue_node/pb"
+)
+
+// Create a credential password the same way rescuenode.com encodes them
+func newTestPassword(t *testing.T, nodeAddress common.Address, issued time.Time, operatorType pb.OperatorType) string {
+	msg := &pb.AuthenticatedCredential{
+		Credential: &pb.Credential{
+			NodeId:       nodeAddress.Bytes(),
+			Timestamp:    issued.Unix(),
+			OperatorType: operatorType,
+		},
+		Mac: []byte("mac"),
+	}
+	bytes, err := proto.Marshal(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.URLEncoding.EncodeToString(bytes)
+}
+
+func TestGetCredentialStatus(t *testing.T) {
+	nodeAddress := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	username := base64.URLEncoding.EncodeToString(nodeAddress.Bytes())
+	issued := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+		solo     bool
+		expires  time.Time
+		err      bool
+	}{
+		{
+			name:     "rocket pool credential",
+			username: username,
+			password: newTestPassword(t, nodeAddress, issued, pb.OperatorType_OT_ROCKETPOOL),
+			expires:  issued.Add(rpAuthValidity),
+		},
+		{
+			name:     "solo credential",
+			username: username,
+			password: newTestPassword(t, nodeAddress, issued, pb.OperatorType_OT_SOLO),
+			solo:     true,
+			expires:  issued.Add(soloAuthValidity),
+		},
+		{
+			name:     "missing username",
+			password: newTestPassword(t, nodeAddress, issued, pb.OperatorType_OT_ROCKETPOOL),
+			err:      true,
+		},
+		{
+			name:     "username that isn't base64",
+			username: "not base64!",
+			password: newTestPassword(t, nodeAddress, issued, pb.OperatorType_OT_ROCKETPOOL),
+			err:      true,
+		},
+		{
+			name:     "missing password",
+			username: username,
+			err:      true,
+		},
+		{
+			name:     "password that isn't base64",
+			username: username,
+			password: "not base64!",
+			err:      true,
+		},
+		{
+			name:     "password that isn't a credential",
+			username: username,
+			password: base64.URLEncoding.EncodeToString([]byte{0xff, 0xff, 0xff}),
+			err:      true,
+		},
+		{
+			name:     "password without a credential",
+			username: username,
+			password: base64.URLEncoding.EncodeToString([]byte{}),
+			err:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rescueNode := NewRescueNode().(*RescueNode)
+			rescueNode.SetCredential(test.username, test.password)
+			status, err := rescueNode.GetCredentialStatus()
+			if test.err {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.NodeAddress != nodeAddress {
+				t.Fatalf("expected node address %s, got %s", nodeAddress.Hex(), status.NodeAddress.Hex())
+			}
+			if status.Solo != test.solo {
+				t.Fatalf("expected solo to be %t", test.solo)
+			}
+			if !status.Issued.Equal(issued) {
+				t.Fatalf("expected issue time %s, got %s", issued, status.Issued)
+			}
+			if !status.Expires.Equal(test.expires) {
+				t.Fatalf("expected expiry %s, got %s", test.expires, status.Expires)
+			}
+		})
+	}
+}
+
+func TestCredentialTimeLeft(t *testing.T) {
+	nodeAddress := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	username := base64.URLEncoding.EncodeToString(nodeAddress.Bytes())
+	rescueNode := NewRescueNode().(*RescueNode)
+
+	// A credential issued a day ago still has most of its validity left
+	rescueNode.SetCredential(username, newTestPassword(t, nodeAddress, time.Now().Add(-24*time.Hour), pb.OperatorType_OT_ROCKETPOOL))
+	status, err := rescueNode.GetCredentialStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	timeLeft := status.GetTimeLeft()
+	if timeLeft <= rpAuthValidity-25*time.Hour || timeLeft > rpAuthValidity-24*time.Hour {
+		t.Fatalf("expected about %s left, got %s", rpAuthValidity-24*time.Hour, timeLeft)
+	}
+	if timeLeft < CredentialExpiryWarning {
+		t.Fatalf("didn't expect a fresh credential to be within the expiry warning")
+	}
+
+	// Setting a new credential replaces the old one; this solo credential expired a day ago
+	rescueNode.SetCredential(username, newTestPassword(t, nodeAddress, time.Now().Add(-soloAuthValidity-24*time.Hour), pb.OperatorType_OT_SOLO))
+	status, err = rescueNode.GetCredentialStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Solo {
+		t.Fatalf("expected the new credential to be used")
+	}
+	if status.GetTimeLeft() >= 0 {
+		t.Fatalf("expected the credential to have expired, got %s left", status.GetTimeLeft())
+	}
+}
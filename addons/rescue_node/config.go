@@ -38,6 +38,7 @@ func NewConfig() *RescueNodeConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Validator},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 
 		Password: config.Parameter{
@@ -49,6 +50,7 @@ func NewConfig() *RescueNodeConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Validator},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 	}
 }
@@ -1,8 +1,12 @@
 package minipool
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/urfave/cli"
 
+	"github.com/rocket-pool/smartnode/shared/services/performance"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -38,6 +42,85 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "history",
+				Aliases:   []string{"hi"},
+				Usage:     "Show the full lifecycle of a minipool, from creation to close, with the block, time, transaction and gas cost of each step",
+				UsageText: "rocketpool minipool history [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "minipool, m",
+						Usage: "The minipool to show the history of (address, starting with 0x)",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if c.String("minipool") != "" {
+						if _, err := cliutils.ValidateAddress("minipool address", c.String("minipool")); err != nil {
+							return err
+						}
+					}
+
+					// Run
+					return getHistory(c)
+
+				},
+			},
+
+			{
+				Name:      "performance",
+				Aliases:   []string{"pf"},
+				Usage:     "Show the attestation, sync committee and block proposal performance of the node's validators, as tracked by the node daemon",
+				UsageText: "rocketpool minipool performance [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "window, w",
+						Usage: "The time window to summarize (1d, 7d or 30d)",
+						Value: "7d",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Validate flags
+					if _, exists := performance.WindowDurations[c.String("window")]; !exists {
+						return fmt.Errorf("Invalid window '%s' - valid options are %s", c.String("window"), strings.Join(performance.Windows, ", "))
+					}
+
+					// Run
+					return getPerformance(c)
+
+				},
+			},
+
+			{
+				Name:      "proposals",
+				Aliases:   []string{"pr"},
+				Usage:     "Show the blocks proposed by the node's validators, the rewards they paid and whether they used the correct fee recipient, as audited by the node daemon",
+				UsageText: "rocketpool minipool proposals",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getProposals(c)
+
+				},
+			},
+
 			{
 				Name:      "stake",
 				Aliases:   []string{"t"},
@@ -0,0 +1,97 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/hex"
+	"github.com/rocket-pool/smartnode/shared/utils/math"
+)
+
+func getHistory(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the minipool to show
+	var minipoolAddress common.Address
+	if c.String("minipool") != "" {
+		minipoolAddress = common.HexToAddress(c.String("minipool"))
+	} else {
+		// Get minipool statuses
+		status, err := rp.MinipoolStatus()
+		if err != nil {
+			return err
+		}
+		if len(status.Minipools) == 0 {
+			fmt.Println("The node does not have any minipools yet.")
+			return nil
+		}
+
+		// Prompt for minipool selection
+		options := make([]string, len(status.Minipools))
+		for mi, minipool := range status.Minipools {
+			options[mi] = fmt.Sprintf("%s (%s)", minipool.Address.Hex(), minipool.Status.Status.String())
+		}
+		selected, _ := cliutils.Select("Please select a minipool to show the history of:", options)
+		minipoolAddress = status.Minipools[selected].Address
+	}
+
+	// Get the history
+	fmt.Println("Searching the chain for the minipool's events, this may take a while...")
+	history, err := rp.MinipoolHistory(minipoolAddress)
+	if err != nil {
+		return err
+	}
+
+	// Print the summary
+	fmt.Printf("Minipool:         %s\n", history.Address.Hex())
+	fmt.Printf("Validator pubkey: %s\n", hex.AddPrefix(history.ValidatorPubkey.Hex()))
+	if history.ValidatorIndex != "" {
+		fmt.Printf("Validator index:  %s\n", history.ValidatorIndex)
+	} else {
+		fmt.Printf("Validator index:  not seen on the Beacon Chain yet\n")
+	}
+	if history.Finalised {
+		fmt.Printf("Current status:   %s (finalized)\n", history.CurrentStatus.String())
+	} else {
+		fmt.Printf("Current status:   %s\n", history.CurrentStatus.String())
+	}
+	fmt.Printf("Searched from:    block %d\n", history.StartBlock)
+	fmt.Println()
+
+	// Print the timeline
+	for _, event := range history.Events {
+		fmt.Printf("--------------------\n")
+		fmt.Printf("%s\n", event.Name)
+		fmt.Printf("    %s\n", event.Description)
+		fmt.Printf("    Time:     %s\n", event.Time.Format(TimeFormat))
+		if event.IsBeacon {
+			fmt.Printf("    Source:   Beacon Chain\n")
+			continue
+		}
+		if event.BlockNumber == 0 {
+			continue
+		}
+		fmt.Printf("    Block:    %d\n", event.BlockNumber)
+		fmt.Printf("    Tx:       %s\n", event.TxHash.Hex())
+		fmt.Printf("    Sent by:  %s\n", event.From.Hex())
+		if event.GasCost != nil {
+			fmt.Printf("    Gas cost: %.6f ETH (%d gas)\n", math.RoundDown(eth.WeiToEth(event.GasCost), 6), event.GasUsed)
+		}
+	}
+	fmt.Printf("--------------------\n\n")
+
+	fmt.Printf("Total gas cost of all transactions: %.6f ETH\n", math.RoundDown(eth.WeiToEth(history.TotalGasCost), 6))
+	return nil
+
+}
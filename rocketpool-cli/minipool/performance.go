@@ -0,0 +1,64 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func getPerformance(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the performance summary
+	window := c.String("window")
+	response, err := rp.MinipoolPerformance(window)
+	if err != nil {
+		return err
+	}
+
+	if !response.TrackingEnabled {
+		fmt.Println("Validator performance tracking is disabled. You can enable it in the Smartnode section of the `rocketpool service config` TUI.")
+		if len(response.Validators) == 0 {
+			return nil
+		}
+		fmt.Println("Showing the last performance that was recorded before it was disabled.")
+		fmt.Println()
+	}
+	if len(response.Validators) == 0 {
+		fmt.Println("No validator performance has been recorded yet. The node daemon tracks your validators once their epochs are finalized, so check back in a few minutes.")
+		return nil
+	}
+
+	fmt.Printf("Validator performance over the last %s (up to epoch %d):\n\n", window, response.LastProcessedEpoch)
+	for _, validator := range response.Validators {
+		counters := validator.Counters
+		fmt.Printf("--------------------\n\n")
+		fmt.Printf("Minipool:                %s\n", validator.MinipoolAddress.Hex())
+		fmt.Printf("Validator index:         %s\n", validator.ValidatorIndex)
+		fmt.Printf("Attestations included:   %d / %d (%.2f%%)\n", counters.AttestationsIncluded, counters.AttestationDuties, counters.GetInclusionRate()*100)
+		fmt.Printf("Correct head votes:      %d\n", counters.CorrectHeadVotes)
+		fmt.Printf("Correct target votes:    %d\n", counters.CorrectTargetVotes)
+		fmt.Printf("Avg. inclusion delay:    %.2f slots\n", counters.GetAverageInclusionDelay())
+		if counters.SyncCommitteeDuties > 0 {
+			fmt.Printf("Sync committee:          %d / %d signatures (%.2f%%)\n", counters.SyncCommitteeSignatures, counters.SyncCommitteeDuties, counters.GetSyncParticipationRate()*100)
+		}
+		if counters.ProposalDuties > 0 {
+			fmt.Printf("Block proposals:         %d proposed, %d missed\n", counters.ProposedBlocks, counters.GetMissedProposals())
+		}
+		if counters.EpochsWithoutProposerDuties > 0 {
+			fmt.Printf("%sNOTE: The proposer duties for %d epoch(s) weren't available from your Beacon Node, so any proposals missed in them aren't counted.%s\n", colorYellow, counters.EpochsWithoutProposerDuties, colorReset)
+		}
+		fmt.Println()
+	}
+
+	return nil
+
+}
@@ -0,0 +1,71 @@
+package minipool
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func getProposals(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the proposal audits
+	response, err := rp.MinipoolProposals()
+	if err != nil {
+		return err
+	}
+
+	if !response.AuditEnabled {
+		fmt.Println("Proposal auditing is disabled. You can enable it in the Smartnode section of the `rocketpool service config` TUI.")
+		if len(response.Proposals) == 0 {
+			return nil
+		}
+		fmt.Println("Showing the proposals that were audited before it was disabled.")
+		fmt.Println()
+	}
+	if len(response.Proposals) == 0 {
+		fmt.Println("None of your validators have proposed a block since auditing started.")
+		return nil
+	}
+
+	wrongCount := 0
+	for _, proposal := range response.Proposals {
+		if !proposal.IsCorrect {
+			wrongCount++
+		}
+	}
+
+	fmt.Printf("Blocks proposed by your validators (audited up to epoch %d):\n\n", response.LastProcessedEpoch)
+	for _, proposal := range response.Proposals {
+		fmt.Printf("--------------------\n\n")
+		fmt.Printf("Slot:                %d\n", proposal.Slot)
+		fmt.Printf("Execution block:     %d\n", proposal.ExecutionBlockNumber)
+		fmt.Printf("Time:                %s\n", proposal.Time.Local().Format(TimeFormat))
+		fmt.Printf("Minipool:            %s\n", proposal.MinipoolAddress.Hex())
+		fmt.Printf("Validator index:     %s\n", proposal.ValidatorIndex)
+		fmt.Printf("MEV-Boost:           %t\n", proposal.IsMevBoost)
+		fmt.Printf("Reward:              %.6f ETH\n", eth.WeiToEth(proposal.Reward))
+		fmt.Printf("Expected recipient:  %s\n", proposal.ExpectedRecipient.Hex())
+		if proposal.IsCorrect {
+			fmt.Printf("Actual recipient:    %s\n", proposal.ActualRecipient.Hex())
+		} else {
+			fmt.Printf("%sActual recipient:    %s (WRONG)%s\n", colorRed, proposal.ActualRecipient.Hex(), colorReset)
+		}
+		fmt.Println()
+	}
+
+	if wrongCount > 0 {
+		fmt.Printf("%s%d of these blocks paid an incorrect fee recipient and may result in a penalty for your node.%s\n", colorRed, wrongCount, colorReset)
+	}
+	return nil
+
+}
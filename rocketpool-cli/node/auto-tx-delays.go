@@ -0,0 +1,49 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func getAutoTxDelays(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the delays
+	response, err := rp.NodeAutoTxDelays()
+	if err != nil {
+		return err
+	}
+
+	if response.ThresholdGwei == 0 {
+		fmt.Printf("%sThe Automatic TX Gas Threshold is 0, so the node daemon won't submit automatic transactions.%s\n\n", colorYellow, colorReset)
+	}
+	if len(response.Delays) == 0 {
+		fmt.Println("No automatic transactions are currently being delayed.")
+		return nil
+	}
+
+	fmt.Printf("The node daemon is delaying %d automatic transaction(s) because of the gas price:\n\n", len(response.Delays))
+	for _, delay := range response.Delays {
+		fmt.Printf("--------------------\n\n")
+		fmt.Printf("Transaction:     %s (%s)\n", delay.Action, delay.Subject)
+		fmt.Printf("Delayed since:   %s (%d check(s))\n", delay.FirstDelayed.Local().Format(time.RFC1123), delay.DelayCount)
+		fmt.Printf("Last checked:    %s\n", delay.LastDelayed.Local().Format(time.RFC1123))
+		fmt.Printf("Reason:          %s\n", delay.Reason)
+		if !delay.Deadline.IsZero() {
+			fmt.Printf("Deadline:        %s\n", delay.Deadline.Local().Format(time.RFC1123))
+		}
+		fmt.Println()
+	}
+	return nil
+
+}
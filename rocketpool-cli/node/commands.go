@@ -696,6 +696,23 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "auto-tx-delays",
+				Usage:     "Show the automatic transactions the node daemon is holding back because of the gas price, and why",
+				UsageText: "rocketpool node auto-tx-delays",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getAutoTxDelays(c)
+
+				},
+			},
+
 			{
 				Name:      "distribute-fees",
 				Aliases:   []string{"b"},
@@ -136,3 +136,62 @@ func distribute(c *cli.Context) error {
 	return nil
 
 }
+
+// Distribute the node's fee distributor balance before joining or leaving the Smoothing Pool, so the balance collected under the previous status is settled first.
+// Returns false if the user cancelled.
+func distributeBeforeSmoothingPoolChange(c *cli.Context, rp *rocketpool.Client) (bool, error) {
+
+	// Nothing to do if the distributor hasn't been created yet
+	isInitializedResponse, err := rp.IsFeeDistributorInitialized()
+	if err != nil {
+		return false, err
+	}
+	if !isInitializedResponse.IsInitialized {
+		return true, nil
+	}
+
+	// Get the gas estimate
+	canDistributeResponse, err := rp.CanDistribute()
+	if err != nil {
+		return false, err
+	}
+	balance := eth.WeiToEth(canDistributeResponse.Balance)
+	if balance == 0 {
+		return true, nil
+	}
+
+	// Print info
+	rEthShare := balance - canDistributeResponse.NodeShare
+	fmt.Printf("Your fee distributor has a balance of %.6f ETH, which must be distributed before changing your Smoothing Pool status. It will be distributed as follows:\n", balance)
+	fmt.Printf("\tYour withdrawal address will receive %.6f ETH.\n", canDistributeResponse.NodeShare)
+	fmt.Printf("\trETH pool stakers will receive %.6f ETH.\n\n", rEthShare)
+
+	// Assign max fees
+	err = gas.AssignMaxFeeAndLimit(canDistributeResponse.GasInfo, rp, c.Bool("yes"))
+	if err != nil {
+		return false, err
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to distribute the ETH from your node's fee distributor?")) {
+		fmt.Println("Cancelled. Your Smoothing Pool status has NOT been changed, since your fee distributor's balance must be distributed first.")
+		return false, nil
+	}
+
+	// Distribute
+	response, err := rp.Distribute()
+	if err != nil {
+		return false, err
+	}
+
+	fmt.Printf("Distributing rewards...\n")
+	cliutils.PrintTransactionHash(rp, response.TxHash)
+	if _, err = rp.WaitForTransaction(response.TxHash); err != nil {
+		return false, err
+	}
+
+	fmt.Println("Successfully distributed your fee distributor's balance.")
+	fmt.Println()
+	return true, nil
+
+}
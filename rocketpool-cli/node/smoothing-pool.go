@@ -3,6 +3,7 @@ package node
 import (
 	"fmt"
 
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/urfave/cli"
 
 	"github.com/rocket-pool/smartnode/shared/services/gas"
@@ -35,6 +36,15 @@ func joinSmoothingPool(c *cli.Context) error {
 		return nil
 	}
 
+	// Distribute the fee distributor's balance first
+	distributed, err := distributeBeforeSmoothingPoolChange(c, rp)
+	if err != nil {
+		return err
+	}
+	if !distributed {
+		return nil
+	}
+
 	// Print some info
 	fmt.Println("You are about to opt into the Smoothing Pool.")
 	fmt.Println("Your fee recipient will be changed to the Smoothing Pool contract.")
@@ -48,6 +58,10 @@ func joinSmoothingPool(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if !canResponse.CanChange {
+		fmt.Printf("Your fee distributor still has a balance of %.6f ETH, which must be distributed with `rocketpool node distribute-fees` before changing your Smoothing Pool status.\n", eth.WeiToEth(canResponse.UndistributedBalance))
+		return nil
+	}
 
 	// Assign max fees
 	err = gas.AssignMaxFeeAndLimit(canResponse.GasInfo, rp, c.Bool("yes"))
@@ -106,6 +120,15 @@ func leaveSmoothingPool(c *cli.Context) error {
 		return nil
 	}
 
+	// Distribute the fee distributor's balance first
+	distributed, err := distributeBeforeSmoothingPoolChange(c, rp)
+	if err != nil {
+		return err
+	}
+	if !distributed {
+		return nil
+	}
+
 	// Print some info
 	fmt.Println("You are about to opt out of the Smoothing Pool.")
 	fmt.Println("Your fee recipient will be changed back to your node's distributor contract once the next Epoch has been finalized.")
@@ -119,6 +142,10 @@ func leaveSmoothingPool(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if !canResponse.CanChange {
+		fmt.Printf("Your fee distributor still has a balance of %.6f ETH, which must be distributed with `rocketpool node distribute-fees` before changing your Smoothing Pool status.\n", eth.WeiToEth(canResponse.UndistributedBalance))
+		return nil
+	}
 
 	// Assign max fees
 	err = gas.AssignMaxFeeAndLimit(canResponse.GasInfo, rp, c.Bool("yes"))
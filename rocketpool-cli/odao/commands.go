@@ -50,6 +50,31 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "duties",
+				Aliases:   []string{"du"},
+				Usage:     "Show whether the node submitted its oracle DAO duties and whether they matched consensus",
+				UsageText: "rocketpool odao duties [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "limit, l",
+						Usage: "The number of most recent rounds to show (0 for all of them)",
+						Value: 20,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getDuties(c)
+
+				},
+			},
+
 			{
 				Name:      "member-settings",
 				Aliases:   []string{"b"},
@@ -0,0 +1,159 @@
+package odao
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/duties"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+const (
+	colorReset  string = "\033[0m"
+	colorRed    string = "\033[31m"
+	colorGreen  string = "\033[32m"
+	colorYellow string = "\033[33m"
+)
+
+func getDuties(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the duty record
+	response, err := rp.TNDAODuties(c.Uint64("limit"))
+	if err != nil {
+		return err
+	}
+
+	// Print the summary
+	fmt.Printf("%s=== Summary ===%s\n", colorGreen, colorReset)
+	if !response.IsMember {
+		fmt.Printf("%sThe node is not a member of the oracle DAO, so it isn't performing any duties.%s\n", colorYellow, colorReset)
+	}
+	if response.LastUpdateTime.IsZero() {
+		fmt.Println("The watchtower hasn't recorded any oracle DAO duties yet.")
+		return nil
+	}
+	fmt.Printf("Last updated by the watchtower at %s.\n\n", response.LastUpdateTime.Format(time.RFC822))
+	for _, summary := range response.Summaries {
+		fmt.Printf("%s:\n", getDutyName(summary.Type))
+		fmt.Printf("\tRounds:           %d (%d submitted)\n", summary.Rounds, summary.Submitted)
+		if summary.LastSubmissionTime.IsZero() {
+			fmt.Println("\tLast submission:  never")
+		} else {
+			fmt.Printf("\tLast submission:  %s\n", summary.LastSubmissionTime.Format(time.RFC822))
+		}
+		fmt.Printf("\tFailed attempts:  %d\n", summary.Failures)
+		for _, result := range duties.DutyResults {
+			if summary.Results[result] > 0 {
+				fmt.Printf("\t%-17s %s%d%s\n", string(result)+":", getResultColor(result), summary.Results[result], colorReset)
+			}
+		}
+	}
+	fmt.Println()
+
+	// Print the rounds
+	fmt.Printf("%s=== Rounds ===%s\n", colorGreen, colorReset)
+	if len(response.Rounds) == 0 {
+		fmt.Println("No rounds have been recorded yet.")
+	}
+	for _, round := range response.Rounds {
+		result := round.Result
+		if !round.Resolved {
+			result = duties.DutyResult_Pending
+		}
+		fmt.Printf("%s %s: %s%s%s\n", getDutyName(round.Type), getRoundName(round), getResultColor(result), result, colorReset)
+		if round.Submitted && !round.SubmissionTime.IsZero() {
+			fmt.Printf("\tSubmitted at %s (transaction %s)\n", round.SubmissionTime.Format(time.RFC822), round.SubmissionTx.Hex())
+		} else if round.Submitted {
+			fmt.Println("\tSubmitted, but the submission wasn't recorded locally")
+		} else {
+			fmt.Println("\tNot submitted")
+		}
+		printDutyValues("Submitted", round.SubmittedValues)
+		if round.Resolved && round.ConsensusReached {
+			printDutyValues("Consensus", round.ConsensusValues)
+		}
+		if round.Failures > 0 {
+			fmt.Printf("\t%sFailed attempts: %d, latest at %s: %s%s\n", colorYellow, round.Failures, round.LastErrorTime.Format(time.RFC822), round.LastError, colorReset)
+		}
+	}
+	return nil
+
+}
+
+// Get the display name of a duty
+func getDutyName(dutyType duties.DutyType) string {
+	switch dutyType {
+	case duties.DutyType_Balances:
+		return "Network balances"
+	case duties.DutyType_Prices:
+		return "RPL price"
+	case duties.DutyType_RewardsTree:
+		return "Rewards tree"
+	case duties.DutyType_Scrub:
+		return "Scrub check"
+	default:
+		return string(dutyType)
+	}
+}
+
+// Get the display name of a round
+func getRoundName(round duties.DutyRound) string {
+	switch round.Type {
+	case duties.DutyType_Balances, duties.DutyType_Prices:
+		return fmt.Sprintf("for block %s", round.Round)
+	case duties.DutyType_RewardsTree:
+		return fmt.Sprintf("for interval %s", round.Round)
+	case duties.DutyType_Scrub:
+		return fmt.Sprintf("for minipool %s", round.Round)
+	default:
+		return round.Round
+	}
+}
+
+// Get the color to print a result in
+func getResultColor(result duties.DutyResult) string {
+	switch result {
+	case duties.DutyResult_Matched:
+		return colorGreen
+	case duties.DutyResult_Mismatched, duties.DutyResult_Missed:
+		return colorRed
+	default:
+		return colorYellow
+	}
+}
+
+// Print the values of a round, converting wei amounts to ETH
+func printDutyValues(label string, values map[string]string) {
+	if len(values) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Printf("\t%s:\n", label)
+	for _, key := range keys {
+		value := values[key]
+		switch key {
+		case duties.BalancesValue_TotalEth, duties.BalancesValue_StakingEth, duties.BalancesValue_RethSupply, duties.PricesValue_RplPrice:
+			if amount, ok := big.NewInt(0).SetString(value, 10); ok {
+				value = fmt.Sprintf("%.6f", eth.WeiToEth(amount))
+			}
+		}
+		fmt.Printf("\t\t%-15s %s\n", key+":", value)
+	}
+}
@@ -0,0 +1,134 @@
+package pdao
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func getChallenges(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the challenge record
+	response, err := rp.PDAOChallenges()
+	if err != nil {
+		return err
+	}
+	summary := response.Summary
+
+	// Print the summary
+	fmt.Printf("%s=== Summary ===%s\n", colorGreen, colorReset)
+	if response.LastUpdateTime.IsZero() {
+		fmt.Println("The node daemon hasn't recorded any Protocol DAO challenge activity yet.")
+	} else {
+		fmt.Printf("Last updated by the node daemon at %s.\n", response.LastUpdateTime.Format(time.RFC822))
+	}
+	if !response.VerifyEnabled {
+		fmt.Printf("%sProposal verification is disabled, so your node isn't checking or challenging other nodes' proposals.%s\n", colorYellow, colorReset)
+	}
+	fmt.Printf("Proposals monitored:    %d\n", summary.MonitoredProposals)
+	fmt.Printf("Root submissions seen:  %d\n", summary.RootSubmissions)
+	fmt.Printf("Mismatched proposals:   %d\n", summary.MismatchedProposals)
+	fmt.Printf("Challenges issued:      %d\n", summary.ChallengesIssued)
+	fmt.Printf("Challenges received:    %d (%d answered)\n", summary.ChallengesReceived, summary.ChallengesAnswered)
+	if summary.ChallengesUnanswered > 0 {
+		fmt.Printf("%sUnanswered challenges:  %d%s\n", colorYellow, summary.ChallengesUnanswered, colorReset)
+	}
+	fmt.Printf("Proposals defeated:     %d\n", summary.ProposalsDefeated)
+	printBondAtRisk("Proposal bonds at risk:", eth.WeiToEth(summary.ProposalBondsAtRisk))
+	printBondAtRisk("Challenge bonds at risk:", eth.WeiToEth(summary.ChallengeBondsAtRisk))
+	fmt.Println()
+
+	// Print the claimable bonds
+	fmt.Printf("%s=== Claimable Bonds ===%s\n", colorGreen, colorReset)
+	if len(response.ClaimableBonds) == 0 {
+		fmt.Println("There are no bonds to claim.")
+	} else {
+		for _, bond := range response.ClaimableBonds {
+			fmt.Printf("Proposal %d (proposer: %t): %.6f RPL to unlock, %.6f RPL in rewards\n", bond.ProposalID, bond.IsProposer, eth.WeiToEth(bond.UnlockAmount), eth.WeiToEth(bond.RewardAmount))
+		}
+		fmt.Printf("Use %srocketpool pdao proposals claim-bonds%s to claim them.\n", colorBlue, colorReset)
+	}
+	fmt.Println()
+
+	// Print the monitored proposals
+	fmt.Printf("%s=== Proposals ===%s\n", colorGreen, colorReset)
+	if len(response.Proposals) == 0 {
+		fmt.Println("No proposals have been recorded yet.")
+	}
+	for _, prop := range response.Proposals {
+		if prop.IsOwn {
+			fmt.Printf("Proposal %d (yours): %s\n", prop.ID, types.ProtocolDaoProposalStates[prop.State])
+		} else {
+			fmt.Printf("Proposal %d (by %s): %s\n", prop.ID, prop.Proposer.Hex(), types.ProtocolDaoProposalStates[prop.State])
+		}
+		fmt.Printf("\tChallenge window ends: %s\n", prop.ChallengeWindowEnd.Format(time.RFC822))
+		if prop.RootChecked {
+			if prop.RootMatchesLocalTree {
+				fmt.Println("\tRoot:                  matches your voting tree")
+			} else {
+				fmt.Printf("\tRoot:                  %sdoesn't match your voting tree%s\n", colorYellow, colorReset)
+			}
+		}
+		fmt.Printf("\tRoot submissions seen: %d\n", len(prop.RootSubmissions))
+		if prop.DefeatIndex != 0 {
+			fmt.Printf("\tDefeated by your node at index %d (%s)\n", prop.DefeatIndex, prop.DefeatTx.Hex())
+		}
+
+		indices := make([]uint64, 0, len(prop.Challenges))
+		for index := range prop.Challenges {
+			indices = append(indices, index)
+		}
+		sort.Slice(indices, func(i, j int) bool {
+			return indices[i] < indices[j]
+		})
+		for _, index := range indices {
+			printRecordedChallenge(prop.Challenges[index])
+		}
+		fmt.Println()
+	}
+	return nil
+
+}
+
+// Print an amount of bonded RPL, highlighting it if it's non-zero
+func printBondAtRisk(label string, amount float64) {
+	if amount > 0 {
+		fmt.Printf("%-23s %s%.6f RPL%s\n", label, colorYellow, amount, colorReset)
+		return
+	}
+	fmt.Printf("%-23s %.6f RPL\n", label, amount)
+}
+
+// Print a single challenge from the record
+func printRecordedChallenge(challenge *proposals.RecordedChallenge) {
+	challenger := challenge.Challenger.Hex()
+	if challenge.IsOwn {
+		challenger = "your node"
+	}
+	stateName := proposals.GetChallengeStateName(challenge.State)
+	if challenge.State == types.ChallengeState_Challenged && !challenge.IsOwn {
+		stateName = fmt.Sprintf("%s%s%s", colorYellow, stateName, colorReset)
+	}
+	fmt.Printf("\tChallenge at index %d by %s: %s\n", challenge.Index, challenger, stateName)
+	if challenge.ChallengeTx != (common.Hash{}) {
+		fmt.Printf("\t\tChallenge TX: %s\n", challenge.ChallengeTx.Hex())
+	}
+	if !challenge.ResponseTime.IsZero() {
+		fmt.Printf("\t\tAnswered at %s (%s)\n", challenge.ResponseTime.Format(time.RFC822), challenge.ResponseTx.Hex())
+	}
+}
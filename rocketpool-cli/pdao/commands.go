@@ -158,6 +158,36 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "delegates",
+				Aliases:   []string{"dl"},
+				Usage:     "List the top voting delegates by voting power, along with how many nodes delegate to them and how often they vote on proposals",
+				UsageText: "rocketpool pdao delegates [--limit count] [--history count]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "limit, l",
+						Usage: "The number of delegates to show (0 for all of them)",
+						Value: 10,
+					},
+					cli.UintFlag{
+						Name:  "history",
+						Usage: "The number of recent proposals to show each delegate's votes for",
+						Value: 5,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getDelegates(c)
+
+				},
+			},
+
 			{
 				Name:      "claim-bonds",
 				Aliases:   []string{"cb"},
@@ -186,6 +216,193 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:    "vote-policy",
+				Aliases: []string{"vp"},
+				Usage:   "Manage the local voting policy the node uses to vote on proposals automatically",
+				Subcommands: []cli.Command{
+					{
+						Name:      "status",
+						Aliases:   []string{"s"},
+						Usage:     "Show the voting policy and the decisions it has made on active proposals",
+						UsageText: "rocketpool pdao vote-policy status",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return getVotePolicyStatus(c)
+
+						},
+					},
+
+					{
+						Name:      "confirm",
+						Aliases:   []string{"c"},
+						Usage:     "Confirm the current voting policy file so the node stops running it in dry-run mode and starts casting votes",
+						UsageText: "rocketpool pdao vote-policy confirm [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm all interactive questions",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return confirmVotePolicy(c)
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:      "digest",
+				Aliases:   []string{"dg"},
+				Usage:     "Show a digest of the governance activity relevant to your node, including voting deadlines and whether your node or its delegate has voted",
+				UsageText: "rocketpool pdao digest",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getDigest(c)
+
+				},
+			},
+
+			{
+				Name:      "challenges",
+				Aliases:   []string{"ch"},
+				Usage:     "Show the root submissions and challenges your node has seen, issued, or answered, along with any bonds at risk or ready to claim",
+				UsageText: "rocketpool pdao challenges",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return getChallenges(c)
+
+				},
+			},
+
+			{
+				Name:    "voting-artifacts",
+				Aliases: []string{"va"},
+				Usage:   "Share the voting info snapshots and trees used for proposals with other nodes",
+				Subcommands: []cli.Command{
+					{
+						Name:      "export",
+						Aliases:   []string{"e"},
+						Usage:     "Export the voting artifacts for a block or proposal to a file",
+						UsageText: "rocketpool pdao voting-artifacts export [--block number | --proposal id] [--out path]",
+						Flags: []cli.Flag{
+							cli.UintFlag{
+								Name:  "block, b",
+								Usage: "The block to export the voting artifacts for",
+							},
+							cli.Uint64Flag{
+								Name:  "proposal, p",
+								Usage: "The proposal whose target block to export the voting artifacts for",
+							},
+							cli.StringFlag{
+								Name:  "out, o",
+								Usage: "A path to copy the exported file to",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return exportVotingArtifacts(c)
+
+						},
+					},
+					{
+						Name:      "import",
+						Aliases:   []string{"i"},
+						Usage:     "Verify the voting artifacts in a file against a trusted root and import them",
+						UsageText: "rocketpool pdao voting-artifacts import file [--root hash]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "root, r",
+								Usage: "The network tree root hash to verify the file against; if omitted, the roots of on-chain proposals for the same block that made it through their challenge phase are used",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run
+							return importVotingArtifacts(c, c.Args().Get(0))
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:      "simulate",
+				Aliases:   []string{"sim"},
+				Usage:     "Simulate what a proposal, or a draft setting change, would do to the network before voting on it",
+				UsageText: "rocketpool pdao simulate [--proposal id | --contract name --setting path --value raw-value] [options]",
+				Flags: []cli.Flag{
+					cli.Uint64Flag{
+						Name:  "proposal, p",
+						Usage: "The ID of the proposal to simulate",
+					},
+					cli.StringFlag{
+						Name:  "contract, c",
+						Usage: "The settings contract of a draft setting change (e.g. rocketDAOProtocolSettingsNode)",
+					},
+					cli.StringFlag{
+						Name:  "setting, s",
+						Usage: "The path of the setting to change in a draft setting change (e.g. node.per.minipool.stake.minimum)",
+					},
+					cli.StringFlag{
+						Name:  "value, v",
+						Usage: "The raw on-chain value of a draft setting change; percentages are fractions of 1 ETH in wei (e.g. 100000000000000000 for 10%)",
+					},
+					cli.BoolFlag{
+						Name:  "all, a",
+						Usage: "Show every affected node instead of only the most affected ones",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return simulateProposal(c)
+
+				},
+			},
+
 			{
 				Name:    "propose",
 				Aliases: []string{"p"},
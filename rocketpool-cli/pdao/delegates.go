@@ -0,0 +1,101 @@
+package pdao
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func getDelegates(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the delegates
+	fmt.Println("Building a voting info snapshot of the network, this may take a moment...")
+	response, err := rp.PDAODelegates(c.Uint64("limit"))
+	if err != nil {
+		return err
+	}
+	insights := response.Insights
+	fmt.Println()
+
+	// Print the summary
+	fmt.Printf("%s=== Summary ===%s\n", colorGreen, colorReset)
+	fmt.Printf("Snapshot block:           %d\n", insights.BlockNumber)
+	fmt.Printf("Nodes:                    %d\n", insights.NodeCount)
+	fmt.Printf("Total voting power:       %.6f\n", eth.WeiToEth(insights.TotalVotingPower))
+	fmt.Printf("Delegates:                %d\n", insights.DelegateCount)
+	fmt.Printf("Self-delegated nodes:     %d\n", insights.SelfDelegated)
+	fmt.Printf("Proposals put to a vote:  %d\n", insights.EligibleProposals)
+	fmt.Println()
+
+	// Print the delegates
+	fmt.Printf("%s=== Delegates ===%s\n", colorGreen, colorReset)
+	if len(insights.Delegates) == 0 {
+		fmt.Println("No node has delegated its voting power to another address yet.")
+	}
+	history := int(c.Uint("history"))
+	for _, delegate := range insights.Delegates {
+		printDelegate(delegate, insights.TotalVotingPower, response.NodeDelegate, history)
+	}
+
+	fmt.Printf("Use %srocketpool pdao set-voting-delegate%s to change your node's delegate.\n", colorBlue, colorReset)
+	return nil
+
+}
+
+// Print a single delegate's insights
+func printDelegate(delegate *proposals.DelegateInsight, totalVotingPower *big.Int, nodeDelegate common.Address, history int) {
+	if delegate.Rank > 0 {
+		fmt.Printf("#%d: %s", delegate.Rank, delegate.Address.Hex())
+	} else {
+		fmt.Printf("Unranked: %s", delegate.Address.Hex())
+	}
+	if delegate.Address == nodeDelegate {
+		fmt.Printf(" %s(your delegate)%s", colorYellow, colorReset)
+	}
+	fmt.Println()
+
+	// Voting power
+	share := float64(0)
+	if totalVotingPower.Sign() > 0 {
+		share, _ = new(big.Float).Quo(new(big.Float).SetInt(delegate.VotingPower), new(big.Float).SetInt(totalVotingPower)).Float64()
+	}
+	fmt.Printf("\tVoting power:  %.6f (%.2f%% of the network)\n", eth.WeiToEth(delegate.VotingPower), share*100)
+	if delegate.SelfDelegated {
+		fmt.Printf("\tOwn power:     %.6f\n", eth.WeiToEth(delegate.OwnVotingPower))
+	}
+	fmt.Printf("\tDelegators:    %d nodes\n", delegate.Delegators)
+
+	// Participation
+	if delegate.EligibleProposals == 0 {
+		fmt.Println("\tParticipation: no proposals have been put to a vote yet")
+	} else {
+		participation := fmt.Sprintf("%d of %d proposals (%.2f%%)", delegate.ProposalsVoted, delegate.EligibleProposals, delegate.ParticipationRate*100)
+		if delegate.ProposalsVoted < delegate.EligibleProposals/2 {
+			participation = fmt.Sprintf("%s%s%s", colorYellow, participation, colorReset)
+		}
+		fmt.Printf("\tParticipation: %s\n", participation)
+	}
+
+	// Recent votes
+	if history > len(delegate.Votes) {
+		history = len(delegate.Votes)
+	}
+	for _, vote := range delegate.Votes[:history] {
+		fmt.Printf("\t\tProposal %d (%s): %s\n", vote.ProposalID, types.ProtocolDaoProposalStates[vote.State], types.VoteDirections[vote.Direction])
+	}
+	fmt.Println()
+}
@@ -0,0 +1,120 @@
+package pdao
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func getDigest(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the digest
+	response, err := rp.PDAODigest()
+	if err != nil {
+		return err
+	}
+	digest := response.Digest
+
+	// Print the changes since the last notification
+	fmt.Printf("%s=== Updates ===%s\n", colorGreen, colorReset)
+	if response.LastSentTime.IsZero() {
+		fmt.Println("The node hasn't sent a governance digest yet.")
+	} else {
+		fmt.Printf("The last digest was sent at %s.\n", response.LastSentTime.Format(time.RFC822))
+	}
+	if len(response.Events) == 0 {
+		fmt.Println("Nothing has changed since then.")
+	} else {
+		for _, event := range response.Events {
+			fmt.Printf("- %s\n", event.Description)
+		}
+	}
+	fmt.Println()
+
+	// Print the pDAO proposals
+	fmt.Printf("%s=== Protocol DAO Proposals ===%s\n", colorGreen, colorReset)
+	fmt.Printf("On-chain voting delegate: %s\n", digest.OnchainDelegate.Hex())
+	openCount := 0
+	for _, prop := range digest.PdaoProposals {
+		if !prop.IsOpen() {
+			continue
+		}
+		openCount++
+		fmt.Printf("Proposal %d: %s\n", prop.ID, prop.Message)
+		fmt.Printf("\tState:     %s (until %s)\n", types.ProtocolDaoProposalStates[prop.State], prop.PhaseEnd.Format(time.RFC822))
+		if prop.State == types.ProtocolDaoProposalState_ActivePhase1 || prop.State == types.ProtocolDaoProposalState_ActivePhase2 {
+			fmt.Printf("\tNode vote: %s\n", types.VoteDirections[prop.NodeVote])
+			if prop.Delegate != digest.NodeAddress {
+				fmt.Printf("\tDelegate:  %s (%s)\n", prop.Delegate.Hex(), types.VoteDirections[prop.DelegateVote])
+			}
+			if !prop.IsRepresented() {
+				fmt.Printf("\t%sNeither your node nor its delegate has voted on this proposal yet.%s\n", colorYellow, colorReset)
+			}
+		}
+		for _, challenge := range prop.Challenges {
+			fmt.Printf("\tChallenge: index %d by %s (%s)\n", challenge.Index, challenge.Challenger.Hex(), proposals.GetChallengeStateName(challenge.State))
+		}
+		fmt.Println()
+	}
+	if openCount == 0 {
+		fmt.Println("There are no open Protocol DAO proposals.")
+		fmt.Println()
+	}
+
+	// Print the oDAO proposals
+	if digest.IsOdaoMember {
+		fmt.Printf("%s=== Oracle DAO Proposals ===%s\n", colorGreen, colorReset)
+		openCount = 0
+		for _, prop := range digest.OdaoProposals {
+			if !prop.IsOpen() {
+				continue
+			}
+			openCount++
+			fmt.Printf("Proposal %d: %s\n", prop.ID, prop.Message)
+			fmt.Printf("\tState:  %s (voting ends %s)\n", prop.State.String(), prop.EndTime.Format(time.RFC822))
+			if prop.MemberVoted {
+				fmt.Printf("\tVoted:  yes (in favor: %t)\n\n", prop.MemberSupported)
+			} else {
+				fmt.Printf("\tVoted:  %sno%s\n\n", colorYellow, colorReset)
+			}
+		}
+		if openCount == 0 {
+			fmt.Println("There are no open Oracle DAO proposals.")
+			fmt.Println()
+		}
+	}
+
+	// Print the Snapshot proposals
+	fmt.Printf("%s=== Snapshot Proposals ===%s\n", colorGreen, colorReset)
+	if digest.SnapshotError != "" {
+		fmt.Printf("%sCouldn't get Snapshot proposals: %s%s\n", colorYellow, digest.SnapshotError, colorReset)
+	}
+	if len(digest.SnapshotProposals) == 0 {
+		fmt.Println("There are no active Snapshot proposals.")
+	}
+	for _, prop := range digest.SnapshotProposals {
+		fmt.Printf("%s\n", prop.Title)
+		fmt.Printf("\tEnds:   %s\n", prop.End.Format(time.RFC822))
+		fmt.Printf("\tLink:   %s\n", prop.Link)
+		if prop.Voted {
+			fmt.Println("\tVoted:  yes")
+		} else {
+			fmt.Printf("\tVoted:  %sno%s\n", colorYellow, colorReset)
+		}
+		fmt.Println()
+	}
+	return nil
+
+}
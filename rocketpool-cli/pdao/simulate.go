@@ -0,0 +1,152 @@
+package pdao
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The number of affected nodes to show unless all of them are requested
+const simulationNodeLimit int = 20
+
+func simulateProposal(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Check the arguments
+	proposalID := c.Uint64("proposal")
+	contractName := c.String("contract")
+	settingName := c.String("setting")
+	value := c.String("value")
+	isDraft := contractName != "" || settingName != "" || value != ""
+	if proposalID == 0 && !isDraft {
+		return fmt.Errorf("Please specify either --proposal or the --contract, --setting, and --value of a draft setting change.")
+	}
+	if proposalID != 0 && isDraft {
+		return fmt.Errorf("--proposal can't be combined with a draft setting change.")
+	}
+	if isDraft && (contractName == "" || settingName == "" || value == "") {
+		return fmt.Errorf("A draft setting change needs --contract, --setting, and --value.")
+	}
+
+	// Run the simulation
+	fmt.Println("Building the current network state and simulating the proposal; this may take a few minutes...")
+	var response api.PDAOSimulateProposalResponse
+	if isDraft {
+		response, err = rp.PDAOSimulateSetting(contractName, settingName, value)
+	} else {
+		response, err = rp.PDAOSimulateProposal(proposalID)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+
+	// Print the proposal
+	sim := response.Simulation
+	fmt.Printf("%s=== Proposal ===%s\n", colorGreen, colorReset)
+	if isDraft {
+		fmt.Printf("Draft: %s\n", response.Message)
+	} else {
+		fmt.Printf("Proposal %d: %s (%s)\n", response.ProposalID, response.Message, types.ProtocolDaoProposalStates[response.State])
+		if response.Method != "" {
+			fmt.Printf("Payload:    %s\n", response.Method)
+		}
+	}
+	for _, change := range sim.SettingChanges {
+		fmt.Printf("Setting:    %s: %s = %s\n", change.Contract, change.Path, change.GetValueString())
+	}
+	if sim.RewardsPercentages != nil {
+		fmt.Printf("Rewards:    node operators %.2f%%, Oracle DAO %.2f%%, Protocol DAO %.2f%%\n", eth.WeiToEth(sim.RewardsPercentages.NodePercent)*100, eth.WeiToEth(sim.RewardsPercentages.OdaoPercent)*100, eth.WeiToEth(sim.RewardsPercentages.PdaoPercent)*100)
+	}
+	if len(sim.SettingChanges) == 0 && sim.RewardsPercentages == nil {
+		fmt.Printf("%sThis proposal doesn't change any settings or rewards percentages, so it has no simulated effects.%s\n", colorYellow, colorReset)
+		return nil
+	}
+	for _, setting := range sim.UnsupportedSettings {
+		fmt.Printf("%sThe effects of %s aren't simulated.%s\n", colorYellow, setting, colorReset)
+	}
+	fmt.Println()
+
+	// Print the network-wide changes
+	fmt.Printf("%s=== Network ===%s\n", colorGreen, colorReset)
+	printSimulatedPercent("Minimum RPL collateral", sim.Before.MinCollateralFraction, sim.After.MinCollateralFraction)
+	printSimulatedPercent("Maximum RPL collateral", sim.Before.MaxCollateralFraction, sim.After.MaxCollateralFraction)
+	printSimulatedFloat("Minimum node fee", sim.Before.NodeFeeBounds.Minimum*100, sim.After.NodeFeeBounds.Minimum*100, "%")
+	printSimulatedFloat("Target node fee", sim.Before.NodeFeeBounds.Target*100, sim.After.NodeFeeBounds.Target*100, "%")
+	printSimulatedFloat("Maximum node fee", sim.Before.NodeFeeBounds.Maximum*100, sim.After.NodeFeeBounds.Maximum*100, "%")
+	printSimulatedFloat("Current node fee", sim.Before.NodeFee*100, sim.After.NodeFee*100, "%")
+	printSimulatedPercent("Node operator RPL rewards", sim.Before.NodeOperatorRewardsPercent, sim.After.NodeOperatorRewardsPercent)
+	printSimulatedPercent("Oracle DAO RPL rewards", sim.Before.TrustedNodeOperatorRewardsPercent, sim.After.TrustedNodeOperatorRewardsPercent)
+	printSimulatedPercent("Protocol DAO RPL rewards", sim.Before.ProtocolDaoRewardsPercent, sim.After.ProtocolDaoRewardsPercent)
+	printSimulatedFloat("Total effective RPL stake", eth.WeiToEth(sim.Before.TotalEffectiveStake), eth.WeiToEth(sim.After.TotalEffectiveStake), " RPL")
+	printSimulatedFloat("Nodes below minimum collateral", float64(sim.Before.NodesBelowMinimum), float64(sim.After.NodesBelowMinimum), "")
+	printSimulatedFloat("Nodes above maximum collateral", float64(sim.Before.NodesAboveMaximum), float64(sim.After.NodesAboveMaximum), "")
+	fmt.Println()
+
+	// Print the local node
+	fmt.Printf("%s=== Your Node ===%s\n", colorGreen, colorReset)
+	if sim.LocalNode == nil {
+		fmt.Println("Your node isn't registered, so it wouldn't be affected.")
+	} else if !sim.LocalNode.IsAffected() {
+		fmt.Println("Your node wouldn't be affected.")
+	} else {
+		printSimulatedNode(sim.LocalNode)
+	}
+	fmt.Println()
+
+	// Print the most affected nodes
+	fmt.Printf("%s=== Affected Nodes ===%s\n", colorGreen, colorReset)
+	fmt.Printf("%d of %d nodes would be affected.\n", len(sim.AffectedNodes), sim.NodeCount)
+	limit := len(sim.AffectedNodes)
+	if !c.Bool("all") && limit > simulationNodeLimit {
+		limit = simulationNodeLimit
+		fmt.Printf("Showing the %d nodes with the largest change to their estimated rewards (use --all to show every node).\n", limit)
+	}
+	fmt.Println()
+	for i := 0; i < limit; i++ {
+		printSimulatedNode(&sim.AffectedNodes[i])
+		fmt.Println()
+	}
+
+	fmt.Println("Estimated rewards are this interval's node operator RPL rewards split by effective stake, before participation scaling.")
+	return nil
+
+}
+
+// Print a percentage that's stored as a fraction of 1 ETH
+func printSimulatedPercent(label string, before *big.Int, after *big.Int) {
+	printSimulatedFloat(label, eth.WeiToEth(before)*100, eth.WeiToEth(after)*100, "%")
+}
+
+// Print a value before and after the simulation, highlighting it if it changed
+func printSimulatedFloat(label string, before float64, after float64, unit string) {
+	if before == after {
+		fmt.Printf("%-32s %.4f%s\n", label+":", before, unit)
+		return
+	}
+	fmt.Printf("%-32s %.4f%s -> %s%.4f%s%s\n", label+":", before, unit, colorYellow, after, unit, colorReset)
+}
+
+// Print how a single node would be affected
+func printSimulatedNode(node *proposals.SimulatedNodeChange) {
+	fmt.Printf("Node %s\n", node.NodeAddress.Hex())
+	fmt.Printf("\tRPL stake:         %.4f RPL\n", eth.WeiToEth(node.RplStake))
+	fmt.Printf("\tBorrowed ETH:      %.4f ETH\n", eth.WeiToEth(node.BorrowedEth))
+	fmt.Printf("\tCollateral ratio:  %.2f%%\n", node.CollateralRatio*100)
+	fmt.Printf("\tCollateral status: %s -> %s\n", node.Before.CollateralStatus, node.After.CollateralStatus)
+	fmt.Printf("\tEffective stake:   %.4f -> %.4f RPL\n", eth.WeiToEth(node.Before.EffectiveStake), eth.WeiToEth(node.After.EffectiveStake))
+	fmt.Printf("\tEstimated rewards: %.4f -> %.4f RPL\n", eth.WeiToEth(node.Before.EstimatedRplRewards), eth.WeiToEth(node.After.EstimatedRplRewards))
+}
@@ -21,6 +21,7 @@ const (
 	colorBlue             string = "\033[36m"
 	colorReset            string = "\033[0m"
 	colorGreen            string = "\033[32m"
+	colorYellow           string = "\033[33m"
 	signallingAddressLink string = "https://docs.rocketpool.net/guides/houston/participate#setting-your-snapshot-signalling-address"
 	challengeLink         string = "https://docs.rocketpool.net/guides/houston/pdao#challenge-process"
 )
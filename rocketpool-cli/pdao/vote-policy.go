@@ -0,0 +1,150 @@
+package pdao
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+func getVotePolicyStatus(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the status
+	response, err := rp.PDAOVotePolicyStatus()
+	if err != nil {
+		return err
+	}
+	if !printVotePolicy(response) {
+		return nil
+	}
+
+	// Print the decisions
+	fmt.Printf("%s=== Decisions ===%s\n", colorGreen, colorReset)
+	if len(response.Decisions) == 0 {
+		fmt.Println("The voting policy hasn't evaluated any proposals yet.")
+		return nil
+	}
+	for _, decision := range response.Decisions {
+		printVotePolicyDecision(decision)
+	}
+	return nil
+
+}
+
+func confirmVotePolicy(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the status
+	response, err := rp.PDAOVotePolicyStatus()
+	if err != nil {
+		return err
+	}
+	if !printVotePolicy(response) {
+		return nil
+	}
+	if response.IsConfirmed {
+		fmt.Println("This version of the voting policy has already been confirmed.")
+		return nil
+	}
+
+	// Show what the dry run would have done
+	fmt.Printf("%s=== Dry-Run Decisions ===%s\n", colorGreen, colorReset)
+	dryRunCount := 0
+	for _, decision := range response.Decisions {
+		if decision.Status == proposals.VotePolicyStatus_DryRun && decision.PolicyHash == response.PolicyHash {
+			printVotePolicyDecision(decision)
+			dryRunCount++
+		}
+	}
+	if dryRunCount == 0 {
+		fmt.Println("The node hasn't recorded any dry-run votes for the current policy yet. You may want to wait until it has evaluated an active proposal before confirming.")
+		fmt.Println()
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Are you sure you want to confirm policy %s? The node will start casting votes on your behalf according to its rules, and votes cannot be changed once they're cast.", response.PolicyHash))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Confirm
+	confirmResponse, err := rp.PDAOConfirmVotePolicy(response.PolicyHash)
+	if err != nil {
+		return err
+	}
+	if !confirmResponse.HashMatch {
+		fmt.Println("The voting policy file changed while it was being reviewed. Please run this command again to review the new version.")
+		return nil
+	}
+
+	fmt.Println("The voting policy has been confirmed. The node will cast votes with it from now on; any change to the policy file will put it back into dry-run mode until it's confirmed again.")
+	return nil
+
+}
+
+// Print the voting policy's summary, returning true if it was loaded successfully
+func printVotePolicy(response api.PDAOVotePolicyStatusResponse) bool {
+	fmt.Printf("%s=== Voting Policy ===%s\n", colorGreen, colorReset)
+	if !response.Enabled {
+		fmt.Printf("%sThe voting policy is disabled. Enable it in the Smartnode section of `rocketpool service config`.%s\n", colorYellow, colorReset)
+	}
+	if !response.PolicyExists {
+		fmt.Printf("No voting policy file was found at %s.\n", response.PolicyPath)
+		return false
+	}
+	if response.PolicyError != "" {
+		fmt.Printf("%sThe voting policy file couldn't be loaded: %s%s\n", colorYellow, response.PolicyError, colorReset)
+		return false
+	}
+
+	fmt.Printf("Policy file: %s\n", response.PolicyPath)
+	fmt.Printf("Policy hash: %s\n", response.PolicyHash)
+	fmt.Printf("Rules:       %d\n", response.RuleCount)
+	fmt.Printf("Lead time:   %s\n", response.LeadTime)
+	if response.ForcedDryRun {
+		fmt.Println("Mode:        dry-run (set in the policy file)")
+	} else if response.IsConfirmed && response.AutoTxDisabled {
+		fmt.Println("Mode:        dry-run (automatic transactions are disabled because the automatic tx gas threshold is 0)")
+	} else if response.IsConfirmed {
+		fmt.Println("Mode:        live")
+	} else {
+		fmt.Println("Mode:        dry-run (not confirmed yet)")
+	}
+	fmt.Println()
+	return true
+}
+
+// Print a single voting policy decision
+func printVotePolicyDecision(decision proposals.VotePolicyDecision) {
+	fmt.Printf("Proposal %d (%s, %s):\n", decision.ProposalID, types.ProtocolDaoProposalStates[decision.State], decision.Type)
+	if decision.Rule != "" {
+		fmt.Printf("\tRule:     %s (%s)\n", decision.Rule, decision.Action)
+	}
+	fmt.Printf("\tStatus:   %s\n", decision.Status)
+	fmt.Printf("\tReason:   %s\n", decision.Reason)
+	fmt.Printf("\tDeadline: %s\n", decision.Deadline.Format(time.RFC822))
+	if decision.TxHash != (common.Hash{}) {
+		fmt.Printf("\tTX:       %s\n", decision.TxHash.Hex())
+	}
+	fmt.Printf("\tUpdated:  %s\n\n", decision.UpdatedTime.Format(time.RFC822))
+}
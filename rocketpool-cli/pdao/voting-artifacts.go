@@ -0,0 +1,137 @@
+package pdao
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+)
+
+func exportVotingArtifacts(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+
+	// Get the block to export
+	blockNumber := uint32(c.Uint("block"))
+	proposalID := c.Uint64("proposal")
+	if (blockNumber == 0) == (proposalID == 0) {
+		return fmt.Errorf("Please specify either --block or --proposal.")
+	}
+	if proposalID != 0 {
+		response, err := rp.PDAOProposalDetails(proposalID)
+		if err != nil {
+			return err
+		}
+		blockNumber = response.Proposal.TargetBlock
+	}
+
+	// Export the artifacts
+	fmt.Printf("Exporting the voting artifacts for block %d; if they haven't been generated yet, this may take a few minutes...\n", blockNumber)
+	response, err := rp.PDAOExportVotingArtifacts(blockNumber)
+	if err != nil {
+		return err
+	}
+	exportPath := cfg.Smartnode.GetVotingArtifactsExportPath(blockNumber, false)
+
+	// Copy it to the requested location
+	outPath := c.String("out")
+	if outPath != "" {
+		err = copyVotingArtifacts(exportPath, outPath)
+		if err != nil {
+			return err
+		}
+		exportPath = outPath
+	}
+
+	fmt.Printf("%sExported the voting artifacts for block %d to %s.%s\n", colorGreen, blockNumber, exportPath, colorReset)
+	fmt.Printf("Nodes:        %d\n", response.NodeCount)
+	fmt.Printf("Root hash:    %s\n", response.NetworkRoot.Hash.Hex())
+	fmt.Printf("Voting power: %.6f\n", eth.WeiToEth(response.NetworkRoot.Sum))
+	fmt.Println("Share the root hash along with the file so the recipient can verify it.")
+	return nil
+
+}
+
+func importVotingArtifacts(c *cli.Context, path string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get config
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("Error loading configuration: %w", err)
+	}
+
+	// Check the root
+	rootHash := c.String("root")
+	if rootHash != "" {
+		hashBytes := common.FromHex(rootHash)
+		if len(hashBytes) != common.HashLength {
+			return fmt.Errorf("Invalid root hash '%s'.", rootHash)
+		}
+	} else {
+		fmt.Printf("%sNo root was provided, so the bundle will be verified against the roots of proposals for its block that made it through their challenge phase.%s\n", colorYellow, colorReset)
+	}
+
+	// Copy the bundle where the node daemon can see it
+	err = copyVotingArtifacts(path, cfg.Smartnode.GetVotingArtifactsImportPath(false))
+	if err != nil {
+		return err
+	}
+
+	// Import it
+	fmt.Println("Rebuilding and verifying the network tree from the bundle...")
+	response, err := rp.PDAOImportVotingArtifacts(rootHash)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%sImported the voting artifacts for block %d.%s\n", colorGreen, response.BlockNumber, colorReset)
+	fmt.Printf("Nodes:     %d\n", response.NodeCount)
+	fmt.Printf("Root hash: %s\n", response.NetworkRoot.Hash.Hex())
+	if len(response.VerifiedProposals) > 0 {
+		fmt.Printf("Verified against the on-chain root of proposals %v.\n", response.VerifiedProposals)
+	} else {
+		fmt.Println("Verified against the provided root.")
+	}
+	return nil
+
+}
+
+// Copy a voting artifact bundle
+func copyVotingArtifacts(source string, destination string) error {
+	bytes, err := os.ReadFile(source)
+	if err != nil {
+		return fmt.Errorf("Error reading voting artifacts from %s: %w", source, err)
+	}
+	err = os.MkdirAll(filepath.Dir(destination), 0775)
+	if err != nil {
+		return fmt.Errorf("Error creating folder for voting artifacts: %w", err)
+	}
+	err = os.WriteFile(destination, bytes, 0664)
+	if err != nil {
+		return fmt.Errorf("Error writing voting artifacts to %s: %w", destination, err)
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Create an encrypted backup of the node
+func createBackup(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smart Node.")
+	}
+
+	// The validator client's database can't be copied safely while it runs, so export it with the validator client
+	// stopped and hand it to the daemon through the data folder
+	includeSlashingProtection := false
+	if cfg.IsNativeMode {
+		fmt.Println("NOTE: Slashing protection data can't be exported in Native Mode, so it won't be in the backup. Please back it up with your validator client's own commands.")
+	} else {
+		cc, _ := cfg.GetSelectedConsensusClient()
+		if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("The validator client must be stopped briefly while its slashing protection database is exported into the backup. Do you want to back up the node and its %s slashing protection database?", cc))) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+		exportPath, err := homedir.Expand(cfg.Smartnode.GetBackupSlashingProtectionPath(false))
+		if err != nil {
+			return fmt.Errorf("error expanding data path: %w", err)
+		}
+		data, err := exportSlashingProtectionForBackup(rp, cfg)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(exportPath, data, 0600); err != nil {
+			return fmt.Errorf("error saving the exported slashing protection data to %s: %w", exportPath, err)
+		}
+		defer os.Remove(exportPath)
+		includeSlashingProtection = true
+	}
+
+	fmt.Println("Creating a backup, this may take a minute...")
+	response, err := rp.CreateBackup(includeSlashingProtection)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%sSaved %s (%s) to %s.%s\n", colorGreen, response.Backup.Name, humanize.IBytes(uint64(response.Backup.Size)), response.Destination, colorReset)
+	for _, name := range response.Deleted {
+		fmt.Printf("Deleted old backup %s.\n", name)
+	}
+	fmt.Println("You'll need your backup passphrase to restore it, so make sure it's stored somewhere safe outside of this machine.")
+	return nil
+
+}
+
+// Print the backups in the configured destination
+func listBackups(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	response, err := rp.ListBackups()
+	if err != nil {
+		return err
+	}
+	if len(response.Backups) == 0 {
+		fmt.Printf("There are no backups in %s.\n", response.Destination)
+		return nil
+	}
+
+	fmt.Printf("Backups in %s:\n", response.Destination)
+	for _, backup := range response.Backups {
+		fmt.Printf("\t%s  %s  %s\n", backup.Name, backup.CreatedAt.Local().Format(time.RFC822), humanize.IBytes(uint64(backup.Size)))
+	}
+	return nil
+
+}
+
+// Restore a backup into the data folder and settings
+func restoreBackup(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Use the newest backup by default
+	name := c.String("name")
+	if name == "" {
+		listResponse, err := rp.ListBackups()
+		if err != nil {
+			return err
+		}
+		if len(listResponse.Backups) == 0 {
+			fmt.Printf("There are no backups in %s.\n", listResponse.Destination)
+			return nil
+		}
+		name = listResponse.Backups[len(listResponse.Backups)-1].Name
+	}
+
+	// Get the node address the backup's wallet must be for
+	var expectedAddress common.Address
+	if c.String("node-address") != "" {
+		address, err := cliutils.ValidateAddress("node address", c.String("node-address"))
+		if err != nil {
+			return err
+		}
+		expectedAddress = address
+	} else {
+		status, err := rp.WalletStatus()
+		if err != nil {
+			return err
+		}
+		if !status.WalletInitialized {
+			return fmt.Errorf("The node wallet isn't loaded, so the backup's wallet can't be checked against it. Please provide the address of the node the backup is for with --node-address.")
+		}
+		expectedAddress = status.AccountAddress
+	}
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smart Node.")
+	}
+
+	// Prompt for confirmation
+	fmt.Printf("%sWARNING: Restoring %s will replace your node wallet, validator keys, custom keys, rolling records, rewards trees, and settings with the ones in the backup.\n", colorYellow, name)
+	fmt.Println("Your current slashing protection database is kept, and the slashing protection data in the backup is imported into it. That data is only as recent as the backup itself. Before you start your validator client again, make sure it has been stopped for at least 15 minutes and that these keys aren't running on any other machine, or you may be slashed.")
+	fmt.Printf("Your current files will be kept next to the restored ones.%s\n\n", colorReset)
+	if cfg.IsNativeMode {
+		fmt.Println("Please stop your node and validator client services before continuing.")
+	} else {
+		fmt.Println("Your node daemon and validator client will be stopped, and will stay stopped until you start them again.")
+	}
+	fmt.Printf("The backup will only be restored if its wallet is for node %s.\n", expectedAddress.Hex())
+	if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to restore this backup?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Nothing may use the files while they're replaced
+	if !cfg.IsNativeMode {
+		if err := stopContainersForRestore(rp); err != nil {
+			return err
+		}
+	}
+
+	// Restore it
+	response, err := rp.RestoreBackup(name, expectedAddress)
+	if err != nil {
+		return err
+	}
+	for path, movedPath := range response.MovedAside {
+		fmt.Printf("Moved %s to %s.\n", path, movedPath)
+	}
+
+	// The daemons can't write to the settings file, so save the restored settings here
+	if response.Settings != "" {
+		var settings map[string]map[string]string
+		if err := yaml.Unmarshal([]byte(response.Settings), &settings); err != nil {
+			return fmt.Errorf("the backup's files were restored, but its settings couldn't be read: %w", err)
+		}
+		if err := rp.SaveSettings(settings); err != nil {
+			return fmt.Errorf("the backup's files were restored, but its settings couldn't be saved: %w", err)
+		}
+		fmt.Println("Restored the settings.")
+	}
+
+	// Import the backup's slashing protection data into the restored validator client
+	if response.SlashingProtection == "" {
+		fmt.Printf("%sThis backup doesn't have any slashing protection data, so only your current slashing protection database will be used.%s\n", colorYellow, colorReset)
+	} else if err := importBackupSlashingProtection(rp, cfg.IsNativeMode, []byte(response.SlashingProtection)); err != nil {
+		return err
+	}
+
+	fmt.Printf("%sRestored the backup of node %s made on %s.%s\n", colorGreen, response.Manifest.NodeAddress.Hex(), response.Manifest.CreatedAt.Local().Format(time.RFC822), colorReset)
+	fmt.Println("Please run `rocketpool service start` to use the restored files.")
+	return nil
+
+}
+
+// Stop the validator client just long enough to export its slashing protection database
+func exportSlashingProtectionForBackup(rp *rocketpool.Client, cfg *config.RocketPoolConfig) ([]byte, error) {
+	prefix, err := rp.GetContainerPrefix()
+	if err != nil {
+		return nil, err
+	}
+	validatorContainer := prefix + ValidatorContainerSuffix
+	status, err := rp.GetDockerStatus(validatorContainer)
+	if err != nil {
+		return nil, fmt.Errorf("error getting validator client status: %w", err)
+	}
+	if status == "running" {
+		fmt.Printf("Stopping %s...\n", validatorContainer)
+		if _, err := rp.StopContainer(validatorContainer); err != nil {
+			return nil, fmt.Errorf("error stopping validator client: %w", err)
+		}
+		defer func() {
+			fmt.Printf("Starting %s...\n", validatorContainer)
+			if _, err := rp.StartContainer(validatorContainer); err != nil {
+				fmt.Printf("%sWARNING: Couldn't restart the validator client: %s\nPlease run `rocketpool service start` to start it again.%s\n", colorRed, err.Error(), colorReset)
+			}
+		}()
+	}
+
+	fmt.Println("Exporting the slashing protection database...")
+	return rp.ExportSlashingProtection(cfg)
+}
+
+// Stop the node daemon and validator client and make sure they stay stopped before a backup is restored. The node
+// daemon goes first so it can't start the validator client again.
+func stopContainersForRestore(rp *rocketpool.Client) error {
+	prefix, err := rp.GetContainerPrefix()
+	if err != nil {
+		return err
+	}
+	containers := []string{
+		prefix + NodeContainerSuffix,
+		prefix + ValidatorContainerSuffix,
+	}
+	for _, container := range containers {
+		status, err := rp.GetDockerStatus(container)
+		if err != nil {
+			return fmt.Errorf("error checking the status of %s: %w", container, err)
+		}
+		if status != "running" && status != "restarting" {
+			continue
+		}
+		fmt.Printf("Stopping %s...\n", container)
+		if _, err := rp.StopContainer(container); err != nil {
+			return fmt.Errorf("error stopping %s: %w", container, err)
+		}
+	}
+	for _, container := range containers {
+		status, err := rp.GetDockerStatus(container)
+		if err != nil {
+			return fmt.Errorf("error checking the status of %s: %w", container, err)
+		}
+		if status == "running" || status == "restarting" {
+			return fmt.Errorf("%s is still %s; please stop it with `docker stop %s` and try again", container, status, container)
+		}
+	}
+	return nil
+}
+
+// Import the slashing protection data from a backup into the restored validator client, keeping a copy in case that
+// has to be done by hand
+func importBackupSlashingProtection(rp *rocketpool.Client, isNativeMode bool, data []byte) error {
+	configPath, err := homedir.Expand(rp.ConfigPath())
+	if err != nil {
+		return fmt.Errorf("error expanding config path: %w", err)
+	}
+	copyPath := filepath.Join(configPath, keystore.SlashingProtectionFile)
+	if err := os.WriteFile(copyPath, data, 0600); err != nil {
+		return fmt.Errorf("error saving the backup's slashing protection data to %s: %w", copyPath, err)
+	}
+	if isNativeMode {
+		fmt.Printf("%sThe backup's slashing protection data was saved to %s. Please import it with your validator client's own commands before you start it.%s\n", colorYellow, copyPath, colorReset)
+		return nil
+	}
+
+	// Use the restored settings, since they may be for a different validator client
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading the restored settings: %w", err)
+	}
+	fmt.Println("Importing the backup's slashing protection data...")
+	if err := rp.ImportSlashingProtection(cfg, data); err != nil {
+		return fmt.Errorf("%w\nYour validator client is stopped. The backup's slashing protection data was saved to %s; import it with `rocketpool wallet slashing-protection import %s` before running `rocketpool service start`", err, copyPath, copyPath)
+	}
+	fmt.Printf("Imported the backup's slashing protection data. A copy was saved to %s.\n", copyPath)
+	return nil
+}
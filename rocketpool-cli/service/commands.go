@@ -151,7 +151,7 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				Name:      "config",
 				Aliases:   []string{"c"},
 				Usage:     "Configure the Rocket Pool service",
-				UsageText: "rocketpool service config",
+				UsageText: "rocketpool service config [options]",
 				Flags:     configFlags,
 				Action: func(c *cli.Context) error {
 
@@ -164,6 +164,156 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 					return configureService(c)
 
 				},
+				Subcommands: []cli.Command{
+
+					{
+						Name:      "validate",
+						Aliases:   []string{"v"},
+						Usage:     "Check a settings file against every parameter's type, format, and options without applying it",
+						UsageText: "rocketpool service config validate settings-file",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run command
+							return validateConfigFile(c, c.Args().Get(0))
+
+						},
+					},
+
+					{
+						Name:      "diff",
+						Aliases:   []string{"d"},
+						Usage:     "Show the settings a settings file would change and the containers that would be restarted",
+						UsageText: "rocketpool service config diff settings-file",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run command
+							return diffConfigFile(c, c.Args().Get(0))
+
+						},
+					},
+
+					{
+						Name:      "schema",
+						Aliases:   []string{"s"},
+						Usage:     "Print a JSON Schema (draft 2020-12) describing the settings file, including addon settings",
+						UsageText: "rocketpool service config schema [options]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "out, o",
+								Usage: "Write the schema to this file instead of printing it",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return printConfigSchema(c)
+
+						},
+					},
+
+					{
+						Name:      "apply",
+						Aliases:   []string{"a"},
+						Usage:     "Apply a settings file and restart only the containers affected by its changes. The Rocket Pool directory and native mode settings are always kept from this node.",
+						UsageText: "rocketpool service config apply [options] settings-file",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm applying the changes and moving the slashing protection history to a new validator client",
+							},
+							cli.BoolFlag{
+								Name:  "no-restart",
+								Usage: "Save the changes without restarting the affected containers; the slashing protection history is not moved to a new validator client",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run command
+							return applyConfigFile(c, c.Args().Get(0))
+
+						},
+					},
+
+					{
+						Name:      "migrate",
+						Aliases:   []string{"m"},
+						Usage:     "Migrate the settings file to this Smartnode version (or back to an older one with --to), showing every setting each migration changes. The previous settings are backed up.",
+						UsageText: "rocketpool service config migrate [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "dry-run, d",
+								Usage: "Only show the settings that would change",
+							},
+							cli.StringFlag{
+								Name:  "to, t",
+								Usage: "The Smartnode version to migrate the settings to (defaults to this version)",
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm the migration",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return migrateConfig(c)
+
+						},
+					},
+
+					{
+						Name:      "rollback",
+						Aliases:   []string{"r"},
+						Usage:     "List the versioned settings backups and restore one of them exactly (the newest by default)",
+						UsageText: "rocketpool service config rollback [options]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "backup, b",
+								Usage: "The name of the backup to restore (defaults to the newest one)",
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm restoring the backup",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return rollbackConfig(c)
+
+						},
+					},
+				},
 			},
 
 			{
@@ -212,6 +362,268 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "render",
+				Aliases:   []string{"rn"},
+				Usage:     "Render the service templates for the current configuration into a folder without deploying them, validate the compose files, and compare them to the deployed ones",
+				UsageText: "rocketpool service render --out folder",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "out, o",
+						Usage: "The new or empty folder to render the templates into",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+					if c.String("out") == "" {
+						return fmt.Errorf("Please specify the folder to render the templates into with --out.")
+					}
+
+					// Run command
+					return renderTemplates(c)
+
+				},
+			},
+
+			{
+				Name:      "doctor",
+				Aliases:   []string{"dr"},
+				Usage:     "Run a suite of checks on your node's clients, validator setup, and machine, and report how to fix any problems. Exits with status 1 if any check fails.",
+				UsageText: "rocketpool service doctor [options]",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "json, j",
+						Usage: "Print the report as JSON",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return runDoctor(c)
+
+				},
+			},
+
+			{
+				Name:      "support-bundle",
+				Aliases:   []string{"sb"},
+				Usage:     "Collect your redacted settings, recent logs, client and node status, and system stats into a tarball you can share with the support team",
+				UsageText: "rocketpool service support-bundle [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The path to save the support bundle to (defaults to rocketpool-support-<timestamp>.tar.gz in the current directory)",
+					},
+					cli.StringFlag{
+						Name:  "tail, t",
+						Usage: "The number of log lines to collect from each container",
+						Value: defaultSupportBundleLogTail,
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run command
+					return createSupportBundle(c)
+
+				},
+			},
+
+			{
+				Name:    "backup",
+				Aliases: []string{"b"},
+				Usage:   "Manage encrypted backups of your node wallet, settings, validator keys, custom keys, rolling records, rewards trees, and exported slashing protection data",
+				Subcommands: []cli.Command{
+
+					{
+						Name:      "create",
+						Aliases:   []string{"c"},
+						Usage:     "Create a backup and save it to the destination in the Backups section of `rocketpool service config`",
+						UsageText: "rocketpool service backup create [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm stopping the validator client while its slashing protection database is exported",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return createBackup(c)
+
+						},
+					},
+
+					{
+						Name:      "list",
+						Aliases:   []string{"l"},
+						Usage:     "List the backups in the configured destination",
+						UsageText: "rocketpool service backup list",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return listBackups(c)
+
+						},
+					},
+
+					{
+						Name:      "restore",
+						Aliases:   []string{"r"},
+						Usage:     "Restore a backup (the newest one by default) if its wallet is for the expected node address",
+						UsageText: "rocketpool service backup restore [options]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "name, n",
+								Usage: "The name of the backup to restore, as shown by the list command (defaults to the newest one)",
+							},
+							cli.StringFlag{
+								Name:  "node-address, a",
+								Usage: "The node address the backup's wallet must be for (defaults to the current node wallet's address; required if there is no node wallet)",
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm restoring the backup and stopping the node daemon and validator client",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return restoreBackup(c)
+
+						},
+					},
+				},
+			},
+
+			{
+				Name:    "rescue-node",
+				Aliases: []string{"rs"},
+				Usage:   "Manage the Rescue Node add-on, a community-run fallback for your validator client",
+				Subcommands: []cli.Command{
+
+					{
+						Name:      "status",
+						Aliases:   []string{"s"},
+						Usage:     "Show whether the Rescue Node is enabled and when its credential expires",
+						UsageText: "rocketpool service rescue-node status",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return getRescueNodeStatus(c)
+
+						},
+					},
+
+					{
+						Name:      "request-credential",
+						Aliases:   []string{"r"},
+						Usage:     "Sign a Rescue Node credential request with the node wallet",
+						UsageText: "rocketpool service rescue-node request-credential",
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return requestRescueNodeCredential(c)
+
+						},
+					},
+
+					{
+						Name:      "enable",
+						Aliases:   []string{"e"},
+						Usage:     "Switch the validator client to the Rescue Node and restart it",
+						UsageText: "rocketpool service rescue-node enable [options]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "username, u",
+								Usage: "The username of a new credential from rescuenode.com",
+							},
+							cli.StringFlag{
+								Name:  "password, p",
+								Usage: "The password of a new credential from rescuenode.com",
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm restarting the validator client",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return enableRescueNode(c)
+
+						},
+					},
+
+					{
+						Name:      "disable",
+						Aliases:   []string{"d"},
+						Usage:     "Switch the validator client back to your own Consensus client and restart it",
+						UsageText: "rocketpool service rescue-node disable [options]",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm restarting the validator client",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run command
+							return disableRescueNode(c)
+
+						},
+					},
+				},
+			},
+
 			{
 				Name:      "pause",
 				Aliases:   []string{"p"},
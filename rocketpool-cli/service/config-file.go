@@ -0,0 +1,285 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Check a settings file without applying it
+func validateConfigFile(c *cli.Context, path string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the current config so the machine-specific settings match this node
+	currentCfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+
+	// Check the file
+	cfg, err := loadConfigFile(path, currentCfg)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s%s is valid for %s.%s\n", colorGreen, path, cfg.Smartnode.Network.Value, colorReset)
+	return nil
+
+}
+
+// Show the changes applying a settings file would make
+func diffConfigFile(c *cli.Context, path string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the current config and the new one
+	currentCfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	cfg, err := loadConfigFile(path, currentCfg)
+	if err != nil {
+		return err
+	}
+
+	// Print the changes
+	if isNew {
+		fmt.Printf("%sThere is no existing configuration, so the changes are compared against the default settings.%s\n\n", colorYellow, colorReset)
+	}
+	printConfigChanges(currentCfg, cfg)
+	return nil
+
+}
+
+// Apply a settings file and restart the containers affected by its changes
+func applyConfigFile(c *cli.Context, path string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the current config and the new one
+	currentCfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	cfg, err := loadConfigFile(path, currentCfg)
+	if err != nil {
+		return err
+	}
+
+	// Print the changes
+	hasChanges, containers, changeNetworks := printConfigChanges(currentCfg, cfg)
+	if !isNew && changeNetworks {
+		return fmt.Errorf("%s selects a different network. Changing networks removes your chain data, node wallet, and validator keys, so it must be done with `rocketpool service config`.", path)
+	}
+	if !hasChanges && !isNew {
+		return nil
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm("Would you like to apply these changes?")) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Save the config
+	err = rp.SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+	fmt.Println("Your changes have been saved!")
+
+	// Exit immediately if we're in native mode
+	if cfg.IsNativeMode {
+		fmt.Println("Please restart your daemon service for them to take effect.")
+		return nil
+	}
+	if isNew {
+		fmt.Println("Please run `rocketpool service start` when you are ready to launch.")
+		return nil
+	}
+
+	// Leave the containers alone if requested; moving the slashing protection history needs a restart, so it's skipped too
+	prefix := fmt.Sprint(currentCfg.Smartnode.ProjectName.Value)
+	oldCc, _ := currentCfg.GetSelectedConsensusClient()
+	newCc, _ := cfg.GetSelectedConsensusClient()
+	if c.Bool("no-restart") {
+		if oldCc != newCc {
+			fmt.Printf("%sNOTE: You have changed your validator client from %s to %s, but the slashing protection history was not moved because --no-restart was set. Move it with `rocketpool wallet slashing-protection export` and `import` before starting the new client.%s\n", colorYellow, oldCc, newCc, colorReset)
+		}
+		if len(containers) > 0 {
+			fmt.Println("Please run `rocketpool service start` when you are ready to apply the changes.")
+		}
+		return nil
+	}
+
+	// Carry the slashing protection history over if the validator client changed
+	if oldCc != newCc {
+		_, err = migrateSlashingProtection(c, rp, currentCfg, cfg, prefix, c.Bool("yes"))
+		if err != nil {
+			return fmt.Errorf("the settings were saved, but the slashing protection database could not be moved to %s: %w", newCc, err)
+		}
+	}
+
+	// Restart the affected containers
+	if len(containers) == 0 {
+		return nil
+	}
+	return restartContainers(c, rp, prefix, containers)
+
+}
+
+// Print the JSON Schema of the settings file
+func printConfigSchema(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config; some defaults depend on the local Rocket Pool directory
+	cfg, _, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+
+	// Serialize the schema; regexes are easier to read without HTML escaping
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(cfg.GetJsonSchema())
+	if err != nil {
+		return fmt.Errorf("error serializing settings schema: %w", err)
+	}
+
+	// Print or save it
+	outPath := c.String("out")
+	if outPath == "" {
+		fmt.Print(buffer.String())
+		return nil
+	}
+	err = os.WriteFile(outPath, buffer.Bytes(), 0644)
+	if err != nil {
+		return fmt.Errorf("error writing settings schema to %s: %w", outPath, err)
+	}
+	fmt.Printf("Wrote the settings schema to %s.\n", outPath)
+	return nil
+
+}
+
+// Load and validate a settings file. The machine-specific settings (the Rocket Pool directory and native mode) are
+// always taken from the current configuration so the same file can be shared between nodes, and files without a
+// version are treated as being written for this one.
+func loadConfigFile(path string, currentCfg *config.RocketPoolConfig) (*config.RocketPoolConfig, error) {
+
+	// Read the file
+	expandedPath, err := homedir.Expand(path)
+	if err != nil {
+		return nil, fmt.Errorf("error expanding settings file path [%s]: %w", path, err)
+	}
+	settings, err := config.ReadSettingsFile(expandedPath)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		settings = map[string]map[string]string{}
+	}
+	root, exists := settings["root"]
+	if !exists {
+		root = map[string]string{}
+		settings["root"] = root
+	}
+	root["rpDir"] = currentCfg.RocketPoolDirectory
+	root["isNative"] = fmt.Sprint(currentCfg.IsNativeMode)
+	currentVersion := fmt.Sprintf("v%s", shared.RocketPoolVersion)
+	if root["version"] == "" {
+		root["version"] = currentVersion
+	}
+
+	// Validate it
+	cfg, errors := config.ValidateSettings(settings)
+	if len(errors) > 0 {
+		fmt.Printf("%s%s has the following problems:\n\n", colorRed, path)
+		for _, err := range errors {
+			fmt.Printf("%s\n\n", err)
+		}
+		fmt.Print(colorReset)
+		return nil, fmt.Errorf("%s is not a valid settings file", path)
+	}
+
+	// Settings from an older Smartnode version get the latest defaults, just like an upgrade in the TUI
+	if cfg.Version != currentVersion {
+		fmt.Printf("%s%s was written for Smartnode %s; settings that are replaced during upgrades (such as container versions) will use the defaults for %s.%s\n\n", colorYellow, path, cfg.Version, currentVersion, colorReset)
+		err = cfg.UpdateDefaults()
+		if err != nil {
+			return nil, fmt.Errorf("error upgrading configuration with the latest parameters: %w", err)
+		}
+	}
+	return cfg, nil
+
+}
+
+// Print the changes between two configurations.
+// Returns whether there were any changes, the containers that must be restarted, and whether the network changed.
+func printConfigChanges(oldCfg *config.RocketPoolConfig, newCfg *config.RocketPoolConfig) (bool, []cfgtypes.ContainerID, bool) {
+
+	changedSettings, totalAffectedContainers, changeNetworks := newCfg.GetChanges(oldCfg)
+
+	// Print the changed settings by category
+	categories := make([]string, 0, len(changedSettings))
+	for category, settings := range changedSettings {
+		if len(settings) > 0 {
+			categories = append(categories, category)
+		}
+	}
+	sort.Strings(categories)
+	if len(categories) == 0 {
+		fmt.Println("No changes; the settings file matches the current configuration.")
+		return false, nil, false
+	}
+	for _, category := range categories {
+		fmt.Printf("%s%s%s\n", colorLightBlue, category, colorReset)
+		for _, setting := range changedSettings[category] {
+			fmt.Printf("\t%s [%s]: %s => %s\n", setting.Name, setting.ID, setting.OldValue, setting.NewValue)
+		}
+		fmt.Println()
+	}
+
+	// Print the affected containers
+	containers := make([]cfgtypes.ContainerID, 0, len(totalAffectedContainers))
+	for container := range totalAffectedContainers {
+		containers = append(containers, container)
+	}
+	sort.Slice(containers, func(i, j int) bool {
+		return containers[i] < containers[j]
+	})
+	if len(containers) > 0 {
+		prefix := fmt.Sprint(oldCfg.Smartnode.ProjectName.Value)
+		fmt.Println("The following containers must be restarted for these changes to take effect:")
+		for _, container := range containers {
+			fmt.Printf("\t%s_%s\n", prefix, container)
+		}
+		fmt.Println()
+	}
+	if changeNetworks {
+		fmt.Printf("%sThese changes select a different network.%s\n\n", colorYellow, colorReset)
+	}
+	return true, containers, changeNetworks
+
+}
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-version"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/config/migration"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// Migrate the settings file to the current Smartnode version, or back to an older one
+func migrateConfig(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Read the settings file as-is; loading it normally would migrate it before the changes could be shown
+	path, err := rp.SettingsFilePath()
+	if err != nil {
+		return err
+	}
+	_, err = os.Stat(path)
+	if os.IsNotExist(err) {
+		fmt.Println("There is no settings file to migrate yet. Please run `rocketpool service config` to create one.")
+		return nil
+	}
+	settings, err := config.ReadSettingsFile(path)
+	if err != nil {
+		return err
+	}
+	configVersion, err := migration.GetVersionFromConfig(settings)
+	if err != nil {
+		return err
+	}
+
+	// Get the version to migrate to
+	currentVersion, err := version.NewSemver(shared.RocketPoolVersion)
+	if err != nil {
+		return fmt.Errorf("error parsing Smartnode version %s: %w", shared.RocketPoolVersion, err)
+	}
+	targetVersion := currentVersion
+	if c.String("to") != "" {
+		targetVersion, err = version.NewSemver(c.String("to"))
+		if err != nil {
+			return fmt.Errorf("invalid version %s: %w", c.String("to"), err)
+		}
+		if targetVersion.GreaterThan(currentVersion) {
+			return fmt.Errorf("settings can't be migrated to v%s because this is Smartnode v%s", targetVersion.String(), currentVersion.String())
+		}
+	}
+	if configVersion.Equal(targetVersion) {
+		fmt.Printf("Your settings are already up to date for Smartnode v%s.\n", targetVersion.String())
+		return nil
+	}
+
+	// Build the migrated settings
+	var newSettings map[string]map[string]string
+	if targetVersion.LessThan(configVersion) {
+		newSettings, err = getDowngradedSettings(settings, configVersion, targetVersion)
+	} else {
+		newSettings, err = getUpgradedSettings(settings, filepath.Dir(path), configVersion)
+	}
+	if err != nil {
+		return err
+	}
+
+	if c.Bool("dry-run") {
+		fmt.Println("This was a dry run; your settings have not been changed.")
+		return nil
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Would you like to migrate your settings from v%s to v%s?", configVersion.String(), targetVersion.String()))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Save the settings; the old ones are backed up because the version changes
+	err = rp.SaveSettings(newSettings)
+	if err != nil {
+		return fmt.Errorf("error saving migrated settings: %w", err)
+	}
+	fmt.Printf("%sYour settings have been migrated to v%s.%s\n", colorGreen, targetVersion.String(), colorReset)
+	backups, err := rp.GetConfigBackups()
+	if err == nil && len(backups) > 0 {
+		fmt.Printf("Your previous settings were backed up to %s; you can restore them with `rocketpool service config rollback --backup %s`.\n", backups[0].Path, backups[0].Name)
+	}
+	if targetVersion.LessThan(currentVersion) {
+		fmt.Printf("%sThis version of the Smartnode will migrate them forward again the next time it loads them, so install v%s before using them.%s\n", colorYellow, targetVersion.String(), colorReset)
+	}
+	return nil
+
+}
+
+// Apply the pending upgrades to the settings and print what each one changes
+func getUpgradedSettings(settings map[string]map[string]string, configDir string, configVersion *version.Version) (map[string]map[string]string, error) {
+
+	// Run the upgrades on a copy so the original settings can be deserialized separately
+	upgradedSettings := migration.CopyConfig(settings)
+	steps, err := migration.UpgradeConfig(upgradedSettings)
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range steps {
+		printMigrationStep(fmt.Sprintf("Upgrade from v%s", step.Version.String()), step)
+	}
+
+	// Load the settings into the current config, with the latest defaults just like an upgrade in the TUI
+	cfg := config.NewRocketPoolConfig(configDir, false)
+	err = cfg.Deserialize(migration.CopyConfig(settings))
+	if err != nil {
+		return nil, fmt.Errorf("error loading settings: %w", err)
+	}
+	err = cfg.UpdateDefaults()
+	if err != nil {
+		return nil, fmt.Errorf("error upgrading configuration with the latest parameters: %w", err)
+	}
+
+	// Print what saving them in the current format changes
+	newSettings := cfg.Serialize()
+	printMigrationStep(fmt.Sprintf("Save for v%s", shared.RocketPoolVersion), migration.MigrationStep{
+		Description: fmt.Sprintf("Add new parameters, remove retired ones, and update the defaults that change with every release since v%s", configVersion.String()),
+		Changes:     migration.GetChanges(upgradedSettings, newSettings),
+	})
+	return newSettings, nil
+
+}
+
+// Reverse the upgrades made since the target version and print what each one changes
+func getDowngradedSettings(settings map[string]map[string]string, configVersion *version.Version, targetVersion *version.Version) (map[string]map[string]string, error) {
+
+	newSettings := migration.CopyConfig(settings)
+	steps, err := migration.DowngradeConfig(newSettings, targetVersion)
+	if err != nil {
+		return nil, err
+	}
+	for _, step := range steps {
+		printMigrationStep(fmt.Sprintf("Reverse upgrade from v%s", step.Version.String()), step)
+	}
+	printMigrationStep(fmt.Sprintf("Save for v%s", targetVersion.String()), migration.MigrationStep{
+		Description: fmt.Sprintf("Mark the settings as belonging to v%s instead of v%s", targetVersion.String(), configVersion.String()),
+		Changes: []migration.SettingChange{{
+			Section:  "root",
+			Key:      "version",
+			OldValue: settings["root"]["version"],
+			NewValue: newSettings["root"]["version"],
+		}},
+	})
+	return newSettings, nil
+
+}
+
+// Print the settings a migration step changes
+func printMigrationStep(title string, step migration.MigrationStep) {
+	fmt.Printf("%s%s: %s%s\n", colorLightBlue, title, step.Description, colorReset)
+	if len(step.Changes) == 0 {
+		fmt.Println("\tNo settings change.")
+	}
+	for _, change := range step.Changes {
+		switch {
+		case change.Added:
+			fmt.Printf("\t%s+ %s.%s: %s%s\n", colorGreen, change.Section, change.Key, change.NewValue, colorReset)
+		case change.Removed:
+			fmt.Printf("\t%s- %s.%s: %s%s\n", colorRed, change.Section, change.Key, change.OldValue, colorReset)
+		default:
+			fmt.Printf("\t%s~ %s.%s: %s => %s%s\n", colorYellow, change.Section, change.Key, change.OldValue, change.NewValue, colorReset)
+		}
+	}
+	fmt.Println()
+}
+
+// Restore the settings file from one of its versioned backups
+func rollbackConfig(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the backups
+	backups, err := rp.GetConfigBackups()
+	if err != nil {
+		return err
+	}
+	if len(backups) == 0 {
+		fmt.Println("There are no settings backups yet. One is made every time your settings are saved for a different Smartnode version.")
+		return nil
+	}
+
+	// Print them
+	fmt.Println("Settings backups (newest first):")
+	for _, backup := range backups {
+		fmt.Printf("\t%s (%s, saved %s)\n", backup.Name, backup.Version, backup.Time.Format("2006-01-02 15:04:05"))
+	}
+	fmt.Println()
+
+	// Find the one to restore; the newest one undoes the latest migration
+	backupName := c.String("backup")
+	if backupName == "" {
+		backupName = backups[0].Name
+	}
+	found := false
+	for _, backup := range backups {
+		if backup.Name == backupName {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("there is no settings backup named %s", backupName)
+	}
+
+	// Prompt for confirmation
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("Would you like to replace your current settings with %s? Your current settings will be backed up first.", backupName))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Restore it
+	err = rp.RestoreConfigBackup(backupName)
+	if err != nil {
+		return fmt.Errorf("error restoring settings backup: %w", err)
+	}
+	fmt.Printf("%sYour settings have been restored from %s.%s\n", colorGreen, backupName, colorReset)
+	fmt.Println("Please run `rocketpool service start` (or restart your daemon services in native mode) for them to take effect.")
+	return nil
+
+}
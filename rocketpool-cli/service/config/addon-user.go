@@ -0,0 +1,106 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/rivo/tview"
+	"github.com/rocket-pool/smartnode/addons/manifest"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// The page wrapper for the config of an addon from the user addons folder
+type AddonUserPage struct {
+	addonsPage   *AddonsPage
+	page         *page
+	layout       *standardLayout
+	masterConfig *config.RocketPoolConfig
+	addon        *manifest.UserAddon
+	enabledBox   *parameterizedFormItem
+	otherParams  []*parameterizedFormItem
+}
+
+// Creates a new page for the settings of a user addon
+func NewAddonUserPage(addonsPage *AddonsPage, addon *manifest.UserAddon) *AddonUserPage {
+
+	configPage := &AddonUserPage{
+		addonsPage:   addonsPage,
+		masterConfig: addonsPage.home.md.Config,
+		addon:        addon,
+	}
+	configPage.createContent()
+
+	configPage.page = newPage(
+		addonsPage.page,
+		fmt.Sprintf("settings-addon-%s", addon.GetID()),
+		addon.GetName(),
+		addon.GetDescription(),
+		configPage.layout.grid,
+	)
+
+	return configPage
+
+}
+
+// Get the underlying page
+func (configPage *AddonUserPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the content for the user addon settings page
+func (configPage *AddonUserPage) createContent() {
+
+	// Create the layout
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, configPage.addon.GetConfig().GetConfigTitle())
+	configPage.layout.setupEscapeReturnHomeHandler(configPage.addonsPage.home.md, configPage.addonsPage.page)
+
+	// Get the parameters
+	enabledParam := configPage.addon.GetEnabledParameter()
+	otherParams := []*cfgtypes.Parameter{}
+
+	for _, param := range configPage.addon.GetConfig().GetParameters() {
+		if param.ID != enabledParam.ID {
+			otherParams = append(otherParams, param)
+		}
+	}
+
+	// Set up the form items
+	configPage.enabledBox = createParameterizedCheckbox(enabledParam)
+	configPage.otherParams = createParameterizedFormItems(otherParams, configPage.layout.descriptionBox)
+
+	// Map the parameters to the form items in the layout
+	configPage.layout.mapParameterizedFormItems(configPage.enabledBox)
+	configPage.layout.mapParameterizedFormItems(configPage.otherParams...)
+
+	// Set up the setting callbacks
+	configPage.enabledBox.item.(*tview.Checkbox).SetChangedFunc(func(checked bool) {
+		if enabledParam.Value == checked {
+			return
+		}
+		enabledParam.Value = checked
+		configPage.handleEnableChanged()
+	})
+
+	// Do the initial draw
+	configPage.handleEnableChanged()
+
+}
+
+// Handle all of the form changes when the Enabled box has changed
+func (configPage *AddonUserPage) handleEnableChanged() {
+	configPage.layout.form.Clear(true)
+	configPage.layout.form.AddFormItem(configPage.enabledBox.item)
+
+	// Only add the addon's settings if it's enabled
+	if configPage.addon.GetEnabledParameter().Value == false {
+		return
+	}
+	configPage.layout.addFormItems(configPage.otherParams)
+	configPage.layout.refresh()
+}
+
+// Handle a bulk redraw request
+func (configPage *AddonUserPage) handleLayoutChanged() {
+	configPage.handleEnableChanged()
+}
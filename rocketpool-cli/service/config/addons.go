@@ -19,6 +19,7 @@ type AddonsPage struct {
 	gwwButton        *parameterizedFormItem
 	rescueNodePage   *AddonRescueNodePage
 	rescueNodeButton *parameterizedFormItem
+	userAddonPages   []*AddonUserPage
 	categoryList     *tview.List
 	addonSubpages    []settingsPage
 	content          tview.Primitive
@@ -46,6 +47,13 @@ func NewAddonsPage(home *settingsHome) *AddonsPage {
 		addonsPage.gwwPage,
 		addonsPage.rescueNodePage,
 	}
+
+	// Add a page for each of the addons in the user addons folder
+	for _, addon := range home.md.Config.UserAddons {
+		userAddonPage := NewAddonUserPage(addonsPage, addon)
+		addonsPage.userAddonPages = append(addonsPage.userAddonPages, userAddonPage)
+		addonSubpages = append(addonSubpages, userAddonPage)
+	}
 	addonsPage.addonSubpages = addonSubpages
 
 	// Add the subpages to the main display
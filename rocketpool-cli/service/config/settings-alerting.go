@@ -19,6 +19,9 @@ var alertingParametersNativeMode map[string]interface{} = map[string]interface{}
 	"alertEnabled_MinipoolStaked":              nil,
 	"alertEnabled_ExecutionClientSyncComplete": nil,
 	"alertEnabled_BeaconClientSyncComplete":    nil,
+	"alertEnabled_ProposalFeeRecipientWrong":   nil,
+	"alertEnabled_RewardsClaimed":              nil,
+	"alertEnabled_GovernanceDigest":            nil,
 }
 
 var alertingParametersDockerMode map[string]interface{} = map[string]interface{}{
@@ -43,6 +46,10 @@ var alertingParametersDockerMode map[string]interface{} = map[string]interface{}
 	"alertEnabled_MinipoolStaked":              nil,
 	"alertEnabled_ExecutionClientSyncComplete": nil,
 	"alertEnabled_BeaconClientSyncComplete":    nil,
+	"alertEnabled_ProposalFeeRecipientWrong":   nil,
+	"alertEnabled_RewardsClaimed":              nil,
+	"alertEnabled_GovernanceDigest":            nil,
+	"alertEnabled_RescueNodeCredentialExpiry":  nil,
 }
 
 // The page wrapper for the alerting config
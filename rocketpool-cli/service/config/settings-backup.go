@@ -0,0 +1,139 @@
+package config
+
+import (
+	"github.com/rivo/tview"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// The page wrapper for the backup config
+type BackupConfigPage struct {
+	mainDisplay    *mainDisplay
+	homePage       *page
+	page           *page
+	layout         *standardLayout
+	masterConfig   *config.RocketPoolConfig
+	enableBox      *parameterizedFormItem
+	intervalBox    *parameterizedFormItem
+	retentionBox   *parameterizedFormItem
+	passphraseBox  *parameterizedFormItem
+	destinationBox *parameterizedFormItem
+	localPathBox   *parameterizedFormItem
+	s3EndpointBox  *parameterizedFormItem
+	s3RegionBox    *parameterizedFormItem
+	s3BucketBox    *parameterizedFormItem
+	s3PrefixBox    *parameterizedFormItem
+	s3AccessKeyBox *parameterizedFormItem
+	s3SecretKeyBox *parameterizedFormItem
+}
+
+func NewBackupConfigPage(home *settingsHome) *BackupConfigPage {
+	configPage := &BackupConfigPage{
+		mainDisplay:  home.md,
+		homePage:     home.homePage,
+		masterConfig: home.md.Config,
+	}
+
+	configPage.createContent()
+	configPage.initPage(false)
+
+	return configPage
+}
+
+func NewBackupConfigPageForNative(home *settingsNativeHome) *BackupConfigPage {
+	configPage := &BackupConfigPage{
+		mainDisplay:  home.md,
+		homePage:     home.homePage,
+		masterConfig: home.md.Config,
+	}
+
+	configPage.createContent()
+	configPage.initPage(true)
+
+	return configPage
+}
+
+func (configPage *BackupConfigPage) initPage(isNative bool) {
+	id := "settings-backup"
+	if isNative {
+		id = "settings-backup-native"
+	}
+	configPage.page = newPage(
+		configPage.homePage,
+		id,
+		"Backups",
+		"Select this to configure encrypted backups of your node wallet, settings, validator keys, and other node data.",
+		configPage.layout.grid,
+	)
+}
+
+func (configPage *BackupConfigPage) getPage() *page {
+	return configPage.page
+}
+
+// Creates the UI form items of the backup config page.
+func (configPage *BackupConfigPage) createContent() {
+	configPage.layout = newStandardLayout()
+	configPage.layout.createForm(&configPage.masterConfig.Smartnode.Network, "Backup Settings")
+	configPage.layout.setupEscapeReturnHomeHandler(configPage.mainDisplay, configPage.homePage)
+
+	// Set up the UI components
+	backupConfig := configPage.masterConfig.Backup
+	configPage.enableBox = createParameterizedCheckbox(&backupConfig.Enabled)
+	configPage.intervalBox = createParameterizedUintField(&backupConfig.Interval)
+	configPage.retentionBox = createParameterizedUintField(&backupConfig.Retention)
+	configPage.passphraseBox = createParameterizedStringField(&backupConfig.Passphrase)
+	configPage.destinationBox = createParameterizedDropDown(&backupConfig.Destination, configPage.layout.descriptionBox)
+	configPage.localPathBox = createParameterizedStringField(&backupConfig.LocalPath)
+	configPage.s3EndpointBox = createParameterizedStringField(&backupConfig.S3Endpoint)
+	configPage.s3RegionBox = createParameterizedStringField(&backupConfig.S3Region)
+	configPage.s3BucketBox = createParameterizedStringField(&backupConfig.S3Bucket)
+	configPage.s3PrefixBox = createParameterizedStringField(&backupConfig.S3Prefix)
+	configPage.s3AccessKeyBox = createParameterizedStringField(&backupConfig.S3AccessKey)
+	configPage.s3SecretKeyBox = createParameterizedStringField(&backupConfig.S3SecretKey)
+
+	// Map the parameters to the form items in the layout
+	configPage.layout.mapParameterizedFormItems(configPage.enableBox, configPage.intervalBox, configPage.retentionBox, configPage.passphraseBox,
+		configPage.destinationBox, configPage.localPathBox, configPage.s3EndpointBox, configPage.s3RegionBox, configPage.s3BucketBox,
+		configPage.s3PrefixBox, configPage.s3AccessKeyBox, configPage.s3SecretKeyBox)
+
+	// Set up the setting callbacks
+	configPage.enableBox.item.(*tview.Checkbox).SetChangedFunc(func(checked bool) {
+		if backupConfig.Enabled.Value == checked {
+			return
+		}
+		backupConfig.Enabled.Value = checked
+		configPage.handleLayoutChanged()
+	})
+	configPage.destinationBox.item.(*DropDown).SetSelectedFunc(func(text string, index int) {
+		if backupConfig.Destination.Value == backupConfig.Destination.Options[index].Value {
+			return
+		}
+		backupConfig.Destination.Value = backupConfig.Destination.Options[index].Value
+		configPage.handleLayoutChanged()
+	})
+
+	// Do the initial draw
+	configPage.handleLayoutChanged()
+}
+
+// Handle all of the form changes when the Enable box or the destination has changed
+func (configPage *BackupConfigPage) handleLayoutChanged() {
+	configPage.layout.form.Clear(true)
+	configPage.layout.addFormItems([]*parameterizedFormItem{configPage.enableBox})
+
+	// The interval and retention only matter for scheduled backups, but manual backups still need the rest
+	if configPage.masterConfig.Backup.Enabled.Value == true {
+		configPage.layout.addFormItems([]*parameterizedFormItem{configPage.intervalBox, configPage.retentionBox})
+	}
+	configPage.layout.addFormItems([]*parameterizedFormItem{configPage.passphraseBox, configPage.destinationBox})
+
+	switch configPage.masterConfig.Backup.Destination.Value.(config.BackupDestination) {
+	case config.BackupDestination_Local:
+		configPage.layout.addFormItems([]*parameterizedFormItem{configPage.localPathBox})
+	case config.BackupDestination_S3:
+		configPage.layout.addFormItems([]*parameterizedFormItem{configPage.s3EndpointBox, configPage.s3RegionBox, configPage.s3BucketBox,
+			configPage.s3PrefixBox, configPage.s3AccessKeyBox, configPage.s3SecretKeyBox})
+	}
+
+	configPage.layout.refresh()
+}
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"github.com/rivo/tview"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/graffiti"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 )
 
@@ -25,6 +27,8 @@ type ConsensusConfigPage struct {
 	externalLodestarItems   []*parameterizedFormItem
 	externalPrysmItems      []*parameterizedFormItem
 	externalTekuItems       []*parameterizedFormItem
+	graffitiTemplateBox     *parameterizedFormItem
+	graffitiTemplateItems   []*parameterizedFormItem
 }
 
 // Creates a new page for the Consensus client settings
@@ -76,6 +80,8 @@ func (configPage *ConsensusConfigPage) createContent() {
 	configPage.externalLodestarItems = createParameterizedFormItems(configPage.masterConfig.ExternalLodestar.GetParameters(), configPage.layout.descriptionBox)
 	configPage.externalPrysmItems = createParameterizedFormItems(configPage.masterConfig.ExternalPrysm.GetParameters(), configPage.layout.descriptionBox)
 	configPage.externalTekuItems = createParameterizedFormItems(configPage.masterConfig.ExternalTeku.GetParameters(), configPage.layout.descriptionBox)
+	configPage.graffitiTemplateBox = createParameterizedCheckbox(&configPage.masterConfig.GraffitiTemplate.Enabled)
+	configPage.graffitiTemplateItems = createParameterizedFormItems(configPage.masterConfig.GraffitiTemplate.GetParameters()[1:], configPage.layout.descriptionBox)
 
 	// Map the parameters to the form items in the layout
 	configPage.layout.mapParameterizedFormItems(configPage.ccModeDropdown, configPage.ccDropdown, configPage.externalCcDropdown)
@@ -90,6 +96,8 @@ func (configPage *ConsensusConfigPage) createContent() {
 	configPage.layout.mapParameterizedFormItems(configPage.externalLodestarItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.externalPrysmItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.externalTekuItems...)
+	configPage.layout.mapParameterizedFormItems(configPage.graffitiTemplateBox)
+	configPage.layout.mapParameterizedFormItems(configPage.graffitiTemplateItems...)
 
 	// Set up the setting callbacks
 	configPage.ccModeDropdown.item.(*DropDown).SetSelectedFunc(func(text string, index int) {
@@ -114,6 +122,13 @@ func (configPage *ConsensusConfigPage) createContent() {
 		configPage.masterConfig.ExternalConsensusClient.Value = configPage.masterConfig.ExternalConsensusClient.Options[index].Value
 		configPage.handleExternalCcChanged()
 	})
+	configPage.graffitiTemplateBox.item.(*tview.Checkbox).SetChangedFunc(func(checked bool) {
+		if configPage.masterConfig.GraffitiTemplate.Enabled.Value == checked {
+			return
+		}
+		configPage.masterConfig.GraffitiTemplate.Enabled.Value = checked
+		configPage.handleCcModeChanged()
+	})
 
 	// Do the initial draw
 	configPage.handleCcModeChanged()
@@ -156,6 +171,7 @@ func (configPage *ConsensusConfigPage) handleLocalCcChanged() {
 	case cfgtypes.ConsensusClient_Teku:
 		configPage.layout.addFormItemsWithCommonParams(configPage.ccCommonItems, configPage.tekuItems, configPage.masterConfig.Teku.UnsupportedCommonParams)
 	}
+	configPage.addGraffitiTemplateItems(selectedCc)
 
 	configPage.layout.refresh()
 }
@@ -179,10 +195,22 @@ func (configPage *ConsensusConfigPage) handleExternalCcChanged() {
 	case cfgtypes.ConsensusClient_Teku:
 		configPage.layout.addFormItems(configPage.externalTekuItems)
 	}
+	configPage.addGraffitiTemplateItems(selectedCc)
 
 	configPage.layout.refresh()
 }
 
+// Add the graffiti template settings if the selected client can read a graffiti file
+func (configPage *ConsensusConfigPage) addGraffitiTemplateItems(selectedCc cfgtypes.ConsensusClient) {
+	if !graffiti.IsFileSupported(selectedCc) {
+		return
+	}
+	configPage.layout.form.AddFormItem(configPage.graffitiTemplateBox.item)
+	if configPage.masterConfig.GraffitiTemplate.Enabled.Value == true {
+		configPage.layout.addFormItems(configPage.graffitiTemplateItems)
+	}
+}
+
 // Handle a bulk redraw request
 func (configPage *ConsensusConfigPage) handleLayoutChanged() {
 	configPage.handleCcModeChanged()
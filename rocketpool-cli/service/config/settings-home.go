@@ -22,6 +22,7 @@ type settingsHome struct {
 	mevBoostPage     *MevBoostConfigPage
 	metricsPage      *MetricsConfigPage
 	alertingPage     *AlertingConfigPage
+	backupPage       *BackupConfigPage
 	addonsPage       *AddonsPage
 	categoryList     *tview.List
 	settingsSubpages []settingsPage
@@ -48,6 +49,7 @@ func newSettingsHome(md *mainDisplay) *settingsHome {
 	home.mevBoostPage = NewMevBoostConfigPage(home)
 	home.metricsPage = NewMetricsConfigPage(home)
 	home.alertingPage = NewAlertingConfigPage(home)
+	home.backupPage = NewBackupConfigPage(home)
 	home.addonsPage = NewAddonsPage(home)
 	settingsSubpages := []settingsPage{
 		home.smartnodePage,
@@ -57,6 +59,7 @@ func newSettingsHome(md *mainDisplay) *settingsHome {
 		home.mevBoostPage,
 		home.metricsPage,
 		home.alertingPage,
+		home.backupPage,
 		home.addonsPage,
 	}
 	home.settingsSubpages = settingsSubpages
@@ -252,4 +255,8 @@ func (home *settingsHome) refresh() {
 	if home.alertingPage != nil {
 		home.alertingPage.layout.refresh()
 	}
+
+	if home.backupPage != nil {
+		home.backupPage.layout.refresh()
+	}
 }
@@ -8,23 +8,24 @@ import (
 
 // The page wrapper for the metrics config
 type MetricsConfigPage struct {
-	home                       *settingsHome
-	page                       *page
-	layout                     *standardLayout
-	masterConfig               *config.RocketPoolConfig
-	enableMetricsBox           *parameterizedFormItem
-	enableOdaoMetricsBox       *parameterizedFormItem
-	ecMetricsPortBox           *parameterizedFormItem
-	bnMetricsPortBox           *parameterizedFormItem
-	vcMetricsPortBox           *parameterizedFormItem
-	nodeMetricsPortBox         *parameterizedFormItem
-	exporterMetricsPortBox     *parameterizedFormItem
-	watchtowerMetricsPortBox   *parameterizedFormItem
-	grafanaItems               []*parameterizedFormItem
-	prometheusItems            []*parameterizedFormItem
-	exporterItems              []*parameterizedFormItem
-	enableBitflyNodeMetricsBox *parameterizedFormItem
-	bitflyNodeMetricsItems     []*parameterizedFormItem
+	home                         *settingsHome
+	page                         *page
+	layout                       *standardLayout
+	masterConfig                 *config.RocketPoolConfig
+	enableMetricsBox             *parameterizedFormItem
+	enableOdaoMetricsBox         *parameterizedFormItem
+	enablePdaoDelegateMetricsBox *parameterizedFormItem
+	ecMetricsPortBox             *parameterizedFormItem
+	bnMetricsPortBox             *parameterizedFormItem
+	vcMetricsPortBox             *parameterizedFormItem
+	nodeMetricsPortBox           *parameterizedFormItem
+	exporterMetricsPortBox       *parameterizedFormItem
+	watchtowerMetricsPortBox     *parameterizedFormItem
+	grafanaItems                 []*parameterizedFormItem
+	prometheusItems              []*parameterizedFormItem
+	exporterItems                []*parameterizedFormItem
+	enableBitflyNodeMetricsBox   *parameterizedFormItem
+	bitflyNodeMetricsItems       []*parameterizedFormItem
 }
 
 // Creates a new page for the metrics / stats settings
@@ -64,6 +65,7 @@ func (configPage *MetricsConfigPage) createContent() {
 	// Set up the form items
 	configPage.enableMetricsBox = createParameterizedCheckbox(&configPage.masterConfig.EnableMetrics)
 	configPage.enableOdaoMetricsBox = createParameterizedCheckbox(&configPage.masterConfig.EnableODaoMetrics)
+	configPage.enablePdaoDelegateMetricsBox = createParameterizedCheckbox(&configPage.masterConfig.EnablePdaoDelegateMetrics)
 	configPage.ecMetricsPortBox = createParameterizedUint16Field(&configPage.masterConfig.EcMetricsPort)
 	configPage.bnMetricsPortBox = createParameterizedUint16Field(&configPage.masterConfig.BnMetricsPort)
 	configPage.vcMetricsPortBox = createParameterizedUint16Field(&configPage.masterConfig.VcMetricsPort)
@@ -77,7 +79,7 @@ func (configPage *MetricsConfigPage) createContent() {
 	configPage.bitflyNodeMetricsItems = createParameterizedFormItems(configPage.masterConfig.BitflyNodeMetrics.GetParameters(), configPage.layout.descriptionBox)
 
 	// Map the parameters to the form items in the layout
-	configPage.layout.mapParameterizedFormItems(configPage.enableMetricsBox, configPage.enableOdaoMetricsBox, configPage.ecMetricsPortBox, configPage.bnMetricsPortBox, configPage.vcMetricsPortBox, configPage.nodeMetricsPortBox, configPage.exporterMetricsPortBox, configPage.watchtowerMetricsPortBox)
+	configPage.layout.mapParameterizedFormItems(configPage.enableMetricsBox, configPage.enableOdaoMetricsBox, configPage.enablePdaoDelegateMetricsBox, configPage.ecMetricsPortBox, configPage.bnMetricsPortBox, configPage.vcMetricsPortBox, configPage.nodeMetricsPortBox, configPage.exporterMetricsPortBox, configPage.watchtowerMetricsPortBox)
 	configPage.layout.mapParameterizedFormItems(configPage.grafanaItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.prometheusItems...)
 	configPage.layout.mapParameterizedFormItems(configPage.exporterItems...)
@@ -110,7 +112,7 @@ func (configPage *MetricsConfigPage) handleLayoutChanged() {
 	configPage.layout.form.AddFormItem(configPage.enableMetricsBox.item)
 
 	if configPage.masterConfig.EnableMetrics.Value == true {
-		configPage.layout.addFormItems([]*parameterizedFormItem{configPage.enableOdaoMetricsBox, configPage.ecMetricsPortBox, configPage.bnMetricsPortBox, configPage.vcMetricsPortBox, configPage.nodeMetricsPortBox, configPage.exporterMetricsPortBox, configPage.watchtowerMetricsPortBox})
+		configPage.layout.addFormItems([]*parameterizedFormItem{configPage.enableOdaoMetricsBox, configPage.enablePdaoDelegateMetricsBox, configPage.ecMetricsPortBox, configPage.bnMetricsPortBox, configPage.vcMetricsPortBox, configPage.nodeMetricsPortBox, configPage.exporterMetricsPortBox, configPage.watchtowerMetricsPortBox})
 		configPage.layout.addFormItems(configPage.grafanaItems)
 		configPage.layout.addFormItems(configPage.prometheusItems)
 		configPage.layout.addFormItems(configPage.exporterItems)
@@ -19,6 +19,7 @@ type settingsNativeHome struct {
 	fallbackPage     *NativeFallbackConfigPage
 	metricsPage      *NativeMetricsConfigPage
 	alertingPage     *AlertingConfigPage
+	backupPage       *BackupConfigPage
 	categoryList     *tview.List
 	settingsSubpages []*page
 	content          tview.Primitive
@@ -42,12 +43,14 @@ func newSettingsNativeHome(md *mainDisplay) *settingsNativeHome {
 	home.fallbackPage = NewNativeFallbackConfigPage(home)
 	home.metricsPage = NewNativeMetricsConfigPage(home)
 	home.alertingPage = NewAlertingConfigPageForNative(home)
+	home.backupPage = NewBackupConfigPageForNative(home)
 	settingsSubpages := []*page{
 		home.smartnodePage.page,
 		home.nativePage.page,
 		home.fallbackPage.page,
 		home.metricsPage.page,
 		home.alertingPage.page,
+		home.backupPage.page,
 	}
 	home.settingsSubpages = settingsSubpages
 
@@ -219,4 +222,8 @@ func (home *settingsNativeHome) refresh() {
 	if home.alertingPage != nil {
 		home.alertingPage.layout.refresh()
 	}
+
+	if home.backupPage != nil {
+		home.backupPage.layout.refresh()
+	}
 }
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+const (
+	// The free space below which a volume's disk fails the doctor check
+	doctorMinFreeSpace uint64 = 10 * 1024 * 1024 * 1024
+
+	// The time to wait for a port to accept a connection
+	portCheckTimeout time.Duration = 3 * time.Second
+)
+
+// A volume checked for free space
+type doctorVolume struct {
+	name        string
+	container   string
+	mountTarget string
+}
+
+// Run a suite of checks on the node and print a report with how to fix the problems. Exits with status 1 if any check
+// failed, so scripts and monitoring can tell.
+func runDoctor(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("No configuration detected. Please run `rocketpool service config` to set up your Smart Node before running the doctor.")
+	}
+
+	if !c.Bool("json") {
+		fmt.Println("Running checks, this may take a minute...")
+		fmt.Println()
+	}
+
+	// Run the checks
+	checks := []api.DoctorCheck{checkWallet(rp)}
+	response, err := rp.RunDoctor()
+	if err != nil {
+		checks = append(checks, api.DoctorCheck{
+			Name:        "Smartnode API",
+			Result:      api.DoctorCheckResult_Fail,
+			Message:     err.Error(),
+			Remediation: "Make sure the Smartnode is running with `rocketpool service status`; start it with `rocketpool service start`.",
+		})
+	} else {
+		checks = append(checks, response.Checks...)
+	}
+	if !cfg.IsNativeMode {
+		checks = append(checks, checkDiskSpace(cfg, rp)...)
+		checks = append(checks, checkPorts(cfg)...)
+	}
+
+	// Print the report
+	if c.Bool("json") {
+		bytes, err := json.MarshalIndent(checks, "", "    ")
+		if err != nil {
+			return fmt.Errorf("error serializing report: %w", err)
+		}
+		fmt.Println(string(bytes))
+	} else {
+		printDoctorReport(checks)
+	}
+	for _, check := range checks {
+		if check.Result == api.DoctorCheckResult_Fail {
+			// Exit with status 1 once the action returns so rp.Close() still runs
+			return cli.NewExitError("", 1)
+		}
+	}
+	return nil
+
+}
+
+// Check that the node wallet and its password are present
+func checkWallet(rp *rocketpool.Client) api.DoctorCheck {
+	check := api.DoctorCheck{
+		Name: "Node wallet",
+	}
+	status, err := rp.WalletStatus()
+	switch {
+	case err != nil:
+		check.Result = api.DoctorCheckResult_Fail
+		check.Message = fmt.Sprintf("Couldn't get the wallet status: %s", err.Error())
+		check.Remediation = "Make sure the Smartnode is running with `rocketpool service status`."
+	case !status.PasswordSet:
+		check.Result = api.DoctorCheckResult_Fail
+		check.Message = "The wallet password isn't stored, so the node can't load its wallet."
+		check.Remediation = "Run `rocketpool wallet recover` with your mnemonic; it asks for the wallet password and stores it again. If this node has never had a wallet, run `rocketpool wallet init` instead."
+	case !status.WalletInitialized:
+		check.Result = api.DoctorCheckResult_Fail
+		check.Message = "The wallet password is stored, but there's no wallet."
+		check.Remediation = "Run `rocketpool wallet init` to create a wallet, or `rocketpool wallet recover` to restore an existing one."
+	default:
+		check.Result = api.DoctorCheckResult_Pass
+		check.Message = fmt.Sprintf("The wallet for node account %s is loaded.", status.AccountAddress.Hex())
+	}
+	return check
+}
+
+// Check the free space on the disks of the Smartnode's volumes
+func checkDiskSpace(cfg *config.RocketPoolConfig, rp *rocketpool.Client) []api.DoctorCheck {
+	prefix := fmt.Sprint(cfg.Smartnode.ProjectName.Value)
+	volumes := []doctorVolume{
+		{name: "Data folder", container: prefix + ApiContainerSuffix, mountTarget: dataFolderVolumeName},
+	}
+	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		volumes = append(volumes, doctorVolume{name: "Execution client data", container: prefix + ExecutionContainerSuffix, mountTarget: clientDataVolumeName})
+	}
+	if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		volumes = append(volumes, doctorVolume{name: "Consensus client data", container: prefix + BeaconContainerSuffix, mountTarget: clientDataVolumeName})
+	}
+
+	checks := []api.DoctorCheck{}
+	for _, volume := range volumes {
+		checks = append(checks, checkVolumeDiskSpace(rp, volume))
+	}
+	return checks
+}
+
+// Check the free space on the disk of a volume
+func checkVolumeDiskSpace(rp *rocketpool.Client, volume doctorVolume) api.DoctorCheck {
+	check := api.DoctorCheck{
+		Name: fmt.Sprintf("%s disk space", volume.name),
+	}
+	source, err := rp.GetClientVolumeSource(volume.container, volume.mountTarget)
+	if err != nil || source == "" {
+		check.Result = api.DoctorCheckResult_Warn
+		check.Message = fmt.Sprintf("Couldn't find the volume of the %s container.", volume.container)
+		check.Remediation = "Make sure the container exists with `rocketpool service status`."
+		return check
+	}
+	free, err := getPartitionFreeSpace(rp, source)
+	if err != nil {
+		check.Result = api.DoctorCheckResult_Warn
+		check.Message = fmt.Sprintf("Couldn't get the free space of %s: %s", source, err.Error())
+		return check
+	}
+
+	// Named volumes also report how much they use; bind-mounted folders don't
+	usage := ""
+	volumeName, err := rp.GetClientVolumeName(volume.container, volume.mountTarget)
+	if err == nil && volumeName != "" {
+		used, err := getVolumeSpaceUsed(rp, volumeName)
+		if err == nil {
+			usage = fmt.Sprintf(" uses %s and", humanize.IBytes(used))
+		}
+	}
+	check.Message = fmt.Sprintf("%s%s has %s free on its disk.", source, usage, humanize.IBytes(free))
+
+	switch {
+	case free < doctorMinFreeSpace:
+		check.Result = api.DoctorCheckResult_Fail
+		check.Remediation = "Free up space on the disk now; your clients will stop working when it's full. Pruning the Execution client with `rocketpool service prune-eth1` may help."
+	case free < PruneFreeSpaceRequired:
+		check.Result = api.DoctorCheckResult_Warn
+		check.Remediation = fmt.Sprintf("Free up space on the disk soon; pruning the Execution client needs %s free.", humanize.IBytes(PruneFreeSpaceRequired))
+	default:
+		check.Result = api.DoctorCheckResult_Pass
+	}
+	return check
+}
+
+// Check that the local clients are listening on their P2P ports. This only connects from this machine, so it can't tell
+// whether a firewall or router blocks the ports from the internet.
+func checkPorts(cfg *config.RocketPoolConfig) []api.DoctorCheck {
+	checks := []api.DoctorCheck{}
+	if cfg.ExecutionClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		checks = append(checks, checkPort("Execution client P2P port listening", cfg.ExecutionCommon.P2pPort.Value.(uint16)))
+	}
+	if cfg.ConsensusClientMode.Value.(cfgtypes.Mode) == cfgtypes.Mode_Local {
+		checks = append(checks, checkPort("Consensus client P2P port listening", cfg.ConsensusCommon.P2pPort.Value.(uint16)))
+	}
+	return checks
+}
+
+// Check that a port accepts TCP connections from this machine
+func checkPort(name string, port uint16) api.DoctorCheck {
+	check := api.DoctorCheck{
+		Name: name,
+	}
+	conn, err := net.DialTimeout("tcp", fmt.Sprintf("127.0.0.1:%d", port), portCheckTimeout)
+	if err != nil {
+		check.Result = api.DoctorCheckResult_Fail
+		check.Message = fmt.Sprintf("Nothing is listening on TCP port %d on this machine: %s", port, err.Error())
+		check.Remediation = "Make sure the client is running with `rocketpool service status`, and that no other program is using the port."
+		return check
+	}
+	conn.Close()
+	check.Result = api.DoctorCheckResult_Pass
+	check.Message = fmt.Sprintf("TCP port %d is listening on this machine. This doesn't check that peers can reach it; make sure your firewall and router forward it from the internet.", port)
+	return check
+}
+
+// Print the results of the doctor checks
+func printDoctorReport(checks []api.DoctorCheck) {
+	passed, warnings, failed := 0, 0, 0
+	for _, check := range checks {
+		var label string
+		switch check.Result {
+		case api.DoctorCheckResult_Pass:
+			label = fmt.Sprintf("%s[PASS]%s", colorGreen, colorReset)
+			passed++
+		case api.DoctorCheckResult_Warn:
+			label = fmt.Sprintf("%s[WARN]%s", colorYellow, colorReset)
+			warnings++
+		default:
+			label = fmt.Sprintf("%s[FAIL]%s", colorRed, colorReset)
+			failed++
+		}
+		fmt.Printf("%s %s%s%s: %s\n", label, colorBold, check.Name, colorReset, check.Message)
+		if check.Remediation != "" {
+			fmt.Printf("       %s\n", check.Remediation)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("%d passed, %d warnings, %d failed.\n", passed, warnings, failed)
+}
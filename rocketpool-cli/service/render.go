@@ -0,0 +1,214 @@
+package service
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool/template"
+	"github.com/rocket-pool/smartnode/shared/utils/diff"
+)
+
+// The number of unchanged lines to show around each change
+const renderDiffContext int = 3
+
+// Render the service templates for the current config into a separate folder, validate them, and compare them to the
+// deployed files
+func renderTemplates(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("No configuration detected. Please run `rocketpool service config` to set up your Smart Node before running it.")
+	}
+	printUserAddonErrors(cfg)
+
+	// `service start` applies the latest defaults after an upgrade, so the preview has to as well
+	isUpdate, err := rp.IsFirstRun()
+	if err != nil {
+		return fmt.Errorf("error checking for first-run status: %w", err)
+	}
+	if isUpdate {
+		fmt.Printf("%sSmart Node upgrade detected - the templates are rendered with the latest defaults (such as container versions), which `service start` will apply.%s\n\n", colorYellow, colorReset)
+		err = cfg.UpdateDefaults()
+		if err != nil {
+			return fmt.Errorf("error upgrading configuration with the latest parameters: %w", err)
+		}
+	}
+
+	// Check the output folder; rendering into a folder that already has files would mix them into the comparison
+	configPath, err := homedir.Expand(rp.ConfigPath())
+	if err != nil {
+		return fmt.Errorf("error expanding config path: %w", err)
+	}
+	outDir, err := homedir.Expand(c.String("out"))
+	if err != nil {
+		return fmt.Errorf("error expanding output path: %w", err)
+	}
+	outDir, err = filepath.Abs(outDir)
+	if err != nil {
+		return fmt.Errorf("error getting absolute output path: %w", err)
+	}
+	entries, err := os.ReadDir(outDir)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error reading output folder %s: %w", outDir, err)
+	}
+	if len(entries) > 0 {
+		return fmt.Errorf("%s is not empty; please choose a new or empty folder to render the templates into", outDir)
+	}
+
+	// Render the templates
+	composeFiles, err := rp.RenderTemplates(cfg, outDir)
+	if err != nil {
+		return fmt.Errorf("error rendering templates: %w", err)
+	}
+	fmt.Printf("Rendered the templates for your current configuration into %s.\n\n", outDir)
+
+	// Validate the compose project
+	errors, warnings := template.ValidateComposeProject(composeFiles)
+	for _, warning := range warnings {
+		fmt.Printf("%sWARNING: %s%s\n", colorYellow, warning, colorReset)
+	}
+	if len(warnings) > 0 {
+		fmt.Println()
+	}
+	if len(errors) > 0 {
+		fmt.Printf("%sThe rendered compose files have the following problems:\n\n", colorRed)
+		for _, err := range errors {
+			fmt.Printf("%s\n", err)
+		}
+		fmt.Printf("%s\n", colorReset)
+	} else {
+		fmt.Printf("%sThe rendered compose files are valid.%s\n\n", colorGreen, colorReset)
+	}
+	for _, err := range cfg.Validate() {
+		fmt.Printf("%sConfiguration error: %s%s\n\n", colorRed, err, colorReset)
+	}
+
+	// Compare them to the deployed files
+	err = printRenderDiff(outDir, configPath)
+	if err != nil {
+		return err
+	}
+
+	if len(errors) > 0 {
+		return fmt.Errorf("the rendered compose files have %d problem(s)", len(errors))
+	}
+	return nil
+
+}
+
+// Print the differences between the rendered files and the deployed ones
+func printRenderDiff(outDir string, configPath string) error {
+
+	// Get the rendered files
+	renderedFiles := []string{}
+	err := filepath.WalkDir(outDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			relativePath, err := filepath.Rel(outDir, path)
+			if err != nil {
+				return err
+			}
+			renderedFiles = append(renderedFiles, relativePath)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error reading rendered files: %w", err)
+	}
+	sort.Strings(renderedFiles)
+	rendered := map[string]bool{}
+
+	// Compare each one to its deployed version
+	changed, added := 0, 0
+	for _, relativePath := range renderedFiles {
+		rendered[relativePath] = true
+		newBytes, err := os.ReadFile(filepath.Join(outDir, relativePath))
+		if err != nil {
+			return fmt.Errorf("error reading rendered file %s: %w", relativePath, err)
+		}
+		oldBytes, err := os.ReadFile(filepath.Join(configPath, relativePath))
+		if os.IsNotExist(err) {
+			fmt.Printf("%s%s is new.%s\n\n", colorGreen, relativePath, colorReset)
+			added++
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error reading deployed file %s: %w", relativePath, err)
+		}
+
+		fileDiff := diff.Unified("deployed/"+relativePath, "rendered/"+relativePath, string(oldBytes), string(newBytes), renderDiffContext)
+		if fileDiff == "" {
+			continue
+		}
+		changed++
+		for _, line := range strings.Split(strings.TrimSuffix(fileDiff, "\n"), "\n") {
+			switch {
+			case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+				fmt.Printf("%s%s%s\n", colorBold, line, colorReset)
+			case strings.HasPrefix(line, "@@"):
+				fmt.Printf("%s%s%s\n", colorLightBlue, line, colorReset)
+			case strings.HasPrefix(line, "-"):
+				fmt.Printf("%s%s%s\n", colorRed, line, colorReset)
+			case strings.HasPrefix(line, "+"):
+				fmt.Printf("%s%s%s\n", colorGreen, line, colorReset)
+			default:
+				fmt.Println(line)
+			}
+		}
+		fmt.Println()
+	}
+
+	// `service start` recreates the runtime folder, so anything in it that wasn't rendered will be removed
+	removed := 0
+	runtimeFolder := filepath.Join(configPath, "runtime")
+	err = filepath.WalkDir(runtimeFolder, func(path string, entry fs.DirEntry, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		relativePath, err := filepath.Rel(configPath, path)
+		if err != nil {
+			return err
+		}
+		if !rendered[relativePath] {
+			fmt.Printf("%s%s will be removed.%s\n\n", colorRed, relativePath, colorReset)
+			removed++
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error reading deployed files: %w", err)
+	}
+
+	// Print a summary
+	if changed+added+removed == 0 {
+		fmt.Println("The rendered files match the deployed ones.")
+		return nil
+	}
+	fmt.Printf("Compared to the deployed files, %d file(s) changed, %d are new, and %d will be removed. Run `rocketpool service start` to deploy them.\n", changed, added, removed)
+	return nil
+
+}
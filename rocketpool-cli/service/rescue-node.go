@@ -0,0 +1,214 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/addons/rescue_node"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The site that issues Rescue Node credentials
+const rescueNodeUrl string = "https://rescuenode.com"
+
+// Print the status of the Rescue Node credential
+func getRescueNodeStatus(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, err := loadRescueNodeConfig(rp)
+	if err != nil {
+		return err
+	}
+	rescueNode := cfg.RescueNode.(*rescue_node.RescueNode)
+
+	if rescueNode.GetEnabledParameter().Value == true {
+		fmt.Printf("The Rescue Node is %senabled%s; your validator client is connected to it instead of your own Consensus client.\n", colorYellow, colorReset)
+	} else {
+		fmt.Println("The Rescue Node is disabled; your validator client is connected to your own Consensus client.")
+	}
+
+	// Print the credential
+	status, err := rescueNode.GetCredentialStatus()
+	if err != nil {
+		fmt.Printf("No usable credential is configured (%s).\nRun `rocketpool service rescue-node request-credential` to request one.\n", err.Error())
+		return nil
+	}
+	printRescueNodeCredential(status)
+	return nil
+
+}
+
+// Switch the validator client to the Rescue Node
+func enableRescueNode(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, err := loadRescueNodeConfig(rp)
+	if err != nil {
+		return err
+	}
+	rescueNode := cfg.RescueNode.(*rescue_node.RescueNode)
+	if rescueNode.GetEnabledParameter().Value == true && c.String("username") == "" && c.String("password") == "" {
+		fmt.Println("The Rescue Node is already enabled.")
+		return nil
+	}
+
+	// Set the new credential if one was provided
+	if c.String("username") != "" || c.String("password") != "" {
+		if c.String("username") == "" || c.String("password") == "" {
+			return fmt.Errorf("both --username and --password are required to set a new credential")
+		}
+		rescueNode.SetCredential(c.String("username"), c.String("password"))
+	}
+
+	// Check the credential
+	status, err := rescueNode.GetCredentialStatus()
+	if err != nil {
+		return fmt.Errorf("the Rescue Node credential is not usable: %w\nRun `rocketpool service rescue-node request-credential` to request a credential, then enable the Rescue Node with `--username` and `--password`.", err)
+	}
+	printRescueNodeCredential(status)
+	if status.GetTimeLeft() <= 0 {
+		return fmt.Errorf("the credential has expired; please request a new one")
+	}
+	walletStatus, err := rp.WalletStatus()
+	if err == nil && walletStatus.WalletInitialized && status.NodeAddress != walletStatus.AccountAddress {
+		fmt.Printf("%sWARNING: This credential was issued to %s, but your node account is %s.%s\n", colorYellow, status.NodeAddress.Hex(), walletStatus.AccountAddress.Hex(), colorReset)
+		if !(c.Bool("yes") || cliutils.Confirm("Are you sure you want to use it?")) {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	// Enable it and make sure the validator client can use it
+	rescueNode.SetEnabled(true)
+	cc, _ := cfg.GetSelectedConsensusClient()
+	_, err = rescueNode.GetOverrides(cc)
+	if err != nil {
+		return err
+	}
+
+	return saveRescueNodeConfig(c, rp, cfg, "Rescue Node enabled.")
+
+}
+
+// Switch the validator client back to the node's own Consensus client
+func disableRescueNode(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, err := loadRescueNodeConfig(rp)
+	if err != nil {
+		return err
+	}
+	rescueNode := cfg.RescueNode.(*rescue_node.RescueNode)
+	if rescueNode.GetEnabledParameter().Value == false {
+		fmt.Println("The Rescue Node is already disabled.")
+		return nil
+	}
+
+	// The credential is kept so the Rescue Node can be enabled again while it's still valid
+	rescueNode.SetEnabled(false)
+	return saveRescueNodeConfig(c, rp, cfg, "Rescue Node disabled; your validator client will use your own Consensus client.")
+
+}
+
+// Sign a Rescue Node credential request with the node wallet
+func requestRescueNodeCredential(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the node account
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Sign the request
+	message := rescue_node.GetCredentialRequestMessage(time.Now())
+	response, err := rp.SignMessage(message)
+	if err != nil {
+		return err
+	}
+	request := rescue_node.NewCredentialRequest(status.AccountAddress, message, response.SignedData)
+	bytes, err := json.MarshalIndent(request, "", "    ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Signed credential request:\n\n%s\n\n", string(bytes))
+	fmt.Printf("Paste it into the form at %s to get a username and password, then run `rocketpool service rescue-node enable --username <username> --password <password>`.\n", rescueNodeUrl)
+	return nil
+
+}
+
+// Load the config for a Rescue Node command
+func loadRescueNodeConfig(rp *rocketpool.Client) (*config.RocketPoolConfig, error) {
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew {
+		return nil, fmt.Errorf("No configuration detected. Please run `rocketpool service config` to set up your Smart Node before using the Rescue Node.")
+	}
+	if cfg.IsNativeMode {
+		return nil, fmt.Errorf("The Rescue Node add-on is incompatible with native mode.\nYou can still connect manually, visit the rescue node website for more information.")
+	}
+	return cfg, nil
+}
+
+// Save the config after a Rescue Node change and restart the validator client so it takes effect
+func saveRescueNodeConfig(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig, message string) error {
+	err := rp.SaveConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("error saving config: %w", err)
+	}
+	fmt.Printf("%s%s%s\n\n", colorGreen, message, colorReset)
+
+	prefix := fmt.Sprint(cfg.Smartnode.ProjectName.Value)
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("The %s_%s container must be restarted for the change to take effect. Would you like to restart it now?", prefix, cfgtypes.ContainerID_Validator))) {
+		fmt.Println("Please run `rocketpool service start` when you are ready to apply the change.")
+		return nil
+	}
+	return restartContainers(c, rp, prefix, []cfgtypes.ContainerID{cfgtypes.ContainerID_Validator})
+}
+
+// Print the details of a Rescue Node credential
+func printRescueNodeCredential(status *rescue_node.CredentialStatus) {
+	fmt.Printf("The credential was issued to %s%s%s on %s", colorLightBlue, status.NodeAddress.Hex(), colorReset, status.Issued.Format(time.RFC822))
+	if status.Solo {
+		fmt.Print(" for a solo staker")
+	}
+	fmt.Println(".")
+
+	timeLeft := status.GetTimeLeft().Truncate(time.Second)
+	switch {
+	case timeLeft <= 0:
+		fmt.Printf("%sIt expired %s ago.%s\n\n", colorRed, -timeLeft, colorReset)
+	case timeLeft < rescue_node.CredentialExpiryWarning:
+		fmt.Printf("%sIt expires in %s.%s\n\n", colorYellow, timeLeft, colorReset)
+	default:
+		fmt.Printf("%sIt expires in %s.%s\n\n", colorGreen, timeLeft, colorReset)
+	}
+}
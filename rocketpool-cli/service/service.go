@@ -20,6 +20,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
 	sharedConfig "github.com/rocket-pool/smartnode/shared/types/config"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
@@ -50,6 +51,10 @@ const (
 	colorGreen             string = "\033[32m"
 	colorLightBlue         string = "\033[36m"
 	clearLine              string = "\033[2K"
+
+	// How long to keep retrying a slashing protection import while the Beacon Node it needs starts up
+	slashingProtectionImportTimeout       time.Duration = 5 * time.Minute
+	slashingProtectionImportRetryInterval time.Duration = 15 * time.Second
 )
 
 // Install the Rocket Pool service
@@ -216,6 +221,7 @@ func configureService(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error loading user settings: %w", err)
 	}
+	printUserAddonErrors(cfg)
 
 	// Check if this is a new install
 	isUpdate, err := rp.IsFirstRun()
@@ -235,11 +241,29 @@ func configureService(c *cli.Context) error {
 
 	// Save the config and exit in headless mode
 	if c.NumFlags() > 0 {
+		previousCfg := cfg.CreateCopy()
 		err := configureHeadless(c, cfg)
 		if err != nil {
 			return fmt.Errorf("error updating config from provided arguments: %w", err)
 		}
-		return rp.SaveConfig(cfg)
+		err = rp.SaveConfig(cfg)
+		if err != nil {
+			return err
+		}
+
+		// Carry the slashing protection history over if the validator client changed
+		oldCc, _ := previousCfg.GetSelectedConsensusClient()
+		newCc, _ := cfg.GetSelectedConsensusClient()
+		if !isNew && !cfg.IsNativeMode && oldCc != newCc {
+			// Headless runs can't ask questions and never start the service, so the containers are left stopped
+			prefix := fmt.Sprint(previousCfg.Smartnode.ProjectName.Value)
+			_, err := migrateSlashingProtection(c, rp, previousCfg, cfg, prefix, true)
+			if err != nil {
+				return fmt.Errorf("error moving the slashing protection database to %s: %w", newCc, err)
+			}
+			fmt.Println("Please run `rocketpool service start` when you are ready to launch the new validator client.")
+		}
+		return nil
 	}
 
 	// Check for native mode
@@ -311,17 +335,18 @@ func configureService(c *cli.Context) error {
 				return nil
 			}
 
-			fmt.Println()
-			for _, container := range md.ContainersToRestart {
-				fullName := fmt.Sprintf("%s_%s", prefix, container)
-				fmt.Printf("Stopping %s... ", fullName)
-				rp.StopContainer(fullName)
-				fmt.Print("done!\n")
+			// Carry the slashing protection history over if the validator client changed
+			oldCc, _ := md.PreviousConfig.GetSelectedConsensusClient()
+			newCc, _ := md.Config.GetSelectedConsensusClient()
+			if oldCc != newCc {
+				_, err = migrateSlashingProtection(c, rp, md.PreviousConfig, md.Config, prefix, false)
+				if err != nil {
+					fmt.Printf("%sWARNING: The slashing protection database could not be moved to %s: %s%s\n\n", colorYellow, newCc, err.Error(), colorReset)
+					return nil
+				}
 			}
 
-			fmt.Println()
-			fmt.Println("Applying changes and restarting containers...")
-			return startService(c, true)
+			return restartContainers(c, rp, prefix, md.ContainersToRestart)
 		}
 	} else {
 		fmt.Println("Your changes have not been saved. Your Smart Node configuration is the same as it was before.")
@@ -331,6 +356,33 @@ func configureService(c *cli.Context) error {
 	return err
 }
 
+// Stop the provided containers and start the service again so they pick up the new settings
+func restartContainers(c *cli.Context, rp *rocketpool.Client, prefix string, containers []cfgtypes.ContainerID) error {
+	fmt.Println()
+	for _, container := range containers {
+		fullName := fmt.Sprintf("%s_%s", prefix, container)
+		fmt.Printf("Stopping %s... ", fullName)
+		rp.StopContainer(fullName)
+		fmt.Print("done!\n")
+	}
+
+	fmt.Println()
+	fmt.Println("Applying changes and restarting containers...")
+	return startService(c, true)
+}
+
+// Print the reasons any of the addons in the user addons folder couldn't be loaded
+func printUserAddonErrors(cfg *config.RocketPoolConfig) {
+	if len(cfg.UserAddonErrors) == 0 {
+		return
+	}
+	fmt.Printf("%sWARNING: The following user addons couldn't be loaded and will be ignored:\n", colorYellow)
+	for _, err := range cfg.UserAddonErrors {
+		fmt.Printf("  %s\n", err)
+	}
+	fmt.Printf("%s\n", colorReset)
+}
+
 // Updates a configuration from the provided CLI arguments headlessly
 func configureHeadless(c *cli.Context, cfg *config.RocketPoolConfig) error {
 
@@ -492,6 +544,7 @@ func startService(c *cli.Context, ignoreConfigSuggestion bool) error {
 	if isNew {
 		return fmt.Errorf("No configuration detected. Please run `rocketpool service config` to set up your Smart Node before running it.")
 	}
+	printUserAddonErrors(cfg)
 
 	// Check if this is a new install
 	isUpdate, err := rp.IsFirstRun()
@@ -898,6 +951,93 @@ func checkForValidatorChange(rp *rocketpool.Client, cfg *config.RocketPoolConfig
 	return nil
 }
 
+// Move the slashing protection database from the previous validator client to the new one when switching clients.
+// The new config must already be saved. This recreates the containers for it but leaves them stopped, so the caller is
+// responsible for starting the service afterwards if it returns true.
+func migrateSlashingProtection(c *cli.Context, rp *rocketpool.Client, oldCfg *config.RocketPoolConfig, newCfg *config.RocketPoolConfig, prefix string, yes bool) (bool, error) {
+
+	oldCc, _ := oldCfg.GetSelectedConsensusClient()
+	newCc, _ := newCfg.GetSelectedConsensusClient()
+	fmt.Printf("You have changed your validator client from %s to %s.\n", oldCc, newCc)
+	if !(yes || cliutils.Confirm(fmt.Sprintf("Would you like to carry your slashing protection history over from %s to %s? This will stop your validator client until the new one is ready.", oldCc, newCc))) {
+		fmt.Println("The slashing protection history will not be moved. You can do it later with `rocketpool wallet slashing-protection export` and `import`.")
+		return false, nil
+	}
+
+	// Stop the old validator client so its database is consistent
+	validatorContainer := prefix + ValidatorContainerSuffix
+	status, err := rp.GetDockerStatus(validatorContainer)
+	if err != nil {
+		return false, fmt.Errorf("error getting validator client status: %w", err)
+	}
+	wasRunning := (status == "running")
+	if wasRunning {
+		fmt.Printf("Stopping %s...\n", validatorContainer)
+		if _, err := rp.StopContainer(validatorContainer); err != nil {
+			return false, fmt.Errorf("error stopping validator client: %w", err)
+		}
+	}
+
+	// Export it from the old client, starting it again if that fails
+	fmt.Printf("Exporting the %s slashing protection database...\n", oldCc)
+	data, err := rp.ExportSlashingProtectionForClient(oldCfg, oldCc)
+	if err != nil {
+		if wasRunning {
+			fmt.Printf("Starting %s again...\n", validatorContainer)
+			if _, startErr := rp.StartContainer(validatorContainer); startErr != nil {
+				return false, fmt.Errorf("%w (the validator client could not be started again either: %s)", err, startErr.Error())
+			}
+		}
+		return false, err
+	}
+
+	// Keep a copy so it can be imported manually if anything goes wrong from here on
+	configPath, err := homedir.Expand(rp.ConfigPath())
+	if err != nil {
+		return false, fmt.Errorf("error expanding config path: %w", err)
+	}
+	backupPath := filepath.Join(configPath, keystore.SlashingProtectionFile)
+	if err := os.WriteFile(backupPath, data, 0600); err != nil {
+		return false, fmt.Errorf("error saving the exported database to %s: %w", backupPath, err)
+	}
+
+	// Create the new client's containers without starting them, so nothing signs before the history is imported
+	fmt.Printf("Creating the containers for %s...\n", newCc)
+	if err := rp.CreateService(getComposeFiles(c)); err != nil {
+		return false, fmt.Errorf("error creating the containers for %s: %w\nYour validator client is stopped. The exported database was saved to %s; import it with `rocketpool wallet slashing-protection import %s` before running `rocketpool service start`", newCc, err, backupPath, backupPath)
+	}
+
+	// Lodestar's import looks the chain up on the Beacon Node, so a local one has to be running first
+	_, newMode := newCfg.GetSelectedConsensusClient()
+	needsBeaconNode := (newCc == cfgtypes.ConsensusClient_Lodestar && newMode == cfgtypes.Mode_Local)
+	if needsBeaconNode {
+		beaconContainer := prefix + BeaconContainerSuffix
+		fmt.Printf("Starting %s so the history can be imported...\n", beaconContainer)
+		if _, err := rp.StartContainer(beaconContainer); err != nil {
+			return false, fmt.Errorf("error starting %s: %w\nYour validator client is stopped. The exported database was saved to %s; import it with `rocketpool wallet slashing-protection import %s` before running `rocketpool service start`", beaconContainer, err, backupPath, backupPath)
+		}
+	}
+
+	// Import it into the new one, giving a Beacon Node that was just started time to come up
+	fmt.Printf("Importing it into %s...\n", newCc)
+	deadline := time.Now().Add(slashingProtectionImportTimeout)
+	for {
+		err = rp.ImportSlashingProtectionForClient(newCfg, newCc, data)
+		if err == nil || !needsBeaconNode || time.Now().After(deadline) {
+			break
+		}
+		fmt.Println("The Beacon Node isn't ready yet, trying again shortly...")
+		time.Sleep(slashingProtectionImportRetryInterval)
+	}
+	if err != nil {
+		return false, fmt.Errorf("%w\nYour validator client is stopped. The exported database was saved to %s; import it with `rocketpool wallet slashing-protection import %s` before running `rocketpool service start`", err, backupPath, backupPath)
+	}
+
+	fmt.Printf("%sThe slashing protection history was moved to %s. A copy was saved to %s.%s\n\n", colorGreen, newCc, backupPath, colorReset)
+	return true, nil
+
+}
+
 // Get the name of the container responsible for validator duties based on the client name
 func getContainerNameForValidatorDuties(CurrentValidatorClientName string, rp *rocketpool.Client) (string, error) {
 
@@ -1308,18 +1448,31 @@ func serviceVersion(c *cli.Context) error {
 		return err
 	}
 
+	// Print version info
+	versionInfo, err := getServiceVersionInfo(c, rp, cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Print(versionInfo)
+	return nil
+
+}
+
+// Get the versions of the Rocket Pool client and service, and the clients they're configured to use
+func getServiceVersionInfo(c *cli.Context, rp *rocketpool.Client, cfg *config.RocketPoolConfig) (string, error) {
+
 	// Get RP service version
 	serviceVersion, err := rp.GetServiceVersion()
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// Handle native mode
 	if cfg.IsNativeMode {
-		fmt.Printf("Rocket Pool client version: %s\n", c.App.Version)
-		fmt.Printf("Rocket Pool service version: %s\n", serviceVersion)
-		fmt.Println("Configured for Native Mode")
-		return nil
+		versionInfo := fmt.Sprintf("Rocket Pool client version: %s\n", c.App.Version)
+		versionInfo += fmt.Sprintf("Rocket Pool service version: %s\n", serviceVersion)
+		versionInfo += "Configured for Native Mode\n"
+		return versionInfo, nil
 	}
 
 	// Get the execution client string
@@ -1339,14 +1492,14 @@ func serviceVersion(c *cli.Context) error {
 		case cfgtypes.ExecutionClient_Reth:
 			eth1ClientString = fmt.Sprintf(format, "Reth", cfg.Reth.ContainerTag.Value.(string))
 		default:
-			return fmt.Errorf("unknown local execution client [%v]", eth1Client)
+			return "", fmt.Errorf("unknown local execution client [%v]", eth1Client)
 		}
 
 	case cfgtypes.Mode_External:
 		eth1ClientString = "Externally managed"
 
 	default:
-		return fmt.Errorf("unknown execution client mode [%v]", eth1ClientMode)
+		return "", fmt.Errorf("unknown execution client mode [%v]", eth1ClientMode)
 	}
 
 	// Get the consensus client string
@@ -1368,7 +1521,7 @@ func serviceVersion(c *cli.Context) error {
 		case cfgtypes.ConsensusClient_Teku:
 			eth2ClientString = fmt.Sprintf(format, "Teku", cfg.Teku.ContainerTag.Value.(string))
 		default:
-			return fmt.Errorf("unknown local consensus client [%v]", eth2Client)
+			return "", fmt.Errorf("unknown local consensus client [%v]", eth2Client)
 		}
 
 	case cfgtypes.Mode_External:
@@ -1386,11 +1539,11 @@ func serviceVersion(c *cli.Context) error {
 		case cfgtypes.ConsensusClient_Teku:
 			eth2ClientString = fmt.Sprintf(format, "Teku", cfg.ExternalTeku.ContainerTag.Value.(string))
 		default:
-			return fmt.Errorf("unknown external consensus client [%v]", eth2Client)
+			return "", fmt.Errorf("unknown external consensus client [%v]", eth2Client)
 		}
 
 	default:
-		return fmt.Errorf("unknown consensus client mode [%v]", eth2ClientMode)
+		return "", fmt.Errorf("unknown consensus client mode [%v]", eth2ClientMode)
 	}
 
 	var mevBoostString string
@@ -1404,13 +1557,12 @@ func serviceVersion(c *cli.Context) error {
 		mevBoostString = "Disabled"
 	}
 
-	// Print version info
-	fmt.Printf("Rocket Pool client version: %s\n", c.App.Version)
-	fmt.Printf("Rocket Pool service version: %s\n", serviceVersion)
-	fmt.Printf("Selected Eth 1.0 client: %s\n", eth1ClientString)
-	fmt.Printf("Selected Eth 2.0 client: %s\n", eth2ClientString)
-	fmt.Printf("MEV-Boost client: %s\n", mevBoostString)
-	return nil
+	versionInfo := fmt.Sprintf("Rocket Pool client version: %s\n", c.App.Version)
+	versionInfo += fmt.Sprintf("Rocket Pool service version: %s\n", serviceVersion)
+	versionInfo += fmt.Sprintf("Selected Eth 1.0 client: %s\n", eth1ClientString)
+	versionInfo += fmt.Sprintf("Selected Eth 2.0 client: %s\n", eth2ClientString)
+	versionInfo += fmt.Sprintf("MEV-Boost client: %s\n", mevBoostString)
+	return versionInfo, nil
 
 }
 
@@ -0,0 +1,354 @@
+package service
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/goccy/go-json"
+	"github.com/shirou/gopsutil/v3/disk"
+	"github.com/shirou/gopsutil/v3/mem"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+const (
+	// The number of log lines to collect from each container by default
+	defaultSupportBundleLogTail string = "2000"
+
+	// The note in the manifest about the files that are left out of a support bundle
+	supportBundleExclusions string = "Wallet, password, and validator keystore files are never included. Sensitive settings (API keys and client URLs) are masked in the settings file and removed from every other file."
+)
+
+// The manifest describing the contents of a support bundle
+type supportBundleManifest struct {
+	CreatedAt     time.Time           `json:"createdAt"`
+	ClientVersion string              `json:"clientVersion"`
+	Network       string              `json:"network"`
+	Exclusions    string              `json:"exclusions"`
+	Files         []supportBundleFile `json:"files"`
+	Errors        []string            `json:"errors,omitempty"`
+}
+
+// A file in a support bundle
+type supportBundleFile struct {
+	Path        string `json:"path"`
+	Description string `json:"description"`
+	Size        int    `json:"size"`
+}
+
+// The disk and memory stats of the machine running the Smartnode
+type supportBundleSystemStats struct {
+	Memory *mem.VirtualMemoryStat `json:"memory,omitempty"`
+	Swap   *mem.SwapMemoryStat    `json:"swap,omitempty"`
+	Disks  []*disk.UsageStat      `json:"disks"`
+}
+
+// The image a container runs
+type supportBundleImage struct {
+	Container string   `json:"container"`
+	Image     string   `json:"image"`
+	Digests   []string `json:"digests"`
+}
+
+// Collects files into a support bundle, removing sensitive values from them
+type supportBundle struct {
+	manifest supportBundleManifest
+	contents map[string][]byte
+	redactor *strings.Replacer
+}
+
+// Collect diagnostic information about the node into a tarball that can be shared with the support team
+func createSupportBundle(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Load the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("No configuration detected. Please run `rocketpool service config` to set up your Smart Node before creating a support bundle.")
+	}
+
+	// Get the output path
+	createdAt := time.Now().UTC()
+	bundleName := fmt.Sprintf("rocketpool-support-%s", createdAt.Format("20060102-150405"))
+	outputPath := c.String("output")
+	if outputPath == "" {
+		outputPath = bundleName + ".tar.gz"
+	}
+	tail := c.String("tail")
+	if tail == "" {
+		tail = defaultSupportBundleLogTail
+	}
+
+	bundle := newSupportBundle(cfg, c.App.Version, createdAt)
+	fmt.Println("Collecting support information, this may take a minute...")
+
+	// Settings, with the sensitive values masked
+	settingsBytes, err := yaml.Marshal(cfg.SerializeRedacted())
+	if err != nil {
+		bundle.addError("settings", err)
+	} else {
+		bundle.addFile("settings/"+rocketpool.SettingsFile, "The user settings, with sensitive values masked", settingsBytes)
+	}
+
+	// Versions
+	versionInfo, err := getServiceVersionInfo(c, rp, cfg)
+	if err != nil {
+		bundle.addError("version", err)
+	} else {
+		bundle.addFile("version.txt", "The output of `rocketpool service version`", []byte(fmt.Sprintf("Network: %s\n%s", cfg.GetNetwork(), versionInfo)))
+	}
+
+	// Client sync status
+	clientStatus, err := rp.GetClientStatus()
+	if err != nil {
+		bundle.addError("client status", err)
+	} else {
+		bundle.addJson("client-status.json", "The sync status of the primary and fallback clients", clientStatus)
+	}
+
+	// Node status
+	nodeStatus, err := rp.NodeStatus()
+	if err != nil {
+		bundle.addError("node status", err)
+	} else {
+		bundle.addJson("node-status.json", "The output of `rocketpool node status`", nodeStatus)
+	}
+
+	// Disk and memory stats
+	bundle.addJson("system.json", "The disk and memory usage of this machine", getSupportBundleSystemStats(bundle))
+
+	// Container logs and images
+	if cfg.IsNativeMode {
+		bundle.addError("containers", fmt.Errorf("container logs and images aren't available in Native Mode"))
+	} else {
+		containers, err := rp.GetComposeContainers(getComposeFiles(c))
+		if err != nil {
+			bundle.addError("containers", err)
+		}
+		images := []supportBundleImage{}
+		for _, container := range containers {
+			logs, err := rp.GetContainerLogs(container, tail)
+			if err != nil {
+				bundle.addError(fmt.Sprintf("%s logs", container), err)
+			} else {
+				bundle.addFile("logs/"+container+".log", fmt.Sprintf("The last %s log lines of the %s container", tail, container), logs)
+			}
+
+			image, err := rp.GetDockerImage(container)
+			if err != nil {
+				bundle.addError(fmt.Sprintf("%s image", container), err)
+				continue
+			}
+			digests, err := rp.GetDockerImageDigests(image)
+			if err != nil {
+				bundle.addError(fmt.Sprintf("%s image digests", image), err)
+			}
+			images = append(images, supportBundleImage{
+				Container: container,
+				Image:     image,
+				Digests:   digests,
+			})
+		}
+		bundle.addJson("images.json", "The Docker image and digests of each container", images)
+	}
+
+	// Write the bundle
+	err = bundle.write(outputPath, bundleName)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	for _, collectionErr := range bundle.manifest.Errors {
+		fmt.Printf("%sWARNING: Couldn't collect %s%s\n", colorYellow, collectionErr, colorReset)
+	}
+	if info, err := os.Stat(outputPath); err == nil {
+		fmt.Printf("%sSaved the support bundle to %s (%s).%s\n", colorGreen, outputPath, humanize.IBytes(uint64(info.Size())), colorReset)
+	} else {
+		fmt.Printf("%sSaved the support bundle to %s.%s\n", colorGreen, outputPath, colorReset)
+	}
+	fmt.Println("Sensitive settings have been removed from it, but please review its contents before sharing it.")
+	return nil
+
+}
+
+// Create a new support bundle for the given config
+func newSupportBundle(cfg *config.RocketPoolConfig, clientVersion string, createdAt time.Time) *supportBundle {
+	return &supportBundle{
+		manifest: supportBundleManifest{
+			CreatedAt:     createdAt,
+			ClientVersion: clientVersion,
+			Network:       string(cfg.GetNetwork()),
+			Exclusions:    supportBundleExclusions,
+			Files:         []supportBundleFile{},
+		},
+		contents: map[string][]byte{},
+		redactor: getSensitiveValueRedactor(cfg),
+	}
+}
+
+// Add a file to the bundle, removing any sensitive values from it
+func (b *supportBundle) addFile(path string, description string, contents []byte) {
+	contents = []byte(b.redactor.Replace(string(contents)))
+	b.contents[path] = contents
+	b.manifest.Files = append(b.manifest.Files, supportBundleFile{
+		Path:        path,
+		Description: description,
+		Size:        len(contents),
+	})
+}
+
+// Add a value to the bundle as a JSON file
+func (b *supportBundle) addJson(path string, description string, value interface{}) {
+	bytes, err := json.MarshalIndent(value, "", "    ")
+	if err != nil {
+		b.addError(path, err)
+		return
+	}
+	b.addFile(path, description, bytes)
+}
+
+// Record something that couldn't be collected
+func (b *supportBundle) addError(name string, err error) {
+	b.manifest.Errors = append(b.manifest.Errors, b.redactor.Replace(fmt.Sprintf("%s: %s", name, err.Error())))
+}
+
+// Write the bundle and its manifest to a gzipped tarball
+func (b *supportBundle) write(outputPath string, bundleName string) error {
+	manifestBytes, err := json.MarshalIndent(b.manifest, "", "    ")
+	if err != nil {
+		return fmt.Errorf("error serializing support bundle manifest: %w", err)
+	}
+
+	file, err := os.OpenFile(outputPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("error creating support bundle %s: %w", outputPath, err)
+	}
+	defer file.Close()
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	// Write the manifest first, then the files in the order they were collected
+	paths := []string{"manifest.json"}
+	contents := map[string][]byte{"manifest.json": manifestBytes}
+	for _, bundleFile := range b.manifest.Files {
+		paths = append(paths, bundleFile.Path)
+		contents[bundleFile.Path] = b.contents[bundleFile.Path]
+	}
+	for _, path := range paths {
+		header := &tar.Header{
+			Name:    bundleName + "/" + path,
+			Mode:    0644,
+			Size:    int64(len(contents[path])),
+			ModTime: b.manifest.CreatedAt,
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return fmt.Errorf("error writing %s to support bundle: %w", path, err)
+		}
+		if _, err := tarWriter.Write(contents[path]); err != nil {
+			return fmt.Errorf("error writing %s to support bundle: %w", path, err)
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("error finishing support bundle: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("error finishing support bundle: %w", err)
+	}
+	return nil
+}
+
+// Get a replacer that masks the values of the config's sensitive parameters wherever they appear
+func getSensitiveValueRedactor(cfg *config.RocketPoolConfig) *strings.Replacer {
+	params := cfg.GetParameters()
+	for _, subconfig := range cfg.GetSubconfigs() {
+		params = append(params, subconfig.GetParameters()...)
+	}
+
+	// Lists like the custom rewards tree URLs can show up one entry at a time, and so can the arguments in additional
+	// flags; bare flag names like --http are left alone so they don't mask the rest of the logs
+	values := []string{}
+	for _, param := range params {
+		if !param.Sensitive || param.Value == nil {
+			continue
+		}
+		value := fmt.Sprint(param.Value)
+		values = append(values, value)
+		if strings.Contains(value, ";") {
+			values = append(values, strings.Split(value, ";")...)
+		}
+		fields := strings.Fields(value)
+		if len(fields) > 1 {
+			for _, field := range fields {
+				if !strings.HasPrefix(field, "-") || strings.Contains(field, "=") {
+					values = append(values, field)
+				}
+			}
+		}
+	}
+
+	// Replace longer values first so a value that contains another one is masked completely
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+	pairs := []string{}
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value != "" {
+			pairs = append(pairs, value, cfgtypes.RedactedValue)
+		}
+	}
+	return strings.NewReplacer(pairs...)
+}
+
+// Get the disk and memory usage of this machine
+func getSupportBundleSystemStats(bundle *supportBundle) supportBundleSystemStats {
+	stats := supportBundleSystemStats{
+		Disks: []*disk.UsageStat{},
+	}
+
+	memory, err := mem.VirtualMemory()
+	if err != nil {
+		bundle.addError("memory stats", err)
+	} else {
+		stats.Memory = memory
+	}
+	swap, err := mem.SwapMemory()
+	if err != nil {
+		bundle.addError("swap stats", err)
+	} else {
+		stats.Swap = swap
+	}
+
+	partitions, err := disk.Partitions(false)
+	if err != nil {
+		bundle.addError("disk stats", err)
+		return stats
+	}
+	for _, partition := range partitions {
+		usage, err := disk.Usage(partition.Mountpoint)
+		if err != nil {
+			bundle.addError(fmt.Sprintf("disk stats for %s", partition.Mountpoint), err)
+			continue
+		}
+		stats.Disks = append(stats.Disks, usage)
+	}
+	return stats
+}
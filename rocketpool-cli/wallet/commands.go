@@ -5,6 +5,7 @@ import (
 
 	"github.com/urfave/cli"
 
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
 	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
 )
 
@@ -213,6 +214,118 @@ func RegisterCommands(app *cli.App, name string, aliases []string) {
 
 				},
 			},
+			{
+				Name:      "migrate-out",
+				Usage:     "Stop the node daemon and validator client and export the node wallet and slashing protection database to an encrypted migration bundle",
+				UsageText: "rocketpool wallet migrate-out [options]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "output, o",
+						Usage: "The file to write the migration bundle to",
+						Value: "rocketpool-migration.json",
+					},
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm stopping the validator client",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					return migrateOut(c)
+
+				},
+			},
+
+			{
+				Name:      "migrate-in",
+				Usage:     "Import a migration bundle made with `migrate-out` on another machine, waiting a few epochs after the old validator client stopped before loading the keys",
+				UsageText: "rocketpool wallet migrate-in [options] bundle-file",
+				Flags: []cli.Flag{
+					cli.BoolFlag{
+						Name:  "yes, y",
+						Usage: "Automatically confirm the migration",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					return migrateIn(c, c.Args().Get(0))
+
+				},
+			},
+
+			{
+				Name:    "slashing-protection",
+				Aliases: []string{"sp"},
+				Usage:   "Export or import the validator client's slashing protection database in the EIP-3076 interchange format",
+				Subcommands: []cli.Command{
+
+					{
+						Name:      "export",
+						Aliases:   []string{"e"},
+						Usage:     "Export the slashing protection database of the configured validator client to an EIP-3076 interchange file",
+						UsageText: "rocketpool wallet slashing-protection export [options]",
+						Flags: []cli.Flag{
+							cli.StringFlag{
+								Name:  "output, o",
+								Usage: "The file to write the interchange data to",
+								Value: keystore.SlashingProtectionFile,
+							},
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm stopping the validator client during the export",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 0); err != nil {
+								return err
+							}
+
+							// Run
+							return exportSlashingProtection(c)
+
+						},
+					},
+
+					{
+						Name:      "import",
+						Aliases:   []string{"i"},
+						Usage:     "Import an EIP-3076 interchange file into the slashing protection database of the configured validator client",
+						UsageText: "rocketpool wallet slashing-protection import [options] interchange-file",
+						Flags: []cli.Flag{
+							cli.BoolFlag{
+								Name:  "yes, y",
+								Usage: "Automatically confirm stopping the validator client during the import",
+							},
+						},
+						Action: func(c *cli.Context) error {
+
+							// Validate args
+							if err := cliutils.ValidateArgCount(c, 1); err != nil {
+								return err
+							}
+
+							// Run
+							return importSlashingProtection(c, c.Args().Get(0))
+
+						},
+					},
+				},
+			},
+
 			{
 				Name:      "set-ens-name",
 				Aliases:   []string{"ens"},
@@ -0,0 +1,146 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+func migrateIn(c *cli.Context, bundlePath string) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smart Node.")
+	}
+	if cfg.IsNativeMode {
+		return fmt.Errorf("key migration is not supported in Native Mode")
+	}
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if status.WalletInitialized {
+		fmt.Println("The node wallet is already initialized on this machine.")
+		return nil
+	}
+	if status.PasswordSet {
+		fmt.Println("A node password is already set on this machine. Please remove it with `rocketpool wallet purge` before migrating a wallet in.")
+		return nil
+	}
+
+	// Read and decrypt the bundle
+	bytes, err := os.ReadFile(bundlePath)
+	if err != nil {
+		return fmt.Errorf("error reading migration bundle: %w", err)
+	}
+	passphrase := cliutils.PromptPassword("Please enter the passphrase the migration bundle was encrypted with:", "^.*$", "")
+	bundle, err := decryptMigrationBundle(bytes, passphrase)
+	if err != nil {
+		return err
+	}
+	network := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	if bundle.Network != network {
+		return fmt.Errorf("the migration bundle is for the %s network, but this node is configured for %s", bundle.Network, network)
+	}
+
+	// Confirm
+	cc, _ := cfg.GetSelectedConsensusClient()
+	fmt.Printf("Node address:               %s\n", bundle.NodeAddress.Hex())
+	fmt.Printf("Old consensus client:       %s\n", bundle.ConsensusClient)
+	fmt.Printf("New consensus client:       %s\n", cc)
+	fmt.Printf("Old validator client stopped in epoch %d.\n\n", bundle.StoppedEpoch)
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%sMake sure the validator client on the old machine is stopped and will not be started again.%s\nDo you want to migrate this wallet onto this machine?", colorYellow, colorReset))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	// Stop the validator client while the slashing protection database is replaced
+	prefix, err := rp.GetContainerPrefix()
+	if err != nil {
+		return err
+	}
+	validatorContainer := prefix + validator.ValidatorContainerSuffix
+	fmt.Printf("Stopping %s...\n", validatorContainer)
+	if _, err := rp.StopContainer(validatorContainer); err != nil {
+		return fmt.Errorf("error stopping validator client: %w", err)
+	}
+
+	// Wait for the doppelganger epochs to pass on-chain before any keys are loaded
+	safeEpoch := bundle.StoppedEpoch + migrationDoppelgangerEpochs
+	for {
+		head, err := rp.BeaconHead()
+		if err != nil {
+			return err
+		}
+		if head.Epoch >= safeEpoch {
+			break
+		}
+		fmt.Printf("The Beacon Chain is at epoch %d. Waiting until epoch %d before loading your validator keys...\n", head.Epoch, safeEpoch)
+		time.Sleep(migrationDoppelgangerPollInterval)
+	}
+
+	// Import the slashing protection database
+	fmt.Println("Importing the slashing protection database...")
+	if err := rp.ImportSlashingProtection(cfg, bundle.SlashingProtection); err != nil {
+		return err
+	}
+
+	// Import the wallet
+	if _, err := rp.SetPassword(bundle.Password); err != nil {
+		return err
+	}
+	response, err := rp.ImportMigrationWallet(bundle.Wallet)
+	if err != nil {
+		return err
+	}
+	if response.AccountAddress != bundle.NodeAddress {
+		return fmt.Errorf("the imported wallet has address %s, but the migration bundle was made for %s", response.AccountAddress.Hex(), bundle.NodeAddress.Hex())
+	}
+	fmt.Printf("The node wallet was successfully imported. Node account: %s\n", response.AccountAddress.Hex())
+
+	// Rebuild the validator keys for the new validator client
+	fmt.Println("Rebuilding node validator keystores...")
+	rebuildResponse, err := rp.RebuildWallet()
+	if err != nil {
+		return err
+	}
+	if len(rebuildResponse.ValidatorKeys) > 0 {
+		fmt.Println("Validator keys:")
+		for _, key := range rebuildResponse.ValidatorKeys {
+			fmt.Println(key.Hex())
+		}
+	} else {
+		fmt.Println("No validator keys were found.")
+	}
+
+	// Start the validator client
+	fmt.Printf("Starting %s...\n", validatorContainer)
+	if _, err := rp.StartContainer(validatorContainer); err != nil {
+		return fmt.Errorf("error starting validator client: %w", err)
+	}
+
+	fmt.Printf("%sThe migration is complete. Please check your validator client's logs to make sure it is attesting.%s\n", colorGreen, colorReset)
+	fmt.Println("Keys in the 'custom-keys' folder are not included in migration bundles; you'll need to copy them over and run `rocketpool wallet rebuild` if you have any.")
+	return nil
+
+}
@@ -0,0 +1,149 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool-cli/service"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+// Prompt for the passphrase to encrypt a migration bundle with
+func promptMigrationPassphrase() string {
+	for {
+		passphrase := cliutils.PromptPassword(
+			"Please enter a passphrase to encrypt the migration bundle with:",
+			fmt.Sprintf("^.{%d,}$", migrationBundleMinPassLength),
+			fmt.Sprintf("Your passphrase must be at least %d characters long. Please try again:", migrationBundleMinPassLength),
+		)
+		confirmation := cliutils.PromptPassword("Please confirm your passphrase:", "^.*$", "")
+		if passphrase == confirmation {
+			return passphrase
+		}
+		fmt.Println("Passphrase confirmation does not match.")
+		fmt.Println("")
+	}
+}
+
+func migrateOut(c *cli.Context) error {
+
+	// Get RP client
+	rp, err := rocketpool.NewClientFromCtx(c).WithReady()
+	if err != nil {
+		return err
+	}
+	defer rp.Close()
+
+	// Get the config
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew {
+		return fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smart Node.")
+	}
+	if cfg.IsNativeMode {
+		return fmt.Errorf("key migration is not supported in Native Mode")
+	}
+
+	// Get & check wallet status
+	status, err := rp.WalletStatus()
+	if err != nil {
+		return err
+	}
+	if !status.WalletInitialized {
+		fmt.Println("The node wallet is not initialized.")
+		return nil
+	}
+
+	// Check the output file
+	outputPath := c.String("output")
+	if _, err := os.Stat(outputPath); err == nil {
+		return fmt.Errorf("%s already exists; please remove it or choose a different output file with --output", outputPath)
+	}
+
+	// Confirm
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("%sWARNING: This will stop your node daemon and validator client so your validator keys can be moved to another machine.\nYour validators will NOT attest from this machine anymore.%s\n\nDo you want to continue?", colorYellow, colorReset))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	passphrase := promptMigrationPassphrase()
+
+	// Stop the node daemon first so it can't restart the validator client, then stop the validator client
+	prefix, err := rp.GetContainerPrefix()
+	if err != nil {
+		return err
+	}
+	containers := []string{
+		prefix + service.NodeContainerSuffix,
+		prefix + validator.ValidatorContainerSuffix,
+	}
+	for _, container := range containers {
+		fmt.Printf("Stopping %s...\n", container)
+		if _, err := rp.StopContainer(container); err != nil {
+			return fmt.Errorf("error stopping %s: %w", container, err)
+		}
+	}
+
+	// Make sure they're both stopped before anything is exported
+	for _, container := range containers {
+		containerStatus, err := rp.GetDockerStatus(container)
+		if err != nil {
+			return fmt.Errorf("error checking the status of %s: %w", container, err)
+		}
+		if containerStatus != "exited" && containerStatus != "created" {
+			return fmt.Errorf("%s is still %s; please stop it with `docker stop %s` and try again", container, containerStatus, container)
+		}
+	}
+
+	// Record the epoch the validator client was stopped in, so the new machine can wait for it on-chain
+	head, err := rp.BeaconHead()
+	if err != nil {
+		return err
+	}
+
+	// Export the slashing protection database
+	cc, _ := cfg.GetSelectedConsensusClient()
+	fmt.Println("Exporting the slashing protection database...")
+	slashingProtection, err := rp.ExportSlashingProtection(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Export the wallet
+	export, err := rp.ExportWallet()
+	if err != nil {
+		return err
+	}
+
+	// Build and write the bundle
+	bundle := migrationBundle{
+		Version:            migrationBundleVersion,
+		Network:            cfg.Smartnode.Network.Value.(cfgtypes.Network),
+		NodeAddress:        status.AccountAddress,
+		ConsensusClient:    cc,
+		StoppedEpoch:       head.Epoch,
+		Password:           export.Password,
+		Wallet:             export.Wallet,
+		SlashingProtection: slashingProtection,
+	}
+	fmt.Println("Encrypting the migration bundle...")
+	bytes, err := encryptMigrationBundle(&bundle, passphrase)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(outputPath, bytes, 0600); err != nil {
+		return fmt.Errorf("error writing migration bundle to %s: %w", outputPath, err)
+	}
+
+	fmt.Printf("%sWrote the migration bundle to %s.%s\n\n", colorGreen, outputPath, colorReset)
+	fmt.Println("Copy it to your new machine and run `rocketpool wallet migrate-in` there.")
+	fmt.Printf("%sWARNING: Do NOT start the node daemon or validator client on this machine again, or run `rocketpool service start` here, unless you have purged its keys with `rocketpool wallet purge`.\nRunning your validator keys on two machines at once **will get you slashed!**%s\n", colorRed, colorReset)
+	return nil
+
+}
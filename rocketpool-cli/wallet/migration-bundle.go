@@ -0,0 +1,147 @@
+package wallet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"golang.org/x/crypto/scrypt"
+
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+const (
+	// The current version of the migration bundle format
+	migrationBundleVersion uint = 1
+
+	// The scrypt parameters used to derive the bundle key from its passphrase
+	migrationBundleScryptN       int = 1 << 18
+	migrationBundleScryptR       int = 8
+	migrationBundleScryptP       int = 1
+	migrationBundleKeyLength     int = 32
+	migrationBundleSaltLength    int = 32
+	migrationBundleMinPassLength int = 12
+
+	// The number of epochs to wait after the old validator client stopped before the keys can be loaded again
+	migrationDoppelgangerEpochs uint64 = 3
+
+	// How often to check the chain head while waiting for the doppelganger epochs to pass
+	migrationDoppelgangerPollInterval time.Duration = 30 * time.Second
+)
+
+// The contents of a migration bundle
+type migrationBundle struct {
+	Version            uint                     `json:"version"`
+	Network            cfgtypes.Network         `json:"network"`
+	NodeAddress        common.Address           `json:"nodeAddress"`
+	ConsensusClient    cfgtypes.ConsensusClient `json:"consensusClient"`
+	StoppedEpoch       uint64                   `json:"stoppedEpoch"`
+	Password           string                   `json:"password"`
+	Wallet             string                   `json:"wallet"`
+	SlashingProtection json.RawMessage          `json:"slashingProtection"`
+}
+
+// A migration bundle encrypted with a passphrase, as it's stored on disk
+type encryptedMigrationBundle struct {
+	Version    uint   `json:"version"`
+	Salt       []byte `json:"salt"`
+	ScryptN    int    `json:"scryptN"`
+	ScryptR    int    `json:"scryptR"`
+	ScryptP    int    `json:"scryptP"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// Serialize and encrypt a migration bundle with the given passphrase
+func encryptMigrationBundle(bundle *migrationBundle, passphrase string) ([]byte, error) {
+	plaintext, err := json.Marshal(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing migration bundle: %w", err)
+	}
+
+	// Derive the key
+	salt := make([]byte, migrationBundleSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("error generating salt: %w", err)
+	}
+	gcm, err := getMigrationBundleCipher(passphrase, salt, migrationBundleScryptN, migrationBundleScryptR, migrationBundleScryptP)
+	if err != nil {
+		return nil, err
+	}
+
+	// Encrypt the bundle
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("error generating nonce: %w", err)
+	}
+	encrypted := encryptedMigrationBundle{
+		Version:    migrationBundleVersion,
+		Salt:       salt,
+		ScryptN:    migrationBundleScryptN,
+		ScryptR:    migrationBundleScryptR,
+		ScryptP:    migrationBundleScryptP,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, plaintext, nil),
+	}
+	bytes, err := json.Marshal(encrypted)
+	if err != nil {
+		return nil, fmt.Errorf("error serializing encrypted migration bundle: %w", err)
+	}
+	return bytes, nil
+}
+
+// Decrypt and deserialize a migration bundle with the given passphrase
+func decryptMigrationBundle(bytes []byte, passphrase string) (*migrationBundle, error) {
+	var encrypted encryptedMigrationBundle
+	if err := json.Unmarshal(bytes, &encrypted); err != nil {
+		return nil, fmt.Errorf("error deserializing migration bundle; it may be corrupted: %w", err)
+	}
+	if encrypted.Version != migrationBundleVersion {
+		return nil, fmt.Errorf("migration bundle version %d is not supported by this version of the Smartnode", encrypted.Version)
+	}
+
+	// Only accept the key derivation parameters this version writes, so a crafted bundle can't make scrypt exhaust memory or CPU
+	if encrypted.ScryptN != migrationBundleScryptN || encrypted.ScryptR != migrationBundleScryptR || encrypted.ScryptP != migrationBundleScryptP {
+		return nil, fmt.Errorf("migration bundle has unsupported key derivation parameters (N=%d, r=%d, p=%d); it may be corrupted", encrypted.ScryptN, encrypted.ScryptR, encrypted.ScryptP)
+	}
+
+	// Decrypt the bundle
+	gcm, err := getMigrationBundleCipher(passphrase, encrypted.Salt, encrypted.ScryptN, encrypted.ScryptR, encrypted.ScryptP)
+	if err != nil {
+		return nil, err
+	}
+	if len(encrypted.Nonce) != gcm.NonceSize() {
+		return nil, fmt.Errorf("migration bundle has an invalid nonce; it may be corrupted")
+	}
+	plaintext, err := gcm.Open(nil, encrypted.Nonce, encrypted.Ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error decrypting migration bundle; the passphrase may be incorrect")
+	}
+
+	bundle := new(migrationBundle)
+	if err := json.Unmarshal(plaintext, bundle); err != nil {
+		return nil, fmt.Errorf("error deserializing migration bundle contents: %w", err)
+	}
+	return bundle, nil
+}
+
+// Derive the bundle key from the passphrase and create the AES-GCM cipher for it
+func getMigrationBundleCipher(passphrase string, salt []byte, n int, r int, p int) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, n, r, p, migrationBundleKeyLength)
+	if err != nil {
+		return nil, fmt.Errorf("error deriving migration bundle key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating migration bundle cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error creating migration bundle cipher: %w", err)
+	}
+	return gcm, nil
+}
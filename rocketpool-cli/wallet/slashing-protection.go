@@ -0,0 +1,140 @@
+package wallet
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/goccy/go-json"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+	"github.com/rocket-pool/smartnode/shared/utils/validator"
+)
+
+func exportSlashingProtection(c *cli.Context) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, err := loadSlashingProtectionConfig(rp)
+	if err != nil {
+		return err
+	}
+
+	// Check the output file
+	outputPath := c.String("output")
+	if _, err := os.Stat(outputPath); err == nil {
+		return fmt.Errorf("%s already exists; please remove it or choose a different output file with --output", outputPath)
+	}
+
+	// Export the database while the validator client is stopped
+	cc, _ := cfg.GetSelectedConsensusClient()
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("The validator client must be stopped briefly while its slashing protection database is exported. Do you want to export the %s slashing protection database?", cc))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	var data []byte
+	err = runWithValidatorStopped(rp, func() error {
+		fmt.Println("Exporting the slashing protection database...")
+		data, err = rp.ExportSlashingProtection(cfg)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// Write the file
+	if err := os.WriteFile(outputPath, data, 0600); err != nil {
+		return fmt.Errorf("error writing slashing protection interchange file to %s: %w", outputPath, err)
+	}
+	fmt.Printf("%sExported the slashing protection database to %s.%s\n", colorGreen, outputPath, colorReset)
+	return nil
+
+}
+
+func importSlashingProtection(c *cli.Context, inputPath string) error {
+
+	// Get RP client
+	rp := rocketpool.NewClientFromCtx(c)
+	defer rp.Close()
+
+	// Get the config
+	cfg, err := loadSlashingProtectionConfig(rp)
+	if err != nil {
+		return err
+	}
+
+	// Read the file
+	data, err := os.ReadFile(inputPath)
+	if err != nil {
+		return fmt.Errorf("error reading slashing protection interchange file: %w", err)
+	}
+	if !json.Valid(data) {
+		return fmt.Errorf("%s is not a valid EIP-3076 interchange file", inputPath)
+	}
+
+	// Import the database while the validator client is stopped
+	cc, _ := cfg.GetSelectedConsensusClient()
+	if !(c.Bool("yes") || cliutils.Confirm(fmt.Sprintf("The validator client must be stopped briefly while the interchange file is imported. Do you want to import %s into the %s slashing protection database?", inputPath, cc))) {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+	err = runWithValidatorStopped(rp, func() error {
+		fmt.Println("Importing the slashing protection database...")
+		return rp.ImportSlashingProtection(cfg, data)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%sImported %s into the slashing protection database.%s\n", colorGreen, inputPath, colorReset)
+	return nil
+
+}
+
+// Load the config and make sure slashing protection commands are supported by it
+func loadSlashingProtectionConfig(rp *rocketpool.Client) (*config.RocketPoolConfig, error) {
+	cfg, isNew, err := rp.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error loading user settings: %w", err)
+	}
+	if isNew {
+		return nil, fmt.Errorf("Settings file not found. Please run `rocketpool service config` to set up your Smart Node.")
+	}
+	if cfg.IsNativeMode {
+		return nil, fmt.Errorf("slashing protection import and export are not supported in Native Mode; please use your validator client's own commands instead")
+	}
+	return cfg, nil
+}
+
+// Stop the validator client if it's running, run the given function, and then start it again
+func runWithValidatorStopped(rp *rocketpool.Client, run func() error) error {
+	prefix, err := rp.GetContainerPrefix()
+	if err != nil {
+		return err
+	}
+	validatorContainer := prefix + validator.ValidatorContainerSuffix
+	status, err := rp.GetDockerStatus(validatorContainer)
+	if err != nil {
+		return fmt.Errorf("error getting validator client status: %w", err)
+	}
+
+	if status == "running" {
+		fmt.Printf("Stopping %s...\n", validatorContainer)
+		if _, err := rp.StopContainer(validatorContainer); err != nil {
+			return fmt.Errorf("error stopping validator client: %w", err)
+		}
+		defer func() {
+			fmt.Printf("Starting %s...\n", validatorContainer)
+			if _, err := rp.StartContainer(validatorContainer); err != nil {
+				fmt.Printf("%sWARNING: Couldn't restart the validator client: %s\nPlease run `rocketpool service start` to start it again.%s\n", colorRed, err.Error(), colorReset)
+			}
+		}()
+	}
+
+	return run()
+}
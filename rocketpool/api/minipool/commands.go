@@ -34,6 +34,64 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "history",
+				Usage:     "Get the lifecycle history of a minipool from its contract events and the Beacon Chain",
+				UsageText: "rocketpool api minipool history minipool-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					minipoolAddress, err := cliutils.ValidateAddress("minipool address", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getMinipoolHistory(c, minipoolAddress))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "performance",
+				Usage:     "Get the tracked attestation, sync committee and proposal performance of the node's validators",
+				UsageText: "rocketpool api minipool performance window",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getMinipoolPerformance(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "proposals",
+				Usage:     "Get the fee recipient audits of the blocks proposed by the node's validators",
+				UsageText: "rocketpool api minipool proposals",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getMinipoolProposals(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "can-stake",
 				Usage:     "Check whether the minipool is ready to be staked, moving from prelaunch to staking status",
@@ -0,0 +1,399 @@
+package minipool
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/settings/trustednode"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+)
+
+// Events emitted by the minipool bond reducer that are indexed by minipool address
+var bondReducerEvents = []string{"BeginBondReduction", "CancelReductionVoted", "ReductionCancelled"}
+
+func getMinipoolHistory(c *cli.Context, minipoolAddress common.Address) (*api.MinipoolHistoryResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolHistoryResponse{
+		Address:      minipoolAddress,
+		TotalGasCost: big.NewInt(0),
+	}
+
+	// Create minipool
+	mp, err := minipool.NewMinipool(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate minipool owner
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+	if err := validateMinipoolOwner(mp, nodeAccount.Address); err != nil {
+		return nil, err
+	}
+
+	// Get the current minipool details
+	status, err := mp.GetStatusDetails(nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting minipool status: %w", err)
+	}
+	response.CurrentStatus = status.Status
+	response.Finalised, err = mp.GetFinalised(nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting minipool finalized status: %w", err)
+	}
+	response.ValidatorPubkey, err = minipool.GetMinipoolPubkey(rp, minipoolAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting minipool pubkey: %w", err)
+	}
+
+	// The minipool can't have any events before the node registered, so start the search there
+	registrationTime, err := node.GetNodeRegistrationTime(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting node registration time: %w", err)
+	}
+	startHeader, err := rewards.GetELBlockHeaderForTime(registrationTime, rp)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting the EL block for the node registration time: %w", err)
+	}
+	response.StartBlock = startHeader.Number.Uint64()
+
+	// Get the event log interval
+	eventLogInterval, err := cfg.GetEventLogInterval()
+	if err != nil {
+		return nil, err
+	}
+	intervalSize := big.NewInt(int64(eventLogInterval))
+
+	// Get the minipool's own events
+	mpContract := mp.GetContract()
+	mpLogs, err := eth.GetLogs(rp, []common.Address{minipoolAddress}, nil, intervalSize, startHeader.Number, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting minipool event logs: %w", err)
+	}
+	events := []api.MinipoolHistoryEvent{}
+	for _, log := range mpLogs {
+		event, err := decodeMinipoolEvent(mpContract, log)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	// Get the bond reduction events
+	bondReducer, err := rp.GetContract("rocketMinipoolBondReducer", nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting bond reducer contract: %w", err)
+	}
+	eventIds := []common.Hash{}
+	for _, name := range bondReducerEvents {
+		abiEvent, exists := bondReducer.ABI.Events[name]
+		if !exists {
+			return nil, fmt.Errorf("Bond reducer contract does not have a %s event", name)
+		}
+		eventIds = append(eventIds, abiEvent.ID)
+	}
+	topicFilter := [][]common.Hash{eventIds, {common.BytesToHash(minipoolAddress.Bytes())}}
+	reducerLogs, err := eth.GetLogs(rp, []common.Address{*bondReducer.Address}, topicFilter, intervalSize, startHeader.Number, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting bond reduction event logs: %w", err)
+	}
+	for _, log := range reducerLogs {
+		event, err := decodeBondReducerEvent(bondReducer, log)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	// Add the block, transaction and gas details
+	if err := addTransactionDetails(rp, events, response.TotalGasCost); err != nil {
+		return nil, err
+	}
+
+	// The first event is always emitted by the transaction that created the minipool
+	sortHistoryEvents(events)
+	if len(events) > 0 {
+		events[0].Name = "Created"
+		events[0].Description = fmt.Sprintf("Minipool created by %s (%s)", events[0].From.Hex(), events[0].Description)
+	}
+
+	// Add the end of the scrub check window
+	scrubPeriodSeconds, err := trustednode.GetScrubPeriod(rp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting scrub period: %w", err)
+	}
+	scrubPeriod := time.Duration(scrubPeriodSeconds) * time.Second
+	for _, event := range events {
+		if event.Name == types.Prelaunch.String() || event.Name == "Vacancy prepared" {
+			events = append(events, api.MinipoolHistoryEvent{
+				Name:        "Scrub check ends",
+				Description: fmt.Sprintf("The oDAO scrub check window (%s) ends and the minipool can be staked or promoted", scrubPeriod),
+				Time:        event.Time.Add(scrubPeriod),
+			})
+		}
+	}
+
+	// Add the Beacon Chain milestones
+	beaconEvents, validatorIndex, err := getBeaconHistoryEvents(bc, response.ValidatorPubkey)
+	if err != nil {
+		return nil, err
+	}
+	response.ValidatorIndex = validatorIndex
+	events = append(events, beaconEvents...)
+
+	// Return response
+	sortHistoryEvents(events)
+	response.Events = events
+	return &response, nil
+
+}
+
+// Decode a log emitted by the minipool contract into a history event
+func decodeMinipoolEvent(contract *rocketpool.Contract, log ethtypes.Log) (api.MinipoolHistoryEvent, error) {
+	event := api.MinipoolHistoryEvent{
+		BlockNumber: log.BlockNumber,
+		TxHash:      log.TxHash,
+	}
+	abiEvent, err := contract.ABI.EventByID(log.Topics[0])
+	if err != nil {
+		event.Name = "Unknown event"
+		event.Description = fmt.Sprintf("Unknown event with topic %s", log.Topics[0].Hex())
+		return event, nil
+	}
+	values, err := unpackHistoryLog(contract, abiEvent, log)
+	if err != nil {
+		return api.MinipoolHistoryEvent{}, err
+	}
+
+	switch abiEvent.Name {
+	case "StatusUpdated":
+		status := types.MinipoolStatus(values["status"].(uint8))
+		event.Name = status.String()
+		event.Description = fmt.Sprintf("Minipool status changed to %s", status.String())
+	case "MinipoolPrestaked":
+		event.Name = "Prestaked"
+		event.Description = fmt.Sprintf("Deposited %.6f ETH to the Beacon deposit contract for the validator", eth.WeiToEth(values["amount"].(*big.Int)))
+	case "ScrubVoted":
+		event.Name = "Scrub vote"
+		event.Description = fmt.Sprintf("oDAO member %s voted to scrub the minipool", values["member"].(common.Address).Hex())
+	case "MinipoolScrubbed":
+		event.Name = "Scrubbed"
+		event.Description = "The minipool was scrubbed by the oDAO"
+	case "MinipoolVacancyPrepared":
+		event.Name = "Vacancy prepared"
+		event.Description = fmt.Sprintf("Solo migration started with a %.6f ETH bond and %.6f ETH on the Beacon Chain", eth.WeiToEth(values["bondAmount"].(*big.Int)), eth.WeiToEth(values["currentBalance"].(*big.Int)))
+	case "MinipoolPromoted":
+		event.Name = "Promoted"
+		event.Description = "The vacant minipool was promoted, completing the solo migration"
+	case "BondReduced":
+		event.Name = "Bond reduced"
+		event.Description = fmt.Sprintf("Bond reduced from %.6f ETH to %.6f ETH", eth.WeiToEth(values["previousBondAmount"].(*big.Int)), eth.WeiToEth(values["newBondAmount"].(*big.Int)))
+	case "DelegateUpgraded":
+		event.Name = "Delegate upgraded"
+		event.Description = fmt.Sprintf("Delegate upgraded from %s to %s", values["oldDelegate"].(common.Address).Hex(), values["newDelegate"].(common.Address).Hex())
+	case "DelegateRolledBack":
+		event.Name = "Delegate rolled back"
+		event.Description = fmt.Sprintf("Delegate rolled back from %s to %s", values["oldDelegate"].(common.Address).Hex(), values["newDelegate"].(common.Address).Hex())
+	case "EtherWithdrawalProcessed":
+		event.Name = "Balance distributed"
+		event.Description = fmt.Sprintf("Distributed %.6f ETH (%.6f ETH to the node, %.6f ETH to rETH holders)", eth.WeiToEth(values["totalBalance"].(*big.Int)), eth.WeiToEth(values["nodeAmount"].(*big.Int)), eth.WeiToEth(values["userAmount"].(*big.Int)))
+	case "EtherDeposited":
+		event.Name = "ETH deposited"
+		event.Description = fmt.Sprintf("%.6f ETH deposited by %s", eth.WeiToEth(values["amount"].(*big.Int)), values["from"].(common.Address).Hex())
+	case "EtherReceived":
+		event.Name = "ETH received"
+		event.Description = fmt.Sprintf("%.6f ETH received from %s", eth.WeiToEth(values["amount"].(*big.Int)), values["from"].(common.Address).Hex())
+	case "EtherWithdrawn":
+		event.Name = "ETH withdrawn"
+		event.Description = fmt.Sprintf("%.6f ETH withdrawn to %s", eth.WeiToEth(values["amount"].(*big.Int)), values["to"].(common.Address).Hex())
+	default:
+		event.Name = abiEvent.Name
+		event.Description = fmt.Sprintf("%s event", abiEvent.Name)
+	}
+	return event, nil
+}
+
+// Decode a log emitted by the bond reducer contract into a history event
+func decodeBondReducerEvent(contract *rocketpool.Contract, log ethtypes.Log) (api.MinipoolHistoryEvent, error) {
+	event := api.MinipoolHistoryEvent{
+		BlockNumber: log.BlockNumber,
+		TxHash:      log.TxHash,
+	}
+	abiEvent, err := contract.ABI.EventByID(log.Topics[0])
+	if err != nil {
+		return api.MinipoolHistoryEvent{}, fmt.Errorf("Error decoding bond reducer event in transaction %s: %w", log.TxHash.Hex(), err)
+	}
+	values, err := unpackHistoryLog(contract, abiEvent, log)
+	if err != nil {
+		return api.MinipoolHistoryEvent{}, err
+	}
+
+	switch abiEvent.Name {
+	case "BeginBondReduction":
+		event.Name = "Bond reduction started"
+		event.Description = fmt.Sprintf("Bond reduction to %.6f ETH requested", eth.WeiToEth(values["newBondAmount"].(*big.Int)))
+	case "CancelReductionVoted":
+		event.Name = "Bond reduction cancel vote"
+		event.Description = fmt.Sprintf("oDAO member %s voted to cancel the bond reduction", values["member"].(common.Address).Hex())
+	case "ReductionCancelled":
+		event.Name = "Bond reduction cancelled"
+		event.Description = "The bond reduction was cancelled by the oDAO"
+	}
+	return event, nil
+}
+
+// Unpack both the indexed and non-indexed values of a log
+func unpackHistoryLog(contract *rocketpool.Contract, abiEvent *abi.Event, log ethtypes.Log) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	if err := contract.Contract.UnpackLogIntoMap(values, abiEvent.Name, log); err != nil {
+		return nil, fmt.Errorf("Error unpacking %s event in transaction %s: %w", abiEvent.Name, log.TxHash.Hex(), err)
+	}
+	return values, nil
+}
+
+// Populate the time, sender and gas details of each event from its block and transaction
+func addTransactionDetails(rp *rocketpool.RocketPool, events []api.MinipoolHistoryEvent, totalGasCost *big.Int) error {
+	headers := map[uint64]*ethtypes.Header{}
+	receipts := map[common.Hash]*ethtypes.Receipt{}
+	senders := map[common.Hash]common.Address{}
+
+	for i := range events {
+		event := &events[i]
+
+		// Get the block time
+		header, exists := headers[event.BlockNumber]
+		if !exists {
+			var err error
+			header, err = rp.Client.HeaderByNumber(context.Background(), big.NewInt(0).SetUint64(event.BlockNumber))
+			if err != nil {
+				return fmt.Errorf("Error getting header for block %d: %w", event.BlockNumber, err)
+			}
+			headers[event.BlockNumber] = header
+		}
+		event.Time = time.Unix(int64(header.Time), 0)
+
+		// Only count the gas of each transaction once
+		if _, exists := receipts[event.TxHash]; exists {
+			event.From = senders[event.TxHash]
+			continue
+		}
+		receipt, err := rp.Client.TransactionReceipt(context.Background(), event.TxHash)
+		if err != nil {
+			return fmt.Errorf("Error getting receipt for transaction %s: %w", event.TxHash.Hex(), err)
+		}
+		receipts[event.TxHash] = receipt
+		tx, _, err := rp.Client.TransactionByHash(context.Background(), event.TxHash)
+		if err != nil {
+			return fmt.Errorf("Error getting transaction %s: %w", event.TxHash.Hex(), err)
+		}
+		sender, err := ethtypes.LatestSignerForChainID(tx.ChainId()).Sender(tx)
+		if err != nil {
+			return fmt.Errorf("Error getting sender of transaction %s: %w", event.TxHash.Hex(), err)
+		}
+		senders[event.TxHash] = sender
+
+		event.From = sender
+		event.GasUsed = receipt.GasUsed
+		event.GasCost = big.NewInt(0).Mul(big.NewInt(0).SetUint64(receipt.GasUsed), receipt.EffectiveGasPrice)
+		totalGasCost.Add(totalGasCost, event.GasCost)
+	}
+	return nil
+}
+
+// Get the Beacon Chain milestones of the minipool's validator
+func getBeaconHistoryEvents(bc beacon.Client, pubkey types.ValidatorPubkey) ([]api.MinipoolHistoryEvent, string, error) {
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, "", fmt.Errorf("Error getting Beacon config: %w", err)
+	}
+	validator, err := bc.GetValidatorStatus(pubkey, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("Error getting validator status: %w", err)
+	}
+	if !validator.Exists {
+		return []api.MinipoolHistoryEvent{}, "", nil
+	}
+
+	milestones := []struct {
+		epoch       uint64
+		name        string
+		description string
+	}{
+		{validator.ActivationEligibilityEpoch, "Deposit processed", "The Beacon Chain processed the validator deposit"},
+		{validator.ActivationEpoch, "Validator active", "The validator became active on the Beacon Chain"},
+		{validator.ExitEpoch, "Validator exited", "The validator exited the Beacon Chain"},
+		{validator.WithdrawableEpoch, "Validator withdrawable", "The validator's full balance became withdrawable"},
+	}
+	events := []api.MinipoolHistoryEvent{}
+	for _, milestone := range milestones {
+		if milestone.epoch == beacon.FarFutureEpoch {
+			continue
+		}
+		events = append(events, api.MinipoolHistoryEvent{
+			Name:        milestone.name,
+			Description: fmt.Sprintf("%s (epoch %d)", milestone.description, milestone.epoch),
+			IsBeacon:    true,
+			Time:        eth2.TimeAt(eth2Config, milestone.epoch),
+		})
+	}
+	if validator.Slashed {
+		events = append(events, api.MinipoolHistoryEvent{
+			Name:        "Validator slashed",
+			Description: "The validator was slashed",
+			IsBeacon:    true,
+			Time:        eth2.TimeAt(eth2Config, validator.ExitEpoch),
+		})
+	}
+	return events, validator.Index, nil
+}
+
+// Sort history events chronologically, keeping events in the same block in log order
+func sortHistoryEvents(events []api.MinipoolHistoryEvent) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+}
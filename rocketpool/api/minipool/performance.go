@@ -0,0 +1,64 @@
+package minipool
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/performance"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getMinipoolPerformance(c *cli.Context, window string) (*api.MinipoolPerformanceResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolPerformanceResponse{
+		Window:     window,
+		Validators: []api.ValidatorPerformanceDetails{},
+	}
+
+	// Check the window
+	duration, exists := performance.WindowDurations[window]
+	if !exists {
+		return nil, fmt.Errorf("unknown performance window '%s'", window)
+	}
+
+	// Check if tracking is enabled
+	response.TrackingEnabled = cfg.Smartnode.TrackValidatorPerformance.Value.(bool)
+
+	// Load the record kept by the node daemon
+	record, err := performance.LoadPerformanceRecord(cfg.Smartnode.GetValidatorPerformancePath())
+	if err != nil {
+		return nil, err
+	}
+	response.LastProcessedEpoch = record.GetLastProcessedEpoch()
+
+	// Summarize each validator over the window
+	minipools := record.GetMinipoolAddresses()
+	for index, summary := range record.GetSummaries(duration, time.Now()) {
+		response.Validators = append(response.Validators, api.ValidatorPerformanceDetails{
+			MinipoolAddress: minipools[index],
+			ValidatorIndex:  index,
+			Counters:        summary,
+		})
+	}
+	sort.Slice(response.Validators, func(i, j int) bool {
+		return response.Validators[i].MinipoolAddress.Hex() < response.Validators[j].MinipoolAddress.Hex()
+	})
+
+	// Return response
+	return &response, nil
+
+}
@@ -0,0 +1,39 @@
+package minipool
+
+import (
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/performance"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getMinipoolProposals(c *cli.Context) (*api.MinipoolProposalsResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.MinipoolProposalsResponse{}
+
+	// Check if auditing is enabled
+	response.AuditEnabled = cfg.Smartnode.AuditProposals.Value.(bool)
+
+	// Load the record kept by the node daemon
+	record, err := performance.LoadProposalAuditRecord(cfg.Smartnode.GetProposalAuditPath())
+	if err != nil {
+		return nil, err
+	}
+	response.LastProcessedEpoch = record.GetLastProcessedEpoch()
+	response.Proposals = record.GetProposals()
+
+	// Return response
+	return &response, nil
+
+}
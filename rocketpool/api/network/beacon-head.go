@@ -0,0 +1,38 @@
+package network
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Get the current head of the Beacon Chain
+func getBeaconHead(c *cli.Context) (*api.BeaconHeadResponse, error) {
+
+	// Get services
+	if err := services.RequireBeaconClientSynced(c); err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.BeaconHeadResponse{}
+
+	// Get the head
+	head, err := bc.GetBeaconHead()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon head: %w", err)
+	}
+	response.Epoch = head.Epoch
+	response.FinalizedEpoch = head.FinalizedEpoch
+
+	// Return response
+	return &response, nil
+
+}
@@ -197,6 +197,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "beacon-head",
+				Usage:     "Get the current and finalized epochs of the Beacon Chain",
+				UsageText: "rocketpool api network beacon-head",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getBeaconHead(c))
+					return nil
+
+				},
+			},
 		},
 	})
 }
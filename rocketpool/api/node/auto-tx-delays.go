@@ -0,0 +1,52 @@
+package node
+
+import (
+	"sort"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getAutoTxDelays(c *cli.Context) (*api.NodeAutoTxDelaysResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.NodeAutoTxDelaysResponse{
+		ThresholdGwei: cfg.Smartnode.AutoTxGasThreshold.Value.(float64),
+		Delays:        []api.AutoTxDelayDetails{},
+	}
+
+	// Load the record kept by the node daemon
+	delays, err := gas.LoadAutoTxDelays(cfg.Smartnode.GetAutoTxDelayRecordPath())
+	if err != nil {
+		return nil, err
+	}
+	for _, delay := range delays {
+		response.Delays = append(response.Delays, api.AutoTxDelayDetails{
+			Action:        delay.Action,
+			Subject:       delay.Subject,
+			FirstDelayed:  delay.FirstDelayed,
+			LastDelayed:   delay.LastDelayed,
+			DelayCount:    delay.DelayCount,
+			MaxFeeGwei:    delay.MaxFeeGwei,
+			ThresholdGwei: delay.ThresholdGwei,
+			Deadline:      delay.Deadline,
+			Reason:        delay.Reason,
+		})
+	}
+	sort.Slice(response.Delays, func(i, j int) bool {
+		return response.Delays[i].FirstDelayed.Before(response.Delays[j].FirstDelayed)
+	})
+
+	// Return response
+	return &response, nil
+
+}
@@ -1064,6 +1064,24 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "auto-tx-delays",
+				Usage:     "Get the automatic transactions the node daemon is holding back because of the gas price, and why",
+				UsageText: "rocketpool api node auto-tx-delays",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getAutoTxDelays(c))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "is-fee-distributor-initialized",
 				Usage:     "Check if the fee distributor contract for this node is initialized and deployed",
@@ -3,11 +3,14 @@ package node
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/node"
 	"github.com/rocket-pool/rocketpool-go/rewards"
 	rocketpoolapi "github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool"
 	"github.com/rocket-pool/smartnode/shared/types/api"
@@ -16,6 +19,10 @@ import (
 	"github.com/urfave/cli"
 )
 
+// Fee distributor balances at or below this many wei are dust and don't block a Smoothing Pool status change;
+// anyone can send ETH to the distributor, so requiring an exact zero balance would let it be griefed
+const undistributedFeeDustThreshold int64 = 1e15 // 0.001 ETH
+
 func getSmoothingPoolRegistrationStatus(c *cli.Context) (*api.GetSmoothingPoolRegistrationStatusResponse, error) {
 
 	// Get services
@@ -100,6 +107,19 @@ func canSetSmoothingPoolStatus(c *cli.Context, status bool) (*api.CanSetSmoothin
 	// Response
 	response := api.CanSetSmoothingPoolRegistrationStatusResponse{}
 
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// The fee distributor has to be distributed before the status can change
+	response.UndistributedBalance, err = getUndistributedFeeBalance(rp, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	response.CanChange = !exceedsFeeDustThreshold(response.UndistributedBalance)
+
 	// Get gas estimate
 	opts, err := w.GetNodeAccountTransactor()
 	if err != nil {
@@ -165,6 +185,15 @@ func setSmoothingPoolStatus(c *cli.Context, status bool) (*api.SetSmoothingPoolR
 		return nil, err
 	}
 
+	// Make sure the fee distributor's balance was settled under the current status first
+	undistributedBalance, err := getUndistributedFeeBalance(rp, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	if exceedsFeeDustThreshold(undistributedBalance) {
+		return nil, fmt.Errorf("your fee distributor has a balance of %.6f ETH, which must be distributed with `rocketpool node distribute-fees` before changing your Smoothing Pool status", eth.WeiToEth(undistributedBalance))
+	}
+
 	// If opting in, change the fee recipient to the Smoothing Pool before submitting the TX so the fee recipient is guaranteed to be non-penalizable at all times
 	if status {
 		smoothingPoolContract, err := rp.GetContract("rocketSmoothingPool", nil)
@@ -210,6 +239,31 @@ func setSmoothingPoolStatus(c *cli.Context, status bool) (*api.SetSmoothingPoolR
 
 }
 
+// Get the balance of the node's fee distributor that has to be distributed before its Smoothing Pool status can change
+func getUndistributedFeeBalance(rp *rocketpoolapi.RocketPool, nodeAddress common.Address) (*big.Int, error) {
+	isInitialized, err := node.GetFeeDistributorInitialized(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error checking if the fee distributor is initialized: %w", err)
+	}
+	if !isInitialized {
+		return big.NewInt(0), nil
+	}
+	distributorAddress, err := node.GetDistributorAddress(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the fee distributor address: %w", err)
+	}
+	balance, err := rp.Client.BalanceAt(context.Background(), distributorAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the fee distributor balance: %w", err)
+	}
+	return balance, nil
+}
+
+// Check if an undistributed fee distributor balance is large enough to block a Smoothing Pool status change
+func exceedsFeeDustThreshold(balance *big.Int) bool {
+	return balance.Cmp(big.NewInt(undistributedFeeDustThreshold)) > 0
+}
+
 func GetSmoothingPoolBalance(rp *rocketpoolapi.RocketPool, ec *services.ExecutionClientManager) (*api.SmoothingRewardsResponse, error) {
 	smoothingPoolContract, err := rp.GetContract("rocketSmoothingPool", nil)
 	if err != nil {
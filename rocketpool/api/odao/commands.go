@@ -53,6 +53,29 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "duties",
+				Aliases:   []string{"du"},
+				Usage:     "Get the record of the node's oracle DAO duty submissions",
+				UsageText: "rocketpool api odao duties limit",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					limit, err := cliutils.ValidateUint("limit", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getDuties(c, limit))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "proposals",
 				Aliases:   []string{"p"},
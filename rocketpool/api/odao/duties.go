@@ -0,0 +1,64 @@
+package odao
+
+import (
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/duties"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getDuties(c *cli.Context, limit uint64) (*api.TNDAODutiesResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.TNDAODutiesResponse{}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get membership status
+	response.IsMember, err = trustednode.GetMemberExists(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the record kept by the watchtower
+	record, err := duties.LoadDutyRecord(cfg.Smartnode.GetOdaoDutyRecordPath())
+	if err != nil {
+		return nil, err
+	}
+	response.LastUpdateTime = record.LastUpdateTime
+	response.Summaries = record.GetSummaries()
+	response.Rounds = record.GetRounds()
+	if limit > 0 && uint64(len(response.Rounds)) > limit {
+		response.Rounds = response.Rounds[:limit]
+	}
+
+	// Return response
+	return &response, nil
+
+}
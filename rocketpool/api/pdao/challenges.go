@@ -0,0 +1,61 @@
+package pdao
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getChallenges(c *cli.Context) (*api.PDAOChallengesResponse, error) {
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PDAOChallengesResponse{
+		VerifyEnabled: cfg.Smartnode.VerifyProposals.Value.(bool),
+	}
+
+	// Get node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Load the record kept by the node daemon
+	record, err := proposals.LoadChallengeRecord(cfg.Smartnode.GetChallengeRecordPath())
+	if err != nil {
+		return nil, err
+	}
+	response.LastUpdateTime = record.LastUpdateTime
+	response.Summary = record.GetSummary()
+	response.Proposals = record.GetProposals()
+
+	// Get the claimable bonds
+	response.ClaimableBonds, err = GetClaimableBonds(rp, cfg, bc, nodeAccount.Address)
+	if err != nil {
+		return nil, fmt.Errorf("error getting claimable bonds: %w", err)
+	}
+	return &response, nil
+}
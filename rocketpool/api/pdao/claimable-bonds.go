@@ -8,10 +8,12 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/rocketpool-go/utils/state"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	"github.com/urfave/cli"
 )
@@ -55,6 +57,16 @@ func getClaimableBonds(c *cli.Context) (*api.PDAOGetClaimableBondsResponse, erro
 		return nil, err
 	}
 
+	// Get the claimable bonds
+	response.ClaimableBonds, err = GetClaimableBonds(rp, cfg, bc, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Get the proposal and challenge bonds the node can claim or unlock
+func GetClaimableBonds(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, nodeAddress common.Address) ([]api.BondClaimResult, error) {
 	// Set up multicall
 	mcAddress := common.HexToAddress(cfg.Smartnode.GetMulticallAddress())
 	bbAddress := common.HexToAddress(cfg.Smartnode.GetBalanceBatcherAddress())
@@ -69,8 +81,7 @@ func getClaimableBonds(c *cli.Context) (*api.PDAOGetClaimableBondsResponse, erro
 		return nil, fmt.Errorf("error getting pDAO proposal details: %w", err)
 	}
 	if len(props) == 0 {
-		response.ClaimableBonds = []api.BondClaimResult{}
-		return &response, nil
+		return []api.BondClaimResult{}, nil
 	}
 
 	// Get some common vars
@@ -88,7 +99,7 @@ func getClaimableBonds(c *cli.Context) (*api.PDAOGetClaimableBondsResponse, erro
 		shouldProcess := false
 		isProposer := false
 
-		if prop.ProposerAddress == nodeAccount.Address {
+		if prop.ProposerAddress == nodeAddress {
 			isProposer = true
 			if prop.State >= types.ProtocolDaoProposalState_QuorumNotMet {
 				shouldProcess = true
@@ -217,7 +228,7 @@ func getClaimableBonds(c *cli.Context) (*api.PDAOGetClaimableBondsResponse, erro
 				}
 
 				// Increment how many refundable challenges we made
-				isOwnChallenge := (challengeInfo.Challenger == nodeAccount.Address)
+				isOwnChallenge := (challengeInfo.Challenger == nodeAddress)
 				if isOwnChallenge {
 					unlockableChallengeCount++
 					claimResult.UnlockableIndices = append(claimResult.UnlockableIndices, challengedIndex)
@@ -264,9 +275,7 @@ func getClaimableBonds(c *cli.Context) (*api.PDAOGetClaimableBondsResponse, erro
 		return first.ProposalID < second.ProposalID
 	})
 
-	// Update the response and return
-	response.ClaimableBonds = claimableBonds
-	return &response, nil
+	return claimableBonds, nil
 }
 
 // Check if a node was part of a proposal's defeat path
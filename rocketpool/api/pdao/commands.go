@@ -1165,6 +1165,186 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 
 				},
 			},
+
+			{
+				Name:      "vote-policy-status",
+				Usage:     "Get the status of the local voting policy and its decisions",
+				UsageText: "rocketpool api pdao vote-policy-status",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getVotePolicyStatus(c))
+					return nil
+
+				},
+			},
+			{
+				Name:      "confirm-vote-policy",
+				Usage:     "Confirm the local voting policy so the node will start casting votes with it",
+				UsageText: "rocketpool api pdao confirm-vote-policy policy-hash",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(confirmVotePolicy(c, c.Args().Get(0)))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "digest",
+				Usage:     "Get a digest of the governance activity relevant to the node",
+				UsageText: "rocketpool api pdao digest",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getDigest(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "challenges",
+				Usage:     "Get the node's record of root submissions, challenges, and bonds for Protocol DAO proposals",
+				UsageText: "rocketpool api pdao challenges",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getChallenges(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "delegates",
+				Usage:     "Get the top voting delegates by voting power along with their on-chain voting history",
+				UsageText: "rocketpool api pdao delegates limit",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					limit, err := cliutils.ValidateUint("limit", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(getDelegates(c, limit))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "export-voting-artifacts",
+				Usage:     "Export the voting info snapshot and network tree root for a block so another node can import them",
+				UsageText: "rocketpool api pdao export-voting-artifacts block-number",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					blockNumber, err := cliutils.ValidateUint32("block-number", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(exportVotingArtifacts(c, blockNumber))
+					return nil
+
+				},
+			},
+			{
+				Name:      "import-voting-artifacts",
+				Usage:     "Verify and import a voting artifact bundle that was copied into the node's import path",
+				UsageText: "rocketpool api pdao import-voting-artifacts [--root root-hash]",
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "root",
+						Usage: "The network tree root hash to verify the bundle against, instead of the roots of on-chain proposals",
+					},
+				},
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(importVotingArtifacts(c, c.String("root")))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "simulate-proposal",
+				Usage:     "Simulate the effects of a proposal against the current network state",
+				UsageText: "rocketpool api pdao simulate-proposal proposal-id",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					proposalId, err := cliutils.ValidatePositiveUint("proposal-id", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(simulateProposal(c, proposalId))
+					return nil
+
+				},
+			},
+			{
+				Name:      "simulate-setting",
+				Usage:     "Simulate the effects of a draft setting change against the current network state",
+				UsageText: "rocketpool api pdao simulate-setting contract-name setting-name value",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 3); err != nil {
+						return err
+					}
+					contractName := c.Args().Get(0)
+					settingName := c.Args().Get(1)
+					value := c.Args().Get(2)
+
+					// Run
+					api.PrintResponse(simulateSetting(c, contractName, settingName, value))
+					return nil
+
+				},
+			},
 		},
 	})
 }
@@ -0,0 +1,73 @@
+package pdao
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getDelegates(c *cli.Context, limit uint64) (*api.PDAODelegatesResponse, error) {
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PDAODelegatesResponse{}
+
+	// Get the node's current delegate
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, fmt.Errorf("error getting node account: %w", err)
+	}
+	response.NodeDelegate, err = network.GetCurrentVotingDelegate(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the node's voting delegate: %w", err)
+	}
+
+	// Get the proposals
+	mcAddress := common.HexToAddress(cfg.Smartnode.GetMulticallAddress())
+	bbAddress := common.HexToAddress(cfg.Smartnode.GetBalanceBatcherAddress())
+	contracts, err := state.NewNetworkContracts(rp, mcAddress, bbAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating network contracts: %w", err)
+	}
+	props, err := state.GetAllProtocolDaoProposalDetails(rp, contracts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pDAO proposal details: %w", err)
+	}
+
+	// Get the delegate insights, always including the node's own delegate
+	propMgr, err := proposals.NewProposalManager(nil, cfg, rp, bc)
+	if err != nil {
+		return nil, fmt.Errorf("error creating proposal manager: %w", err)
+	}
+	response.Insights, err = propMgr.GetDelegateInsights(props, int(limit), response.NodeDelegate)
+	if err != nil {
+		return nil, fmt.Errorf("error getting delegate insights: %w", err)
+	}
+	return &response, nil
+}
@@ -0,0 +1,306 @@
+package pdao
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/dao/trustednode"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// The number of slots to search for a block when looking up the block a proposal was created in
+const digestBlockSearchLimit uint64 = 32
+
+func getDigest(c *cli.Context) (*api.PDAODigestResponse, error) {
+	// Get services
+	if err := services.RequireNodeRegistered(c); err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PDAODigestResponse{}
+
+	// Get the node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Build the digest
+	digest, err := BuildGovernanceDigest(c, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+	response.Digest = *digest
+
+	// Compare it to the last digest the node sent, without updating the record
+	record, err := proposals.LoadGovernanceDigestRecord(cfg.Smartnode.GetGovernanceDigestRecordPath())
+	if err != nil {
+		return nil, err
+	}
+	response.Events = record.GetEvents(digest)
+	response.LastSentTime = record.LastSentTime
+
+	// Return response
+	return &response, nil
+}
+
+// Build a digest of the governance activity relevant to the node: on-chain pDAO proposals and their challenges,
+// oDAO proposals if the node is a member, and active Snapshot proposals.
+func BuildGovernanceDigest(c *cli.Context, nodeAddress common.Address) (*proposals.GovernanceDigest, error) {
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	reg, err := services.GetRocketSignerRegistry(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the latest block
+	blockNumber, err := rp.Client.BlockNumber(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error getting block number: %w", err)
+	}
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(blockNumber),
+	}
+
+	digest := &proposals.GovernanceDigest{
+		NodeAddress:       nodeAddress,
+		BlockNumber:       blockNumber,
+		GeneratedTime:     time.Now(),
+		PdaoProposals:     []proposals.DigestPdaoProposal{},
+		OdaoProposals:     []proposals.DigestOdaoProposal{},
+		SnapshotProposals: []proposals.DigestSnapshotProposal{},
+	}
+
+	// Get the on-chain voting delegate
+	digest.OnchainDelegate, err = network.GetCurrentVotingDelegate(rp, nodeAddress, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting on-chain voting delegate: %w", err)
+	}
+
+	// Get the pDAO proposals
+	pdaoProps, err := protocol.GetProposals(rp, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pDAO proposals: %w", err)
+	}
+	pendingProps := []protocol.ProtocolDaoProposalDetails{}
+	for _, prop := range pdaoProps {
+		digestProp := proposals.DigestPdaoProposal{
+			ID:         prop.ID,
+			Message:    prop.Message,
+			Proposer:   prop.ProposerAddress,
+			State:      prop.State,
+			Challenges: []proposals.DigestChallenge{},
+		}
+		switch prop.State {
+		case types.ProtocolDaoProposalState_Pending:
+			digestProp.PhaseEnd = prop.VotingStartTime
+			pendingProps = append(pendingProps, prop)
+		case types.ProtocolDaoProposalState_ActivePhase1:
+			digestProp.PhaseEnd = prop.Phase1EndTime
+		case types.ProtocolDaoProposalState_ActivePhase2:
+			digestProp.PhaseEnd = prop.Phase2EndTime
+		case types.ProtocolDaoProposalState_Succeeded:
+			digestProp.PhaseEnd = prop.ExpiryTime
+		}
+
+		// Get the votes of the node and the delegate it had when the proposal was created
+		if prop.State == types.ProtocolDaoProposalState_ActivePhase1 || prop.State == types.ProtocolDaoProposalState_ActivePhase2 {
+			digestProp.Delegate, err = network.GetVotingDelegate(rp, nodeAddress, prop.TargetBlock, opts)
+			if err != nil {
+				return nil, fmt.Errorf("error getting voting delegate for proposal %d: %w", prop.ID, err)
+			}
+			digestProp.NodeVote, err = protocol.GetAddressVoteDirection(rp, prop.ID, nodeAddress, opts)
+			if err != nil {
+				return nil, fmt.Errorf("error getting node's vote on proposal %d: %w", prop.ID, err)
+			}
+			if digestProp.Delegate != nodeAddress {
+				digestProp.DelegateVote, err = protocol.GetAddressVoteDirection(rp, prop.ID, digestProp.Delegate, opts)
+				if err != nil {
+					return nil, fmt.Errorf("error getting delegate's vote on proposal %d: %w", prop.ID, err)
+				}
+			}
+		}
+		digest.PdaoProposals = append(digest.PdaoProposals, digestProp)
+	}
+
+	// Get the challenges against proposals that are still in the challenge phase
+	if len(pendingProps) > 0 {
+		challenges, err := getDigestChallenges(c, bc, pendingProps, opts)
+		if err != nil {
+			return nil, err
+		}
+		for i, prop := range digest.PdaoProposals {
+			if propChallenges, exists := challenges[prop.ID]; exists {
+				digest.PdaoProposals[i].Challenges = propChallenges
+			}
+		}
+	}
+
+	// Get the oDAO proposals if the node is a member
+	digest.IsOdaoMember, err = trustednode.GetMemberExists(rp, nodeAddress, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error checking oDAO membership: %w", err)
+	}
+	if digest.IsOdaoMember {
+		odaoProps, err := dao.GetDAOProposalsWithMember(rp, "rocketDAONodeTrustedProposals", nodeAddress, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error getting oDAO proposals: %w", err)
+		}
+		for _, prop := range odaoProps {
+			digest.OdaoProposals = append(digest.OdaoProposals, proposals.DigestOdaoProposal{
+				ID:              prop.ID,
+				Message:         prop.Message,
+				Proposer:        prop.ProposerAddress,
+				State:           prop.State,
+				EndTime:         time.Unix(int64(prop.EndTime), 0),
+				MemberVoted:     prop.MemberVoted,
+				MemberSupported: prop.MemberSupported,
+			})
+		}
+	}
+
+	// Get the active Snapshot proposals, but treat errors as non-fatal
+	snapshotProps, err := GetSnapshotProposals(cfg.Smartnode.GetSnapshotApiDomain(), cfg.Smartnode.GetSnapshotID(), "active")
+	if err != nil {
+		digest.SnapshotError = err.Error()
+	} else {
+		votedIDs := map[string]bool{}
+		if reg != nil && cfg.Smartnode.GetRocketSignerRegistryAddress() != "" {
+			signallingAddress, err := reg.NodeToSigner(&bind.CallOpts{}, nodeAddress)
+			if err != nil {
+				digest.SnapshotError = err.Error()
+			} else {
+				votedProps, err := GetSnapshotVotedProposals(cfg.Smartnode.GetSnapshotApiDomain(), cfg.Smartnode.GetSnapshotID(), nodeAddress, signallingAddress)
+				if err != nil {
+					digest.SnapshotError = err.Error()
+				} else {
+					for _, vote := range votedProps.Data.Votes {
+						votedIDs[vote.Proposal.Id] = true
+					}
+				}
+			}
+		}
+		for _, prop := range snapshotProps.Data.Proposals {
+			digest.SnapshotProposals = append(digest.SnapshotProposals, proposals.DigestSnapshotProposal{
+				ID:    prop.Id,
+				Title: prop.Title,
+				State: prop.State,
+				End:   time.Unix(prop.End, 0),
+				Link:  prop.Link,
+				Voted: votedIDs[prop.Id],
+			})
+		}
+	}
+
+	digest.Sort()
+	return digest, nil
+}
+
+// Get the challenges issued against the provided proposals, keyed by proposal ID
+func getDigestChallenges(c *cli.Context, bc beacon.Client, props []protocol.ProtocolDaoProposalDetails, opts *bind.CallOpts) (map[uint64][]proposals.DigestChallenge, error) {
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Scan from the block the oldest proposal was created in
+	ids := make([]uint64, len(props))
+	startTime := props[0].CreatedTime
+	for i, prop := range props {
+		ids[i] = prop.ID
+		if prop.CreatedTime.Before(startTime) {
+			startTime = prop.CreatedTime
+		}
+	}
+	startBlock, err := getExecutionBlockAtTime(bc, startTime)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the challenge events
+	intervalSize := big.NewInt(int64(cfg.Geth.EventLogInterval))
+	verifierAddresses := cfg.Smartnode.GetPreviousRocketDAOProtocolVerifierAddresses()
+	events, err := protocol.GetChallengeSubmittedEvents(rp, ids, intervalSize, startBlock, opts.BlockNumber, verifierAddresses, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error scanning for ChallengeSubmitted events: %w", err)
+	}
+
+	// Get the current state of each one
+	challenges := map[uint64][]proposals.DigestChallenge{}
+	for _, event := range events {
+		propID := event.ProposalID.Uint64()
+		index := event.Index.Uint64()
+		state, err := protocol.GetChallengeState(rp, propID, index, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error checking state of challenge on proposal %d, index %d: %w", propID, index, err)
+		}
+		challenges[propID] = append(challenges[propID], proposals.DigestChallenge{
+			Index:      index,
+			Challenger: event.Challenger,
+			State:      state,
+			Time:       event.Timestamp,
+		})
+	}
+	return challenges, nil
+}
+
+// Get the number of the first execution block at or after the provided time
+func getExecutionBlockAtTime(bc beacon.Client, blockTime time.Time) (*big.Int, error) {
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon config: %w", err)
+	}
+	genesisTime := time.Unix(int64(eth2Config.GenesisTime), 0)
+	secondsPerSlot := time.Second * time.Duration(eth2Config.SecondsPerSlot)
+	startSlot := uint64(blockTime.Sub(genesisTime) / secondsPerSlot)
+
+	// Skip over missed slots
+	for slot := startSlot; slot < startSlot+digestBlockSearchLimit; slot++ {
+		block, exists, err := bc.GetBeaconBlock(fmt.Sprint(slot))
+		if err != nil {
+			return nil, fmt.Errorf("error getting Beacon block at slot %d: %w", slot, err)
+		}
+		if exists {
+			return big.NewInt(int64(block.ExecutionBlockNumber)), nil
+		}
+	}
+	return nil, fmt.Errorf("couldn't find a Beacon block within %d slots of slot %d", digestBlockSearchLimit, startSlot)
+}
@@ -0,0 +1,190 @@
+package pdao
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	psettings "github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cliutils "github.com/rocket-pool/smartnode/shared/utils/cli"
+)
+
+// The Protocol DAO settings that hold a boolean; every other setting is a uint256
+var boolSettings = map[string]map[string]bool{
+	psettings.AuctionSettingsContractName: {
+		psettings.CreateLotEnabledSettingPath: true,
+		psettings.BidOnLotEnabledSettingPath:  true,
+	},
+	psettings.DepositSettingsContractName: {
+		psettings.DepositEnabledSettingPath:        true,
+		psettings.AssignDepositsEnabledSettingPath: true,
+	},
+	psettings.MinipoolSettingsContractName: {
+		psettings.MinipoolSubmitWithdrawableEnabledSettingPath: true,
+		psettings.BondReductionEnabledSettingPath:              true,
+	},
+	psettings.NetworkSettingsContractName: {
+		psettings.SubmitBalancesEnabledSettingPath: true,
+		psettings.SubmitPricesEnabledSettingPath:   true,
+		psettings.SubmitRewardsEnabledSettingPath:  true,
+	},
+	psettings.NodeSettingsContractName: {
+		psettings.NodeRegistrationEnabledSettingPath:          true,
+		psettings.SmoothingPoolRegistrationEnabledSettingPath: true,
+		psettings.NodeDepositEnabledSettingPath:               true,
+		psettings.VacantMinipoolsEnabledSettingPath:           true,
+	},
+}
+
+func simulateProposal(c *cli.Context, id uint64) (*api.PDAOSimulateProposalResponse, error) {
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PDAOSimulateProposalResponse{
+		ProposalID: id,
+	}
+
+	// Get the proposal
+	proposal, err := protocol.GetProposalDetails(rp, id, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.Message = proposal.Message
+	response.State = proposal.State
+
+	// Decode the payload
+	info, err := proposals.GetProposalPayloadInfo(rp, proposal.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding proposal %d's payload: %w", id, err)
+	}
+	response.Method = info.Method
+
+	// Run the simulation
+	response.Simulation, err = runSimulation(c, rp, info)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+func simulateSetting(c *cli.Context, contractName string, settingName string, value string) (*api.PDAOSimulateProposalResponse, error) {
+	// Get services
+	if err := services.RequireRocketStorage(c); err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PDAOSimulateProposalResponse{
+		Message: fmt.Sprintf("set %s to %s", settingName, value),
+	}
+
+	// Build the draft change
+	change := proposals.ProposalSettingChange{
+		Contract: contractName,
+		Path:     settingName,
+	}
+	if boolSettings[contractName][settingName] {
+		change.Type = types.ProposalSettingType_Bool
+		change.BoolValue, err = cliutils.ValidateBool("value", value)
+	} else {
+		change.Type = types.ProposalSettingType_Uint256
+		change.UintValue, err = cliutils.ValidateBigInt("value", value)
+	}
+	if err != nil {
+		return nil, err
+	}
+	info := &proposals.ProposalPayloadInfo{
+		Type:           proposals.ProposalType_Setting,
+		Settings:       []string{settingName},
+		SettingChanges: []proposals.ProposalSettingChange{change},
+	}
+
+	// Run the simulation
+	response.Simulation, err = runSimulation(c, rp, info)
+	if err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Apply a decoded payload to a copy of the current network state
+func runSimulation(c *cli.Context, rp *rocketpool.RocketPool, info *proposals.ProposalPayloadInfo) (proposals.ProposalSimulation, error) {
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return proposals.ProposalSimulation{}, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return proposals.ProposalSimulation{}, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return proposals.ProposalSimulation{}, err
+	}
+
+	// The local node is optional; without a wallet the simulation just won't highlight it
+	var nodeAddress common.Address
+	nodeAccount, err := w.GetNodeAccount()
+	if err == nil {
+		nodeAddress = nodeAccount.Address
+	}
+
+	// Get the node fee bounds, since they aren't part of the network state
+	var feeBounds proposals.NodeFeeBounds
+	var wg errgroup.Group
+	wg.Go(func() error {
+		var err error
+		feeBounds.Minimum, err = psettings.GetMinimumNodeFee(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		feeBounds.Target, err = psettings.GetTargetNodeFee(rp, nil)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		feeBounds.Maximum, err = psettings.GetMaximumNodeFee(rp, nil)
+		return err
+	})
+	if err := wg.Wait(); err != nil {
+		return proposals.ProposalSimulation{}, fmt.Errorf("error getting node fee bounds: %w", err)
+	}
+
+	// Get the network state
+	stateMgr, err := state.NewNetworkStateManager(rp, cfg, rp.Client, bc, nil)
+	if err != nil {
+		return proposals.ProposalSimulation{}, fmt.Errorf("error creating network state manager: %w", err)
+	}
+	networkState, err := stateMgr.GetHeadState()
+	if err != nil {
+		return proposals.ProposalSimulation{}, fmt.Errorf("error getting network state: %w", err)
+	}
+
+	// Simulate the proposal
+	simulation, err := proposals.SimulateProposal(networkState, feeBounds, nodeAddress, info)
+	if err != nil {
+		return proposals.ProposalSimulation{}, err
+	}
+	return *simulation, nil
+}
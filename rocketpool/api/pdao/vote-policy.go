@@ -0,0 +1,102 @@
+package pdao
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func getVotePolicyStatus(c *cli.Context) (*api.PDAOVotePolicyStatusResponse, error) {
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PDAOVotePolicyStatusResponse{
+		Enabled:        cfg.Smartnode.EnablePdaoVotePolicy.Value.(bool),
+		PolicyPath:     cfg.Smartnode.GetPdaoVotePolicyPath(),
+		AutoTxDisabled: cfg.Smartnode.AutoTxGasThreshold.Value.(float64) == 0,
+		Decisions:      []proposals.VotePolicyDecision{},
+	}
+
+	// Load the record
+	record, err := proposals.LoadVotePolicyRecord(cfg.Smartnode.GetPdaoVotePolicyRecordPath())
+	if err != nil {
+		return nil, err
+	}
+	for _, decision := range record.Decisions {
+		response.Decisions = append(response.Decisions, *decision)
+	}
+	sort.Slice(response.Decisions, func(i, j int) bool {
+		return response.Decisions[i].ProposalID < response.Decisions[j].ProposalID
+	})
+
+	// Load the confirmation
+	confirmation, err := proposals.LoadVotePolicyConfirmation(cfg.Smartnode.GetPdaoVotePolicyConfirmationPath())
+	if err != nil {
+		return nil, err
+	}
+	response.ConfirmedHash = confirmation.PolicyHash
+
+	// Load the policy
+	policy, policyHash, err := proposals.LoadVotePolicy(response.PolicyPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return &response, nil
+	}
+	response.PolicyExists = true
+	if err != nil {
+		response.PolicyError = err.Error()
+		return &response, nil
+	}
+	response.PolicyHash = policyHash
+	response.RuleCount = len(policy.Rules)
+	response.LeadTime = policy.GetLeadTime()
+	response.ForcedDryRun = policy.DryRun
+	response.IsConfirmed = confirmation.IsConfirmed(policyHash)
+
+	// Return response
+	return &response, nil
+}
+
+func confirmVotePolicy(c *cli.Context, expectedHash string) (*api.PDAOConfirmVotePolicyResponse, error) {
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PDAOConfirmVotePolicyResponse{}
+
+	// Make sure the policy hasn't changed since it was reviewed
+	_, policyHash, err := proposals.LoadVotePolicy(cfg.Smartnode.GetPdaoVotePolicyPath())
+	if err != nil {
+		return nil, err
+	}
+	response.PolicyHash = policyHash
+	response.HashMatch = (policyHash == expectedHash)
+	if !response.HashMatch {
+		return &response, nil
+	}
+
+	// Confirm it
+	confirmation := proposals.VotePolicyConfirmation{
+		PolicyHash:    policyHash,
+		ConfirmedTime: time.Now(),
+	}
+	if err := confirmation.Save(cfg.Smartnode.GetPdaoVotePolicyConfirmationPath()); err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+}
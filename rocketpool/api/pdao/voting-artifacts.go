@@ -0,0 +1,144 @@
+package pdao
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/state"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+func exportVotingArtifacts(c *cli.Context, blockNumber uint32) (*api.PDAOExportVotingArtifactsResponse, error) {
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PDAOExportVotingArtifactsResponse{
+		BlockNumber: blockNumber,
+	}
+
+	// Build the bundle, generating the artifacts if they don't exist yet
+	propMgr, err := proposals.NewProposalManager(nil, cfg, rp, bc)
+	if err != nil {
+		return nil, fmt.Errorf("error creating proposal manager: %w", err)
+	}
+	bundle, err := propMgr.CreateArtifactBundle(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	err = proposals.SaveArtifactBundle(bundle, cfg.Smartnode.GetVotingArtifactsExportPath(blockNumber, true))
+	if err != nil {
+		return nil, err
+	}
+
+	response.NetworkRoot = bundle.NetworkRoot
+	response.NodeCount = len(bundle.Snapshot.Info)
+	return &response, nil
+}
+
+func importVotingArtifacts(c *cli.Context, rootHash string) (*api.PDAOImportVotingArtifactsResponse, error) {
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.PDAOImportVotingArtifactsResponse{
+		VerifiedProposals: []uint64{},
+	}
+
+	// Load the bundle
+	importPath := cfg.Smartnode.GetVotingArtifactsImportPath(true)
+	bundle, err := proposals.LoadArtifactBundle(importPath)
+	if err != nil {
+		return nil, err
+	}
+	response.BlockNumber = bundle.BlockNumber
+	response.NetworkRoot = bundle.NetworkRoot
+	if bundle.Snapshot != nil {
+		response.NodeCount = len(bundle.Snapshot.Info)
+	}
+
+	// Get the root to verify the bundle against
+	var expectedRootHash common.Hash
+	if rootHash != "" {
+		// Use the root provided by the user
+		expectedRootHash = common.HexToHash(rootHash)
+	} else {
+		// Use the roots of proposals for the block that made it through their challenge phase; a pending proposal's root
+		// could be a bad one that's about to be challenged, so it can't be trusted
+		mcAddress := common.HexToAddress(cfg.Smartnode.GetMulticallAddress())
+		bbAddress := common.HexToAddress(cfg.Smartnode.GetBalanceBatcherAddress())
+		contracts, err := state.NewNetworkContracts(rp, mcAddress, bbAddress, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating network contracts: %w", err)
+		}
+		props, err := state.GetAllProtocolDaoProposalDetails(rp, contracts)
+		if err != nil {
+			return nil, fmt.Errorf("error getting pDAO proposal details: %w", err)
+		}
+		for _, prop := range props {
+			if prop.TargetBlock != bundle.BlockNumber ||
+				prop.State == types.ProtocolDaoProposalState_Pending ||
+				prop.State == types.ProtocolDaoProposalState_Destroyed {
+				continue
+			}
+			root, err := protocol.GetNode(rp, prop.ID, 1, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error getting root node for proposal %d: %w", prop.ID, err)
+			}
+			if expectedRootHash != (common.Hash{}) && root.Hash != expectedRootHash {
+				return nil, fmt.Errorf("proposals for block %d have different roots; please provide the root to verify against", bundle.BlockNumber)
+			}
+			expectedRootHash = root.Hash
+			response.VerifiedProposals = append(response.VerifiedProposals, prop.ID)
+		}
+		if len(response.VerifiedProposals) == 0 {
+			return nil, fmt.Errorf("no proposal for block %d has made it through its challenge phase yet, so there's no on-chain root to verify the bundle against; please provide the root from a source you trust", bundle.BlockNumber)
+		}
+	}
+
+	// Import it
+	propMgr, err := proposals.NewProposalManager(nil, cfg, rp, bc)
+	if err != nil {
+		return nil, fmt.Errorf("error creating proposal manager: %w", err)
+	}
+	err = propMgr.ImportArtifactBundle(bundle, expectedRootHash)
+	if err != nil {
+		return nil, err
+	}
+
+	// Clean up the import file
+	err = os.Remove(importPath)
+	if err != nil {
+		return nil, fmt.Errorf("error removing imported file: %w", err)
+	}
+	return &response, nil
+}
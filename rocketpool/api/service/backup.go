@@ -0,0 +1,123 @@
+package service
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+)
+
+// Create an encrypted backup of the node and save it to the configured destination, including the slashing protection
+// data the CLI exported if requested
+func createBackup(c *cli.Context, includeSlashingProtection bool) (*api.CreateBackupResponse, error) {
+
+	// Get services
+	if err := services.RequireNodeWallet(c); err != nil {
+		return nil, err
+	}
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	store, err := backup.NewStore(cfg, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.CreateBackupResponse{
+		Destination: store.String(),
+	}
+
+	// Get the node account
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the backup
+	slashingProtectionPath := ""
+	if includeSlashingProtection {
+		slashingProtectionPath = cfg.Smartnode.GetBackupSlashingProtectionPath(true)
+	}
+	response.Backup, response.Deleted, err = backup.Create(cfg, store, os.ExpandEnv(c.GlobalString("settings")), slashingProtectionPath, nodeAccount.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// List the backups in the configured destination
+func listBackups(c *cli.Context) (*api.ListBackupsResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	store, err := backup.NewStore(cfg, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ListBackupsResponse{
+		Destination: store.String(),
+	}
+
+	// Get the backups
+	response.Backups, err = store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response
+	return &response, nil
+
+}
+
+// Restore a backup from the configured destination into the data folder
+func restoreBackup(c *cli.Context, name string, expectedAddress common.Address) (*api.RestoreBackupResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	store, err := backup.NewStore(cfg, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.RestoreBackupResponse{}
+
+	// Restore the backup
+	result, err := backup.Restore(cfg, store, name, expectedAddress)
+	if err != nil {
+		if len(result.MovedAside) > 0 {
+			return nil, fmt.Errorf("%w (files that were already moved aside: %v)", err, result.MovedAside)
+		}
+		return nil, err
+	}
+	response.Manifest = result.Manifest
+	response.Settings = string(result.Settings)
+	response.SlashingProtection = string(result.SlashingProtection)
+	response.MovedAside = result.MovedAside
+
+	// Return response
+	return &response, nil
+
+}
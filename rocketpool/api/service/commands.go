@@ -52,6 +52,87 @@ func RegisterSubcommands(command *cli.Command, name string, aliases []string) {
 				},
 			},
 
+			{
+				Name:      "doctor",
+				Usage:     "Runs the service doctor checks that need the node's clients and data folder",
+				UsageText: "rocketpool api service doctor",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(runDoctor(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "create-backup",
+				Usage:     "Creates an encrypted backup of the node and saves it to the configured destination, optionally including the slashing protection data the CLI exported",
+				UsageText: "rocketpool api service create-backup include-slashing-protection",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 1); err != nil {
+						return err
+					}
+					includeSlashingProtection, err := cliutils.ValidateBool("include-slashing-protection", c.Args().Get(0))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(createBackup(c, includeSlashingProtection))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "list-backups",
+				Usage:     "Lists the backups in the configured destination",
+				UsageText: "rocketpool api service list-backups",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 0); err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(listBackups(c))
+					return nil
+
+				},
+			},
+
+			{
+				Name:      "restore-backup",
+				Usage:     "Restores a backup into the data folder if its wallet is for the expected node address",
+				UsageText: "rocketpool api service restore-backup name expected-address",
+				Action: func(c *cli.Context) error {
+
+					// Validate args
+					if err := cliutils.ValidateArgCount(c, 2); err != nil {
+						return err
+					}
+					name := c.Args().Get(0)
+					expectedAddress, err := cliutils.ValidateAddress("expected address", c.Args().Get(1))
+					if err != nil {
+						return err
+					}
+
+					// Run
+					api.PrintResponse(restoreBackup(c, name, expectedAddress))
+					return nil
+
+				},
+			},
+
 			{
 				Name:      "restart-vc",
 				Usage:     "Restarts the validator client",
@@ -0,0 +1,421 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/minipool"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpsvc "github.com/rocket-pool/smartnode/shared/services/rocketpool"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/types/api"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	hexutil "github.com/rocket-pool/smartnode/shared/utils/hex"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+const (
+	// The number of peers below which a client is considered poorly connected
+	doctorMinPeerCount uint64 = 10
+
+	// How far the system clock can run behind the Beacon Chain before it's flagged
+	doctorMaxClockOffset time.Duration = time.Second
+
+	// The most missing keys or relays to list in a check's message
+	doctorMaxListedItems int = 5
+
+	// The path of the Builder API endpoint that returns a validator's registration
+	relayRegistrationPath string = "/relay/v1/data/validator_registration"
+
+	// The time to wait for a relay to respond
+	relayRequestTimeout time.Duration = 10 * time.Second
+)
+
+// Run the service doctor checks that need the node's clients and data folder
+func runDoctor(c *cli.Context) (*api.ServiceDoctorResponse, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Response
+	response := api.ServiceDoctorResponse{
+		Checks: []api.DoctorCheck{},
+	}
+
+	// Check the clients
+	ecStatus := ec.CheckStatus(cfg)
+	response.Checks = append(response.Checks, checkClientSync("Execution client sync", ecStatus.PrimaryClientStatus))
+	if ecStatus.FallbackEnabled {
+		response.Checks = append(response.Checks, checkClientSync("Fallback Execution client sync", ecStatus.FallbackClientStatus))
+	}
+	ecPeers, err := ec.PeerCount(context.Background())
+	response.Checks = append(response.Checks, checkPeerCount("Execution client peers", ecPeers, err))
+
+	bcStatus := bc.CheckStatus()
+	response.Checks = append(response.Checks, checkClientSync("Consensus client sync", bcStatus.PrimaryClientStatus))
+	if bcStatus.FallbackEnabled {
+		response.Checks = append(response.Checks, checkClientSync("Fallback Consensus client sync", bcStatus.FallbackClientStatus))
+	}
+	bcPeers, err := bc.GetPeerCount()
+	response.Checks = append(response.Checks, checkPeerCount("Consensus client peers", bcPeers, err))
+
+	bcSynced := bcStatus.PrimaryClientStatus.IsSynced || bcStatus.FallbackClientStatus.IsSynced
+	response.Checks = append(response.Checks, checkClockOffset(bc, bcSynced))
+
+	// The rest of the checks are for the node's validators
+	ecSynced := ecStatus.PrimaryClientStatus.IsSynced || ecStatus.FallbackClientStatus.IsSynced
+	nodeAddress, skipReason := getDoctorNodeAddress(rp, w, ecSynced)
+	if skipReason != "" {
+		for _, name := range []string{"Fee recipient", "Validator keys", "MEV-Boost relay registration"} {
+			response.Checks = append(response.Checks, api.DoctorCheck{
+				Name:    name,
+				Result:  api.DoctorCheckResult_Warn,
+				Message: fmt.Sprintf("Skipped because %s.", skipReason),
+			})
+		}
+		return &response, nil
+	}
+	pubkeys, err := getActivePubkeys(rp, nodeAddress)
+	if err != nil {
+		return nil, err
+	}
+	response.Checks = append(response.Checks, checkFeeRecipient(cfg, rp, bc, nodeAddress))
+	response.Checks = append(response.Checks, checkValidatorKeys(cfg, w, pubkeys))
+	response.Checks = append(response.Checks, checkRelayRegistration(cfg, pubkeys))
+
+	// Return response
+	return &response, nil
+
+}
+
+// Check the sync status of a client
+func checkClientSync(name string, status api.ClientStatus) api.DoctorCheck {
+	check := api.DoctorCheck{
+		Name: name,
+	}
+	switch {
+	case !status.IsWorking:
+		check.Result = api.DoctorCheckResult_Fail
+		check.Message = fmt.Sprintf("The client isn't responding: %s", status.Error)
+		check.Remediation = "Check that the client is running with `rocketpool service status`, and look for errors with `rocketpool service logs`."
+	case !status.IsSynced:
+		check.Result = api.DoctorCheckResult_Warn
+		check.Message = fmt.Sprintf("The client is still syncing (%.2f%%).", status.SyncProgress*100)
+		if status.Error != "" {
+			check.Message = fmt.Sprintf("%s %s", check.Message, status.Error)
+		}
+		check.Remediation = "Wait for the client to finish syncing; follow its progress with `rocketpool node sync`."
+	default:
+		check.Result = api.DoctorCheckResult_Pass
+		check.Message = "The client is synced."
+	}
+	return check
+}
+
+// Check the number of peers a client is connected to
+func checkPeerCount(name string, peers uint64, err error) api.DoctorCheck {
+	check := api.DoctorCheck{
+		Name: name,
+	}
+	switch {
+	case err != nil:
+		check.Result = api.DoctorCheckResult_Warn
+		check.Message = fmt.Sprintf("Couldn't get the peer count: %s", err.Error())
+	case peers == 0:
+		check.Result = api.DoctorCheckResult_Fail
+		check.Message = "The client doesn't have any peers."
+		check.Remediation = "Make sure the client's P2P port is open in your firewall and forwarded by your router, and that your internet connection is working."
+	case peers < doctorMinPeerCount:
+		check.Result = api.DoctorCheckResult_Warn
+		check.Message = fmt.Sprintf("The client only has %d peers.", peers)
+		check.Remediation = "Make sure the client's P2P port is open in your firewall and forwarded by your router so other nodes can connect to it."
+	default:
+		check.Result = api.DoctorCheckResult_Pass
+		check.Message = fmt.Sprintf("The client has %d peers.", peers)
+	}
+	return check
+}
+
+// Compare the system clock with the slot timing of the Beacon Chain
+func checkClockOffset(bc *services.BeaconClientManager, bcSynced bool) api.DoctorCheck {
+	check := api.DoctorCheck{
+		Name: "System clock",
+	}
+	if !bcSynced {
+		check.Result = api.DoctorCheckResult_Warn
+		check.Message = "Skipped because the Consensus client isn't synced."
+		return check
+	}
+
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		check.Result = api.DoctorCheckResult_Warn
+		check.Message = fmt.Sprintf("Couldn't get the Beacon Chain config: %s", err.Error())
+		return check
+	}
+	head, _, err := bc.GetBeaconBlockHeader("head")
+	if err != nil {
+		check.Result = api.DoctorCheckResult_Warn
+		check.Message = fmt.Sprintf("Couldn't get the head block: %s", err.Error())
+		return check
+	}
+
+	// A block can't be seen before its slot starts, so a clock behind that time is wrong
+	slotStart := time.Unix(int64(eth2Config.GenesisTime+head.Slot*eth2Config.SecondsPerSlot), 0)
+	sinceSlotStart := time.Since(slotStart).Truncate(time.Millisecond)
+	slotTime := time.Duration(eth2Config.SecondsPerSlot) * time.Second
+	switch {
+	case sinceSlotStart < -doctorMaxClockOffset:
+		check.Result = api.DoctorCheckResult_Fail
+		check.Message = fmt.Sprintf("The system clock is at least %s behind the Beacon Chain; the head block's slot %d hasn't started yet according to it.", -sinceSlotStart, head.Slot)
+		check.Remediation = "Make sure time synchronization is enabled on your machine (for example with `timedatectl set-ntp true` or chrony)."
+	case sinceSlotStart > 2*slotTime:
+		check.Result = api.DoctorCheckResult_Warn
+		check.Message = fmt.Sprintf("The head block's slot %d started %s ago. Either the last few slots were missed, or the system clock is ahead.", head.Slot, sinceSlotStart)
+		check.Remediation = "If this keeps happening, make sure time synchronization is enabled on your machine (for example with `timedatectl set-ntp true` or chrony)."
+	default:
+		check.Result = api.DoctorCheckResult_Pass
+		check.Message = fmt.Sprintf("The system clock matches the Beacon Chain; the head block's slot started %s ago.", sinceSlotStart)
+	}
+	return check
+}
+
+// Get the node account for the validator checks, or the reason they can't be run
+func getDoctorNodeAddress(rp *rocketpool.RocketPool, w *wallet.Wallet, ecSynced bool) (common.Address, string) {
+	if !w.IsInitialized() {
+		return common.Address{}, "the node wallet isn't initialized"
+	}
+	if !ecSynced {
+		return common.Address{}, "the Execution client isn't synced"
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return common.Address{}, fmt.Sprintf("the node account couldn't be loaded (%s)", err.Error())
+	}
+	exists, err := node.GetNodeExists(rp, nodeAccount.Address, nil)
+	if err != nil {
+		return common.Address{}, fmt.Sprintf("the node's registration couldn't be checked (%s)", err.Error())
+	}
+	if !exists {
+		return common.Address{}, "the node isn't registered with Rocket Pool"
+	}
+	return nodeAccount.Address, ""
+}
+
+// Get the pubkeys of the node's minipools that are validating
+func getActivePubkeys(rp *rocketpool.RocketPool, nodeAddress common.Address) ([]types.ValidatorPubkey, error) {
+	pubkeys, err := minipool.GetNodeValidatingMinipoolPubkeys(rp, nodeAddress, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting minipool pubkeys: %w", err)
+	}
+	activePubkeys := []types.ValidatorPubkey{}
+	for _, pubkey := range pubkeys {
+		if pubkey != (types.ValidatorPubkey{}) {
+			activePubkeys = append(activePubkeys, pubkey)
+		}
+	}
+	return activePubkeys, nil
+}
+
+// Check that the fee recipient file has the node's correct fee recipient
+func checkFeeRecipient(cfg *config.RocketPoolConfig, rp *rocketpool.RocketPool, bc *services.BeaconClientManager, nodeAddress common.Address) api.DoctorCheck {
+	check := api.DoctorCheck{
+		Name: "Fee recipient",
+	}
+	feeRecipientInfo, err := rputils.GetFeeRecipientInfoWithoutState(rp, bc, nodeAddress, nil)
+	if err != nil {
+		check.Result = api.DoctorCheckResult_Warn
+		check.Message = fmt.Sprintf("Couldn't get the node's fee recipient: %s", err.Error())
+		return check
+	}
+	feeRecipient := feeRecipientInfo.FeeDistributorAddress
+	if feeRecipientInfo.IsInSmoothingPool || feeRecipientInfo.IsInOptOutCooldown {
+		feeRecipient = feeRecipientInfo.SmoothingPoolAddress
+	}
+
+	exists, correct, err := rpsvc.CheckFeeRecipientFile(feeRecipient, cfg)
+	switch {
+	case err != nil:
+		check.Result = api.DoctorCheckResult_Fail
+		check.Message = fmt.Sprintf("Couldn't read the fee recipient file: %s", err.Error())
+		check.Remediation = "Check the permissions of your data folder."
+	case !exists:
+		check.Result = api.DoctorCheckResult_Fail
+		check.Message = fmt.Sprintf("The fee recipient file %s doesn't exist.", cfg.Smartnode.GetFeeRecipientFilePath())
+		check.Remediation = "The node daemon creates it automatically; make sure the node container is running with `rocketpool service status`."
+	case !correct:
+		check.Result = api.DoctorCheckResult_Fail
+		check.Message = fmt.Sprintf("The fee recipient file doesn't have the node's fee recipient %s.", feeRecipient.Hex())
+		check.Remediation = "The node daemon fixes it automatically within a few minutes; if it doesn't, check its logs with `rocketpool service logs node`."
+	default:
+		check.Result = api.DoctorCheckResult_Pass
+		check.Message = fmt.Sprintf("The fee recipient file has the node's fee recipient %s.", feeRecipient.Hex())
+	}
+	return check
+}
+
+// Check that the validator client has a key for each active minipool
+func checkValidatorKeys(cfg *config.RocketPoolConfig, w *wallet.Wallet, pubkeys []types.ValidatorPubkey) api.DoctorCheck {
+	check := api.DoctorCheck{
+		Name: "Validator keys",
+	}
+	var cc cfgtypes.ConsensusClient
+	if cfg.IsNativeMode {
+		cc = cfg.Native.ConsensusClient.Value.(cfgtypes.ConsensusClient)
+	} else {
+		cc, _ = cfg.GetSelectedConsensusClient()
+	}
+
+	missing := []string{}
+	for _, pubkey := range pubkeys {
+		hasKey, err := w.HasValidatorKey(string(cc), pubkey)
+		if err != nil {
+			check.Result = api.DoctorCheckResult_Fail
+			check.Message = fmt.Sprintf("Couldn't check the key for validator %s: %s", pubkey.Hex(), err.Error())
+			check.Remediation = "Check the permissions of your data folder."
+			return check
+		}
+		if !hasKey {
+			missing = append(missing, pubkey.Hex())
+		}
+	}
+
+	if len(missing) > 0 {
+		check.Result = api.DoctorCheckResult_Fail
+		check.Message = fmt.Sprintf("%d of %d active minipools don't have a key in the %s keystore: %s", len(missing), len(pubkeys), cc, formatDoctorList(missing))
+		check.Remediation = "Run `rocketpool wallet rebuild` to regenerate the validator keys, then restart the validator client."
+		return check
+	}
+	check.Result = api.DoctorCheckResult_Pass
+	check.Message = fmt.Sprintf("All %d active minipools have a key in the %s keystore.", len(pubkeys), cc)
+	return check
+}
+
+// Check that the node's validators are registered with the enabled MEV-Boost relays
+func checkRelayRegistration(cfg *config.RocketPoolConfig, pubkeys []types.ValidatorPubkey) api.DoctorCheck {
+	check := api.DoctorCheck{
+		Name:   "MEV-Boost relay registration",
+		Result: api.DoctorCheckResult_Pass,
+	}
+	if cfg.IsNativeMode || cfg.EnableMevBoost.Value != true {
+		check.Message = "MEV-Boost is disabled."
+		return check
+	}
+	if cfg.MevBoost.Mode.Value.(cfgtypes.Mode) != cfgtypes.Mode_Local {
+		check.Message = "MEV-Boost is managed externally, so its relays can't be checked."
+		return check
+	}
+	relays := cfg.MevBoost.GetEnabledMevRelays()
+	if len(relays) == 0 {
+		check.Result = api.DoctorCheckResult_Fail
+		check.Message = "MEV-Boost is enabled, but no relays are selected."
+		check.Remediation = "Select at least one relay in the MEV-Boost section of `rocketpool service config`."
+		return check
+	}
+	if len(pubkeys) == 0 {
+		check.Message = "There are no active minipools to check."
+		return check
+	}
+
+	// Check one of the validators with each relay, since the validator client registers all of them together
+	network := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	pubkey := pubkeys[0]
+	registered := make([]bool, len(relays))
+	errs := make([]error, len(relays))
+	var wg errgroup.Group
+	for i, relay := range relays {
+		i := i
+		relayUrl := relay.Urls[network]
+		wg.Go(func() error {
+			registered[i], errs[i] = isRegisteredWithRelay(relayUrl, pubkey)
+			return nil
+		})
+	}
+	_ = wg.Wait()
+
+	unregistered := []string{}
+	for i, relay := range relays {
+		switch {
+		case errs[i] != nil:
+			unregistered = append(unregistered, fmt.Sprintf("%s (%s)", relay.Name, errs[i].Error()))
+		case !registered[i]:
+			unregistered = append(unregistered, relay.Name)
+		}
+	}
+	if len(unregistered) > 0 {
+		check.Result = api.DoctorCheckResult_Warn
+		check.Message = fmt.Sprintf("Validator %s isn't registered with %d of %d relays: %s", pubkey.Hex(), len(unregistered), len(relays), formatDoctorList(unregistered))
+		check.Remediation = "The validator client registers with the relays every epoch, so new validators can take a few minutes to show up. If this persists, check the MEV-Boost logs with `rocketpool service logs mev-boost`."
+		return check
+	}
+	check.Message = fmt.Sprintf("Validator %s is registered with all %d enabled relays.", pubkey.Hex(), len(relays))
+	return check
+}
+
+// Check if a validator is registered with a relay using the Builder API
+func isRegisteredWithRelay(relayUrl string, pubkey types.ValidatorPubkey) (bool, error) {
+	// Relay URLs include the relay's pubkey as the user, which isn't part of the request
+	parsedUrl, err := url.Parse(relayUrl)
+	if err != nil {
+		return false, fmt.Errorf("invalid relay URL: %w", err)
+	}
+	parsedUrl.User = nil
+	parsedUrl.Path = relayRegistrationPath
+	parsedUrl.RawQuery = url.Values{"pubkey": []string{hexutil.AddPrefix(pubkey.Hex())}}.Encode()
+
+	client := http.Client{
+		Timeout: relayRequestTimeout,
+	}
+	resp, err := client.Get(parsedUrl.String())
+	if err != nil {
+		return false, fmt.Errorf("couldn't reach the relay")
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusBadRequest, http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("HTTP status %d", resp.StatusCode)
+	}
+}
+
+// Format a list of items for a check message, truncating long ones
+func formatDoctorList(items []string) string {
+	if len(items) <= doctorMaxListedItems {
+		return strings.Join(items, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(items[:doctorMaxListedItems], ", "), len(items)-doctorMaxListedItems)
+}
@@ -0,0 +1,320 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/performance"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	rputils "github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// The number of epochs finality normally trails the head of the chain by
+const optOutFinalityDelay uint64 = 2
+
+// Audit proposals task
+type auditProposals struct {
+	c            *cli.Context
+	log          log.ColorLogger
+	cfg          *config.RocketPoolConfig
+	w            *wallet.Wallet
+	rp           *rocketpool.RocketPool
+	ec           *services.ExecutionClientManager
+	bc           beacon.Client
+	beaconConfig beacon.Eth2Config
+	path         string
+	record       *performance.ProposalAuditRecord
+}
+
+// Create audit proposals task
+func newAuditProposals(c *cli.Context, logger log.ColorLogger) (*auditProposals, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	ec, err := services.GetEthClient(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	beaconConfig, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon config: %w", err)
+	}
+
+	// Load the existing record
+	path := cfg.Smartnode.GetProposalAuditPath()
+	record, err := performance.LoadProposalAuditRecord(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &auditProposals{
+		c:            c,
+		log:          logger,
+		cfg:          cfg,
+		w:            w,
+		rp:           rp,
+		ec:           ec,
+		bc:           bc,
+		beaconConfig: beaconConfig,
+		path:         path,
+		record:       record,
+	}, nil
+
+}
+
+// Audit the blocks proposed by the node's validators in any newly finalized epochs
+func (t *auditProposals) run(state *state.NetworkState) error {
+
+	// Get the node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the range of epochs to process
+	head, err := t.bc.GetBeaconHead()
+	if err != nil {
+		return fmt.Errorf("error getting Beacon head: %w", err)
+	}
+	startEpoch, endEpoch, ok := performance.GetEpochRange(t.record.GetLastProcessedEpoch(), head.FinalizedEpoch)
+	if !ok {
+		return nil
+	}
+
+	// Get the node's validators
+	validators := map[string]common.Address{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAccount.Address] {
+		status, exists := state.ValidatorDetails[mpd.Pubkey]
+		if exists && status.Exists {
+			validators[status.Index] = mpd.MinipoolAddress
+		}
+	}
+
+	// Log
+	t.log.Printlnf("Checking for blocks proposed by the node's validators in epochs %d to %d...", startEpoch, endEpoch)
+
+	// Audit each epoch
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		headers, err := performance.GetHeadersForEpoch(t.bc, epoch, t.beaconConfig.SlotsPerEpoch)
+		if err != nil {
+			return err
+		}
+
+		audits := []performance.ProposalAudit{}
+		for _, header := range headers {
+			if header == nil {
+				continue
+			}
+			minipoolAddress, exists := validators[header.ProposerIndex]
+			if !exists {
+				continue
+			}
+
+			audit, err := t.auditProposal(nodeAccount.Address, header.Slot, header.ProposerIndex, minipoolAddress)
+			if err != nil {
+				return fmt.Errorf("error auditing block proposed in slot %d: %w", header.Slot, err)
+			}
+			if audit != nil {
+				audits = append(audits, *audit)
+			}
+		}
+		t.record.AddEpoch(epoch, audits)
+	}
+
+	// Prune and save the record
+	t.record.Prune(time.Now())
+	err = t.record.Save(t.path)
+	if err != nil {
+		return err
+	}
+
+	// Return
+	return nil
+
+}
+
+// Check that a block proposed by one of the node's validators paid the correct fee recipient
+func (t *auditProposals) auditProposal(nodeAddress common.Address, slot uint64, validatorIndex string, minipoolAddress common.Address) (*performance.ProposalAudit, error) {
+
+	// Get the Beacon block
+	block, exists, err := t.bc.GetBeaconBlock(fmt.Sprint(slot))
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon block: %w", err)
+	}
+	if !exists || !block.HasExecutionPayload {
+		return nil, nil
+	}
+
+	// Get the execution block
+	elBlock, err := t.ec.BlockByNumber(context.Background(), big.NewInt(0).SetUint64(block.ExecutionBlockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("error getting execution block %d: %w", block.ExecutionBlockNumber, err)
+	}
+
+	// Get the fee recipients that were allowed for this block
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(block.ExecutionBlockNumber),
+	}
+	feeRecipientInfo, err := rputils.GetFeeRecipientInfoWithoutState(t.rp, t.bc, nodeAddress, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting fee recipient info at execution block %d: %w", block.ExecutionBlockNumber, err)
+	}
+	isInOptOutCooldown, err := t.isInOptOutCooldown(nodeAddress, block.Slot, feeRecipientInfo.IsInSmoothingPool, opts)
+	if err != nil {
+		return nil, err
+	}
+	isInSmoothingPool := feeRecipientInfo.IsInSmoothingPool || isInOptOutCooldown
+	allowedRecipients := map[common.Address]bool{
+		feeRecipientInfo.SmoothingPoolAddress: true,
+		t.cfg.Smartnode.GetRethAddress():      true,
+	}
+	expectedRecipient := feeRecipientInfo.SmoothingPoolAddress
+	if !feeRecipientInfo.IsInSmoothingPool {
+		allowedRecipients[feeRecipientInfo.FeeDistributorAddress] = true
+	}
+	if !isInSmoothingPool {
+		expectedRecipient = feeRecipientInfo.FeeDistributorAddress
+	}
+
+	audit := &performance.ProposalAudit{
+		Slot:                 block.Slot,
+		ExecutionBlockNumber: block.ExecutionBlockNumber,
+		Time:                 time.Unix(int64(elBlock.Time()), 0),
+		ValidatorIndex:       validatorIndex,
+		MinipoolAddress:      minipoolAddress,
+		IsInSmoothingPool:    isInSmoothingPool,
+		ExpectedRecipient:    expectedRecipient,
+		FeeRecipient:         block.FeeRecipient,
+		ActualRecipient:      block.FeeRecipient,
+	}
+
+	// Check for an MEV-Boost payment from the builder, which is the last transaction of the block
+	if !allowedRecipients[block.FeeRecipient] {
+		txs := elBlock.Transactions()
+		if len(txs) > 0 {
+			lastTx := txs[len(txs)-1]
+			signer := ethtypes.LatestSignerForChainID(big.NewInt(0).SetUint64(uint64(t.cfg.Smartnode.GetChainID())))
+			sender, err := ethtypes.Sender(signer, lastTx)
+			if err == nil && sender == block.FeeRecipient && lastTx.To() != nil {
+				audit.IsMevBoost = true
+				audit.ActualRecipient = *lastTx.To()
+				audit.Reward = lastTx.Value()
+			}
+		}
+	}
+
+	// Vanilla blocks pay the priority fees to the fee recipient
+	if !audit.IsMevBoost {
+		audit.Reward, err = t.getPriorityFees(elBlock)
+		if err != nil {
+			return nil, err
+		}
+	}
+	audit.IsCorrect = allowedRecipients[audit.ActualRecipient]
+
+	// Log and alert
+	if audit.IsCorrect {
+		t.log.Printlnf("Minipool %s proposed block %d in slot %d, paying %.6f ETH to %s.", minipoolAddress.Hex(), block.ExecutionBlockNumber, block.Slot, eth.WeiToEth(audit.Reward), audit.ActualRecipient.Hex())
+	} else {
+		t.log.Println("=== WRONG FEE RECIPIENT DETECTED ===")
+		t.log.Printlnf("Beacon Block:       %d", block.Slot)
+		t.log.Printlnf("Minipool:           %s", minipoolAddress.Hex())
+		t.log.Printlnf("Expected recipient: %s", expectedRecipient.Hex())
+		t.log.Printlnf("ACTUAL RECIPIENT:   %s", audit.ActualRecipient.Hex())
+		t.log.Printlnf("Reward:             %.6f ETH", eth.WeiToEth(audit.Reward))
+		t.log.Println("====================================")
+		alerting.AlertProposalFeeRecipientWrong(t.cfg, minipoolAddress, block.Slot, expectedRecipient, audit.ActualRecipient)
+	}
+	return audit, nil
+
+}
+
+// Check if the node was still in its Smoothing Pool opt-out cooldown when the block in the given slot was proposed.
+// The fee recipient info checks the cooldown against the current chain head, which is usually long past it by the time a block is audited.
+// The cooldown lasts until the epoch after the opt-out is finalized; finality normally trails the chain by two epochs, so that's used
+// instead of looking up the finalized checkpoint of the block's state, which pruned Beacon Nodes can't provide.
+func (t *auditProposals) isInOptOutCooldown(nodeAddress common.Address, slot uint64, isInSmoothingPool bool, opts *bind.CallOpts) (bool, error) {
+	if isInSmoothingPool {
+		return false, nil
+	}
+	optOutTime, err := node.GetSmoothingPoolRegistrationChanged(t.rp, nodeAddress, opts)
+	if err != nil {
+		return false, fmt.Errorf("error getting Smoothing Pool opt-out time at execution block %d: %w", opts.BlockNumber.Uint64(), err)
+	}
+	if optOutTime == time.Unix(0, 0) {
+		return false, nil
+	}
+
+	genesisTime := time.Unix(int64(t.beaconConfig.GenesisTime), 0)
+	optOutEpoch := uint64(optOutTime.Sub(genesisTime).Seconds()) / t.beaconConfig.SecondsPerEpoch
+	blockEpoch := slot / t.beaconConfig.SlotsPerEpoch
+	return blockEpoch < optOutEpoch+1+optOutFinalityDelay, nil
+}
+
+// Get the total priority fees paid by the transactions in a block
+func (t *auditProposals) getPriorityFees(block *ethtypes.Block) (*big.Int, error) {
+	txs := block.Transactions()
+	tips := make([]*big.Int, len(txs))
+	baseFee := block.BaseFee()
+	if baseFee == nil {
+		baseFee = big.NewInt(0)
+	}
+
+	var wg errgroup.Group
+	wg.SetLimit(performance.ThreadLimit)
+	for i, tx := range txs {
+		i := i
+		txHash := tx.Hash()
+		wg.Go(func() error {
+			receipt, err := t.ec.TransactionReceipt(context.Background(), txHash)
+			if err != nil {
+				return fmt.Errorf("error getting receipt for transaction %s: %w", txHash.Hex(), err)
+			}
+			tip := big.NewInt(0).Sub(receipt.EffectiveGasPrice, baseFee)
+			tips[i] = tip.Mul(tip, big.NewInt(0).SetUint64(receipt.GasUsed))
+			return nil
+		})
+	}
+	if err := wg.Wait(); err != nil {
+		return nil, fmt.Errorf("error getting priority fees for execution block %d: %w", block.NumberU64(), err)
+	}
+
+	total := big.NewInt(0)
+	for _, tip := range tips {
+		total.Add(total, tip)
+	}
+	return total, nil
+}
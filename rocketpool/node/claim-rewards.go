@@ -0,0 +1,349 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+const (
+	// How often to check the claim policy, since it requires reading every unclaimed rewards tree
+	claimRewardsCheckInterval time.Duration = time.Hour
+
+	// How long to wait before alerting again if claiming keeps failing
+	claimRewardsFailureAlertInterval time.Duration = 24 * time.Hour
+)
+
+// Claim rewards task
+type claimRewards struct {
+	c                 *cli.Context
+	log               log.ColorLogger
+	cfg               *config.RocketPoolConfig
+	w                 *wallet.Wallet
+	rp                *rocketpool.RocketPool
+	gasPolicy         *rpgas.AutoTxPolicy
+	valueThreshold    *big.Int
+	claimGasThreshold float64
+	maxAge            time.Duration
+	restakePercent    float64
+	maxFee            *big.Int
+	maxPriorityFee    *big.Int
+	gasLimit          uint64
+	disabled          bool
+	lastCheck         time.Time
+	lastFailureAlert  time.Time
+}
+
+// Create claim rewards task
+func newClaimRewards(c *cli.Context, logger log.ColorLogger) (*claimRewards, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the claim policy
+	valueThreshold := cfg.Smartnode.AutoClaimValueThreshold.Value.(float64)
+	claimGasThreshold := cfg.Smartnode.AutoClaimGasThreshold.Value.(float64)
+	maxDays := cfg.Smartnode.AutoClaimMaxDays.Value.(uint64)
+	restakePercent := cfg.Smartnode.AutoClaimRestakePercent.Value.(float64)
+	txGasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+	disabled := false
+	if txGasThreshold == 0 {
+		logger.Println("Automatic tx gas threshold is 0, disabling auto-claim.")
+		disabled = true
+	} else if valueThreshold == 0 && claimGasThreshold == 0 && maxDays == 0 {
+		logger.Println("WARNING: auto-claim is enabled but all of its conditions are disabled, so rewards will never be claimed automatically.")
+	}
+	if restakePercent < 0 {
+		logger.Printlnf("WARNING: Auto-claim restake percent is negative (%.2f%%), restaking nothing", restakePercent)
+		restakePercent = 0
+	} else if restakePercent > 100 {
+		logger.Printlnf("WARNING: Auto-claim restake percent is more than 100%% (%.2f%%), restaking all of the claimed RPL", restakePercent)
+		restakePercent = 100
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested max fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Get the gas policy shared by the automatic transactions
+	gasPolicy, err := services.GetAutoTxPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &claimRewards{
+		c:                 c,
+		log:               logger,
+		cfg:               cfg,
+		w:                 w,
+		rp:                rp,
+		gasPolicy:         gasPolicy,
+		valueThreshold:    eth.EthToWei(valueThreshold),
+		claimGasThreshold: claimGasThreshold,
+		maxAge:            time.Duration(maxDays) * 24 * time.Hour,
+		restakePercent:    restakePercent,
+		maxFee:            maxFee,
+		maxPriorityFee:    priorityFee,
+		gasLimit:          0,
+		disabled:          disabled,
+	}, nil
+
+}
+
+// Claim rewards
+func (t *claimRewards) run(state *state.NetworkState) error {
+
+	// Check if auto-claiming is disabled, and only check periodically
+	if t.disabled || time.Since(t.lastCheck) < claimRewardsCheckInterval {
+		return nil
+	}
+	t.lastCheck = time.Now()
+
+	// Log
+	t.log.Println("Checking for rewards to claim...")
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the intervals that can be claimed
+	intervals, oldestEndTime, totalRpl, totalEth, err := t.getClaimableIntervals(nodeAccount.Address)
+	if err != nil {
+		return err
+	}
+	if len(intervals) == 0 {
+		return nil
+	}
+
+	// Get the value of the rewards in ETH
+	rplValue := big.NewInt(0).Mul(totalRpl, state.NetworkDetails.RplPrice)
+	rplValue.Div(rplValue, eth.EthToWei(1))
+	totalValue := big.NewInt(0).Add(totalEth, rplValue)
+	t.log.Printlnf("%d interval(s) can be claimed for %.6f ETH and %.6f RPL (worth %.6f ETH in total).", len(intervals), eth.WeiToEth(totalEth), eth.WeiToEth(totalRpl), eth.WeiToEth(totalValue))
+
+	// Get the network's suggested max fee, and use it unless the user set one manually
+	networkMaxFee, err := rpgas.GetHeadlessMaxFeeWei()
+	if err != nil {
+		return err
+	}
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee = networkMaxFee
+	}
+
+	// The max age condition is the claim's deadline, so its gas threshold escalates as that approaches
+	deadline := rpgas.AutoTxDeadline{
+		Action:   rpgas.AutoTxAction_ClaimRewards,
+		Subject:  nodeAccount.Address.Hex(),
+		Eligible: oldestEndTime,
+	}
+	if t.maxAge > 0 {
+		deadline.Deadline = oldestEndTime.Add(t.maxAge)
+	}
+	threshold, stage := t.gasPolicy.GetThreshold(deadline, time.Now())
+
+	// Check the policy; the conditions are checked from the most to the least urgent
+	age := time.Since(oldestEndTime)
+	if t.maxAge > 0 && age >= t.maxAge {
+		t.log.Printlnf("The oldest unclaimed interval ended %s ago, which is past the limit of %s.", age.Round(time.Hour), t.maxAge)
+	} else if t.maxAge > 0 && threshold > t.gasPolicy.GetBaseThreshold() {
+		t.log.Printlnf("The oldest unclaimed interval ended %s ago, which is approaching the limit of %s.", age.Round(time.Hour), t.maxAge)
+	} else if t.valueThreshold.Sign() > 0 && totalValue.Cmp(t.valueThreshold) >= 0 {
+		t.log.Printlnf("Unclaimed rewards are above the threshold of %.6f ETH.", eth.WeiToEth(t.valueThreshold))
+	} else if t.claimGasThreshold > 0 && networkMaxFee.Cmp(eth.GweiToWei(t.claimGasThreshold)) < 0 {
+		t.log.Printlnf("Network gas price (%.2f Gwei) is below the auto-claim threshold of %.2f Gwei.", eth.WeiToGwei(networkMaxFee), t.claimGasThreshold)
+		threshold = t.claimGasThreshold
+		stage = "auto-claim gas threshold"
+	} else {
+		t.log.Println("None of the auto-claim conditions have been met yet.")
+		return nil
+	}
+
+	// Get the amount to restake
+	restakeAmount := big.NewInt(0)
+	if t.restakePercent > 0 {
+		restakeAmount = eth.EthToWei(eth.WeiToEth(totalRpl) * t.restakePercent / 100)
+		if restakeAmount.Cmp(totalRpl) > 0 {
+			restakeAmount.Set(totalRpl)
+		}
+	}
+
+	// Claim
+	err = t.claim(nodeAccount.Address, intervals, restakeAmount, maxFee, deadline, threshold, stage)
+	if err != nil {
+		// Don't repeat the alert every check while the claim keeps failing
+		if time.Since(t.lastFailureAlert) >= claimRewardsFailureAlertInterval {
+			alerting.AlertRewardsClaimed(t.cfg, intervals, eth.WeiToEth(totalEth), eth.WeiToEth(totalRpl), eth.WeiToEth(restakeAmount), false)
+			t.lastFailureAlert = time.Now()
+		}
+		return fmt.Errorf("Could not claim rewards for intervals %v: %w", intervals, err)
+	}
+	t.lastFailureAlert = time.Time{}
+	return nil
+
+}
+
+// Get the unclaimed intervals with rewards for the node, the end time of the oldest one, and the total RPL and ETH rewards in them
+func (t *claimRewards) getClaimableIntervals(nodeAddress common.Address) ([]uint64, time.Time, *big.Int, *big.Int, error) {
+	unclaimed, _, err := rprewards.GetClaimStatus(t.rp, nodeAddress)
+	if err != nil {
+		return nil, time.Time{}, nil, nil, fmt.Errorf("error getting rewards claim status: %w", err)
+	}
+
+	intervals := []uint64{}
+	oldestEndTime := time.Now()
+	totalRpl := big.NewInt(0)
+	totalEth := big.NewInt(0)
+	for _, interval := range unclaimed {
+		intervalInfo, err := rprewards.GetIntervalInfo(t.rp, t.cfg, nodeAddress, interval, nil)
+		if err != nil {
+			return nil, time.Time{}, nil, nil, err
+		}
+		if !intervalInfo.TreeFileExists {
+			t.log.Printlnf("Rewards tree for interval %d hasn't been downloaded yet, skipping it.", interval)
+			continue
+		}
+		if !intervalInfo.MerkleRootValid {
+			t.log.Printlnf("WARNING: Rewards tree for interval %d doesn't match the canonical Merkle root, skipping it.", interval)
+			continue
+		}
+		if !intervalInfo.NodeExists {
+			continue
+		}
+
+		intervals = append(intervals, interval)
+		if intervalInfo.EndTime.Before(oldestEndTime) {
+			oldestEndTime = intervalInfo.EndTime
+		}
+		totalRpl.Add(totalRpl, &intervalInfo.CollateralRplAmount.Int)
+		totalRpl.Add(totalRpl, &intervalInfo.ODaoRplAmount.Int)
+		totalEth.Add(totalEth, &intervalInfo.SmoothingPoolEthAmount.Int)
+	}
+
+	return intervals, oldestEndTime, totalRpl, totalEth, nil
+}
+
+// Claim the rewards for the provided intervals, restaking some of the RPL if requested
+func (t *claimRewards) claim(nodeAddress common.Address, intervals []uint64, restakeAmount *big.Int, maxFee *big.Int, deadline rpgas.AutoTxDeadline, threshold float64, stage string) error {
+
+	// Get the claim arguments
+	indices, amountRPL, amountETH, merkleProofs, err := rprewards.GetClaimDataForIntervals(t.rp, t.cfg, nodeAddress, intervals)
+	if err != nil {
+		return err
+	}
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+
+	// Get the gas limit
+	restake := restakeAmount.Sign() > 0
+	var gasInfo rocketpool.GasInfo
+	if restake {
+		gasInfo, err = rewards.EstimateClaimAndStakeGas(t.rp, nodeAddress, indices, amountRPL, amountETH, merkleProofs, restakeAmount, opts)
+	} else {
+		gasInfo, err = rewards.EstimateClaimGas(t.rp, nodeAddress, indices, amountRPL, amountETH, merkleProofs, opts)
+	}
+	if err != nil {
+		return fmt.Errorf("Could not estimate the gas required to claim rewards: %w", err)
+	}
+	var gas *big.Int
+	if t.gasLimit != 0 {
+		gas = new(big.Int).SetUint64(t.gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Print the gas info
+	if !api.PrintAndCheckGasInfo(gasInfo, true, threshold, &t.log, maxFee, t.gasLimit) {
+		t.gasPolicy.RecordDelay(deadline, maxFee, threshold, stage, &t.log)
+		return nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gas.Uint64()
+
+	// Claim rewards
+	var hash common.Hash
+	if restake {
+		t.log.Printlnf("Claiming rewards for intervals %v and restaking %.6f RPL...", intervals, eth.WeiToEth(restakeAmount))
+		hash, err = rewards.ClaimAndStake(t.rp, nodeAddress, indices, amountRPL, amountETH, merkleProofs, restakeAmount, opts)
+	} else {
+		t.log.Printlnf("Claiming rewards for intervals %v...", intervals)
+		hash, err = rewards.Claim(t.rp, nodeAddress, indices, amountRPL, amountETH, merkleProofs, opts)
+	}
+	if err != nil {
+		return err
+	}
+	t.gasPolicy.ClearDelay(deadline, &t.log)
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	if err != nil {
+		return err
+	}
+
+	// Log
+	totalRpl := big.NewInt(0)
+	for _, amount := range amountRPL {
+		totalRpl.Add(totalRpl, amount)
+	}
+	totalEth := big.NewInt(0)
+	for _, amount := range amountETH {
+		totalEth.Add(totalEth, amount)
+	}
+	t.log.Printlnf("Successfully claimed %.6f ETH and %.6f RPL.", eth.WeiToEth(totalEth), eth.WeiToEth(totalRpl))
+	alerting.AlertRewardsClaimed(t.cfg, intervals, eth.WeiToEth(totalEth), eth.WeiToEth(totalRpl), eth.WeiToEth(restakeAmount), true)
+
+	// Return
+	return nil
+
+}
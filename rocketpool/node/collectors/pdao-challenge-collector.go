@@ -0,0 +1,187 @@
+package collectors
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/rocketpool/api/pdao"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+)
+
+// Time to wait between claimable bond checks, since each one scans for challenge events
+const claimableBondsInterval time.Duration = time.Hour
+
+// Represents the collector for the pDAO challenge metrics
+type PdaoChallengeCollector struct {
+	// The number of proposals the node has monitored during their challenge phase
+	monitoredProposals *prometheus.Desc
+
+	// The number of RootSubmitted events the node has seen
+	rootSubmissions *prometheus.Desc
+
+	// The number of proposals whose root didn't match the local voting tree
+	mismatchedProposals *prometheus.Desc
+
+	// The number of challenges the node has issued
+	challengesIssued *prometheus.Desc
+
+	// The number of challenges against the node's proposals, by whether they were answered
+	challengesReceived *prometheus.Desc
+
+	// The number of proposals the node has defeated
+	proposalsDefeated *prometheus.Desc
+
+	// The RPL bonds at risk, by bond type
+	bondsAtRisk *prometheus.Desc
+
+	// The RPL bonds the node can claim, by claim type
+	claimableBonds *prometheus.Desc
+
+	// The Rocket Pool contract manager
+	rp *rocketpool.RocketPool
+
+	// The Rocket Pool config
+	cfg *config.RocketPoolConfig
+
+	// The beacon client
+	bc beacon.Client
+
+	// The node wallet address
+	nodeAddress common.Address
+
+	// The record of challenges kept by the node daemon
+	record *proposals.ChallengeRecord
+
+	// Store values from the latest claimable bond check
+	cachedUnlockableBonds float64
+	cachedRewardableBonds float64
+	lastBondCheckTime     time.Time
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new PdaoChallengeCollector instance
+func NewPdaoChallengeCollector(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, nodeAddress common.Address, record *proposals.ChallengeRecord) *PdaoChallengeCollector {
+	subsystem := "pdao_challenges"
+	return &PdaoChallengeCollector{
+		monitoredProposals: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "monitored_proposals"),
+			"The number of Protocol DAO proposals the node has monitored during their challenge phase",
+			nil, nil,
+		),
+		rootSubmissions: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "root_submissions"),
+			"The number of RootSubmitted events the node has seen",
+			nil, nil,
+		),
+		mismatchedProposals: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "mismatched_proposals"),
+			"The number of proposals whose root didn't match the node's voting tree",
+			nil, nil,
+		),
+		challengesIssued: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "issued"),
+			"The number of challenges the node has issued",
+			nil, nil,
+		),
+		challengesReceived: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "received"),
+			"The number of challenges against the node's proposals",
+			[]string{"status"}, nil,
+		),
+		proposalsDefeated: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "defeated_proposals"),
+			"The number of proposals the node has defeated",
+			nil, nil,
+		),
+		bondsAtRisk: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "bonds_at_risk"),
+			"The amount of RPL the node has bonded on undecided challenges",
+			[]string{"bond"}, nil,
+		),
+		claimableBonds: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "claimable_bonds"),
+			"The amount of RPL the node can claim from proposal and challenge bonds",
+			[]string{"type"}, nil,
+		),
+		rp:          rp,
+		cfg:         cfg,
+		bc:          bc,
+		nodeAddress: nodeAddress,
+		record:      record,
+		logPrefix:   "pDAO Challenge Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *PdaoChallengeCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.monitoredProposals
+	channel <- collector.rootSubmissions
+	channel <- collector.mismatchedProposals
+	channel <- collector.challengesIssued
+	channel <- collector.challengesReceived
+	channel <- collector.proposalsDefeated
+	channel <- collector.bondsAtRisk
+	channel <- collector.claimableBonds
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *PdaoChallengeCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Refresh the claimable bonds
+	if time.Since(collector.lastBondCheckTime) >= claimableBondsInterval {
+		err := collector.updateClaimableBonds()
+		if err != nil {
+			collector.logError(err)
+		} else {
+			collector.lastBondCheckTime = time.Now()
+		}
+	}
+
+	summary := collector.record.GetSummary()
+	channel <- prometheus.MustNewConstMetric(
+		collector.monitoredProposals, prometheus.GaugeValue, float64(summary.MonitoredProposals))
+	channel <- prometheus.MustNewConstMetric(
+		collector.rootSubmissions, prometheus.GaugeValue, float64(summary.RootSubmissions))
+	channel <- prometheus.MustNewConstMetric(
+		collector.mismatchedProposals, prometheus.GaugeValue, float64(summary.MismatchedProposals))
+	channel <- prometheus.MustNewConstMetric(
+		collector.challengesIssued, prometheus.GaugeValue, float64(summary.ChallengesIssued))
+	channel <- prometheus.MustNewConstMetric(
+		collector.challengesReceived, prometheus.GaugeValue, float64(summary.ChallengesAnswered), "answered")
+	channel <- prometheus.MustNewConstMetric(
+		collector.challengesReceived, prometheus.GaugeValue, float64(summary.ChallengesUnanswered), "unanswered")
+	channel <- prometheus.MustNewConstMetric(
+		collector.proposalsDefeated, prometheus.GaugeValue, float64(summary.ProposalsDefeated))
+	channel <- prometheus.MustNewConstMetric(
+		collector.bondsAtRisk, prometheus.GaugeValue, eth.WeiToEth(summary.ProposalBondsAtRisk), "proposal")
+	channel <- prometheus.MustNewConstMetric(
+		collector.bondsAtRisk, prometheus.GaugeValue, eth.WeiToEth(summary.ChallengeBondsAtRisk), "challenge")
+	channel <- prometheus.MustNewConstMetric(
+		collector.claimableBonds, prometheus.GaugeValue, collector.cachedUnlockableBonds, "unlock")
+	channel <- prometheus.MustNewConstMetric(
+		collector.claimableBonds, prometheus.GaugeValue, collector.cachedRewardableBonds, "reward")
+}
+
+// Get the bonds the node can currently claim
+func (collector *PdaoChallengeCollector) updateClaimableBonds() error {
+	claimableBonds, err := pdao.GetClaimableBonds(collector.rp, collector.cfg, collector.bc, collector.nodeAddress)
+	if err != nil {
+		return fmt.Errorf("Error getting claimable bonds: %w", err)
+	}
+
+	unlockable := big.NewInt(0)
+	rewardable := big.NewInt(0)
+	for _, bond := range claimableBonds {
+		unlockable.Add(unlockable, bond.UnlockAmount)
+		rewardable.Add(rewardable, bond.RewardAmount)
+	}
+	collector.cachedUnlockableBonds = eth.WeiToEth(unlockable)
+	collector.cachedRewardableBonds = eth.WeiToEth(rewardable)
+	return nil
+}
+
+// Log error messages
+func (collector *PdaoChallengeCollector) logError(err error) {
+	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+}
@@ -0,0 +1,205 @@
+package collectors
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+)
+
+const (
+	// Time to wait between delegate checks, since each one builds a voting info snapshot of the whole network
+	delegateInsightsInterval time.Duration = 6 * time.Hour
+
+	// Time to wait before retrying a failed delegate check; it doubles with each failure, up to delegateInsightsInterval
+	delegateInsightsRetryInterval time.Duration = 15 * time.Minute
+
+	// The number of top delegates to report on
+	delegateInsightsLimit int = 10
+)
+
+// Represents the collector for the pDAO delegate metrics
+type PdaoDelegateCollector struct {
+	// The number of addresses other nodes delegate their voting power to
+	delegateCount *prometheus.Desc
+
+	// The number of nodes that vote for themselves
+	selfDelegatedNodes *prometheus.Desc
+
+	// The total voting power of the network
+	totalVotingPower *prometheus.Desc
+
+	// The voting power delegated to each of the top delegates
+	votingPower *prometheus.Desc
+
+	// The number of nodes delegating to each of the top delegates
+	delegators *prometheus.Desc
+
+	// The fraction of proposals put to a vote that each of the top delegates voted on
+	participation *prometheus.Desc
+
+	// The rank of the node's delegate by voting power
+	nodeDelegateRank *prometheus.Desc
+
+	// The Rocket Pool contract manager
+	rp *rocketpool.RocketPool
+
+	// The Rocket Pool config
+	cfg *config.RocketPoolConfig
+
+	// The beacon client
+	bc beacon.Client
+
+	// The node wallet address
+	nodeAddress common.Address
+
+	// The thread-safe locker for the network state
+	stateLocker *StateLocker
+
+	// Store values from the latest delegate check
+	cachedInsights     *proposals.DelegateInsights
+	cachedNodeDelegate common.Address
+	nextCheckTime      time.Time
+	failedChecks       int
+
+	// Prefix for logging
+	logPrefix string
+}
+
+// Create a new PdaoDelegateCollector instance
+func NewPdaoDelegateCollector(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, bc beacon.Client, nodeAddress common.Address, stateLocker *StateLocker) *PdaoDelegateCollector {
+	subsystem := "pdao_delegates"
+	return &PdaoDelegateCollector{
+		delegateCount: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "count"),
+			"The number of addresses other nodes delegate their voting power to",
+			nil, nil,
+		),
+		selfDelegatedNodes: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "self_delegated_nodes"),
+			"The number of nodes that vote with their own voting power",
+			nil, nil,
+		),
+		totalVotingPower: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "total_voting_power"),
+			"The total voting power of the network",
+			nil, nil,
+		),
+		votingPower: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "voting_power"),
+			"The voting power delegated to each of the top delegates",
+			[]string{"delegate", "rank"}, nil,
+		),
+		delegators: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "delegators"),
+			"The number of nodes delegating to each of the top delegates",
+			[]string{"delegate", "rank"}, nil,
+		),
+		participation: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "participation"),
+			"The fraction of proposals put to a vote that each of the top delegates voted on",
+			[]string{"delegate", "rank"}, nil,
+		),
+		nodeDelegateRank: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "node_delegate_rank"),
+			"The rank of the node's delegate by voting power (0 if it isn't a delegate for any other node)",
+			[]string{"delegate"}, nil,
+		),
+		rp:          rp,
+		cfg:         cfg,
+		bc:          bc,
+		nodeAddress: nodeAddress,
+		stateLocker: stateLocker,
+		logPrefix:   "pDAO Delegate Collector",
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *PdaoDelegateCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.delegateCount
+	channel <- collector.selfDelegatedNodes
+	channel <- collector.totalVotingPower
+	channel <- collector.votingPower
+	channel <- collector.delegators
+	channel <- collector.participation
+	channel <- collector.nodeDelegateRank
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *PdaoDelegateCollector) Collect(channel chan<- prometheus.Metric) {
+
+	// Refresh the delegate insights, backing off after failures so they aren't retried on every scrape
+	if !time.Now().Before(collector.nextCheckTime) {
+		err := collector.updateInsights()
+		if err != nil {
+			collector.logError(err)
+			retryInterval := delegateInsightsRetryInterval << collector.failedChecks
+			if retryInterval >= delegateInsightsInterval {
+				retryInterval = delegateInsightsInterval
+			} else {
+				collector.failedChecks++
+			}
+			collector.nextCheckTime = time.Now().Add(retryInterval)
+		} else {
+			collector.failedChecks = 0
+			collector.nextCheckTime = time.Now().Add(delegateInsightsInterval)
+		}
+	}
+
+	insights := collector.cachedInsights
+	if insights == nil {
+		return
+	}
+
+	channel <- prometheus.MustNewConstMetric(
+		collector.delegateCount, prometheus.GaugeValue, float64(insights.DelegateCount))
+	channel <- prometheus.MustNewConstMetric(
+		collector.selfDelegatedNodes, prometheus.GaugeValue, float64(insights.SelfDelegated))
+	channel <- prometheus.MustNewConstMetric(
+		collector.totalVotingPower, prometheus.GaugeValue, eth.WeiToEth(insights.TotalVotingPower))
+	for _, delegate := range insights.Delegates {
+		address := delegate.Address.Hex()
+		rank := strconv.Itoa(delegate.Rank)
+		channel <- prometheus.MustNewConstMetric(
+			collector.votingPower, prometheus.GaugeValue, eth.WeiToEth(delegate.VotingPower), address, rank)
+		channel <- prometheus.MustNewConstMetric(
+			collector.delegators, prometheus.GaugeValue, float64(delegate.Delegators), address, rank)
+		channel <- prometheus.MustNewConstMetric(
+			collector.participation, prometheus.GaugeValue, delegate.ParticipationRate, address, rank)
+		if delegate.Address == collector.cachedNodeDelegate {
+			channel <- prometheus.MustNewConstMetric(
+				collector.nodeDelegateRank, prometheus.GaugeValue, float64(delegate.Rank), address)
+		}
+	}
+}
+
+// Build the delegate insights for the top delegates and the node's own delegate
+func (collector *PdaoDelegateCollector) updateInsights() error {
+	state := collector.stateLocker.GetState()
+	if state == nil {
+		return fmt.Errorf("Network state isn't available yet")
+	}
+
+	nodeDelegate, err := network.GetCurrentVotingDelegate(collector.rp, collector.nodeAddress, nil)
+	if err != nil {
+		return fmt.Errorf("Error getting node voting delegate: %w", err)
+	}
+	propMgr, err := proposals.NewProposalManager(nil, collector.cfg, collector.rp, collector.bc)
+	if err != nil {
+		return fmt.Errorf("Error creating proposal manager: %w", err)
+	}
+	insights, err := propMgr.GetDelegateInsights(state.ProtocolDaoProposalDetails, delegateInsightsLimit, nodeDelegate)
+	if err != nil {
+		return fmt.Errorf("Error getting delegate insights: %w", err)
+	}
+
+	collector.cachedInsights = insights
+	collector.cachedNodeDelegate = nodeDelegate
+	return nil
+}
+
+// Log error messages
+func (collector *PdaoDelegateCollector) logError(err error) {
+	fmt.Printf("[%s] %s\n", collector.logPrefix, err.Error())
+}
@@ -0,0 +1,109 @@
+package collectors
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rocket-pool/smartnode/shared/services/performance"
+)
+
+// Represents the collector for the performance metrics of the node's validators
+type ValidatorPerformanceCollector struct {
+	// The fraction of attestation duties that were included on-chain
+	attestationInclusionRate *prometheus.Desc
+
+	// The fraction of included attestations with a correct head and target vote
+	attestationCorrectness *prometheus.Desc
+
+	// The average attestation inclusion delay in slots
+	averageInclusionDelay *prometheus.Desc
+
+	// The fraction of sync committee duties that were fulfilled
+	syncParticipationRate *prometheus.Desc
+
+	// The number of blocks proposed
+	proposedBlocks *prometheus.Desc
+
+	// The number of block proposals that were missed
+	missedProposals *prometheus.Desc
+
+	// The number of epochs whose missed proposals couldn't be counted
+	epochsWithoutProposerDuties *prometheus.Desc
+
+	// The record of validator performance kept by the node daemon
+	record *performance.PerformanceRecord
+}
+
+// Create a new ValidatorPerformanceCollector instance
+func NewValidatorPerformanceCollector(record *performance.PerformanceRecord) *ValidatorPerformanceCollector {
+	subsystem := "validator_performance"
+	labels := []string{"validator", "minipool", "window"}
+	return &ValidatorPerformanceCollector{
+		attestationInclusionRate: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "attestation_inclusion_rate"),
+			"The fraction of the validator's attestation duties that were included on-chain",
+			labels, nil,
+		),
+		attestationCorrectness: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "attestation_correctness"),
+			"The fraction of the validator's included attestations that voted for the correct head and target",
+			labels, nil,
+		),
+		averageInclusionDelay: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "average_inclusion_delay"),
+			"The average number of slots it took for the validator's attestations to be included",
+			labels, nil,
+		),
+		syncParticipationRate: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "sync_participation_rate"),
+			"The fraction of the validator's sync committee duties that were fulfilled",
+			labels, nil,
+		),
+		proposedBlocks: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "proposed_blocks"),
+			"The number of blocks the validator proposed",
+			labels, nil,
+		),
+		missedProposals: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "missed_proposals"),
+			"The number of block proposals the validator missed",
+			labels, nil,
+		),
+		epochsWithoutProposerDuties: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "epochs_without_proposer_duties"),
+			"The number of epochs whose proposer duties weren't available, so missed proposals in them aren't counted",
+			labels, nil,
+		),
+		record: record,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *ValidatorPerformanceCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.attestationInclusionRate
+	channel <- collector.attestationCorrectness
+	channel <- collector.averageInclusionDelay
+	channel <- collector.syncParticipationRate
+	channel <- collector.proposedBlocks
+	channel <- collector.missedProposals
+	channel <- collector.epochsWithoutProposerDuties
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *ValidatorPerformanceCollector) Collect(channel chan<- prometheus.Metric) {
+	now := time.Now()
+	minipools := collector.record.GetMinipoolAddresses()
+	for _, window := range performance.Windows {
+		summaries := collector.record.GetSummaries(performance.WindowDurations[window], now)
+		for index, summary := range summaries {
+			labels := []string{index, minipools[index].Hex(), window}
+			channel <- prometheus.MustNewConstMetric(
+				collector.attestationInclusionRate, prometheus.GaugeValue, summary.GetInclusionRate(), labels...)
+			channel <- prometheus.MustNewConstMetric(
+				collector.attestationCorrectness, prometheus.GaugeValue, summary.GetCorrectnessRate(), labels...)
+			channel <- prometheus.MustNewConstMetric(
+				collector.averageInclusionDelay, prometheus.GaugeValue, summary.GetAverageInclusionDelay(), labels...)
+			channel <- prometheus.MustNewConstMetric(
+				collector.syncParticipationRate, prometheus.GaugeValue, summary.GetSyncParticipationRate(), labels...)
+			channel <- prometheus.MustNewConstMetric(
+				collector.proposedBlocks, prometheus.GaugeValue, float64(summary.ProposedBlocks), labels...)
+			channel <- prometheus.MustNewConstMetric(
+				collector.missedProposals, prometheus.GaugeValue, float64(summary.GetMissedProposals()), labels...)
+			channel <- prometheus.MustNewConstMetric(
+				collector.epochsWithoutProposerDuties, prometheus.GaugeValue, float64(summary.EpochsWithoutProposerDuties), labels...)
+		}
+	}
+}
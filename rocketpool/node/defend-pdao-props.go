@@ -42,12 +42,14 @@ type defendPdaoProps struct {
 	nodeAddress      common.Address
 	propMgr          *proposals.ProposalManager
 	lastScannedBlock *big.Int
+	record           *proposals.ChallengeRecord
+	recordPath       string
 
 	// Smartnode parameters
 	intervalSize *big.Int
 }
 
-func newDefendPdaoProps(c *cli.Context, logger log.ColorLogger) (*defendPdaoProps, error) {
+func newDefendPdaoProps(c *cli.Context, logger log.ColorLogger, record *proposals.ChallengeRecord) (*defendPdaoProps, error) {
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
@@ -114,6 +116,8 @@ func newDefendPdaoProps(c *cli.Context, logger log.ColorLogger) (*defendPdaoProp
 		nodeAddress:      account.Address,
 		propMgr:          propMgr,
 		lastScannedBlock: nil,
+		record:           record,
+		recordPath:       cfg.Smartnode.GetChallengeRecordPath(),
 
 		intervalSize: intervalSize,
 	}, nil
@@ -129,13 +133,19 @@ func (t *defendPdaoProps) run(state *state.NetworkState) error {
 		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
 	}
 
+	// Refresh the challenges in the record
+	err := t.updateChallengeRecord(state, opts)
+	if err != nil {
+		return fmt.Errorf("error updating challenge record: %w", err)
+	}
+
 	// Get any proposals that need to be defended
 	defendableProps, err := t.getDefendableProposals(state, opts)
 	if err != nil {
 		return fmt.Errorf("error checking for defendable proposals: %w", err)
 	}
 	if len(defendableProps) == 0 {
-		return nil
+		return t.record.Save(t.recordPath)
 	}
 
 	// Defend props
@@ -147,6 +157,20 @@ func (t *defendPdaoProps) run(state *state.NetworkState) error {
 	}
 	t.lastScannedBlock = big.NewInt(int64(state.ElBlockNumber))
 
+	return t.record.Save(t.recordPath)
+}
+
+// Refresh the proposal and challenge states in the challenge record
+func (t *defendPdaoProps) updateChallengeRecord(state *state.NetworkState, opts *bind.CallOpts) error {
+	t.record.UpdateProposalStates(state.ProtocolDaoProposalDetails)
+	for _, challenge := range t.record.GetOpenChallenges() {
+		challengeState, err := protocol.GetChallengeState(t.rp, challenge.ProposalID, challenge.Index, opts)
+		if err != nil {
+			return fmt.Errorf("error checking state of challenge on proposal %d, index %d: %w", challenge.ProposalID, challenge.Index, err)
+		}
+		t.record.SetChallengeState(challenge.ProposalID, challenge.Index, challengeState)
+	}
+	t.record.Prune(time.Now())
 	return nil
 }
 
@@ -158,6 +182,7 @@ func (t *defendPdaoProps) getDefendableProposals(state *state.NetworkState, opts
 		if prop.ProposerAddress == t.nodeAddress &&
 			prop.State == types.ProtocolDaoProposalState_Pending {
 			eligibleProps = append(eligibleProps, prop)
+			t.record.UpdateProposal(&prop, t.nodeAddress)
 		}
 	}
 	if len(eligibleProps) == 0 {
@@ -216,6 +241,7 @@ func (t *defendPdaoProps) getDefendableProposals(state *state.NetworkState, opts
 		if err != nil {
 			return nil, fmt.Errorf("error checking state of challenge on proposal %d, index %d: %w", propID, index, err)
 		}
+		t.record.AddChallenge(propMap[propID], t.nodeAddress, index, event.Challenger, event.Timestamp, state, common.Hash{})
 		if state == types.ChallengeState_Challenged {
 			t.log.Printlnf("Proposal %d, index %d has been challenged by %s.", propID, index, event.Challenger.Hex())
 			defendableProposals = append(defendableProposals, defendableProposal{
@@ -285,6 +311,7 @@ func (t *defendPdaoProps) defendProposal(prop defendableProposal) error {
 
 	// Log
 	t.log.Println("Successfully responded to challenge.")
+	t.record.SetChallengeResponse(propID, challengedIndex, t.nodeAddress, hash)
 
 	// Return
 	return nil
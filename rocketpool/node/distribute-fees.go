@@ -0,0 +1,220 @@
+package node
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Distribute fees task
+type distributeFees struct {
+	c                   *cli.Context
+	log                 log.ColorLogger
+	cfg                 *config.RocketPoolConfig
+	w                   *wallet.Wallet
+	rp                  *rocketpool.RocketPool
+	gasPolicy           *rpgas.AutoTxPolicy
+	distributeThreshold *big.Int
+	disabled            bool
+	maxFee              *big.Int
+	maxPriorityFee      *big.Int
+	gasLimit            uint64
+}
+
+// Create distribute fees task
+func newDistributeFees(c *cli.Context, logger log.ColorLogger) (*distributeFees, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if auto-distributing is disabled
+	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
+	distributeThreshold := cfg.Smartnode.FeeDistributorThreshold.Value.(float64)
+	disabled := false
+	if distributeThreshold == 0 {
+		disabled = true
+	} else if gasThreshold == 0 {
+		logger.Println("Automatic tx gas threshold is 0, disabling fee distributor auto-distribute.")
+		disabled = true
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested max fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Get the gas policy shared by the automatic transactions
+	gasPolicy, err := services.GetAutoTxPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &distributeFees{
+		c:                   c,
+		log:                 logger,
+		cfg:                 cfg,
+		w:                   w,
+		rp:                  rp,
+		gasPolicy:           gasPolicy,
+		distributeThreshold: eth.EthToWei(distributeThreshold),
+		disabled:            disabled,
+		maxFee:              maxFee,
+		maxPriorityFee:      priorityFee,
+		gasLimit:            0,
+	}, nil
+
+}
+
+// Distribute the node's fee distributor
+func (t *distributeFees) run(state *state.NetworkState) error {
+
+	// Check if auto-distribute is disabled
+	if t.disabled {
+		return nil
+	}
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	nodeDetails, exists := state.NodeDetailsByAddress[nodeAccount.Address]
+	if !exists {
+		return nil
+	}
+
+	// Smoothing pool members don't collect fees in their distributor, and uninitialized distributors can't be distributed
+	if nodeDetails.SmoothingPoolRegistrationState || !nodeDetails.FeeDistributorInitialised {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking the fee distributor balance...")
+
+	// Check the balance
+	if nodeDetails.DistributorBalance.Cmp(t.distributeThreshold) < 0 {
+		return nil
+	}
+	t.log.Printlnf("Fee distributor balance of %.6f ETH is above the threshold of %.6f ETH.", eth.WeiToEth(nodeDetails.DistributorBalance), eth.WeiToEth(t.distributeThreshold))
+
+	// Distribute
+	if err := t.distribute(nodeDetails.FeeDistributorAddress); err != nil {
+		return fmt.Errorf("Could not distribute fee distributor %s: %w", nodeDetails.FeeDistributorAddress.Hex(), err)
+	}
+
+	// Return
+	return nil
+
+}
+
+// Distribute the fee distributor's balance
+func (t *distributeFees) distribute(distributorAddress common.Address) error {
+
+	distributor, err := node.NewDistributor(t.rp, distributorAddress, nil)
+	if err != nil {
+		return err
+	}
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return err
+	}
+
+	// Get the gas limit
+	gasInfo, err := distributor.EstimateDistributeGas(opts)
+	if err != nil {
+		return fmt.Errorf("Could not estimate the gas required to distribute node fees: %w", err)
+	}
+	var gas *big.Int
+	if t.gasLimit != 0 {
+		gas = new(big.Int).SetUint64(t.gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		if err != nil {
+			return err
+		}
+	}
+
+	// Print the gas info
+	deadline := rpgas.AutoTxDeadline{
+		Action:  rpgas.AutoTxAction_DistributeFees,
+		Subject: distributorAddress.Hex(),
+	}
+	threshold, stage := t.gasPolicy.GetThreshold(deadline, time.Now())
+	if !api.PrintAndCheckGasInfo(gasInfo, true, threshold, &t.log, maxFee, t.gasLimit) {
+		t.gasPolicy.RecordDelay(deadline, maxFee, threshold, stage, &t.log)
+		return nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gas.Uint64()
+
+	// Distribute
+	t.log.Println("Distributing fee distributor balance...")
+	hash, err := distributor.Distribute(opts)
+	if err != nil {
+		return err
+	}
+	t.gasPolicy.ClearDelay(deadline, &t.log)
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	if err != nil {
+		return err
+	}
+
+	// Log
+	t.log.Println("Successfully distributed the fee distributor's balance.")
+
+	// Return
+	return nil
+
+}
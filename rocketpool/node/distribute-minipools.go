@@ -3,6 +3,7 @@ package node
 import (
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/docker/docker/client"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
@@ -34,7 +35,7 @@ type distributeMinipools struct {
 	rp                  *rocketpool.RocketPool
 	bc                  beacon.Client
 	d                   *client.Client
-	gasThreshold        float64
+	gasPolicy           *rpgas.AutoTxPolicy
 	distributeThreshold *big.Int
 	disabled            bool
 	eight               *big.Int
@@ -105,6 +106,12 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 		priorityFee = eth.GweiToWei(priorityFeeGwei)
 	}
 
+	// Get the gas policy shared by the automatic transactions
+	gasPolicy, err := services.GetAutoTxPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+
 	// Return task
 	return &distributeMinipools{
 		c:                   c,
@@ -114,7 +121,7 @@ func newDistributeMinipools(c *cli.Context, logger log.ColorLogger) (*distribute
 		rp:                  rp,
 		bc:                  bc,
 		d:                   d,
-		gasThreshold:        gasThreshold,
+		gasPolicy:           gasPolicy,
 		distributeThreshold: eth.EthToWei(distributeThreshold),
 		disabled:            disabled,
 		eight:               eth.EthToWei(8),
@@ -248,8 +255,14 @@ func (t *distributeMinipools) distributeMinipool(mpd *rpstate.NativeMinipoolDeta
 		}
 	}
 
-	// Print the gas info
-	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+	// Print the gas info; distributions don't have a deadline, so they always wait for the base threshold
+	deadline := rpgas.AutoTxDeadline{
+		Action:  rpgas.AutoTxAction_Distribute,
+		Subject: mpd.MinipoolAddress.Hex(),
+	}
+	threshold, stage := t.gasPolicy.GetThreshold(deadline, time.Now())
+	if !api.PrintAndCheckGasInfo(gasInfo, true, threshold, &t.log, maxFee, t.gasLimit) {
+		t.gasPolicy.RecordDelay(deadline, maxFee, threshold, stage, &t.log)
 		return false, nil
 	}
 
@@ -262,6 +275,7 @@ func (t *distributeMinipools) distributeMinipool(mpd *rpstate.NativeMinipoolDeta
 	if err != nil {
 		return false, err
 	}
+	t.gasPolicy.ClearDelay(deadline, &t.log)
 
 	// Print TX info and wait for it to be included in a block
 	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
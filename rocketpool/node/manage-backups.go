@@ -0,0 +1,93 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Time to wait between checks for a due backup
+const backupCheckInterval time.Duration = time.Hour
+
+// Manage scheduled backups task
+type manageBackups struct {
+	c            *cli.Context
+	log          log.ColorLogger
+	settingsPath string
+}
+
+// Create manage scheduled backups task
+func newManageBackups(c *cli.Context, logger log.ColorLogger) *manageBackups {
+	return &manageBackups{
+		c:            c,
+		log:          logger,
+		settingsPath: os.ExpandEnv(c.GlobalString("settings")),
+	}
+}
+
+// Create a backup if scheduled backups are enabled and the newest one is older than the backup interval
+func (t *manageBackups) run() error {
+
+	// Reload the settings, since backups can be enabled or reconfigured without restarting the node daemon
+	cfg, err := rp.LoadConfigFromFile(t.settingsPath)
+	if err != nil {
+		return fmt.Errorf("error loading settings: %w", err)
+	}
+	if cfg == nil || !cfg.Backup.Enabled.Value.(bool) {
+		return nil
+	}
+
+	// Settings files edited by hand aren't validated, and a 0 interval would back up on every check
+	interval := time.Duration(cfg.Backup.Interval.Value.(uint64)) * time.Hour
+	if interval < time.Hour {
+		return fmt.Errorf("the backup interval must be at least 1 hour; skipping scheduled backup")
+	}
+
+	// Check if a backup is due
+	store, err := backup.NewStore(cfg, true)
+	if err != nil {
+		return fmt.Errorf("error getting backup destination: %w", err)
+	}
+	backups, err := store.List()
+	if err != nil {
+		return err
+	}
+	if len(backups) > 0 && time.Since(backups[len(backups)-1].CreatedAt) < interval {
+		return nil
+	}
+
+	// Get the node account; a backup can't be restored without the wallet, so don't make one until there is a wallet
+	w, err := services.GetWallet(t.c)
+	if err != nil {
+		return err
+	}
+	if !w.IsInitialized() {
+		t.log.Println("The node wallet is not initialized yet, skipping scheduled backup.")
+		return nil
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Create the backup
+	t.log.Printlnf("Creating scheduled backup in %s...", store.String())
+	// The validator client can't be stopped to export its slashing protection data here, so it isn't included
+	info, deleted, err := backup.Create(cfg, store, t.settingsPath, "", nodeAccount.Address)
+	if err != nil {
+		return fmt.Errorf("error creating scheduled backup: %w", err)
+	}
+	t.log.Printlnf("Saved backup %s (%d bytes).", info.Name, info.Size)
+	for _, name := range deleted {
+		t.log.Printlnf("Deleted old backup %s.", name)
+	}
+	return nil
+
+}
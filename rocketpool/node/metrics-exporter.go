@@ -10,11 +10,13 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/performance"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 	"github.com/urfave/cli"
 )
 
-func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker) error {
+func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *collectors.StateLocker, performanceRecord *performance.PerformanceRecord, challengeRecord *proposals.ChallengeRecord) error {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -66,6 +68,7 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	trustedNodeCollector := collectors.NewTrustedNodeCollector(rp, bc, nodeAccount.Address, cfg, stateLocker)
 	beaconCollector := collectors.NewBeaconCollector(rp, bc, ec, nodeAccount.Address, stateLocker)
 	smoothingPoolCollector := collectors.NewSmoothingPoolCollector(rp, ec, stateLocker)
+	pdaoChallengeCollector := collectors.NewPdaoChallengeCollector(rp, cfg, bc, nodeAccount.Address, challengeRecord)
 
 	// Set up Prometheus
 	registry := prometheus.NewRegistry()
@@ -78,6 +81,19 @@ func runMetricsServer(c *cli.Context, logger log.ColorLogger, stateLocker *colle
 	registry.MustRegister(trustedNodeCollector)
 	registry.MustRegister(beaconCollector)
 	registry.MustRegister(smoothingPoolCollector)
+	registry.MustRegister(pdaoChallengeCollector)
+
+	// Set up pDAO delegate metrics if they're enabled, since they're expensive to build
+	if cfg.EnablePdaoDelegateMetrics.Value.(bool) {
+		pdaoDelegateCollector := collectors.NewPdaoDelegateCollector(rp, cfg, bc, nodeAccount.Address, stateLocker)
+		registry.MustRegister(pdaoDelegateCollector)
+	}
+
+	// Set up validator performance metrics if tracking is enabled
+	if performanceRecord != nil {
+		validatorPerformanceCollector := collectors.NewValidatorPerformanceCollector(performanceRecord)
+		registry.MustRegister(validatorPerformanceCollector)
+	}
 
 	// Set up snapshot checking if enabled
 	votingId := cfg.Smartnode.GetVotingSnapshotID()
@@ -0,0 +1,68 @@
+package node
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/addons/rescue_node"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+	"github.com/rocket-pool/smartnode/shared/utils/rp"
+)
+
+// Time to wait between Rescue Node credential checks
+const rescueNodeCheckInterval time.Duration = time.Hour
+
+// Monitor the Rescue Node credential task
+type monitorRescueNode struct {
+	c            *cli.Context
+	log          log.ColorLogger
+	settingsPath string
+}
+
+// Create monitor the Rescue Node credential task
+func newMonitorRescueNode(c *cli.Context, logger log.ColorLogger) *monitorRescueNode {
+	return &monitorRescueNode{
+		c:            c,
+		log:          logger,
+		settingsPath: os.ExpandEnv(c.GlobalString("settings")),
+	}
+}
+
+// Check the Rescue Node credential and send an alert if it's about to expire
+func (t *monitorRescueNode) run() error {
+
+	// Reload the settings, since the Rescue Node can be enabled or disabled without restarting the node daemon
+	cfg, err := rp.LoadConfigFromFile(t.settingsPath)
+	if err != nil {
+		return fmt.Errorf("error loading settings: %w", err)
+	}
+	if cfg == nil || !cfg.RescueNode.GetEnabledParameter().Value.(bool) {
+		return nil
+	}
+
+	// Check the credential
+	status, err := cfg.RescueNode.(*rescue_node.RescueNode).GetCredentialStatus()
+	if err != nil {
+		return fmt.Errorf("error checking Rescue Node credential: %w", err)
+	}
+	timeLeft := status.GetTimeLeft().Truncate(time.Minute)
+	if timeLeft > rescue_node.CredentialExpiryWarning {
+		return nil
+	}
+
+	// Send the alert
+	if timeLeft <= 0 {
+		t.log.Printlnf("WARNING: The Rescue Node credential expired %s ago. Your validator client can't use the Rescue Node until you get a new one.", -timeLeft)
+	} else {
+		t.log.Printlnf("WARNING: The Rescue Node credential expires in %s.", timeLeft)
+	}
+	if err := alerting.AlertRescueNodeCredentialExpiry(cfg, status.Issued, status.Expires); err != nil {
+		return fmt.Errorf("error sending Rescue Node credential alert: %w", err)
+	}
+	return nil
+
+}
@@ -16,6 +16,9 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool/node/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/performance"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
 	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
@@ -38,9 +41,19 @@ const (
 	ManageFeeRecipientColor      = color.FgHiCyan
 	PromoteMinipoolsColor        = color.FgMagenta
 	ReduceBondAmountColor        = color.FgHiBlue
+	PrepareVotingArtifactsColor  = color.FgHiYellow
 	DefendPdaoPropsColor         = color.FgYellow
 	VerifyPdaoPropsColor         = color.FgYellow
+	VotePdaoPropsColor           = color.FgYellow
+	NotifyGovernanceColor        = color.FgHiCyan
+	TrackValidatorPerfColor      = color.FgHiMagenta
+	AuditProposalsColor          = color.FgCyan
+	ClaimRewardsColor            = color.FgHiGreen
+	DistributeFeesColor          = color.FgGreen
 	DistributeMinipoolsColor     = color.FgHiGreen
+	MonitorRescueNodeColor       = color.FgHiRed
+	UpdateGraffitiColor          = color.FgHiBlue
+	ManageBackupsColor           = color.FgHiCyan
 	ErrorColor                   = color.FgRed
 	WarningColor                 = color.FgYellow
 	UpdateColor                  = color.FgHiWhite
@@ -143,11 +156,33 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	distributeFees, err := newDistributeFees(c, log.NewColorLogger(DistributeFeesColor))
+	if err != nil {
+		return err
+	}
 	reduceBonds, err := newReduceBonds(c, log.NewColorLogger(ReduceBondAmountColor))
 	if err != nil {
 		return err
 	}
-	defendPdaoProps, err := newDefendPdaoProps(c, log.NewColorLogger(DefendPdaoPropsColor))
+	var auditProposals *auditProposals
+	if cfg.Smartnode.AuditProposals.Value.(bool) {
+		auditProposals, err = newAuditProposals(c, log.NewColorLogger(AuditProposalsColor))
+		if err != nil {
+			return err
+		}
+	}
+	var prepareVotingArtifacts *prepareVotingArtifacts
+	if cfg.Smartnode.PregenerateVotingArtifacts.Value.(bool) {
+		prepareVotingArtifacts, err = newPrepareVotingArtifacts(c, log.NewColorLogger(PrepareVotingArtifactsColor))
+		if err != nil {
+			return err
+		}
+	}
+	challengeRecord, err := proposals.LoadChallengeRecord(cfg.Smartnode.GetChallengeRecordPath())
+	if err != nil {
+		return err
+	}
+	defendPdaoProps, err := newDefendPdaoProps(c, log.NewColorLogger(DefendPdaoPropsColor), challengeRecord)
 	if err != nil {
 		return err
 	}
@@ -155,11 +190,56 @@ func run(c *cli.Context) error {
 	// Make sure the user opted into this duty
 	verifyEnabled := cfg.Smartnode.VerifyProposals.Value.(bool)
 	if verifyEnabled {
-		verifyPdaoProps, err = newVerifyPdaoProps(c, log.NewColorLogger(VerifyPdaoPropsColor))
+		verifyPdaoProps, err = newVerifyPdaoProps(c, log.NewColorLogger(VerifyPdaoPropsColor), challengeRecord)
+		if err != nil {
+			return err
+		}
+	}
+	var votePdaoProps *votePdaoProps
+	if cfg.Smartnode.EnablePdaoVotePolicy.Value.(bool) {
+		votePdaoProps, err = newVotePdaoProps(c, log.NewColorLogger(VotePdaoPropsColor))
+		if err != nil {
+			return err
+		}
+	}
+	var notifyGovernance *notifyGovernance
+	if cfg.Smartnode.EnableGovernanceDigest.Value.(bool) {
+		notifyGovernance, err = newNotifyGovernance(c, log.NewColorLogger(NotifyGovernanceColor))
 		if err != nil {
 			return err
 		}
 	}
+	var claimRewards *claimRewards
+	if cfg.Smartnode.AutoClaimRewards.Value.(bool) {
+		claimRewards, err = newClaimRewards(c, log.NewColorLogger(ClaimRewardsColor))
+		if err != nil {
+			return err
+		}
+	}
+	var trackValidatorPerformance *trackValidatorPerformance
+	var performanceRecord *performance.PerformanceRecord
+	if cfg.Smartnode.TrackValidatorPerformance.Value.(bool) {
+		trackValidatorPerformance, err = newTrackValidatorPerformance(c, log.NewColorLogger(TrackValidatorPerfColor))
+		if err != nil {
+			return err
+		}
+		performanceRecord = trackValidatorPerformance.record
+	}
+
+	var updateGraffiti *updateGraffiti
+	if cfg.GetGraffitiProvider() == config.GraffitiProvider_Template {
+		updateGraffiti, err = newUpdateGraffiti(c, log.NewColorLogger(UpdateGraffitiColor))
+		if err != nil {
+			return err
+		}
+	}
+
+	// Native mode doesn't support the Rescue Node addon
+	var monitorRescueNode *monitorRescueNode
+	if !cfg.IsNativeMode {
+		monitorRescueNode = newMonitorRescueNode(c, log.NewColorLogger(MonitorRescueNodeColor))
+	}
+	manageBackups := newManageBackups(c, log.NewColorLogger(ManageBackupsColor))
 
 	// Wait group to handle the various threads
 	wg := new(sync.WaitGroup)
@@ -225,12 +305,36 @@ func run(c *cli.Context) error {
 			}
 			time.Sleep(taskCooldown)
 
+			// Audit the fee recipients of recently proposed blocks
+			if auditProposals != nil {
+				if err := auditProposals.run(state); err != nil {
+					errorLog.Println(err)
+				}
+				time.Sleep(taskCooldown)
+			}
+
 			// Run the rewards download check
 			if err := downloadRewardsTrees.run(state); err != nil {
 				errorLog.Println(err)
 			}
 			time.Sleep(taskCooldown)
 
+			// Run the automatic rewards claim
+			if claimRewards != nil {
+				if err := claimRewards.run(state); err != nil {
+					errorLog.Println(err)
+				}
+				time.Sleep(taskCooldown)
+			}
+
+			// Run the voting artifact preparation
+			if prepareVotingArtifacts != nil {
+				if err := prepareVotingArtifacts.run(state); err != nil {
+					errorLog.Println(err)
+				}
+				time.Sleep(taskCooldown)
+			}
+
 			// Run the pDAO proposal defender
 			if err := defendPdaoProps.run(state); err != nil {
 				errorLog.Println(err)
@@ -245,6 +349,22 @@ func run(c *cli.Context) error {
 				time.Sleep(taskCooldown)
 			}
 
+			// Run the pDAO voting policy
+			if votePdaoProps != nil {
+				if err := votePdaoProps.run(state); err != nil {
+					errorLog.Println(err)
+				}
+				time.Sleep(taskCooldown)
+			}
+
+			// Run the governance digest check
+			if notifyGovernance != nil {
+				if err := notifyGovernance.run(state); err != nil {
+					errorLog.Println(err)
+				}
+				time.Sleep(taskCooldown)
+			}
+
 			// Run the minipool stake check
 			if err := stakePrelaunchMinipools.run(state); err != nil {
 				errorLog.Println(err)
@@ -257,6 +377,12 @@ func run(c *cli.Context) error {
 			}
 			time.Sleep(taskCooldown)
 
+			// Run the fee distributor balance check
+			if err := distributeFees.run(state); err != nil {
+				errorLog.Println(err)
+			}
+			time.Sleep(taskCooldown)
+
 			// Run the reduce bond check
 			if err := reduceBonds.run(state); err != nil {
 				errorLog.Println(err)
@@ -268,6 +394,22 @@ func run(c *cli.Context) error {
 				errorLog.Println(err)
 			}
 
+			// Refresh the validator graffiti
+			if updateGraffiti != nil {
+				time.Sleep(taskCooldown)
+				if err := updateGraffiti.run(state); err != nil {
+					errorLog.Println(err)
+				}
+			}
+
+			// Run the validator performance tracker
+			if trackValidatorPerformance != nil {
+				time.Sleep(taskCooldown)
+				if err := trackValidatorPerformance.run(state); err != nil {
+					errorLog.Println(err)
+				}
+			}
+
 			time.Sleep(tasksInterval)
 		}
 		wg.Done()
@@ -275,13 +417,36 @@ func run(c *cli.Context) error {
 
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), stateLocker)
+		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), stateLocker, performanceRecord, challengeRecord)
 		if err != nil {
 			errorLog.Println(err)
 		}
 		wg.Done()
 	}()
 
+	// Run the Rescue Node credential check loop; it runs separately from the task loop because the Rescue Node is
+	// usually needed while the node's own clients are down
+	if monitorRescueNode != nil {
+		go func() {
+			for {
+				if err := monitorRescueNode.run(); err != nil {
+					errorLog.Println(err)
+				}
+				time.Sleep(rescueNodeCheckInterval)
+			}
+		}()
+	}
+
+	// Run the scheduled backup loop; it runs separately from the task loop so slow uploads don't hold it up
+	go func() {
+		for {
+			if err := manageBackups.run(); err != nil {
+				errorLog.Println(err)
+			}
+			time.Sleep(backupCheckInterval)
+		}
+	}()
+
 	// Wait for both threads to stop
 	wg.Wait()
 	return nil
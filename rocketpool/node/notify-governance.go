@@ -0,0 +1,107 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/rocketpool/api/pdao"
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/alerting"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Time to wait between governance checks, since each one queries the Snapshot API
+const governanceDigestInterval time.Duration = time.Hour
+
+// Send governance digests task
+type notifyGovernance struct {
+	c           *cli.Context
+	log         log.ColorLogger
+	cfg         *config.RocketPoolConfig
+	nodeAddress common.Address
+	recordPath  string
+	lastCheck   time.Time
+}
+
+// Create send governance digests task
+func newNotifyGovernance(c *cli.Context, logger log.ColorLogger) (*notifyGovernance, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the node account
+	account, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, fmt.Errorf("error getting node account: %w", err)
+	}
+
+	// Return task
+	return &notifyGovernance{
+		c:           c,
+		log:         logger,
+		cfg:         cfg,
+		nodeAddress: account.Address,
+		recordPath:  cfg.Smartnode.GetGovernanceDigestRecordPath(),
+	}, nil
+
+}
+
+// Check for governance activity and send a digest if anything changed
+func (t *notifyGovernance) run(state *state.NetworkState) error {
+
+	if time.Since(t.lastCheck) < governanceDigestInterval {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking for governance activity...")
+
+	// Build the digest
+	digest, err := pdao.BuildGovernanceDigest(t.c, t.nodeAddress)
+	if err != nil {
+		return fmt.Errorf("error building governance digest: %w", err)
+	}
+	if digest.SnapshotError != "" {
+		t.log.Printlnf("WARNING: couldn't get Snapshot proposals: %s", digest.SnapshotError)
+	}
+	t.lastCheck = time.Now()
+
+	// Compare it to the last one
+	record, err := proposals.LoadGovernanceDigestRecord(t.recordPath)
+	if err != nil {
+		return err
+	}
+	events := record.GetEvents(digest)
+	if len(events) == 0 {
+		return nil
+	}
+
+	// Send the digest
+	for _, event := range events {
+		t.log.Printlnf("%s", event.Description)
+	}
+	if err := alerting.AlertGovernanceDigest(t.cfg, digest, events); err != nil {
+		return fmt.Errorf("error sending governance digest: %w", err)
+	}
+
+	// Update the record
+	record.Update(digest, events)
+	if err := record.Save(t.recordPath); err != nil {
+		return fmt.Errorf("error saving governance digest record: %w", err)
+	}
+	return nil
+
+}
@@ -0,0 +1,121 @@
+package node
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Pre-generate voting artifacts task
+type prepareVotingArtifacts struct {
+	c              *cli.Context
+	log            *log.ColorLogger
+	nodeAddress    common.Address
+	propMgr        *proposals.ProposalManager
+	preparedBlocks map[uint32]bool
+	prunedBlocks   map[uint32]bool
+}
+
+// Create pre-generate voting artifacts task
+func newPrepareVotingArtifacts(c *cli.Context, logger log.ColorLogger) (*prepareVotingArtifacts, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the node account
+	account, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, fmt.Errorf("error getting node account: %w", err)
+	}
+
+	// Make a proposal manager
+	propMgr, err := proposals.NewProposalManager(&logger, cfg, rp, bc)
+	if err != nil {
+		return nil, fmt.Errorf("error creating proposal manager: %w", err)
+	}
+
+	// Return task
+	return &prepareVotingArtifacts{
+		c:              c,
+		log:            &logger,
+		nodeAddress:    account.Address,
+		propMgr:        propMgr,
+		preparedBlocks: map[uint32]bool{},
+		prunedBlocks:   map[uint32]bool{},
+	}, nil
+
+}
+
+// Generate the artifacts for open proposals and prune the ones for finished proposals
+func (t *prepareVotingArtifacts) run(state *state.NetworkState) error {
+
+	// Sort the target blocks by whether they're still needed; a block shared with an open proposal is always kept
+	openBlocks := map[uint32]bool{}
+	finishedBlocks := map[uint32]bool{}
+	for _, prop := range state.ProtocolDaoProposalDetails {
+		if prop.State <= types.ProtocolDaoProposalState_ActivePhase2 {
+			openBlocks[prop.TargetBlock] = true
+		} else {
+			finishedBlocks[prop.TargetBlock] = true
+		}
+	}
+
+	// Generate the artifacts for the open proposals
+	for blockNumber := range openBlocks {
+		if t.preparedBlocks[blockNumber] {
+			continue
+		}
+		t.log.Printlnf("Preparing voting artifacts for block %d...", blockNumber)
+		err := t.propMgr.PregenerateArtifacts(blockNumber, t.nodeAddress)
+		if err != nil {
+			return fmt.Errorf("error preparing voting artifacts for block %d: %w", blockNumber, err)
+		}
+		t.preparedBlocks[blockNumber] = true
+	}
+
+	// Prune the artifacts for the finished proposals
+	pruneBlocks := []uint32{}
+	for blockNumber := range finishedBlocks {
+		if !openBlocks[blockNumber] && !t.prunedBlocks[blockNumber] {
+			pruneBlocks = append(pruneBlocks, blockNumber)
+		}
+	}
+	if len(pruneBlocks) == 0 {
+		return nil
+	}
+	pruned, err := t.propMgr.PruneArtifacts(pruneBlocks)
+	if err != nil {
+		return fmt.Errorf("error pruning voting artifacts: %w", err)
+	}
+	if pruned > 0 {
+		t.log.Printlnf("Deleted %d voting artifact files for finished proposals.", pruned)
+	}
+	for _, blockNumber := range pruneBlocks {
+		t.prunedBlocks[blockNumber] = true
+		delete(t.preparedBlocks, blockNumber)
+	}
+	return nil
+
+}
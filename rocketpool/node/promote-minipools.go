@@ -34,7 +34,7 @@ type promoteMinipools struct {
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
 	d              *client.Client
-	gasThreshold   float64
+	gasPolicy      *rpgas.AutoTxPolicy
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
@@ -61,8 +61,6 @@ func newPromoteMinipools(c *cli.Context, logger log.ColorLogger) (*promoteMinipo
 		return nil, err
 	}
 
-	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
-
 	// Get the user-requested max fee
 	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
 	var maxFee *big.Int
@@ -82,6 +80,12 @@ func newPromoteMinipools(c *cli.Context, logger log.ColorLogger) (*promoteMinipo
 		priorityFee = eth.GweiToWei(priorityFeeGwei)
 	}
 
+	// Get the gas policy shared by the automatic transactions
+	gasPolicy, err := services.GetAutoTxPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+
 	// Return task
 	return &promoteMinipools{
 		c:              c,
@@ -90,7 +94,7 @@ func newPromoteMinipools(c *cli.Context, logger log.ColorLogger) (*promoteMinipo
 		w:              w,
 		rp:             rp,
 		d:              d,
-		gasThreshold:   gasThreshold,
+		gasPolicy:      gasPolicy,
 		maxFee:         maxFee,
 		maxPriorityFee: priorityFee,
 		gasLimit:       0,
@@ -129,7 +133,7 @@ func (t *promoteMinipools) run(state *state.NetworkState) error {
 
 	// Promote minipools
 	for _, mpd := range minipools {
-		_, err := t.promoteMinipool(mpd, opts)
+		_, err := t.promoteMinipool(mpd, state, opts)
 		alerting.AlertMinipoolPromoted(t.cfg, mpd.MinipoolAddress, err == nil)
 		if err != nil {
 			t.log.Println(fmt.Errorf("Could not promote minipool %s: %w", mpd.MinipoolAddress.Hex(), err))
@@ -177,7 +181,7 @@ func (t *promoteMinipools) getVacantMinipools(nodeAddress common.Address, state
 }
 
 // Promote a minipool
-func (t *promoteMinipools) promoteMinipool(mpd *rpstate.NativeMinipoolDetails, callOpts *bind.CallOpts) (bool, error) {
+func (t *promoteMinipools) promoteMinipool(mpd *rpstate.NativeMinipoolDetails, state *state.NetworkState, callOpts *bind.CallOpts) (bool, error) {
 
 	// Log
 	t.log.Printlnf("Promoting minipool %s...", mpd.MinipoolAddress.Hex())
@@ -219,16 +223,26 @@ func (t *promoteMinipools) promoteMinipool(mpd *rpstate.NativeMinipoolDetails, c
 		}
 	}
 
+	// Get the promotion deadline
+	creationTime := time.Unix(mpd.StatusTime.Int64(), 0)
+	deadline := rpgas.AutoTxDeadline{
+		Action:   rpgas.AutoTxAction_Promote,
+		Subject:  mpd.MinipoolAddress.Hex(),
+		Eligible: creationTime.Add(state.NetworkDetails.PromotionScrubPeriod),
+	}
+	deadline.Deadline, err = api.GetTransactionDueTime(t.rp, creationTime)
+	if err != nil {
+		t.log.Printlnf("Error checking if minipool is due: %s\nPromoting now for safety...", err.Error())
+		deadline.Deadline = time.Now()
+	}
+
 	// Print the gas info
-	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+	threshold, stage := t.gasPolicy.GetThreshold(deadline, time.Now())
+	if !api.PrintAndCheckGasInfo(gasInfo, true, threshold, &t.log, maxFee, t.gasLimit) {
 		// Check for the timeout buffer
-		creationTime := time.Unix(mpd.StatusTime.Int64(), 0)
-		isDue, timeUntilDue, err := api.IsTransactionDue(t.rp, creationTime)
-		if err != nil {
-			t.log.Printlnf("Error checking if minipool is due: %s\nPromoting now for safety...", err.Error())
-		}
-		if !isDue {
-			t.log.Printlnf("Time until promoting will be forced for safety: %s", timeUntilDue)
+		if time.Now().Before(deadline.Deadline) {
+			t.gasPolicy.RecordDelay(deadline, maxFee, threshold, stage, &t.log)
+			t.log.Printlnf("Time until promoting will be forced for safety: %s", time.Until(deadline.Deadline))
 			return false, nil
 		}
 
@@ -244,6 +258,7 @@ func (t *promoteMinipools) promoteMinipool(mpd *rpstate.NativeMinipoolDetails, c
 	if err != nil {
 		return false, err
 	}
+	t.gasPolicy.ClearDelay(deadline, &t.log)
 
 	// Print TX info and wait for it to be included in a block
 	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
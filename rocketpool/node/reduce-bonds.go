@@ -36,7 +36,7 @@ type reduceBonds struct {
 	w              *wallet.Wallet
 	rp             *rocketpool.RocketPool
 	d              *client.Client
-	gasThreshold   float64
+	gasPolicy      *rpgas.AutoTxPolicy
 	disabled       bool
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
@@ -100,6 +100,12 @@ func newReduceBonds(c *cli.Context, logger log.ColorLogger) (*reduceBonds, error
 		priorityFee = eth.GweiToWei(priorityFeeGwei)
 	}
 
+	// Get the gas policy shared by the automatic transactions
+	gasPolicy, err := services.GetAutoTxPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+
 	// Return task
 	return &reduceBonds{
 		c:              c,
@@ -108,7 +114,7 @@ func newReduceBonds(c *cli.Context, logger log.ColorLogger) (*reduceBonds, error
 		w:              w,
 		rp:             rp,
 		d:              d,
-		gasThreshold:   gasThreshold,
+		gasPolicy:      gasPolicy,
 		disabled:       disabled,
 		maxFee:         maxFee,
 		maxPriorityFee: priorityFee,
@@ -151,7 +157,7 @@ func (t *reduceBonds) run(state *state.NetworkState) error {
 	latestBlockTime := time.Unix(int64(latestEth1Block.Time), 0)
 
 	// Get reduceable minipools
-	minipools, err := t.getReduceableMinipools(nodeAccount.Address, windowStart, windowLength, latestBlockTime, state, opts)
+	minipools, deadlines, err := t.getReduceableMinipools(nodeAccount.Address, windowStart, windowLength, latestBlockTime, state, opts)
 	if err != nil {
 		return err
 	}
@@ -162,8 +168,17 @@ func (t *reduceBonds) run(state *state.NetworkState) error {
 	// Log
 	t.log.Printlnf("%d minipool(s) are ready for bond reduction...", len(minipools))
 
-	// Workaround for the fee distribution issue
-	success, err := t.forceFeeDistribution()
+	// Workaround for the fee distribution issue; it has to happen before the earliest bond reduction deadline
+	distributeDeadline := rpgas.AutoTxDeadline{
+		Action: rpgas.AutoTxAction_DistributeFees,
+	}
+	for _, deadline := range deadlines {
+		if distributeDeadline.Deadline.IsZero() || deadline.Deadline.Before(distributeDeadline.Deadline) {
+			distributeDeadline.Eligible = deadline.Eligible
+			distributeDeadline.Deadline = deadline.Deadline
+		}
+	}
+	success, err := t.forceFeeDistribution(distributeDeadline)
 	if err != nil {
 		return err
 	}
@@ -174,7 +189,7 @@ func (t *reduceBonds) run(state *state.NetworkState) error {
 	// Reduce bonds
 	successCount := 0
 	for _, mp := range minipools {
-		success, err := t.reduceBond(mp, windowStart, windowLength, latestBlockTime, opts)
+		success, err := t.reduceBond(mp, deadlines[mp.MinipoolAddress], opts)
 		alerting.AlertMinipoolBondReduced(t.cfg, mp.MinipoolAddress, err == nil)
 		if err != nil {
 			t.log.Println(fmt.Errorf("could not reduce bond for minipool %s: %w", mp.MinipoolAddress.Hex(), err))
@@ -191,7 +206,7 @@ func (t *reduceBonds) run(state *state.NetworkState) error {
 }
 
 // Temp mitigation for the
-func (t *reduceBonds) forceFeeDistribution() (bool, error) {
+func (t *reduceBonds) forceFeeDistribution(deadline rpgas.AutoTxDeadline) (bool, error) {
 
 	// Get node account
 	nodeAccount, err := t.w.GetNodeAccount()
@@ -209,6 +224,9 @@ func (t *reduceBonds) forceFeeDistribution() (bool, error) {
 		return false, err
 	}
 
+	// Key the delay by the distributor, the same way the fee distribution task does
+	deadline.Subject = distributorAddress.Hex()
+
 	// Sync
 	var wg errgroup.Group
 	var balanceRaw *big.Int
@@ -276,7 +294,9 @@ func (t *reduceBonds) forceFeeDistribution() (bool, error) {
 	}
 
 	// Print the gas info
-	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+	threshold, stage := t.gasPolicy.GetThreshold(deadline, time.Now())
+	if !api.PrintAndCheckGasInfo(gasInfo, true, threshold, &t.log, maxFee, t.gasLimit) {
+		t.gasPolicy.RecordDelay(deadline, maxFee, threshold, stage, &t.log)
 		return false, nil
 	}
 
@@ -290,6 +310,7 @@ func (t *reduceBonds) forceFeeDistribution() (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	t.gasPolicy.ClearDelay(deadline, &t.log)
 
 	// Print TX info and wait for it to be included in a block
 	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
@@ -303,20 +324,21 @@ func (t *reduceBonds) forceFeeDistribution() (bool, error) {
 }
 
 // Get reduceable minipools
-func (t *reduceBonds) getReduceableMinipools(nodeAddress common.Address, windowStart time.Duration, windowLength time.Duration, latestBlockTime time.Time, state *state.NetworkState, opts *bind.CallOpts) ([]*rpstate.NativeMinipoolDetails, error) {
+func (t *reduceBonds) getReduceableMinipools(nodeAddress common.Address, windowStart time.Duration, windowLength time.Duration, latestBlockTime time.Time, state *state.NetworkState, opts *bind.CallOpts) ([]*rpstate.NativeMinipoolDetails, map[common.Address]rpgas.AutoTxDeadline, error) {
 
 	// Filter minipools
 	reduceableMinipools := []*rpstate.NativeMinipoolDetails{}
+	deadlines := map[common.Address]rpgas.AutoTxDeadline{}
 	for _, mpd := range state.MinipoolDetailsByNode[nodeAddress] {
 
 		// TEMP
 		reduceBondTime, err := minipool.GetReduceBondTime(t.rp, mpd.MinipoolAddress, opts)
 		if err != nil {
-			return nil, fmt.Errorf("error getting reduce bond time for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
+			return nil, nil, fmt.Errorf("error getting reduce bond time for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
 		}
 		reduceBondCancelled, err := minipool.GetReduceBondCancelled(t.rp, mpd.MinipoolAddress, opts)
 		if err != nil {
-			return nil, fmt.Errorf("error getting reduce bond cancelled for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
+			return nil, nil, fmt.Errorf("error getting reduce bond cancelled for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
 		}
 
 		depositBalance := eth.WeiToEth(mpd.NodeDepositBalance)
@@ -328,6 +350,12 @@ func (t *reduceBonds) getReduceableMinipools(nodeAddress common.Address, windowS
 			mpd.Status == types.Staking {
 			if timeSinceReductionStart > windowStart {
 				reduceableMinipools = append(reduceableMinipools, mpd)
+				deadlines[mpd.MinipoolAddress] = rpgas.AutoTxDeadline{
+					Action:   rpgas.AutoTxAction_ReduceBond,
+					Subject:  mpd.MinipoolAddress.Hex(),
+					Eligible: reduceBondTime.Add(windowStart),
+					Deadline: reduceBondTime.Add(windowStart + windowLength),
+				}
 			} else {
 				remainingTime := windowStart - timeSinceReductionStart
 				t.log.Printlnf("Minipool %s has %s left until it can have its bond reduced.", mpd.MinipoolAddress.Hex(), remainingTime)
@@ -336,12 +364,12 @@ func (t *reduceBonds) getReduceableMinipools(nodeAddress common.Address, windowS
 	}
 
 	// Return
-	return reduceableMinipools, nil
+	return reduceableMinipools, deadlines, nil
 
 }
 
 // Reduce a minipool's bond
-func (t *reduceBonds) reduceBond(mpd *rpstate.NativeMinipoolDetails, windowStart time.Duration, windowLength time.Duration, latestBlockTime time.Time, callOpts *bind.CallOpts) (bool, error) {
+func (t *reduceBonds) reduceBond(mpd *rpstate.NativeMinipoolDetails, deadline rpgas.AutoTxDeadline, callOpts *bind.CallOpts) (bool, error) {
 
 	// Log
 	t.log.Printlnf("Reducing bond for minipool %s...", mpd.MinipoolAddress.Hex())
@@ -385,17 +413,11 @@ func (t *reduceBonds) reduceBond(mpd *rpstate.NativeMinipoolDetails, windowStart
 		}
 	}
 
-	// TEMP
-	reduceBondTime, err := minipool.GetReduceBondTime(t.rp, mpd.MinipoolAddress, callOpts)
-	if err != nil {
-		return false, fmt.Errorf("error getting reduce bond time for minipool %s: %w", mpd.MinipoolAddress.Hex(), err)
-	}
-
 	// Print the gas info
-	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
-		timeSinceReductionStart := latestBlockTime.Sub(reduceBondTime)
-		remainingTime := (windowStart + windowLength) - timeSinceReductionStart
-		t.log.Printlnf("Time until bond reduction times out: %s", remainingTime)
+	threshold, stage := t.gasPolicy.GetThreshold(deadline, time.Now())
+	if !api.PrintAndCheckGasInfo(gasInfo, true, threshold, &t.log, maxFee, t.gasLimit) {
+		t.gasPolicy.RecordDelay(deadline, maxFee, threshold, stage, &t.log)
+		t.log.Printlnf("Time until bond reduction times out: %s", time.Until(deadline.Deadline))
 		return false, nil
 	}
 
@@ -408,6 +430,7 @@ func (t *reduceBonds) reduceBond(mpd *rpstate.NativeMinipoolDetails, windowStart
 	if err != nil {
 		return false, err
 	}
+	t.gasPolicy.ClearDelay(deadline, &t.log)
 
 	// Print TX info and wait for it to be included in a block
 	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
@@ -37,7 +37,7 @@ type stakePrelaunchMinipools struct {
 	rp             *rocketpool.RocketPool
 	bc             beacon.Client
 	d              *client.Client
-	gasThreshold   float64
+	gasPolicy      *rpgas.AutoTxPolicy
 	maxFee         *big.Int
 	maxPriorityFee *big.Int
 	gasLimit       uint64
@@ -68,8 +68,6 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 		return nil, err
 	}
 
-	gasThreshold := cfg.Smartnode.AutoTxGasThreshold.Value.(float64)
-
 	// Get the user-requested max fee
 	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
 	var maxFee *big.Int
@@ -89,6 +87,12 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 		priorityFee = eth.GweiToWei(priorityFeeGwei)
 	}
 
+	// Get the gas policy shared by the automatic transactions
+	gasPolicy, err := services.GetAutoTxPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+
 	// Return task
 	return &stakePrelaunchMinipools{
 		c:              c,
@@ -98,7 +102,7 @@ func newStakePrelaunchMinipools(c *cli.Context, logger log.ColorLogger) (*stakeP
 		rp:             rp,
 		bc:             bc,
 		d:              d,
-		gasThreshold:   gasThreshold,
+		gasPolicy:      gasPolicy,
 		maxFee:         maxFee,
 		maxPriorityFee: priorityFee,
 		gasLimit:       0,
@@ -270,16 +274,26 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetai
 		}
 	}
 
+	// Get the staking deadline
+	prelaunchTime := time.Unix(mpd.StatusTime.Int64(), 0)
+	deadline := rpgas.AutoTxDeadline{
+		Action:   rpgas.AutoTxAction_Stake,
+		Subject:  mpd.MinipoolAddress.Hex(),
+		Eligible: prelaunchTime.Add(state.NetworkDetails.ScrubPeriod),
+	}
+	deadline.Deadline, err = api.GetTransactionDueTime(t.rp, prelaunchTime)
+	if err != nil {
+		t.log.Printlnf("Error checking if minipool is due: %s\nStaking now for safety...", err.Error())
+		deadline.Deadline = time.Now()
+	}
+
 	// Print the gas info
-	if !api.PrintAndCheckGasInfo(gasInfo, true, t.gasThreshold, &t.log, maxFee, t.gasLimit) {
+	threshold, stage := t.gasPolicy.GetThreshold(deadline, time.Now())
+	if !api.PrintAndCheckGasInfo(gasInfo, true, threshold, &t.log, maxFee, t.gasLimit) {
 		// Check for the timeout buffer
-		prelaunchTime := time.Unix(mpd.StatusTime.Int64(), 0)
-		isDue, timeUntilDue, err := api.IsTransactionDue(t.rp, prelaunchTime)
-		if err != nil {
-			t.log.Printlnf("Error checking if minipool is due: %s\nStaking now for safety...", err.Error())
-		}
-		if !isDue {
-			t.log.Printlnf("Time until staking will be forced for safety: %s", timeUntilDue)
+		if time.Now().Before(deadline.Deadline) {
+			t.gasPolicy.RecordDelay(deadline, maxFee, threshold, stage, &t.log)
+			t.log.Printlnf("Time until staking will be forced for safety: %s", time.Until(deadline.Deadline))
 			return false, nil
 		}
 
@@ -299,6 +313,7 @@ func (t *stakePrelaunchMinipools) stakeMinipool(mpd *rpstate.NativeMinipoolDetai
 	if err != nil {
 		return false, err
 	}
+	t.gasPolicy.ClearDelay(deadline, &t.log)
 
 	// Print TX info and wait for it to be included in a block
 	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
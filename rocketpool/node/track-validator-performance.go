@@ -0,0 +1,178 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/performance"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/eth2"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Track validator performance task
+type trackValidatorPerformance struct {
+	c       *cli.Context
+	log     log.ColorLogger
+	cfg     *config.RocketPoolConfig
+	w       *wallet.Wallet
+	bc      beacon.Client
+	path    string
+	record  *performance.PerformanceRecord
+	tracker *performance.Tracker
+}
+
+// Create track validator performance task
+func newTrackValidatorPerformance(c *cli.Context, logger log.ColorLogger) (*trackValidatorPerformance, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+	eth2Config, err := bc.GetEth2Config()
+	if err != nil {
+		return nil, fmt.Errorf("error getting Beacon config: %w", err)
+	}
+
+	// Load the existing record
+	path := cfg.Smartnode.GetValidatorPerformancePath()
+	record, err := performance.LoadPerformanceRecord(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &trackValidatorPerformance{
+		c:       c,
+		log:     logger,
+		cfg:     cfg,
+		w:       w,
+		bc:      bc,
+		path:    path,
+		record:  record,
+		tracker: performance.NewTracker(bc, eth2Config),
+	}, nil
+
+}
+
+// Process any newly finalized epochs
+func (t *trackValidatorPerformance) run(state *state.NetworkState) error {
+
+	// Get the node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the latest finalized epoch; an epoch is only processed once the epoch after it is finalized too,
+	// since its attestations can still be included there
+	head, err := t.bc.GetBeaconHead()
+	if err != nil {
+		return fmt.Errorf("error getting Beacon head: %w", err)
+	}
+	if head.FinalizedEpoch == 0 {
+		return nil
+	}
+	targetEpoch := head.FinalizedEpoch - 1
+
+	// Save the proposer duties of the current epoch while the Beacon Node can still serve them, since some can't by the time it's finalized
+	savedDuties := t.saveProposerDuties(state, nodeAccount.Address, head.Epoch)
+
+	// Get the range of epochs to process
+	startEpoch, endEpoch, ok := performance.GetEpochRange(t.record.GetLastProcessedEpoch(), targetEpoch)
+	if !ok {
+		if savedDuties {
+			return t.record.Save(t.path)
+		}
+		return nil
+	}
+
+	// Log
+	t.log.Printlnf("Tracking validator performance for epochs %d to %d...", startEpoch, endEpoch)
+
+	// Process each epoch
+	for epoch := startEpoch; epoch <= endEpoch; epoch++ {
+		validators := t.getActiveValidators(state, nodeAccount.Address, epoch)
+		indices := make([]string, 0, len(validators))
+		for index := range validators {
+			indices = append(indices, index)
+		}
+
+		counters, err := t.tracker.ProcessEpoch(epoch, indices, t.record.GetProposerDuties(epoch))
+		if err != nil {
+			return fmt.Errorf("error processing validator performance for epoch %d: %w", epoch, err)
+		}
+		for _, validatorCounters := range counters {
+			if validatorCounters.EpochsWithoutProposerDuties > 0 {
+				t.log.Printlnf("WARNING: the proposer duties for epoch %d aren't available, so missed proposals in it won't be counted.", epoch)
+				break
+			}
+		}
+		t.record.AddEpoch(epoch, eth2.TimeAt(state.BeaconConfig, epoch), counters, validators)
+	}
+
+	// Prune and save the record
+	t.record.Prune(time.Now())
+	err = t.record.Save(t.path)
+	if err != nil {
+		return err
+	}
+
+	// Return
+	return nil
+
+}
+
+// Save the proposer duties of the node's validators for an epoch if they haven't been saved already, returning true if they were
+func (t *trackValidatorPerformance) saveProposerDuties(state *state.NetworkState, nodeAddress common.Address, epoch uint64) bool {
+	if t.record.GetProposerDuties(epoch) != nil {
+		return false
+	}
+	validators := t.getActiveValidators(state, nodeAddress, epoch)
+	if len(validators) == 0 {
+		return false
+	}
+	indices := make([]string, 0, len(validators))
+	for index := range validators {
+		indices = append(indices, index)
+	}
+
+	duties, err := t.tracker.GetProposerDuties(epoch, indices)
+	if err != nil {
+		t.log.Printlnf("WARNING: %s", err.Error())
+		return false
+	}
+	t.record.SaveProposerDuties(epoch, duties)
+	return true
+}
+
+// Get the validators belonging to the node's minipools that were active during the given epoch, keyed by validator index
+func (t *trackValidatorPerformance) getActiveValidators(state *state.NetworkState, nodeAddress common.Address, epoch uint64) map[string]common.Address {
+	validators := map[string]common.Address{}
+	for _, mpd := range state.MinipoolDetailsByNode[nodeAddress] {
+		status, exists := state.ValidatorDetails[mpd.Pubkey]
+		if !exists || !status.Exists {
+			continue
+		}
+		if status.ActivationEpoch <= epoch && epoch < status.ExitEpoch {
+			validators[status.Index] = mpd.MinipoolAddress
+		}
+	}
+	return validators
+}
@@ -0,0 +1,167 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/urfave/cli"
+	"github.com/wealdtech/go-ens/v3"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/graffiti"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Time to wait between lookups of the node's ENS name
+const ensNameCacheTime time.Duration = time.Hour
+
+// Update graffiti task
+type updateGraffiti struct {
+	c    *cli.Context
+	log  log.ColorLogger
+	cfg  *config.RocketPoolConfig
+	w    *wallet.Wallet
+	rp   *rocketpool.RocketPool
+	tmpl *graffiti.Template
+
+	ensName       string
+	ensLookupTime time.Time
+}
+
+// Create update graffiti task
+func newUpdateGraffiti(c *cli.Context, logger log.ColorLogger) (*updateGraffiti, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Parse the template
+	tmpl, err := graffiti.NewTemplate(cfg.GraffitiTemplate.Template.Value.(string))
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &updateGraffiti{
+		c:    c,
+		log:  logger,
+		cfg:  cfg,
+		w:    w,
+		rp:   rp,
+		tmpl: tmpl,
+	}, nil
+
+}
+
+// Render the graffiti template for each validator and update the graffiti file
+func (t *updateGraffiti) run(state *state.NetworkState) error {
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+
+	// Get the values that are shared by every validator
+	minipools := state.MinipoolDetailsByNode[nodeAccount.Address]
+	data := t.cfg.GetGraffitiTemplateData(time.Now())
+	data.MinipoolCount = len(minipools)
+	data.ENSName = t.getEnsName(nodeAccount.Address)
+
+	// Render the default graffiti, which falls back to the static one if the template doesn't fit
+	defaultGraffiti, err := t.tmpl.Render(data)
+	if err != nil {
+		t.log.Printlnf("WARNING: Couldn't render the default graffiti, using the static one instead: %s", err.Error())
+		t.warnIfEnsNameTooLong(data)
+		defaultGraffiti, err = t.cfg.Graffiti()
+		if err != nil {
+			return fmt.Errorf("error getting static graffiti: %w", err)
+		}
+	}
+
+	// Render the graffiti for each validator
+	validators := []graffiti.ValidatorGraffiti{}
+	for _, mpd := range minipools {
+		validatorData := data
+		validatorData.Pubkey = graffiti.FormatPubkey(mpd.Pubkey)
+		index := ""
+		validator, exists := state.ValidatorDetails[mpd.Pubkey]
+		if exists && validator.Exists {
+			index = validator.Index
+		}
+		validatorData.ValidatorIndex = index
+
+		validatorGraffiti, err := t.tmpl.Render(validatorData)
+		if err != nil {
+			t.log.Printlnf("WARNING: Couldn't render the graffiti for validator %s, using the default one instead: %s", mpd.Pubkey.Hex(), err.Error())
+			t.warnIfEnsNameTooLong(validatorData)
+			validatorGraffiti = defaultGraffiti
+		}
+		validators = append(validators, graffiti.ValidatorGraffiti{
+			Pubkey:   mpd.Pubkey,
+			Index:    index,
+			Graffiti: validatorGraffiti,
+		})
+	}
+
+	// Write the file
+	cc, _ := t.cfg.GetSelectedConsensusClient()
+	contents, err := graffiti.FormatFile(cc, defaultGraffiti, validators)
+	if err != nil {
+		return err
+	}
+	updated, err := graffiti.WriteFile(t.cfg.Smartnode.GetGraffitiFilePath(true), contents)
+	if err != nil {
+		return err
+	}
+	if updated {
+		t.log.Printlnf("Updated the graffiti file; the default graffiti is now [%s].", defaultGraffiti)
+	}
+	return nil
+
+}
+
+// Get the node's ENS name, looking it up again once the cached one is stale
+func (t *updateGraffiti) getEnsName(address common.Address) string {
+	if time.Since(t.ensLookupTime) < ensNameCacheTime {
+		return t.ensName
+	}
+	t.ensLookupTime = time.Now()
+
+	name, err := ens.ReverseResolve(t.rp.Client, address)
+	if err != nil {
+		// Nodes without a reverse record are normal, so this isn't worth logging
+		t.ensName = ""
+		return ""
+	}
+	t.ensName = name
+	return name
+}
+
+// The settings can't know the node's ENS name when they check the template, so explain when it's the reason the
+// template doesn't fit
+func (t *updateGraffiti) warnIfEnsNameTooLong(data graffiti.TemplateData) {
+	ensName := data.ENSName
+	if ensName == "" {
+		return
+	}
+	data.ENSName = ""
+	if _, err := t.tmpl.Render(data); err == nil {
+		t.log.Printlnf("WARNING: The graffiti template only fits without your node's ENS name [%s]; use a shorter template to include it.", ensName)
+	}
+}
@@ -24,6 +24,7 @@ import (
 )
 
 type challenge struct {
+	proposal        *protocol.ProtocolDaoProposalDetails
 	proposalID      uint64
 	challengedIndex uint64
 	challengedNode  types.VotingTreeNode
@@ -51,12 +52,14 @@ type verifyPdaoProps struct {
 	lastScannedBlock    *big.Int
 	validPropCache      map[uint64]bool
 	rootSubmissionCache map[uint64]map[uint64]*protocol.RootSubmitted
+	record              *proposals.ChallengeRecord
+	recordPath          string
 
 	// Smartnode parameters
 	intervalSize *big.Int
 }
 
-func newVerifyPdaoProps(c *cli.Context, logger log.ColorLogger) (*verifyPdaoProps, error) {
+func newVerifyPdaoProps(c *cli.Context, logger log.ColorLogger, record *proposals.ChallengeRecord) (*verifyPdaoProps, error) {
 	// Get services
 	cfg, err := services.GetConfig(c)
 	if err != nil {
@@ -125,6 +128,8 @@ func newVerifyPdaoProps(c *cli.Context, logger log.ColorLogger) (*verifyPdaoProp
 		lastScannedBlock:    nil,
 		validPropCache:      map[uint64]bool{},
 		rootSubmissionCache: map[uint64]map[uint64]*protocol.RootSubmitted{},
+		record:              record,
+		recordPath:          cfg.Smartnode.GetChallengeRecordPath(),
 
 		intervalSize: intervalSize,
 	}, nil
@@ -163,7 +168,7 @@ func (t *verifyPdaoProps) run(state *state.NetworkState) error {
 	}
 
 	t.lastScannedBlock = big.NewInt(int64(state.ElBlockNumber))
-	return nil
+	return t.record.Save(t.recordPath)
 }
 
 func (t *verifyPdaoProps) getChallengesandDefeats(state *state.NetworkState, opts *bind.CallOpts) ([]challenge, []defeat, error) {
@@ -208,8 +213,10 @@ func (t *verifyPdaoProps) getChallengesandDefeats(state *state.NetworkState, opt
 		if propRoot.Sum.Cmp(localRoot.Sum) == 0 && propRoot.Hash == localRoot.Hash {
 			t.log.Printlnf("Proposal %d matches the local tree artifacts, so it does not need to be challenged.", prop.ID)
 			t.validPropCache[prop.ID] = true
+			t.record.SetRootCheck(&prop, t.nodeAddress, true)
 			continue
 		}
+		t.record.SetRootCheck(&prop, t.nodeAddress, false)
 
 		// This proposal has a mismatch and must be challenged
 		t.log.Printlnf("Proposal %d does not match the local tree artifacts and must be challenged.", prop.ID)
@@ -270,6 +277,7 @@ func (t *verifyPdaoProps) getChallengesandDefeats(state *state.NetworkState, opt
 		}
 		eventsForProp[rootIndex] = &event
 		t.rootSubmissionCache[propID] = eventsForProp
+		t.record.AddRootSubmission(propMap[propID], t.nodeAddress, &event)
 	}
 
 	// For each proposal, crawl down the tree looking at mismatched indices to challenge until arriving at one that hasn't been challenged yet
@@ -324,6 +332,7 @@ func (t *verifyPdaoProps) getChallengeOrDefeatForProposal(prop protocol.Protocol
 		case types.ChallengeState_Unchallenged:
 			// If it's unchallenged, this is the index to challenge
 			return &challenge{
+				proposal:        &prop,
 				proposalID:      prop.ID,
 				challengedIndex: newChallengedIndex,
 				challengedNode:  challengedNode,
@@ -400,6 +409,7 @@ func (t *verifyPdaoProps) submitChallenge(challenge challenge) error {
 
 	// Log
 	t.log.Println("Successfully submitted challenge.")
+	t.record.AddChallenge(challenge.proposal, t.nodeAddress, challengedIndex, t.nodeAddress, time.Now(), types.ChallengeState_Challenged, hash)
 
 	// Return
 	return nil
@@ -456,6 +466,7 @@ func (t *verifyPdaoProps) submitDefeat(defeat defeat) error {
 
 	// Log
 	t.log.Println("Successfully defeated proposal.")
+	t.record.SetDefeated(propID, challengedIndex, hash)
 
 	// Return
 	return nil
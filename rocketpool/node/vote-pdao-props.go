@@ -0,0 +1,408 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/urfave/cli"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/api"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Vote on pDAO proposals task
+type votePdaoProps struct {
+	c              *cli.Context
+	log            log.ColorLogger
+	cfg            *config.RocketPoolConfig
+	w              *wallet.Wallet
+	rp             *rocketpool.RocketPool
+	gasPolicy      *rpgas.AutoTxPolicy
+	maxFee         *big.Int
+	maxPriorityFee *big.Int
+	gasLimit       uint64
+	nodeAddress    common.Address
+	propMgr        *proposals.ProposalManager
+	policyPath     string
+	recordPath     string
+	confirmPath    string
+}
+
+// Create vote on pDAO proposals task
+func newVotePdaoProps(c *cli.Context, logger log.ColorLogger) (*votePdaoProps, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+	bc, err := services.GetBeaconClient(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the user-requested max fee
+	maxFeeGwei := cfg.Smartnode.ManualMaxFee.Value.(float64)
+	var maxFee *big.Int
+	if maxFeeGwei == 0 {
+		maxFee = nil
+	} else {
+		maxFee = eth.GweiToWei(maxFeeGwei)
+	}
+
+	// Get the user-requested priority fee
+	priorityFeeGwei := cfg.Smartnode.PriorityFee.Value.(float64)
+	var priorityFee *big.Int
+	if priorityFeeGwei == 0 {
+		logger.Println("WARNING: priority fee was missing or 0, setting a default of 2.")
+		priorityFee = eth.GweiToWei(2)
+	} else {
+		priorityFee = eth.GweiToWei(priorityFeeGwei)
+	}
+
+	// Get the node account
+	account, err := w.GetNodeAccount()
+	if err != nil {
+		return nil, fmt.Errorf("error getting node account: %w", err)
+	}
+
+	// Make a proposal manager
+	propMgr, err := proposals.NewProposalManager(&logger, cfg, rp, bc)
+	if err != nil {
+		return nil, fmt.Errorf("error creating proposal manager: %w", err)
+	}
+
+	// Get the gas policy shared by the automatic transactions
+	gasPolicy, err := services.GetAutoTxPolicy(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &votePdaoProps{
+		c:              c,
+		log:            logger,
+		cfg:            cfg,
+		w:              w,
+		rp:             rp,
+		gasPolicy:      gasPolicy,
+		maxFee:         maxFee,
+		maxPriorityFee: priorityFee,
+		gasLimit:       0,
+		nodeAddress:    account.Address,
+		propMgr:        propMgr,
+		policyPath:     cfg.Smartnode.GetPdaoVotePolicyPath(),
+		recordPath:     cfg.Smartnode.GetPdaoVotePolicyRecordPath(),
+		confirmPath:    cfg.Smartnode.GetPdaoVotePolicyConfirmationPath(),
+	}, nil
+
+}
+
+// Vote on pDAO proposals according to the local voting policy
+func (t *votePdaoProps) run(state *state.NetworkState) error {
+
+	// Get the active proposals
+	activeProps := []protocol.ProtocolDaoProposalDetails{}
+	for _, prop := range state.ProtocolDaoProposalDetails {
+		if prop.State == types.ProtocolDaoProposalState_ActivePhase1 || prop.State == types.ProtocolDaoProposalState_ActivePhase2 {
+			activeProps = append(activeProps, prop)
+		}
+	}
+	if len(activeProps) == 0 {
+		return nil
+	}
+
+	// Log
+	t.log.Println("Checking the voting policy for Protocol DAO proposals...")
+
+	// Load the policy and its record
+	policy, policyHash, err := proposals.LoadVotePolicy(t.policyPath)
+	if errors.Is(err, os.ErrNotExist) {
+		t.log.Printlnf("The voting policy is enabled but %s doesn't exist, so no votes will be cast.", t.policyPath)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	record, err := proposals.LoadVotePolicyRecord(t.recordPath)
+	if err != nil {
+		return err
+	}
+	confirmation, err := proposals.LoadVotePolicyConfirmation(t.confirmPath)
+	if err != nil {
+		return err
+	}
+	dryRun := policy.DryRun || !confirmation.IsConfirmed(policyHash)
+	if dryRun {
+		t.log.Println("The voting policy is in dry-run mode; votes will be recorded but not cast. Review them with `rocketpool pdao vote-policy status` and enable voting with `rocketpool pdao vote-policy confirm`.")
+	} else if t.gasPolicy.GetBaseThreshold() == 0 {
+		t.log.Println("Automatic transactions are disabled because the automatic tx gas threshold is 0; votes will be recorded but not cast.")
+		dryRun = true
+	}
+
+	// Get the latest state
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+
+	// Evaluate each proposal
+	for _, prop := range activeProps {
+		if existing, exists := record.GetDecision(prop.ID); exists && existing.Status == proposals.VotePolicyStatus_Voted {
+			continue
+		}
+		decision, err := t.processProposal(prop, policy, dryRun, opts)
+		if err != nil {
+			t.log.Printlnf("WARNING: error processing proposal %d with the voting policy: %s", prop.ID, err.Error())
+			continue
+		}
+		decision.PolicyHash = policyHash
+		record.SetDecision(decision)
+	}
+
+	// Save the record
+	return record.Save(t.recordPath)
+
+}
+
+// Decide how to vote on a proposal and cast the vote if it's due
+func (t *votePdaoProps) processProposal(prop protocol.ProtocolDaoProposalDetails, policy *proposals.VotePolicy, dryRun bool, opts *bind.CallOpts) (*proposals.VotePolicyDecision, error) {
+
+	// Get the phase window
+	phaseStart := prop.VotingStartTime
+	phaseEnd := prop.Phase1EndTime
+	if prop.State == types.ProtocolDaoProposalState_ActivePhase2 {
+		phaseStart = prop.Phase1EndTime
+		phaseEnd = prop.Phase2EndTime
+	}
+	decision := &proposals.VotePolicyDecision{
+		ProposalID:  prop.ID,
+		State:       prop.State,
+		Deadline:    phaseEnd,
+		UpdatedTime: time.Now(),
+	}
+
+	// Check if the node has already voted
+	nodeDirection, err := protocol.GetAddressVoteDirection(t.rp, prop.ID, t.nodeAddress, opts)
+	if err != nil {
+		return nil, fmt.Errorf("error getting the node's vote: %w", err)
+	}
+	if nodeDirection != types.VoteDirection_NoVote {
+		decision.Direction = nodeDirection
+		decision.Status = proposals.VotePolicyStatus_Voted
+		decision.Reason = "the node has voted on this proposal"
+		return decision, nil
+	}
+
+	// Find the matching rule
+	info, err := proposals.GetProposalPayloadInfo(t.rp, prop.Payload)
+	if err != nil {
+		return nil, err
+	}
+	decision.Type = info.Type
+	rule := policy.Evaluate(prop.ProposerAddress, info)
+	if rule == nil {
+		decision.Status = proposals.VotePolicyStatus_NoMatch
+		decision.Reason = "no rule matches this proposal"
+		return decision, nil
+	}
+	decision.Rule = rule.Name
+	decision.Action = rule.Action
+	if rule.Action == proposals.VotePolicyAction_Skip {
+		decision.Status = proposals.VotePolicyStatus_Skipped
+		decision.Reason = fmt.Sprintf("rule %s skips this proposal", rule.Name)
+		return decision, nil
+	}
+
+	// Get the vote direction
+	castBy := phaseEnd.Add(-policy.GetLeadTime())
+	direction := rule.Action.GetVoteDirection()
+	if rule.Action == proposals.VotePolicyAction_FollowDelegate {
+		delegate := common.HexToAddress(rule.Delegate)
+		direction, err = protocol.GetAddressVoteDirection(t.rp, prop.ID, delegate, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the vote of delegate %s: %w", delegate.Hex(), err)
+		}
+		if direction == types.VoteDirection_NoVote {
+			if time.Now().Before(castBy) {
+				decision.Status = proposals.VotePolicyStatus_WaitingForDelegate
+				decision.Reason = fmt.Sprintf("waiting for %s to vote until %s", delegate.Hex(), castBy.Format(time.RFC822))
+			} else {
+				decision.Status = proposals.VotePolicyStatus_DelegateMissed
+				decision.Reason = fmt.Sprintf("%s did not vote before %s", delegate.Hex(), castBy.Format(time.RFC822))
+			}
+			return decision, nil
+		}
+	}
+	decision.Direction = direction
+
+	// Make sure the node can vote in this phase
+	var votingPower *big.Int
+	var nodeIndex uint64
+	var proof []types.VotingTreeNode
+	if prop.State == types.ProtocolDaoProposalState_ActivePhase1 {
+		votingPower, nodeIndex, proof, err = t.propMgr.GetArtifactsForVoting(prop.TargetBlock, t.nodeAddress)
+		if err != nil {
+			return nil, fmt.Errorf("error getting voting artifacts: %w", err)
+		}
+		if votingPower.Cmp(common.Big0) == 0 {
+			decision.Status = proposals.VotePolicyStatus_NoVotingPower
+			decision.Reason = "the node has no delegated voting power in phase 1; it can override its delegate's vote in phase 2"
+			return decision, nil
+		}
+	} else {
+		delegate, err := network.GetVotingDelegate(t.rp, t.nodeAddress, prop.TargetBlock, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the node's voting delegate: %w", err)
+		}
+		if delegate != t.nodeAddress {
+			delegateDirection, err := protocol.GetAddressVoteDirection(t.rp, prop.ID, delegate, opts)
+			if err != nil {
+				return nil, fmt.Errorf("error getting the vote of delegate %s: %w", delegate.Hex(), err)
+			}
+			if delegateDirection == direction {
+				decision.Status = proposals.VotePolicyStatus_RepresentedByDelegate
+				decision.Reason = fmt.Sprintf("the node's delegate %s already voted '%s'", delegate.Hex(), types.VoteDirections[direction])
+				return decision, nil
+			}
+		}
+		votingPower, err = network.GetVotingPower(t.rp, t.nodeAddress, prop.TargetBlock, opts)
+		if err != nil {
+			return nil, fmt.Errorf("error getting the node's voting power: %w", err)
+		}
+		if votingPower.Cmp(common.Big0) == 0 {
+			decision.Status = proposals.VotePolicyStatus_NoVotingPower
+			decision.Reason = "the node had no voting power at the proposal's snapshot"
+			return decision, nil
+		}
+	}
+
+	// Stop here in dry-run mode
+	if dryRun {
+		decision.Status = proposals.VotePolicyStatus_DryRun
+		decision.Reason = fmt.Sprintf("would vote '%s' with %.6f voting power", types.VoteDirections[direction], eth.WeiToEth(votingPower))
+		t.log.Printlnf("DRY RUN: rule %s would vote '%s' on proposal %d.", rule.Name, types.VoteDirections[direction], prop.ID)
+		return decision, nil
+	}
+
+	// Cast the vote
+	deadline := rpgas.AutoTxDeadline{
+		Action:   rpgas.AutoTxAction_Vote,
+		Subject:  fmt.Sprintf("proposal %d", prop.ID),
+		Eligible: phaseStart,
+		Deadline: castBy,
+	}
+	hash, err := t.vote(prop, direction, votingPower, nodeIndex, proof, deadline)
+	if err != nil {
+		return nil, err
+	}
+	if hash == nil {
+		decision.Status = proposals.VotePolicyStatus_Queued
+		decision.Reason = fmt.Sprintf("waiting for gas to drop before voting '%s'", types.VoteDirections[direction])
+		return decision, nil
+	}
+	decision.Status = proposals.VotePolicyStatus_Voted
+	decision.Reason = fmt.Sprintf("voted '%s' according to rule %s", types.VoteDirections[direction], rule.Name)
+	decision.TxHash = *hash
+	return decision, nil
+
+}
+
+// Submit a vote on a proposal, returning nil if it was delayed because of the gas price
+func (t *votePdaoProps) vote(prop protocol.ProtocolDaoProposalDetails, direction types.VoteDirection, votingPower *big.Int, nodeIndex uint64, proof []types.VotingTreeNode, deadline rpgas.AutoTxDeadline) (*common.Hash, error) {
+
+	// Log
+	isOverride := (prop.State == types.ProtocolDaoProposalState_ActivePhase2)
+	t.log.Printlnf("Voting '%s' on proposal %d...", types.VoteDirections[direction], prop.ID)
+
+	// Get transactor
+	opts, err := t.w.GetNodeAccountTransactor()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the gas limit
+	var gasInfo rocketpool.GasInfo
+	if isOverride {
+		gasInfo, err = protocol.EstimateOverrideVoteGas(t.rp, prop.ID, direction, opts)
+	} else {
+		gasInfo, err = protocol.EstimateVoteOnProposalGas(t.rp, prop.ID, direction, votingPower, nodeIndex, proof, opts)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Could not estimate the gas required to vote on proposal %d: %w", prop.ID, err)
+	}
+	var gas *big.Int
+	if t.gasLimit != 0 {
+		gas = new(big.Int).SetUint64(t.gasLimit)
+	} else {
+		gas = new(big.Int).SetUint64(gasInfo.SafeGasLimit)
+	}
+
+	// Get the max fee
+	maxFee := t.maxFee
+	if maxFee == nil || maxFee.Uint64() == 0 {
+		maxFee, err = rpgas.GetHeadlessMaxFeeWei()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Print the gas info
+	threshold, stage := t.gasPolicy.GetThreshold(deadline, time.Now())
+	if !api.PrintAndCheckGasInfo(gasInfo, true, threshold, &t.log, maxFee, t.gasLimit) {
+		t.gasPolicy.RecordDelay(deadline, maxFee, threshold, stage, &t.log)
+		return nil, nil
+	}
+
+	opts.GasFeeCap = maxFee
+	opts.GasTipCap = t.maxPriorityFee
+	opts.GasLimit = gas.Uint64()
+
+	// Vote
+	var hash common.Hash
+	if isOverride {
+		hash, err = protocol.OverrideVote(t.rp, prop.ID, direction, opts)
+	} else {
+		hash, err = protocol.VoteOnProposal(t.rp, prop.ID, direction, votingPower, nodeIndex, proof, opts)
+	}
+	if err != nil {
+		return nil, err
+	}
+	t.gasPolicy.ClearDelay(deadline, &t.log)
+
+	// Print TX info and wait for it to be included in a block
+	err = api.PrintAndWaitForTransaction(t.cfg, hash, t.rp.Client, &t.log)
+	if err != nil {
+		return nil, err
+	}
+
+	// Log
+	t.log.Printlnf("Successfully voted '%s' on proposal %d.", types.VoteDirections[direction], prop.ID)
+
+	// Return
+	return &hash, nil
+
+}
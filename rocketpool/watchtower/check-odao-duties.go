@@ -0,0 +1,272 @@
+package watchtower
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/rocket-pool/rocketpool-go/network"
+	"github.com/rocket-pool/rocketpool-go/rewards"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/urfave/cli"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rocket-pool/smartnode/shared/services"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/duties"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// Check Oracle DAO duties task
+type checkOdaoDuties struct {
+	c      *cli.Context
+	log    log.ColorLogger
+	cfg    *config.RocketPoolConfig
+	w      *wallet.Wallet
+	rp     *rocketpool.RocketPool
+	record *duties.DutyRecord
+}
+
+// Create check Oracle DAO duties task
+func newCheckOdaoDuties(c *cli.Context, logger log.ColorLogger, record *duties.DutyRecord) (*checkOdaoDuties, error) {
+
+	// Get services
+	cfg, err := services.GetConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	w, err := services.GetWallet(c)
+	if err != nil {
+		return nil, err
+	}
+	rp, err := services.GetRocketPool(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return task
+	return &checkOdaoDuties{
+		c:      c,
+		log:    logger,
+		cfg:    cfg,
+		w:      w,
+		rp:     rp,
+		record: record,
+	}, nil
+
+}
+
+// Compare the node's duty submissions against the consensus the Oracle DAO reached
+func (t *checkOdaoDuties) run(state *state.NetworkState) error {
+
+	// Log
+	t.log.Println("Checking Oracle DAO duty consensus...")
+
+	// Get node account
+	nodeAccount, err := t.w.GetNodeAccount()
+	if err != nil {
+		return err
+	}
+	opts := &bind.CallOpts{
+		BlockNumber: big.NewInt(0).SetUint64(state.ElBlockNumber),
+	}
+
+	// Resolve each duty
+	if err := t.checkBalances(nodeAccount.Address, state, opts); err != nil {
+		return fmt.Errorf("error checking network balance consensus: %w", err)
+	}
+	if err := t.checkPrices(nodeAccount.Address, state, opts); err != nil {
+		return fmt.Errorf("error checking RPL price consensus: %w", err)
+	}
+	if err := t.checkRewardsTrees(nodeAccount.Address, state, opts); err != nil {
+		return fmt.Errorf("error checking rewards tree consensus: %w", err)
+	}
+	t.checkScrubs(state)
+
+	// Save the record
+	t.record.Prune(time.Now())
+	if err := t.record.Save(t.cfg.Smartnode.GetOdaoDutyRecordPath()); err != nil {
+		return fmt.Errorf("error saving Oracle DAO duty record: %w", err)
+	}
+	return nil
+
+}
+
+// Resolve the network balance rounds
+func (t *checkOdaoDuties) checkBalances(nodeAddress common.Address, state *state.NetworkState, opts *bind.CallOpts) error {
+
+	// Rounds before the latest consensus block were superseded
+	consensusBlock := state.NetworkDetails.BalancesBlock.Uint64()
+	t.resolveSupersededRounds(duties.DutyType_Balances, consensusBlock)
+	round := strconv.FormatUint(consensusBlock, 10)
+	if consensusBlock == 0 || t.record.IsResolved(duties.DutyType_Balances, round) {
+		return nil
+	}
+
+	// Get the submission status and the balances the Oracle DAO agreed on
+	var submitted bool
+	var totalEth, stakingEth, rethSupply *big.Int
+	var wg errgroup.Group
+	wg.Go(func() error {
+		blockNumberBuf := make([]byte, 32)
+		big.NewInt(0).SetUint64(consensusBlock).FillBytes(blockNumberBuf)
+		var err error
+		submitted, err = t.rp.RocketStorage.GetBool(opts, crypto.Keccak256Hash([]byte(networkBalanceSubmissionKey), nodeAddress.Bytes(), blockNumberBuf))
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		totalEth, err = network.GetTotalETHBalance(t.rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		stakingEth, err = network.GetStakingETHBalance(t.rp, opts)
+		return err
+	})
+	wg.Go(func() error {
+		var err error
+		rethSupply, err = network.GetTotalRETHSupply(t.rp, opts)
+		return err
+	})
+	if err := wg.Wait(); err != nil {
+		return err
+	}
+
+	result := t.record.Resolve(duties.DutyType_Balances, round, consensusBlock, submitted, true, map[string]string{
+		duties.BalancesValue_TotalEth:   totalEth.String(),
+		duties.BalancesValue_StakingEth: stakingEth.String(),
+		duties.BalancesValue_RethSupply: rethSupply.String(),
+	})
+	t.printResult(duties.DutyType_Balances, round, result)
+	return nil
+
+}
+
+// Resolve the RPL price rounds
+func (t *checkOdaoDuties) checkPrices(nodeAddress common.Address, state *state.NetworkState, opts *bind.CallOpts) error {
+
+	// Rounds before the latest consensus block were superseded
+	consensusBlock := state.NetworkDetails.PricesBlock
+	t.resolveSupersededRounds(duties.DutyType_Prices, consensusBlock)
+	round := strconv.FormatUint(consensusBlock, 10)
+	if consensusBlock == 0 || t.record.IsResolved(duties.DutyType_Prices, round) {
+		return nil
+	}
+
+	// The submission key includes the slot timestamp, so it can only be checked if the round was recorded locally
+	submitted := false
+	for _, dutyRound := range t.record.GetUnresolvedRounds(duties.DutyType_Prices) {
+		if dutyRound.Round != round {
+			continue
+		}
+		slotTimestamp, err := strconv.ParseUint(dutyRound.SubmittedValues[duties.PricesValue_SlotTimestamp], 10, 64)
+		if err != nil {
+			break
+		}
+		blockNumberBuf := make([]byte, 32)
+		big.NewInt(0).SetUint64(consensusBlock).FillBytes(blockNumberBuf)
+		slotTimestampBuf := make([]byte, 32)
+		big.NewInt(0).SetUint64(slotTimestamp).FillBytes(slotTimestampBuf)
+		submitted, err = t.rp.RocketStorage.GetBool(opts, crypto.Keccak256Hash([]byte(SubmissionKey), nodeAddress.Bytes(), blockNumberBuf, slotTimestampBuf))
+		if err != nil {
+			return err
+		}
+	}
+
+	result := t.record.Resolve(duties.DutyType_Prices, round, consensusBlock, submitted, true, map[string]string{
+		duties.PricesValue_RplPrice: state.NetworkDetails.RplPrice.String(),
+	})
+	t.printResult(duties.DutyType_Prices, round, result)
+	return nil
+
+}
+
+// Resolve the rewards tree rounds
+func (t *checkOdaoDuties) checkRewardsTrees(nodeAddress common.Address, state *state.NetworkState, opts *bind.CallOpts) error {
+
+	// Every interval before the current one is finished
+	currentIndex := state.NetworkDetails.RewardIndex
+	if currentIndex == 0 {
+		return nil
+	}
+	indices := []uint64{currentIndex - 1}
+	for _, dutyRound := range t.record.GetUnresolvedRounds(duties.DutyType_RewardsTree) {
+		index, err := strconv.ParseUint(dutyRound.Round, 10, 64)
+		if err != nil || index >= currentIndex-1 {
+			continue
+		}
+		indices = append(indices, index)
+	}
+
+	for _, index := range indices {
+		round := strconv.FormatUint(index, 10)
+		if t.record.IsResolved(duties.DutyType_RewardsTree, round) {
+			continue
+		}
+
+		submitted, err := rewards.GetTrustedNodeSubmitted(t.rp, nodeAddress, index, opts)
+		if err != nil {
+			return fmt.Errorf("error checking if the node submitted interval %d: %w", index, err)
+		}
+		root, err := rewards.MerkleRoots(t.rp, big.NewInt(0).SetUint64(index), opts)
+		if err != nil {
+			return fmt.Errorf("error getting the Merkle root of interval %d: %w", index, err)
+		}
+		rootHash := common.BytesToHash(root)
+
+		result := t.record.Resolve(duties.DutyType_RewardsTree, round, 0, submitted, rootHash != (common.Hash{}), map[string]string{
+			duties.RewardsTreeValue_MerkleRoot: rootHash.Hex(),
+		})
+		t.printResult(duties.DutyType_RewardsTree, round, result)
+	}
+	return nil
+
+}
+
+// Resolve the scrub vote rounds
+func (t *checkOdaoDuties) checkScrubs(state *state.NetworkState) {
+
+	for _, dutyRound := range t.record.GetUnresolvedRounds(duties.DutyType_Scrub) {
+		// A prelaunch minipool can only disappear by being dissolved and closed
+		vote := duties.ScrubVote_Scrub
+		mpd, exists := state.MinipoolDetailsByAddress[common.HexToAddress(dutyRound.Round)]
+		if exists {
+			switch mpd.Status {
+			case types.Dissolved:
+			case types.Staking, types.Withdrawable:
+				vote = duties.ScrubVote_Stake
+			default:
+				continue
+			}
+		}
+
+		result := t.record.Resolve(duties.DutyType_Scrub, dutyRound.Round, dutyRound.Block, false, true, map[string]string{
+			duties.ScrubValue_Vote: vote,
+		})
+		t.printResult(duties.DutyType_Scrub, dutyRound.Round, result)
+	}
+
+}
+
+// Resolve the unresolved rounds of a duty that were overtaken by a later consensus block
+func (t *checkOdaoDuties) resolveSupersededRounds(dutyType duties.DutyType, consensusBlock uint64) {
+	for _, dutyRound := range t.record.GetUnresolvedRounds(dutyType) {
+		if dutyRound.Block < consensusBlock {
+			result := t.record.Resolve(dutyType, dutyRound.Round, dutyRound.Block, false, false, nil)
+			t.printResult(dutyType, dutyRound.Round, result)
+		}
+	}
+}
+
+// Log the result of a round
+func (t *checkOdaoDuties) printResult(dutyType duties.DutyType, round string, result duties.DutyResult) {
+	t.log.Printlnf("Resolved %s round %s: %s", dutyType, round, result)
+}
@@ -0,0 +1,97 @@
+package collectors
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/rocket-pool/smartnode/shared/services/duties"
+)
+
+// Represents the collector for the Oracle DAO duty metrics
+type OdaoDutyCollector struct {
+
+	// The number of rounds of each duty by result
+	roundsDesc *prometheus.Desc
+
+	// The number of rounds of each duty the node submitted to
+	submittedDesc *prometheus.Desc
+
+	// The number of failed submission attempts for each duty
+	failuresDesc *prometheus.Desc
+
+	// The time of the node's latest submission for each duty
+	lastSubmissionTimeDesc *prometheus.Desc
+
+	// The result of the latest resolved round of each duty
+	lastResultDesc *prometheus.Desc
+
+	// The duty record
+	record *duties.DutyRecord
+}
+
+// Create a new OdaoDutyCollector instance
+func NewOdaoDutyCollector(record *duties.DutyRecord) *OdaoDutyCollector {
+	subsystem := "odao_duties"
+	return &OdaoDutyCollector{
+		roundsDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "rounds"),
+			"The number of rounds of each duty by result",
+			[]string{"duty", "result"}, nil,
+		),
+		submittedDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "submitted"),
+			"The number of rounds of each duty the node submitted to",
+			[]string{"duty"}, nil,
+		),
+		failuresDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "failures"),
+			"The number of failed submission attempts for each duty",
+			[]string{"duty"}, nil,
+		),
+		lastSubmissionTimeDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_submission_time"),
+			"The time of the node's latest submission for each duty",
+			[]string{"duty"}, nil,
+		),
+		lastResultDesc: prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, "last_result"),
+			"The result of the latest resolved round of each duty (1 for the result it had)",
+			[]string{"duty", "result"}, nil,
+		),
+		record: record,
+	}
+}
+
+// Write metric descriptions to the Prometheus channel
+func (collector *OdaoDutyCollector) Describe(channel chan<- *prometheus.Desc) {
+	channel <- collector.roundsDesc
+	channel <- collector.submittedDesc
+	channel <- collector.failuresDesc
+	channel <- collector.lastSubmissionTimeDesc
+	channel <- collector.lastResultDesc
+}
+
+// Collect the latest metric values and pass them to Prometheus
+func (collector *OdaoDutyCollector) Collect(channel chan<- prometheus.Metric) {
+
+	for _, summary := range collector.record.GetSummaries() {
+		duty := string(summary.Type)
+		for _, result := range duties.DutyResults {
+			channel <- prometheus.MustNewConstMetric(
+				collector.roundsDesc, prometheus.GaugeValue, float64(summary.Results[result]), duty, string(result))
+
+			lastResult := float64(0)
+			if summary.LastResult == result {
+				lastResult = 1
+			}
+			channel <- prometheus.MustNewConstMetric(
+				collector.lastResultDesc, prometheus.GaugeValue, lastResult, duty, string(result))
+		}
+		channel <- prometheus.MustNewConstMetric(
+			collector.submittedDesc, prometheus.GaugeValue, float64(summary.Submitted), duty)
+		channel <- prometheus.MustNewConstMetric(
+			collector.failuresDesc, prometheus.GaugeValue, float64(summary.Failures), duty)
+
+		lastSubmissionTime := float64(0)
+		if !summary.LastSubmissionTime.IsZero() {
+			lastSubmissionTime = float64(summary.LastSubmissionTime.Unix())
+		}
+		channel <- prometheus.MustNewConstMetric(
+			collector.lastSubmissionTimeDesc, prometheus.GaugeValue, lastSubmissionTime, duty)
+	}
+
+}
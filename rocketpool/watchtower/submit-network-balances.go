@@ -22,6 +22,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/duties"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
@@ -44,6 +45,7 @@ type submitNetworkBalances struct {
 	ec        rocketpool.ExecutionClient
 	rp        *rocketpool.RocketPool
 	bc        beacon.Client
+	record    *duties.DutyRecord
 	lock      *sync.Mutex
 	isRunning bool
 }
@@ -67,7 +69,7 @@ type minipoolBalanceDetails struct {
 }
 
 // Create submit network balances task
-func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*submitNetworkBalances, error) {
+func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, record *duties.DutyRecord) (*submitNetworkBalances, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -102,6 +104,7 @@ func newSubmitNetworkBalances(c *cli.Context, logger log.ColorLogger, errorLogge
 		ec:        ec,
 		rp:        rp,
 		bc:        bc,
+		record:    record,
 		lock:      lock,
 		isRunning: false,
 	}, nil
@@ -219,6 +222,7 @@ func (t *submitNetworkBalances) run(state *state.NetworkState) error {
 
 		// Submit balances
 		if err := t.submitBalances(balances); err != nil {
+			t.record.AddFailure(duties.DutyType_Balances, fmt.Sprint(targetBlockNumber), targetBlockNumber, err)
 			t.handleError(fmt.Errorf("%s could not submit network balances: %w", logPrefix, err))
 			return
 		}
@@ -543,6 +547,13 @@ func (t *submitNetworkBalances) submitBalances(balances networkBalances) error {
 	// Log
 	t.log.Printlnf("Successfully submitted network balances for block %d.", balances.Block)
 
+	// Record the submission
+	t.record.AddSubmission(duties.DutyType_Balances, fmt.Sprint(balances.Block), balances.Block, map[string]string{
+		duties.BalancesValue_TotalEth:   totalEth.String(),
+		duties.BalancesValue_StakingEth: balances.MinipoolsStaking.String(),
+		duties.BalancesValue_RethSupply: balances.RETHSupply.String(),
+	}, hash)
+
 	// Return
 	return nil
 
@@ -22,6 +22,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/duties"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
@@ -44,6 +45,7 @@ type submitRewardsTree_Rolling struct {
 	bc          beacon.Client
 	genesisTime time.Time
 	recordMgr   *rprewards.RollingRecordManager
+	record      *duties.DutyRecord
 	stateMgr    *state.NetworkStateManager
 	logPrefix   string
 
@@ -52,7 +54,7 @@ type submitRewardsTree_Rolling struct {
 }
 
 // Create submit rewards tree with rolling record support
-func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, stateMgr *state.NetworkStateManager) (*submitRewardsTree_Rolling, error) {
+func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, stateMgr *state.NetworkStateManager, record *duties.DutyRecord) (*submitRewardsTree_Rolling, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -126,6 +128,7 @@ func newSubmitRewardsTree_Rolling(c *cli.Context, logger log.ColorLogger, errorL
 		rp:          rp,
 		bc:          bc,
 		stateMgr:    stateMgr,
+		record:      record,
 		genesisTime: genesisTime,
 		logPrefix:   logPrefix,
 		lock:        lock,
@@ -508,6 +511,7 @@ func (t *submitRewardsTree_Rolling) runRewardsIntervalReport(client *rocketpool.
 		// Submit to the contracts
 		err = t.submitRewardsSnapshot(currentIndexBig, snapshotBeaconBlock, elBlockIndex, existingRewardsFile.Impl().GetHeader(), cid.String(), big.NewInt(int64(intervalsPassed)))
 		if err != nil {
+			t.record.AddFailure(duties.DutyType_RewardsTree, fmt.Sprint(currentIndex), elBlockIndex, err)
 			return fmt.Errorf("error submitting rewards snapshot: %w", err)
 		}
 
@@ -590,6 +594,7 @@ func (t *submitRewardsTree_Rolling) generateTree(rp *rocketpool.RocketPool, stat
 		// Submit to the contracts
 		err = t.submitRewardsSnapshot(big.NewInt(int64(currentIndex)), snapshotBeaconBlock, elBlockIndex, rewardsFile.GetHeader(), cid.String(), big.NewInt(int64(intervalsPassed)))
 		if err != nil {
+			t.record.AddFailure(duties.DutyType_RewardsTree, fmt.Sprint(currentIndex), elBlockIndex, err)
 			return fmt.Errorf("Error submitting rewards snapshot: %w", err)
 		}
 
@@ -689,6 +694,12 @@ func (t *submitRewardsTree_Rolling) submitRewardsSnapshot(index *big.Int, consen
 		return err
 	}
 
+	// Record the submission
+	t.record.AddSubmission(duties.DutyType_RewardsTree, index.String(), executionBlock, map[string]string{
+		duties.RewardsTreeValue_MerkleRoot: treeRoot.Hex(),
+		duties.RewardsTreeValue_Cid:        cid,
+	}, hash)
+
 	// Return
 	return nil
 }
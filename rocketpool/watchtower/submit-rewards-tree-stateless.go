@@ -23,6 +23,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/duties"
 	rprewards "github.com/rocket-pool/smartnode/shared/services/rewards"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
@@ -44,6 +45,7 @@ type submitRewardsTree_Stateless struct {
 	rp               *rocketpool.RocketPool
 	ec               rocketpool.ExecutionClient
 	bc               beacon.Client
+	record           *duties.DutyRecord
 	lock             *sync.Mutex
 	isRunning        bool
 	generationPrefix string
@@ -51,7 +53,7 @@ type submitRewardsTree_Stateless struct {
 }
 
 // Create submit rewards Merkle Tree task
-func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager) (*submitRewardsTree_Stateless, error) {
+func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, m *state.NetworkStateManager, record *duties.DutyRecord) (*submitRewardsTree_Stateless, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -85,6 +87,7 @@ func newSubmitRewardsTree_Stateless(c *cli.Context, logger log.ColorLogger, erro
 		bc:               bc,
 		w:                w,
 		rp:               rp,
+		record:           record,
 		lock:             lock,
 		isRunning:        false,
 		generationPrefix: "[Merkle Tree]",
@@ -223,6 +226,7 @@ func (t *submitRewardsTree_Stateless) Run(nodeTrusted bool, state *state.Network
 		// Submit to the contracts
 		err = t.submitRewardsSnapshot(currentIndexBig, snapshotBeaconBlock, elBlockIndex, proofWrapper.GetHeader(), cid.String(), big.NewInt(int64(intervalsPassed)))
 		if err != nil {
+			t.record.AddFailure(duties.DutyType_RewardsTree, fmt.Sprint(currentIndex), elBlockIndex, err)
 			return fmt.Errorf("Error submitting rewards snapshot: %w", err)
 		}
 
@@ -389,6 +393,7 @@ func (t *submitRewardsTree_Stateless) generateTreeImpl(rp *rocketpool.RocketPool
 		// Submit to the contracts
 		err = t.submitRewardsSnapshot(big.NewInt(int64(currentIndex)), snapshotBeaconBlock, elBlockIndex, rewardsFile.GetHeader(), cid.String(), big.NewInt(int64(intervalsPassed)))
 		if err != nil {
+			t.record.AddFailure(duties.DutyType_RewardsTree, fmt.Sprint(currentIndex), elBlockIndex, err)
 			return fmt.Errorf("Error submitting rewards snapshot: %w", err)
 		}
 
@@ -488,6 +493,12 @@ func (t *submitRewardsTree_Stateless) submitRewardsSnapshot(index *big.Int, cons
 		return err
 	}
 
+	// Record the submission
+	t.record.AddSubmission(duties.DutyType_RewardsTree, index.String(), executionBlock, map[string]string{
+		duties.RewardsTreeValue_MerkleRoot: treeRoot.Hex(),
+		duties.RewardsTreeValue_Cid:        cid,
+	}, hash)
+
 	// Return
 	return nil
 }
@@ -24,6 +24,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/duties"
 	rpgas "github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
@@ -248,12 +249,13 @@ type submitRplPrice struct {
 	ec        rocketpool.ExecutionClient
 	rp        *rocketpool.RocketPool
 	bc        beacon.Client
+	record    *duties.DutyRecord
 	lock      *sync.Mutex
 	isRunning bool
 }
 
 // Create submit RPL price task
-func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger) (*submitRplPrice, error) {
+func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, record *duties.DutyRecord) (*submitRplPrice, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -288,6 +290,7 @@ func newSubmitRplPrice(c *cli.Context, logger log.ColorLogger, errorLogger log.C
 		w:      w,
 		rp:     rp,
 		bc:     bc,
+		record: record,
 		lock:   lock,
 	}, nil
 
@@ -441,6 +444,7 @@ func (t *submitRplPrice) run(state *state.NetworkState) error {
 
 		// Submit RPL price
 		if err := t.submitRplPrice(targetBlockNumber, submissionTimestamp, rplPrice); err != nil {
+			t.record.AddFailure(duties.DutyType_Prices, fmt.Sprint(targetBlockNumber), targetBlockNumber, err)
 			t.handleError(fmt.Errorf("%s could not submit RPL price: %w", logPrefix, err))
 			return
 		}
@@ -609,6 +613,12 @@ func (t *submitRplPrice) submitRplPrice(blockNumber uint64, slotTimestamp uint64
 	// Log
 	t.log.Printlnf("Successfully submitted RPL price for block %d.", blockNumber)
 
+	// Record the submission
+	t.record.AddSubmission(duties.DutyType_Prices, fmt.Sprint(blockNumber), blockNumber, map[string]string{
+		duties.PricesValue_RplPrice:      rplPrice.String(),
+		duties.PricesValue_SlotTimestamp: fmt.Sprint(slotTimestamp),
+	}, hash)
+
 	// Return
 	return nil
 
@@ -25,6 +25,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/duties"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	"github.com/rocket-pool/smartnode/shared/utils/api"
@@ -50,6 +51,7 @@ type submitScrubMinipools struct {
 	bc        beacon.Client
 	it        *iterationData
 	coll      *collectors.ScrubCollector
+	record    *duties.DutyRecord
 	lock      *sync.Mutex
 	isRunning bool
 }
@@ -83,7 +85,7 @@ type minipoolDetails struct {
 }
 
 // Create submit scrub minipools task
-func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, coll *collectors.ScrubCollector) (*submitScrubMinipools, error) {
+func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger log.ColorLogger, coll *collectors.ScrubCollector, record *duties.DutyRecord) (*submitScrubMinipools, error) {
 
 	// Get services
 	cfg, err := services.GetConfig(c)
@@ -119,6 +121,7 @@ func newSubmitScrubMinipools(c *cli.Context, logger log.ColorLogger, errorLogger
 		ec:        ec,
 		bc:        bc,
 		coll:      coll,
+		record:    record,
 		lock:      lock,
 		isRunning: false,
 	}, nil
@@ -316,8 +319,9 @@ func (t *submitScrubMinipools) verifyBeaconWithdrawalCredentials(state *state.Ne
 
 	// Scrub the offending minipools
 	for _, minipool := range minipoolsToScrub {
-		err := t.submitVoteScrubMinipool(minipool)
+		err := t.submitVoteScrubMinipool(minipool, duties.ScrubReason_WithdrawalCredentials)
 		if err != nil {
+			t.record.AddFailure(duties.DutyType_Scrub, minipool.GetAddress().Hex(), 0, err)
 			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
 		}
 	}
@@ -410,8 +414,9 @@ func (t *submitScrubMinipools) verifyPrestakeEvents() {
 
 	// Scrub the offending minipools
 	for _, minipool := range minipoolsToScrub {
-		err := t.submitVoteScrubMinipool(minipool)
+		err := t.submitVoteScrubMinipool(minipool, duties.ScrubReason_PrestakeSignature)
 		if err != nil {
+			t.record.AddFailure(duties.DutyType_Scrub, minipool.GetAddress().Hex(), 0, err)
 			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
 		}
 	}
@@ -488,8 +493,9 @@ func (t *submitScrubMinipools) verifyDeposits() error {
 
 	// Scrub the offending minipools
 	for _, minipool := range minipoolsToScrub {
-		err := t.submitVoteScrubMinipool(minipool)
+		err := t.submitVoteScrubMinipool(minipool, duties.ScrubReason_DepositContract)
 		if err != nil {
+			t.record.AddFailure(duties.DutyType_Scrub, minipool.GetAddress().Hex(), 0, err)
 			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
 		}
 	}
@@ -548,8 +554,9 @@ func (t *submitScrubMinipools) checkSafetyScrub(state *state.NetworkState) error
 
 	// Scrub the offending minipools
 	for _, minipool := range minipoolsToScrub {
-		err := t.submitVoteScrubMinipool(minipool)
+		err := t.submitVoteScrubMinipool(minipool, duties.ScrubReason_Safety)
 		if err != nil {
+			t.record.AddFailure(duties.DutyType_Scrub, minipool.GetAddress().Hex(), 0, err)
 			t.log.Printlnf("ALERT: Couldn't scrub minipool %s: %s", minipool.GetAddress().Hex(), err.Error())
 		}
 	}
@@ -559,7 +566,7 @@ func (t *submitScrubMinipools) checkSafetyScrub(state *state.NetworkState) error
 }
 
 // Submit minipool scrub status
-func (t *submitScrubMinipools) submitVoteScrubMinipool(mp minipool.Minipool) error {
+func (t *submitScrubMinipools) submitVoteScrubMinipool(mp minipool.Minipool, reason string) error {
 
 	// Log
 	t.log.Printlnf("Voting to scrub minipool %s...", mp.GetAddress().Hex())
@@ -602,6 +609,12 @@ func (t *submitScrubMinipools) submitVoteScrubMinipool(mp minipool.Minipool) err
 	// Log
 	t.log.Printlnf("Successfully voted to scrub the minipool %s.", mp.GetAddress().Hex())
 
+	// Record the vote
+	t.record.AddSubmission(duties.DutyType_Scrub, mp.GetAddress().Hex(), 0, map[string]string{
+		duties.ScrubValue_Vote:   duties.ScrubVote_Scrub,
+		duties.ScrubValue_Reason: reason,
+	}, hash)
+
 	// Return
 	return nil
 
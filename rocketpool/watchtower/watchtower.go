@@ -18,6 +18,7 @@ import (
 	"github.com/rocket-pool/smartnode/rocketpool/watchtower/collectors"
 	"github.com/rocket-pool/smartnode/shared/services"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/duties"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
 )
@@ -44,6 +45,7 @@ const (
 	CancelBondsColor               = color.FgGreen
 	CheckSoloMigrationsColor       = color.FgCyan
 	FinalizeProposalsColor         = color.FgMagenta
+	CheckOdaoDutiesColor           = color.FgHiBlue
 	UpdateColor                    = color.FgHiWhite
 )
 
@@ -106,6 +108,13 @@ func run(c *cli.Context) error {
 	bondReductionCollector := collectors.NewBondReductionCollector()
 	soloMigrationCollector := collectors.NewSoloMigrationCollector()
 
+	// Load the record of the node's Oracle DAO duties
+	dutyRecord, err := duties.LoadDutyRecord(cfg.Smartnode.GetOdaoDutyRecordPath())
+	if err != nil {
+		return err
+	}
+	odaoDutyCollector := collectors.NewOdaoDutyCollector(dutyRecord)
+
 	// Initialize error logger
 	errorLog := log.NewColorLogger(ErrorColor)
 	updateLog := log.NewColorLogger(UpdateColor)
@@ -127,11 +136,11 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error during respond-to-challenges check: %w", err)
 	}
-	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor), errorLog)
+	submitRplPrice, err := newSubmitRplPrice(c, log.NewColorLogger(SubmitRplPriceColor), errorLog, dutyRecord)
 	if err != nil {
 		return fmt.Errorf("error during rpl price check: %w", err)
 	}
-	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor), errorLog)
+	submitNetworkBalances, err := newSubmitNetworkBalances(c, log.NewColorLogger(SubmitNetworkBalancesColor), errorLog, dutyRecord)
 	if err != nil {
 		return fmt.Errorf("error during network balances check: %w", err)
 	}
@@ -139,19 +148,19 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error during timed-out minipools check: %w", err)
 	}
-	submitScrubMinipools, err := newSubmitScrubMinipools(c, log.NewColorLogger(SubmitScrubMinipoolsColor), errorLog, scrubCollector)
+	submitScrubMinipools, err := newSubmitScrubMinipools(c, log.NewColorLogger(SubmitScrubMinipoolsColor), errorLog, scrubCollector, dutyRecord)
 	if err != nil {
 		return fmt.Errorf("error during scrub check: %w", err)
 	}
 	var submitRewardsTree_Stateless *submitRewardsTree_Stateless
 	var submitRewardsTree_Rolling *submitRewardsTree_Rolling
 	if !useRollingRecords {
-		submitRewardsTree_Stateless, err = newSubmitRewardsTree_Stateless(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+		submitRewardsTree_Stateless, err = newSubmitRewardsTree_Stateless(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m, dutyRecord)
 		if err != nil {
 			return fmt.Errorf("error during stateless rewards tree check: %w", err)
 		}
 	} else {
-		submitRewardsTree_Rolling, err = newSubmitRewardsTree_Rolling(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m)
+		submitRewardsTree_Rolling, err = newSubmitRewardsTree_Rolling(c, log.NewColorLogger(SubmitRewardsTreeColor), errorLog, m, dutyRecord)
 		if err != nil {
 			return fmt.Errorf("error during rolling rewards tree check: %w", err)
 		}
@@ -176,6 +185,10 @@ func run(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("error creating finalize-pdao-proposals task: %w", err)
 	}
+	checkOdaoDuties, err := newCheckOdaoDuties(c, log.NewColorLogger(CheckOdaoDutiesColor), dutyRecord)
+	if err != nil {
+		return fmt.Errorf("error during Oracle DAO duty check: %w", err)
+	}
 
 	intervalDelta := maxTasksInterval - minTasksInterval
 	secondsDelta := intervalDelta.Seconds()
@@ -299,6 +312,12 @@ func run(c *cli.Context) error {
 				if err := checkSoloMigrations.run(state); err != nil {
 					errorLog.Println(err)
 				}
+				time.Sleep(taskCooldown)
+
+				// Run the Oracle DAO duty check
+				if err := checkOdaoDuties.run(state); err != nil {
+					errorLog.Println(err)
+				}
 				/*time.Sleep(taskCooldown)
 
 				// Run the fee recipient penalty check
@@ -329,7 +348,7 @@ func run(c *cli.Context) error {
 
 	// Run metrics loop
 	go func() {
-		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector, bondReductionCollector, soloMigrationCollector)
+		err := runMetricsServer(c, log.NewColorLogger(MetricsColor), scrubCollector, bondReductionCollector, soloMigrationCollector, odaoDutyCollector)
 		if err != nil {
 			errorLog.Println(err)
 		}
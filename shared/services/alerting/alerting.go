@@ -3,14 +3,17 @@ package alerting
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/go-openapi/strfmt"
+	"github.com/rocket-pool/rocketpool-go/types"
 	apiclient "github.com/rocket-pool/smartnode/shared/services/alerting/alertmanager/client"
 	apialert "github.com/rocket-pool/smartnode/shared/services/alerting/alertmanager/client/alert"
 	"github.com/rocket-pool/smartnode/shared/services/alerting/alertmanager/models"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
 )
 
 const (
@@ -177,6 +180,144 @@ func AlertMinipoolStaked(cfg *config.RocketPoolConfig, minipoolAddress common.Ad
 	return sendAlert(alert, cfg)
 }
 
+// Sends an alert when a block proposed by one of the node's validators paid a fee recipient other than the one required by Rocket Pool.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertProposalFeeRecipientWrong(cfg *config.RocketPoolConfig, minipoolAddress common.Address, slot uint64, expectedRecipient common.Address, actualRecipient common.Address) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertProposalFeeRecipientWrong.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_ProposalFeeRecipientWrong.Value != true {
+		logMessage("alert for ProposalFeeRecipientWrong is disabled, not sending.")
+		return nil
+	}
+
+	alert := createAlert(
+		fmt.Sprintf("ProposalFeeRecipientWrong-%d-%s", slot, minipoolAddress.Hex()),
+		fmt.Sprintf("Minipool %s proposed a block with the wrong fee recipient", minipoolAddress.Hex()),
+		fmt.Sprintf("The block proposed by minipool %s in slot %d paid its rewards to %s instead of %s. This can get your node penalized; check your validator client and MEV-Boost configuration.", minipoolAddress.Hex(), slot, actualRecipient.Hex(), expectedRecipient.Hex()),
+		SeverityCritical,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{
+			"minipool": minipoolAddress.Hex(),
+		},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the node automatically claimed its rewards or attempted to (success or failure).
+// If alerting/metrics are disabled, this function does nothing.
+func AlertRewardsClaimed(cfg *config.RocketPoolConfig, intervals []uint64, ethAmount float64, rplAmount float64, restakeAmount float64, succeeded bool) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertRewardsClaimed.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_RewardsClaimed.Value != true {
+		logMessage("alert for RewardsClaimed is disabled, not sending.")
+		return nil
+	}
+
+	// prepare the alert information:
+	endsAt, severity, succeededOrFailedText := getAlertSettingsForEvent(succeeded)
+	alert := createAlert(
+		fmt.Sprintf("RewardsClaimed-%s-%v", succeededOrFailedText, intervals),
+		fmt.Sprintf("Rewards claim %s", succeededOrFailedText),
+		fmt.Sprintf("The automatic claim of %.6f ETH and %.6f RPL (restaking %.6f RPL) from intervals %v completed with status %s.", ethAmount, rplAmount, restakeAmount, intervals, succeededOrFailedText),
+		severity,
+		endsAt,
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends a digest of governance activity when new proposals, challenges, phase transitions, or voting deadlines need attention.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertGovernanceDigest(cfg *config.RocketPoolConfig, digest *proposals.GovernanceDigest, events []proposals.DigestEvent) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertGovernanceDigest.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_GovernanceDigest.Value != true {
+		logMessage("alert for GovernanceDigest is disabled, not sending.")
+		return nil
+	}
+
+	// List what changed
+	var description strings.Builder
+	description.WriteString("Governance activity since the last digest:\n")
+	for _, event := range events {
+		description.WriteString(fmt.Sprintf("- %s\n", event.Description))
+	}
+
+	// List the upcoming deadlines and whether the node is represented
+	deadlines := []string{}
+	for _, prop := range digest.PdaoProposals {
+		if prop.State != types.ProtocolDaoProposalState_ActivePhase1 && prop.State != types.ProtocolDaoProposalState_ActivePhase2 {
+			continue
+		}
+		deadlines = append(deadlines, fmt.Sprintf("- pDAO proposal %d: %s ends %s; node vote: %s, delegate vote: %s", prop.ID, types.ProtocolDaoProposalStates[prop.State], prop.PhaseEnd.UTC().Format(time.RFC822), types.VoteDirections[prop.NodeVote], types.VoteDirections[prop.DelegateVote]))
+	}
+	for _, prop := range digest.OdaoProposals {
+		if prop.State != types.Active {
+			continue
+		}
+		deadlines = append(deadlines, fmt.Sprintf("- oDAO proposal %d: voting ends %s; voted: %t", prop.ID, prop.EndTime.UTC().Format(time.RFC822), prop.MemberVoted))
+	}
+	for _, prop := range digest.SnapshotProposals {
+		deadlines = append(deadlines, fmt.Sprintf("- Snapshot proposal \"%s\": voting ends %s; voted: %t", prop.Title, prop.End.UTC().Format(time.RFC822), prop.Voted))
+	}
+	if len(deadlines) > 0 {
+		description.WriteString("Open votes:\n")
+		description.WriteString(strings.Join(deadlines, "\n"))
+	}
+
+	alert := createAlert(
+		fmt.Sprintf("GovernanceDigest-%d", digest.GeneratedTime.Unix()),
+		fmt.Sprintf("Governance digest: %d update(s)", len(events)),
+		description.String(),
+		SeverityInfo,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityInfo)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
+// Sends an alert when the Rescue Node credential is about to expire or already has.
+// If alerting/metrics are disabled, this function does nothing.
+func AlertRescueNodeCredentialExpiry(cfg *config.RocketPoolConfig, issued time.Time, expires time.Time) error {
+	if !isAlertingEnabled(cfg) {
+		logMessage("alerting is disabled, not sending AlertRescueNodeCredentialExpiry.")
+		return nil
+	}
+
+	if cfg.Alertmanager.AlertEnabled_RescueNodeCredentialExpiry.Value != true {
+		logMessage("alert for RescueNodeCredentialExpiry is disabled, not sending.")
+		return nil
+	}
+
+	timeLeft := time.Until(expires).Truncate(time.Minute)
+	summary := fmt.Sprintf("Rescue Node credential expires in %s", timeLeft)
+	severity := SeverityWarning
+	expiredText := "expiring"
+	if timeLeft <= 0 {
+		summary = "Rescue Node credential expired"
+		severity = SeverityCritical
+		expiredText = "expired"
+	}
+	alert := createAlert(
+		fmt.Sprintf("RescueNodeCredentialExpiry-%s-%d", expiredText, issued.Unix()),
+		summary,
+		fmt.Sprintf("The Rescue Node credential issued at %s expires at %s. Your validator client can't use the Rescue Node once it has expired; run `rocketpool service rescue-node request-credential` to request a new one, or `rocketpool service rescue-node disable` to switch back to your own Consensus client.", issued.UTC().Format(time.RFC822), expires.UTC().Format(time.RFC822)),
+		severity,
+		strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityCritical)),
+		map[string]string{},
+	)
+	return sendAlert(alert, cfg)
+}
+
 // Gets various settings for an alert based on whether a process succeeded or failed.
 func getAlertSettingsForEvent(succeeded bool) (strfmt.DateTime, Severity, string) {
 	endsAt := strfmt.DateTime(time.Now().Add(DefaultEndsAtDurationForSeverityInfo))
@@ -0,0 +1,288 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+)
+
+const (
+	// The name of the manifest in a backup archive
+	ManifestFilename string = "manifest.json"
+
+	// The start and end of backup filenames
+	filenamePrefix    string = "rocketpool-backup-"
+	filenameExtension string = ".tar.gz.age"
+
+	// The format of the timestamp in backup filenames
+	filenameTimeFormat string = "20060102-150405"
+
+	// The start of the names of Lighthouse's and Nimbus's slashing protection databases and their journals
+	sqliteSlashingProtectionPrefix string = "slashing_protection.sqlite"
+)
+
+// The names of the other validator clients' slashing protection databases. The clients keep writing to them while
+// they run, so a copy could be torn; backups carry an exported interchange file instead.
+var slashingProtectionDatabases = map[string]bool{
+	"validator.db":    true, // Prysm
+	"validator-db":    true, // Lodestar
+	"slashprotection": true, // Teku
+}
+
+// Describes the contents of a backup archive
+type Manifest struct {
+	CreatedAt        time.Time      `json:"createdAt"`
+	SmartnodeVersion string         `json:"smartnodeVersion"`
+	Network          string         `json:"network"`
+	NodeAddress      common.Address `json:"nodeAddress"`
+	Entries          []string       `json:"entries"`
+}
+
+// A file or folder to include in a backup
+type Source struct {
+	// The path of the file or folder in the archive
+	Name string
+
+	// The path of the file or folder on disk
+	Path string
+}
+
+// Get the filename of a backup made at the given time
+func GetFilename(createdAt time.Time) string {
+	return filenamePrefix + createdAt.UTC().Format(filenameTimeFormat) + filenameExtension
+}
+
+// Get the time a backup was made from its filename; returns false if it isn't the name of a backup
+func ParseFilename(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, filenamePrefix) || !strings.HasSuffix(name, filenameExtension) {
+		return time.Time{}, false
+	}
+	timestamp := strings.TrimSuffix(strings.TrimPrefix(name, filenamePrefix), filenameExtension)
+	createdAt, err := time.Parse(filenameTimeFormat, timestamp)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return createdAt, true
+}
+
+// Write an archive of the sources, encrypted with the passphrase. Sources that don't exist are skipped; the
+// manifest's entries list the ones that were included.
+func Write(w io.Writer, passphrase string, manifest Manifest, sources []Source) (Manifest, error) {
+
+	// Find the sources that exist
+	manifest.Entries = []string{}
+	includedSources := []Source{}
+	for _, source := range sources {
+		_, err := os.Stat(source.Path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return Manifest{}, fmt.Errorf("error checking %s: %w", source.Path, err)
+		}
+		manifest.Entries = append(manifest.Entries, source.Name)
+		includedSources = append(includedSources, source)
+	}
+
+	// Set up the encrypted stream
+	recipient, err := age.NewScryptRecipient(passphrase)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("error creating encryption key: %w", err)
+	}
+	encryptedWriter, err := age.Encrypt(w, recipient)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("error starting encryption: %w", err)
+	}
+	gzipWriter := gzip.NewWriter(encryptedWriter)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	// Write the manifest first so it can be read without extracting everything
+	manifestBytes, err := json.MarshalIndent(manifest, "", "    ")
+	if err != nil {
+		return Manifest{}, fmt.Errorf("error serializing backup manifest: %w", err)
+	}
+	err = tarWriter.WriteHeader(&tar.Header{
+		Name:    ManifestFilename,
+		Mode:    0600,
+		Size:    int64(len(manifestBytes)),
+		ModTime: manifest.CreatedAt,
+	})
+	if err != nil {
+		return Manifest{}, fmt.Errorf("error writing backup manifest: %w", err)
+	}
+	if _, err := tarWriter.Write(manifestBytes); err != nil {
+		return Manifest{}, fmt.Errorf("error writing backup manifest: %w", err)
+	}
+
+	// Write the sources
+	for _, source := range includedSources {
+		if err := addToArchive(tarWriter, source); err != nil {
+			return Manifest{}, err
+		}
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return Manifest{}, fmt.Errorf("error finishing backup archive: %w", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return Manifest{}, fmt.Errorf("error finishing backup archive: %w", err)
+	}
+	if err := encryptedWriter.Close(); err != nil {
+		return Manifest{}, fmt.Errorf("error finishing backup encryption: %w", err)
+	}
+	return manifest, nil
+
+}
+
+// Decrypt an archive with the passphrase and extract it into the target folder
+func Read(r io.Reader, passphrase string, targetDir string) (Manifest, error) {
+
+	identity, err := age.NewScryptIdentity(passphrase)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("error creating decryption key: %w", err)
+	}
+	decryptedReader, err := age.Decrypt(r, identity)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("error decrypting backup (is the passphrase correct?): %w", err)
+	}
+	gzipReader, err := gzip.NewReader(decryptedReader)
+	if err != nil {
+		return Manifest{}, fmt.Errorf("error decompressing backup: %w", err)
+	}
+	defer gzipReader.Close()
+	tarReader := tar.NewReader(gzipReader)
+
+	var manifest *Manifest
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return Manifest{}, fmt.Errorf("error reading backup archive: %w", err)
+		}
+
+		// Make sure the entry stays inside the target folder
+		name := path.Clean(header.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return Manifest{}, fmt.Errorf("backup archive has an invalid path [%s]", header.Name)
+		}
+		targetPath := filepath.Join(targetDir, filepath.FromSlash(name))
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(targetPath, os.FileMode(header.Mode).Perm()|0700); err != nil {
+				return Manifest{}, fmt.Errorf("error creating %s: %w", targetPath, err)
+			}
+
+		case tar.TypeReg:
+			if name == ManifestFilename {
+				manifest = &Manifest{}
+				if err := json.NewDecoder(tarReader).Decode(manifest); err != nil {
+					return Manifest{}, fmt.Errorf("error reading backup manifest: %w", err)
+				}
+				continue
+			}
+			if err := extractFile(tarReader, targetPath, os.FileMode(header.Mode).Perm()); err != nil {
+				return Manifest{}, err
+			}
+
+		default:
+			return Manifest{}, fmt.Errorf("backup archive has an unsupported entry [%s]", header.Name)
+		}
+	}
+
+	if manifest == nil {
+		return Manifest{}, fmt.Errorf("backup archive doesn't have a manifest")
+	}
+	return *manifest, nil
+
+}
+
+// Check if a file or folder is one of the validator clients' slashing protection databases
+func IsSlashingProtectionDatabase(name string) bool {
+	return strings.HasPrefix(name, sqliteSlashingProtectionPrefix) || slashingProtectionDatabases[name]
+}
+
+// Add a file or folder to an archive, leaving out slashing protection databases
+func addToArchive(tarWriter *tar.Writer, source Source) error {
+	return filepath.WalkDir(source.Path, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", filePath, err)
+		}
+		if IsSlashingProtectionDatabase(entry.Name()) {
+			if entry.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// Skip anything that isn't a regular file or folder, such as sockets and symlinks
+		if !entry.IsDir() && !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", filePath, err)
+		}
+		relPath, err := filepath.Rel(source.Path, filePath)
+		if err != nil {
+			return fmt.Errorf("error getting path of %s: %w", filePath, err)
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return fmt.Errorf("error creating archive entry for %s: %w", filePath, err)
+		}
+		header.Name = path.Join(source.Name, filepath.ToSlash(relPath))
+		if entry.IsDir() {
+			header.Name += "/"
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return fmt.Errorf("error writing %s to backup archive: %w", filePath, err)
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			return fmt.Errorf("error opening %s: %w", filePath, err)
+		}
+		defer file.Close()
+
+		// Files like the rolling records can grow while they're being read; only the part that was there when the
+		// header was written fits in the archive
+		if _, err := io.CopyN(tarWriter, file, header.Size); err != nil {
+			return fmt.Errorf("error writing %s to backup archive: %w", filePath, err)
+		}
+		return nil
+	})
+}
+
+// Extract a file from an archive
+func extractFile(r io.Reader, targetPath string, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(targetPath), 0700); err != nil {
+		return fmt.Errorf("error creating folder for %s: %w", targetPath, err)
+	}
+	file, err := os.OpenFile(targetPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", targetPath, err)
+	}
+	defer file.Close()
+	if _, err := io.Copy(file, r); err != nil {
+		return fmt.Errorf("error extracting %s: %w", targetPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package backup
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestFilename(t *testing.T) {
+	createdAt := time.Date(2024, 6, 1, 12, 30, 45, 0, time.UTC)
+	name := GetFilename(createdAt.In(time.FixedZone("UTC+2", 2*60*60)))
+	if name != "rocketpool-backup-20240601-123045.tar.gz.age" {
+		t.Fatalf("unexpected filename %s", name)
+	}
+	parsed, isBackup := ParseFilename(name)
+	if !isBackup || !parsed.Equal(createdAt) {
+		t.Fatalf("expected %s to be parsed as %s, got %s", name, createdAt, parsed)
+	}
+
+	for _, name := range []string{
+		"rocketpool-backup-20240601-123045.tar.gz",
+		"other-backup-20240601-123045.tar.gz.age",
+		"rocketpool-backup-20240601.tar.gz.age",
+		".tmp-rocketpool-backup-20240601-123045.tar.gz.age-123",
+	} {
+		if _, isBackup := ParseFilename(name); isBackup {
+			t.Fatalf("expected %s not to be a backup name", name)
+		}
+	}
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	// Lay out a data folder with validator client databases that must be left out
+	dataDir := t.TempDir()
+	files := map[string]string{
+		"wallet": "wallet",
+		"validators/lighthouse/validators/0x01/voting-keystore.json":      "keystore",
+		"validators/lighthouse/validators/slashing_protection.sqlite":     "database",
+		"validators/lighthouse/validators/slashing_protection.sqlite-wal": "journal",
+		"validators/teku/slashprotection/0x01.yml":                        "database",
+		"validators/prysm-non-hd/direct/validator.db":                     "database",
+		"validators/rp-graffiti.txt":                                      "graffiti",
+	}
+	for name, contents := range files {
+		path := filepath.Join(dataDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sources := []Source{
+		{Name: walletEntry, Path: filepath.Join(dataDir, "wallet")},
+		{Name: passwordEntry, Path: filepath.Join(dataDir, "password")},
+		{Name: validatorsEntry, Path: filepath.Join(dataDir, "validators")},
+	}
+
+	manifest := Manifest{
+		CreatedAt:        time.Date(2024, 6, 1, 12, 30, 45, 0, time.UTC),
+		SmartnodeVersion: "v1.13.0",
+		Network:          "mainnet",
+		NodeAddress:      common.HexToAddress("0x1111111111111111111111111111111111111111"),
+	}
+	archive := &bytes.Buffer{}
+	written, err := Write(archive, "correct horse battery staple", manifest, sources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(written.Entries, ",") != walletEntry+","+validatorsEntry {
+		t.Fatalf("expected the missing password to be skipped, got entries %v", written.Entries)
+	}
+
+	// The wrong passphrase can't open it
+	if _, err := Read(bytes.NewReader(archive.Bytes()), "wrong passphrase", t.TempDir()); err == nil {
+		t.Fatalf("expected the wrong passphrase to be rejected")
+	}
+
+	targetDir := t.TempDir()
+	read, err := Read(bytes.NewReader(archive.Bytes()), "correct horse battery staple", targetDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !read.CreatedAt.Equal(manifest.CreatedAt) || read.NodeAddress != manifest.NodeAddress || len(read.Entries) != 2 {
+		t.Fatalf("the manifest didn't survive the round trip: %+v", read)
+	}
+
+	expected := map[string]string{
+		"data/wallet": "wallet",
+		"data/validators/lighthouse/validators/0x01/voting-keystore.json": "keystore",
+		"data/validators/rp-graffiti.txt":                                 "graffiti",
+	}
+	for name, contents := range expected {
+		path := filepath.Join(targetDir, filepath.FromSlash(name))
+		actual, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(actual) != contents {
+			t.Fatalf("expected %s to contain [%s], got [%s]", name, contents, string(actual))
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != 0600 {
+			t.Fatalf("expected %s to keep mode 0600, got %o", name, info.Mode().Perm())
+		}
+	}
+	for _, name := range []string{
+		"data/validators/lighthouse/validators/slashing_protection.sqlite",
+		"data/validators/lighthouse/validators/slashing_protection.sqlite-wal",
+		"data/validators/teku/slashprotection",
+		"data/validators/prysm-non-hd/direct/validator.db",
+	} {
+		if _, err := os.Stat(filepath.Join(targetDir, filepath.FromSlash(name))); !os.IsNotExist(err) {
+			t.Fatalf("expected the slashing protection database %s to be left out", name)
+		}
+	}
+}
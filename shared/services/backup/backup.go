@@ -0,0 +1,275 @@
+package backup
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/passwords"
+	"github.com/rocket-pool/smartnode/shared/services/wallet"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+const (
+	// The paths of the node's files in a backup archive
+	settingsEntry        string = "user-settings.yml"
+	walletEntry          string = "data/wallet"
+	passwordEntry        string = "data/password"
+	validatorsEntry      string = "data/validators"
+	customKeysEntry      string = "data/custom-keys"
+	customPasswordsEntry string = "data/custom-key-passwords"
+	recordsEntry         string = "data/records"
+	rewardsTreesEntry    string = "data/rewards-trees"
+
+	// The path of the exported slashing protection interchange file in a backup archive
+	slashingProtectionEntry string = "slashing-protection.json"
+
+	// The suffix added to the files a restore replaces
+	preRestoreSuffix string = ".pre-restore-"
+)
+
+// The result of restoring a backup
+type RestoreResult struct {
+	Manifest Manifest
+
+	// The backed up settings file, if it was in the backup. It isn't restored here because the Smartnode's
+	// daemons can't write to it, so the CLI saves it.
+	Settings []byte
+
+	// The EIP-3076 interchange file, if it was in the backup. The validator client has to import it, so the CLI
+	// does that.
+	SlashingProtection []byte
+
+	// The files that were replaced, and the paths they were moved to
+	MovedAside map[string]string
+}
+
+// Get the files and folders of the node that are backed up. The validator clients' slashing protection databases
+// are left out of the validator folder; see Create.
+func GetSources(cfg *config.RocketPoolConfig, settingsPath string) []Source {
+	return []Source{
+		{Name: settingsEntry, Path: settingsPath},
+		{Name: walletEntry, Path: cfg.Smartnode.GetWalletPath()},
+		{Name: passwordEntry, Path: cfg.Smartnode.GetPasswordPath()},
+		{Name: validatorsEntry, Path: cfg.Smartnode.GetValidatorKeychainPath()},
+		{Name: customKeysEntry, Path: cfg.Smartnode.GetCustomKeyPath()},
+		{Name: customPasswordsEntry, Path: cfg.Smartnode.GetCustomKeyPasswordFilePath()},
+		{Name: recordsEntry, Path: cfg.Smartnode.GetRecordsPath()},
+		{Name: rewardsTreesEntry, Path: filepath.Dir(cfg.Smartnode.GetRewardsTreePath(0, true))},
+	}
+}
+
+// Create an encrypted backup of the node, save it to the store, and delete the old backups beyond the retention
+// setting. If slashingProtectionPath isn't empty, it must be an EIP-3076 interchange file exported from the stopped
+// validator client, and it's included in the backup. Returns the new backup and the names of the deleted ones.
+func Create(cfg *config.RocketPoolConfig, store Store, settingsPath string, slashingProtectionPath string, nodeAddress common.Address) (Info, []string, error) {
+
+	passphrase, err := getPassphrase(cfg)
+	if err != nil {
+		return Info{}, nil, err
+	}
+	manifest := Manifest{
+		CreatedAt:        time.Now().UTC().Truncate(time.Second),
+		SmartnodeVersion: shared.RocketPoolVersion,
+		Network:          string(cfg.Smartnode.Network.Value.(cfgtypes.Network)),
+		NodeAddress:      nodeAddress,
+	}
+
+	// Write the archive to a temporary file first, since uploads need to know its size
+	file, err := os.CreateTemp("", "rocketpool-backup-*")
+	if err != nil {
+		return Info{}, nil, fmt.Errorf("error creating temporary backup file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	sources := GetSources(cfg, settingsPath)
+	if slashingProtectionPath != "" {
+		if _, err := os.Stat(slashingProtectionPath); err != nil {
+			return Info{}, nil, fmt.Errorf("error reading the exported slashing protection data: %w", err)
+		}
+		sources = append(sources, Source{Name: slashingProtectionEntry, Path: slashingProtectionPath})
+	}
+	manifest, err = Write(file, passphrase, manifest, sources)
+	if err != nil {
+		return Info{}, nil, err
+	}
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return Info{}, nil, fmt.Errorf("error getting backup size: %w", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return Info{}, nil, fmt.Errorf("error rewinding backup file: %w", err)
+	}
+
+	// Save it
+	info := Info{
+		Name:      GetFilename(manifest.CreatedAt),
+		CreatedAt: manifest.CreatedAt,
+		Size:      size,
+	}
+	if err := store.Put(info.Name, file, size); err != nil {
+		return Info{}, nil, err
+	}
+
+	// Delete the old ones
+	deleted, err := Prune(store, cfg.Backup.Retention.Value.(uint64))
+	if err != nil {
+		return info, deleted, fmt.Errorf("backup %s was saved, but old backups couldn't be deleted: %w", info.Name, err)
+	}
+	return info, deleted, nil
+
+}
+
+// Restore a backup from the store into the data folder. The backup's wallet must be for the expected node address;
+// nothing is restored if it isn't. The validator client and node daemon must be stopped. The current slashing
+// protection databases are kept in the restored validator folder, since the backup doesn't have any.
+func Restore(cfg *config.RocketPoolConfig, store Store, name string, expectedAddress common.Address) (RestoreResult, error) {
+
+	if _, isBackup := ParseFilename(name); !isBackup {
+		return RestoreResult{}, fmt.Errorf("[%s] isn't the name of a backup", name)
+	}
+	if expectedAddress == (common.Address{}) {
+		return RestoreResult{}, fmt.Errorf("the node address the backup's wallet must be for is required")
+	}
+	passphrase, err := getPassphrase(cfg)
+	if err != nil {
+		return RestoreResult{}, err
+	}
+
+	// Extract the backup next to the data it replaces, so it can be moved into place without copying
+	dataDir := filepath.Dir(cfg.Smartnode.GetWalletPath())
+	if err := os.MkdirAll(dataDir, 0700); err != nil {
+		return RestoreResult{}, fmt.Errorf("error creating data folder: %w", err)
+	}
+	stagingDir, err := os.MkdirTemp(dataDir, ".backup-restore-*")
+	if err != nil {
+		return RestoreResult{}, fmt.Errorf("error creating restore folder: %w", err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	archive, err := os.Create(filepath.Join(stagingDir, name))
+	if err != nil {
+		return RestoreResult{}, fmt.Errorf("error creating restore file: %w", err)
+	}
+	defer archive.Close()
+	if err := store.Get(name, archive); err != nil {
+		return RestoreResult{}, err
+	}
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		return RestoreResult{}, fmt.Errorf("error rewinding backup file: %w", err)
+	}
+	contentsDir := filepath.Join(stagingDir, "contents")
+	manifest, err := Read(archive, passphrase, contentsDir)
+	if err != nil {
+		return RestoreResult{}, err
+	}
+
+	// Make sure the wallet is the expected one
+	pm := passwords.NewPasswordManager(filepath.Join(contentsDir, filepath.FromSlash(passwordEntry)))
+	w, err := wallet.NewWallet(filepath.Join(contentsDir, filepath.FromSlash(walletEntry)), cfg.Smartnode.GetChainID(), nil, nil, 0, pm)
+	if err != nil {
+		return RestoreResult{}, fmt.Errorf("error loading the backup's node wallet: %w", err)
+	}
+	if !w.IsInitialized() {
+		return RestoreResult{}, fmt.Errorf("the backup doesn't have a node wallet and password; nothing was restored")
+	}
+	nodeAccount, err := w.GetNodeAccount()
+	if err != nil {
+		return RestoreResult{}, fmt.Errorf("error getting the backup's node account: %w", err)
+	}
+	if nodeAccount.Address != expectedAddress {
+		return RestoreResult{}, fmt.Errorf("the backup's wallet is for node %s, but %s was expected; nothing was restored", nodeAccount.Address.Hex(), expectedAddress.Hex())
+	}
+
+	// Move the files into place, keeping the ones they replace
+	result := RestoreResult{
+		Manifest:   manifest,
+		MovedAside: map[string]string{},
+	}
+	suffix := preRestoreSuffix + time.Now().UTC().Format(filenameTimeFormat)
+	slashingProtectionPath := filepath.Join(contentsDir, slashingProtectionEntry)
+	if _, err := os.Stat(slashingProtectionPath); err == nil {
+		result.SlashingProtection, err = os.ReadFile(slashingProtectionPath)
+		if err != nil {
+			return result, fmt.Errorf("error reading the backup's slashing protection data: %w", err)
+		}
+	}
+	for _, source := range GetSources(cfg, "") {
+		stagedPath := filepath.Join(contentsDir, filepath.FromSlash(source.Name))
+		if _, err := os.Stat(stagedPath); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if source.Name == settingsEntry {
+			result.Settings, err = os.ReadFile(stagedPath)
+			if err != nil {
+				return result, fmt.Errorf("error reading the backup's settings: %w", err)
+			}
+			continue
+		}
+
+		if _, err := os.Stat(source.Path); err == nil {
+			movedPath := source.Path + suffix
+			if err := os.Rename(source.Path, movedPath); err != nil {
+				return result, fmt.Errorf("error moving %s aside: %w", source.Path, err)
+			}
+			result.MovedAside[source.Path] = movedPath
+		}
+		if err := os.MkdirAll(filepath.Dir(source.Path), 0700); err != nil {
+			return result, fmt.Errorf("error creating folder for %s: %w", source.Path, err)
+		}
+		if err := os.Rename(stagedPath, source.Path); err != nil {
+			return result, fmt.Errorf("error restoring %s: %w", source.Path, err)
+		}
+		if movedPath, exists := result.MovedAside[source.Path]; exists && source.Name == validatorsEntry {
+			if err := moveSlashingProtectionDatabases(movedPath, source.Path); err != nil {
+				return result, err
+			}
+		}
+	}
+	return result, nil
+
+}
+
+// Move the slashing protection databases in one validator folder to the same places in another one
+func moveSlashingProtectionDatabases(fromDir string, toDir string) error {
+	return filepath.WalkDir(fromDir, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", filePath, err)
+		}
+		if !IsSlashingProtectionDatabase(entry.Name()) {
+			return nil
+		}
+		relPath, err := filepath.Rel(fromDir, filePath)
+		if err != nil {
+			return fmt.Errorf("error getting path of %s: %w", filePath, err)
+		}
+		targetPath := filepath.Join(toDir, relPath)
+		if err := os.MkdirAll(filepath.Dir(targetPath), 0700); err != nil {
+			return fmt.Errorf("error creating folder for %s: %w", targetPath, err)
+		}
+		if err := os.Rename(filePath, targetPath); err != nil {
+			return fmt.Errorf("error keeping slashing protection database %s: %w", filePath, err)
+		}
+		if entry.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+}
+
+// Get the passphrase backups are encrypted with
+func getPassphrase(cfg *config.RocketPoolConfig) (string, error) {
+	passphrase := cfg.Backup.Passphrase.Value.(string)
+	if passphrase == "" {
+		return "", fmt.Errorf("the backup passphrase is blank; set it in the Backups section of `rocketpool service config`")
+	}
+	return passphrase, nil
+}
@@ -0,0 +1,40 @@
+package backup
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMoveSlashingProtectionDatabases(t *testing.T) {
+	fromDir := t.TempDir()
+	toDir := t.TempDir()
+	for _, name := range []string{
+		"lighthouse/validators/slashing_protection.sqlite",
+		"lighthouse/validators/0x01/voting-keystore.json",
+		"teku/slashprotection/0x01.yml",
+	} {
+		path := filepath.Join(fromDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := moveSlashingProtectionDatabases(fromDir, toDir); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"lighthouse/validators/slashing_protection.sqlite", "teku/slashprotection/0x01.yml"} {
+		if _, err := os.Stat(filepath.Join(toDir, filepath.FromSlash(name))); err != nil {
+			t.Fatalf("expected %s to be moved: %s", name, err.Error())
+		}
+		if _, err := os.Stat(filepath.Join(fromDir, filepath.FromSlash(name))); !os.IsNotExist(err) {
+			t.Fatalf("expected %s to be gone from the old folder", name)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(toDir, "lighthouse", "validators", "0x01")); !os.IsNotExist(err) {
+		t.Fatalf("expected the keystores to stay in the old folder")
+	}
+}
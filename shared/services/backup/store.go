@@ -0,0 +1,273 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+)
+
+// A backup saved in a store
+type Info struct {
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	Size      int64     `json:"size"`
+}
+
+// A place backups are saved to
+type Store interface {
+	// Save a backup
+	Put(name string, r io.Reader, size int64) error
+
+	// Read a backup
+	Get(name string, w io.Writer) error
+
+	// List the saved backups, oldest first
+	List() ([]Info, error)
+
+	// Delete a backup
+	Delete(name string) error
+
+	// Describe where the backups are saved, for logs and the CLI
+	String() string
+}
+
+// Get the store the config saves backups to
+func NewStore(cfg *config.RocketPoolConfig, daemon bool) (Store, error) {
+	switch cfg.Backup.Destination.Value.(config.BackupDestination) {
+	case config.BackupDestination_Local:
+		localPath, err := cfg.Backup.GetLocalPath(daemon)
+		if err != nil {
+			return nil, err
+		}
+		return NewLocalStore(localPath), nil
+
+	case config.BackupDestination_S3:
+		return NewS3Store(cfg.Backup)
+
+	default:
+		return nil, fmt.Errorf("unknown backup destination [%v]", cfg.Backup.Destination.Value)
+	}
+}
+
+// Delete the oldest backups in the store, keeping the provided number of the newest ones. Returns the names of
+// the deleted backups.
+func Prune(store Store, keep uint64) ([]string, error) {
+	deleted := []string{}
+	if keep == 0 {
+		return deleted, nil
+	}
+	backups, err := store.List()
+	if err != nil {
+		return deleted, err
+	}
+	for len(backups) > int(keep) {
+		if err := store.Delete(backups[0].Name); err != nil {
+			return deleted, err
+		}
+		deleted = append(deleted, backups[0].Name)
+		backups = backups[1:]
+	}
+	return deleted, nil
+}
+
+// Sort backups from oldest to newest
+func sortBackups(backups []Info) {
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreatedAt.Before(backups[j].CreatedAt)
+	})
+}
+
+// Saves backups to a folder
+type LocalStore struct {
+	dir string
+}
+
+// Create a store for the given folder
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{
+		dir: dir,
+	}
+}
+
+func (s *LocalStore) Put(name string, r io.Reader, size int64) error {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return fmt.Errorf("error creating backup folder %s: %w", s.dir, err)
+	}
+
+	// Write to a temporary file so a partial backup never shows up in the list
+	backupPath := filepath.Join(s.dir, name)
+	file, err := os.CreateTemp(s.dir, ".tmp-"+name+"-*")
+	if err != nil {
+		return fmt.Errorf("error creating %s: %w", backupPath, err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	if _, err := io.Copy(file, r); err != nil {
+		return fmt.Errorf("error writing %s: %w", backupPath, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error writing %s: %w", backupPath, err)
+	}
+	if err := os.Rename(file.Name(), backupPath); err != nil {
+		return fmt.Errorf("error saving %s: %w", backupPath, err)
+	}
+	return nil
+}
+
+func (s *LocalStore) Get(name string, w io.Writer) error {
+	backupPath := filepath.Join(s.dir, name)
+	file, err := os.Open(backupPath)
+	if err != nil {
+		return fmt.Errorf("error opening %s: %w", backupPath, err)
+	}
+	defer file.Close()
+	if _, err := io.Copy(w, file); err != nil {
+		return fmt.Errorf("error reading %s: %w", backupPath, err)
+	}
+	return nil
+}
+
+func (s *LocalStore) List() ([]Info, error) {
+	backups := []Info{}
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return backups, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading backup folder %s: %w", s.dir, err)
+	}
+	for _, entry := range entries {
+		createdAt, isBackup := ParseFilename(entry.Name())
+		if !isBackup || !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", entry.Name(), err)
+		}
+		backups = append(backups, Info{
+			Name:      entry.Name(),
+			CreatedAt: createdAt,
+			Size:      info.Size(),
+		})
+	}
+	sortBackups(backups)
+	return backups, nil
+}
+
+func (s *LocalStore) Delete(name string) error {
+	backupPath := filepath.Join(s.dir, name)
+	if err := os.Remove(backupPath); err != nil {
+		return fmt.Errorf("error deleting %s: %w", backupPath, err)
+	}
+	return nil
+}
+
+func (s *LocalStore) String() string {
+	return s.dir
+}
+
+// Saves backups to an S3-compatible bucket
+type S3Store struct {
+	client *minio.Client
+	bucket string
+	prefix string
+}
+
+// Create a store for the S3 bucket in the backup config
+func NewS3Store(cfg *config.BackupConfig) (*S3Store, error) {
+	endpoint, secure, err := cfg.GetS3Endpoint()
+	if err != nil {
+		return nil, err
+	}
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.S3AccessKey.Value.(string), cfg.S3SecretKey.Value.(string), ""),
+		Secure: secure,
+		Region: cfg.S3Region.Value.(string),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating S3 client: %w", err)
+	}
+
+	// Treat the prefix as a folder
+	prefix := strings.Trim(cfg.S3Prefix.Value.(string), "/")
+	if prefix != "" {
+		prefix += "/"
+	}
+	return &S3Store{
+		client: client,
+		bucket: cfg.S3Bucket.Value.(string),
+		prefix: prefix,
+	}, nil
+}
+
+func (s *S3Store) Put(name string, r io.Reader, size int64) error {
+	_, err := s.client.PutObject(context.Background(), s.bucket, s.prefix+name, r, size, minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	})
+	if err != nil {
+		return fmt.Errorf("error uploading %s to %s: %w", name, s.String(), err)
+	}
+	return nil
+}
+
+func (s *S3Store) Get(name string, w io.Writer) error {
+	object, err := s.client.GetObject(context.Background(), s.bucket, s.prefix+name, minio.GetObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("error downloading %s from %s: %w", name, s.String(), err)
+	}
+	defer object.Close()
+	if _, err := io.Copy(w, object); err != nil {
+		return fmt.Errorf("error downloading %s from %s: %w", name, s.String(), err)
+	}
+	return nil
+}
+
+func (s *S3Store) List() ([]Info, error) {
+	backups := []Info{}
+	objects := s.client.ListObjects(context.Background(), s.bucket, minio.ListObjectsOptions{
+		Prefix: s.prefix,
+	})
+	for object := range objects {
+		if object.Err != nil {
+			return nil, fmt.Errorf("error listing backups in %s: %w", s.String(), object.Err)
+		}
+		name := path.Base(object.Key)
+		createdAt, isBackup := ParseFilename(name)
+		if !isBackup || object.Key != s.prefix+name {
+			continue
+		}
+		backups = append(backups, Info{
+			Name:      name,
+			CreatedAt: createdAt,
+			Size:      object.Size,
+		})
+	}
+	sortBackups(backups)
+	return backups, nil
+}
+
+func (s *S3Store) Delete(name string) error {
+	err := s.client.RemoveObject(context.Background(), s.bucket, s.prefix+name, minio.RemoveObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("error deleting %s from %s: %w", name, s.String(), err)
+	}
+	return nil
+}
+
+func (s *S3Store) String() string {
+	return fmt.Sprintf("s3://%s/%s", s.bucket, s.prefix)
+}
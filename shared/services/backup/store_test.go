@@ -0,0 +1,48 @@
+package backup
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrune(t *testing.T) {
+	store := NewLocalStore(t.TempDir())
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	names := []string{}
+	for i := 0; i < 5; i++ {
+		name := GetFilename(start.Add(time.Duration(i) * time.Hour))
+		if err := store.Put(name, strings.NewReader("backup"), 6); err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+	}
+
+	// A retention of 0 keeps everything
+	deleted, err := Prune(store, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deleted) != 0 {
+		t.Fatalf("expected nothing to be deleted, got %v", deleted)
+	}
+
+	// Otherwise the oldest ones go
+	deleted, err = Prune(store, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(deleted, ",") != strings.Join(names[:3], ",") {
+		t.Fatalf("expected the 3 oldest backups to be deleted, got %v", deleted)
+	}
+	backups, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 || backups[0].Name != names[3] || backups[1].Name != names[4] {
+		t.Fatalf("expected the 2 newest backups to be kept, got %v", backups)
+	}
+	if backups[1].Size != 6 || !backups[1].CreatedAt.Equal(start.Add(4*time.Hour)) {
+		t.Fatalf("unexpected backup info %+v", backups[1])
+	}
+}
@@ -117,6 +117,17 @@ func (m *BeaconClientManager) GetSyncStatus() (beacon.SyncStatus, error) {
 	return result.(beacon.SyncStatus), nil
 }
 
+// Get the number of peers the client is connected to
+func (m *BeaconClientManager) GetPeerCount() (uint64, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetPeerCount()
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(uint64), nil
+}
+
 // Get the Beacon configuration
 func (m *BeaconClientManager) GetEth2Config() (beacon.Eth2Config, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
@@ -237,6 +248,17 @@ func (m *BeaconClientManager) GetValidatorSyncDuties(indices []string, epoch uin
 	return result.(map[string]bool), nil
 }
 
+// Get the positions of validators within the sync committee
+func (m *BeaconClientManager) GetValidatorSyncCommitteePositions(indices []string, epoch uint64) (map[string][]uint64, error) {
+	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
+		return client.GetValidatorSyncCommitteePositions(indices, epoch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(map[string][]uint64), nil
+}
+
 // Get a validator's proposer duties
 func (m *BeaconClientManager) GetValidatorProposerDuties(indices []string, epoch uint64) (map[string]uint64, error) {
 	result, err := m.runFunction1(func(client beacon.Client) (interface{}, error) {
@@ -63,10 +63,12 @@ type BeaconBlock struct {
 	Attestations         []AttestationInfo
 	FeeRecipient         common.Address
 	ExecutionBlockNumber uint64
+	SyncCommitteeBits    bitfield.Bitvector512
 }
 type BeaconBlockHeader struct {
 	Slot          uint64
 	ProposerIndex string
+	Root          common.Hash
 }
 
 // Committees is an interface as an optimization- since committees responses
@@ -96,6 +98,8 @@ type AttestationInfo struct {
 	AggregationBits bitfield.Bitlist
 	SlotIndex       uint64
 	CommitteeIndex  uint64
+	BeaconBlockRoot common.Hash
+	TargetRoot      common.Hash
 }
 
 // Beacon client type
@@ -116,6 +120,9 @@ const (
 	Unknown
 )
 
+// The epoch used by the Beacon Chain to indicate that an event hasn't been scheduled yet
+const FarFutureEpoch uint64 = 0xffffffffffffffff
+
 type ValidatorState string
 
 const (
@@ -134,6 +141,7 @@ const (
 type Client interface {
 	GetClientType() (BeaconClientType, error)
 	GetSyncStatus() (SyncStatus, error)
+	GetPeerCount() (uint64, error)
 	GetEth2Config() (Eth2Config, error)
 	GetEth2DepositContract() (Eth2DepositContract, error)
 	GetAttestations(blockId string) ([]AttestationInfo, bool, error)
@@ -145,6 +153,7 @@ type Client interface {
 	GetValidatorStatuses(pubkeys []types.ValidatorPubkey, opts *ValidatorStatusOptions) (map[types.ValidatorPubkey]ValidatorStatus, error)
 	GetValidatorIndex(pubkey types.ValidatorPubkey) (string, error)
 	GetValidatorSyncDuties(indices []string, epoch uint64) (map[string]bool, error)
+	GetValidatorSyncCommitteePositions(indices []string, epoch uint64) (map[string][]uint64, error)
 	GetValidatorProposerDuties(indices []string, epoch uint64) (map[string]uint64, error)
 	GetDomainData(domainType []byte, epoch uint64, useGenesisFork bool) ([]byte, error)
 	ExitValidator(validatorIndex string, epoch uint64, signature types.ValidatorSignature) error
@@ -13,6 +13,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
+	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v5/crypto/bls"
 	"github.com/rocket-pool/rocketpool-go/types"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
@@ -29,6 +30,7 @@ const (
 	RequestContentType = "application/json"
 
 	RequestSyncStatusPath                  = "/eth/v1/node/syncing"
+	RequestPeerCountPath                   = "/eth/v1/node/peer_count"
 	RequestEth2ConfigPath                  = "/eth/v1/config/spec"
 	RequestEth2DepositContractMethod       = "/eth/v1/config/deposit_contract"
 	RequestGenesisPath                     = "/eth/v1/beacon/genesis"
@@ -90,6 +92,22 @@ func (c *StandardHttpClient) GetSyncStatus() (beacon.SyncStatus, error) {
 
 }
 
+// Get the number of peers the node is connected to
+func (c *StandardHttpClient) GetPeerCount() (uint64, error) {
+	responseBody, status, err := c.getRequest(RequestPeerCountPath)
+	if err != nil {
+		return 0, fmt.Errorf("Could not get node peer count: %w", err)
+	}
+	if status != http.StatusOK {
+		return 0, fmt.Errorf("Could not get node peer count: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+	var peerCount PeerCountResponse
+	if err := json.Unmarshal(responseBody, &peerCount); err != nil {
+		return 0, fmt.Errorf("Could not decode node peer count: %w", err)
+	}
+	return uint64(peerCount.Data.Connected), nil
+}
+
 // Get the eth2 config
 func (c *StandardHttpClient) GetEth2Config() (beacon.Eth2Config, error) {
 
@@ -345,6 +363,37 @@ func (c *StandardHttpClient) GetValidatorSyncDuties(indices []string, epoch uint
 	return validatorMap, nil
 }
 
+// Get the positions of validators within the sync committee at the given epoch
+func (c *StandardHttpClient) GetValidatorSyncCommitteePositions(indices []string, epoch uint64) (map[string][]uint64, error) {
+
+	// Perform the post request
+	responseBody, status, err := c.postRequest(fmt.Sprintf(RequestValidatorSyncDuties, strconv.FormatUint(epoch, 10)), indices)
+
+	if err != nil {
+		return nil, fmt.Errorf("Could not get validator sync duties: %w", err)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("Could not get validator sync duties: HTTP status %d; response body: '%s'", status, string(responseBody))
+	}
+
+	var response SyncDutiesResponse
+	if err := json.Unmarshal(responseBody, &response); err != nil {
+		return nil, fmt.Errorf("Could not decode validator sync duties data: %w", err)
+	}
+
+	// Map the results
+	positionMap := make(map[string][]uint64)
+	for _, duty := range response.Data {
+		positions := make([]uint64, len(duty.SyncCommitteeIndices))
+		for i, position := range duty.SyncCommitteeIndices {
+			positions[i] = uint64(position)
+		}
+		positionMap[duty.ValidatorIndex] = positions
+	}
+
+	return positionMap, nil
+}
+
 // Sums proposer duties per validators for a given epoch
 func (c *StandardHttpClient) GetValidatorProposerDuties(indices []string, epoch uint64) (map[string]uint64, error) {
 
@@ -489,6 +538,8 @@ func (c *StandardHttpClient) GetAttestations(blockId string) ([]beacon.Attestati
 		bitString := hexutil.RemovePrefix(attestation.AggregationBits)
 		attestationInfo[i].SlotIndex = uint64(attestation.Data.Slot)
 		attestationInfo[i].CommitteeIndex = uint64(attestation.Data.Index)
+		attestationInfo[i].BeaconBlockRoot = common.BytesToHash(attestation.Data.BeaconBlockRoot)
+		attestationInfo[i].TargetRoot = common.BytesToHash(attestation.Data.Target.Root)
 		attestationInfo[i].AggregationBits, err = hex.DecodeString(bitString)
 		if err != nil {
 			return nil, false, fmt.Errorf("Error decoding aggregation bits for attestation %d of block %s: %w", i, blockId, err)
@@ -521,12 +572,19 @@ func (c *StandardHttpClient) GetBeaconBlock(blockId string) (beacon.BeaconBlock,
 		beaconBlock.ExecutionBlockNumber = uint64(block.Data.Message.Body.ExecutionPayload.BlockNumber)
 	}
 
+	// Sync aggregates only exist after Altair
+	if block.Data.Message.Body.SyncAggregate != nil {
+		beaconBlock.SyncCommitteeBits = bitfield.Bitvector512(block.Data.Message.Body.SyncAggregate.SyncCommitteeBits)
+	}
+
 	// Add attestation info
 	for i, attestation := range block.Data.Message.Body.Attestations {
 		bitString := hexutil.RemovePrefix(attestation.AggregationBits)
 		info := beacon.AttestationInfo{
-			SlotIndex:      uint64(attestation.Data.Slot),
-			CommitteeIndex: uint64(attestation.Data.Index),
+			SlotIndex:       uint64(attestation.Data.Slot),
+			CommitteeIndex:  uint64(attestation.Data.Index),
+			BeaconBlockRoot: common.BytesToHash(attestation.Data.BeaconBlockRoot),
+			TargetRoot:      common.BytesToHash(attestation.Data.Target.Root),
 		}
 		info.AggregationBits, err = hex.DecodeString(bitString)
 		if err != nil {
@@ -550,6 +608,7 @@ func (c *StandardHttpClient) GetBeaconBlockHeader(blockId string) (beacon.Beacon
 	beaconBlock := beacon.BeaconBlockHeader{
 		Slot:          uint64(block.Data.Header.Message.Slot),
 		ProposerIndex: block.Data.Header.Message.ProposerIndex,
+		Root:          common.HexToHash(block.Data.Root),
 	}
 	return beaconBlock, true, nil
 }
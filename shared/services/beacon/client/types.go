@@ -37,6 +37,11 @@ type SyncStatusResponse struct {
 		SyncDistance uinteger `json:"sync_distance"`
 	} `json:"data"`
 }
+type PeerCountResponse struct {
+	Data struct {
+		Connected uinteger `json:"connected"`
+	} `json:"data"`
+}
 type Eth2ConfigResponse struct {
 	Data struct {
 		SecondsPerSlot               uinteger  `json:"SECONDS_PER_SLOT"`
@@ -92,7 +97,10 @@ type BeaconBlockResponse struct {
 					DepositCount uinteger  `json:"deposit_count"`
 					BlockHash    byteArray `json:"block_hash"`
 				} `json:"eth1_data"`
-				Attestations     []Attestation `json:"attestations"`
+				Attestations  []Attestation `json:"attestations"`
+				SyncAggregate *struct {
+					SyncCommitteeBits byteArray `json:"sync_committee_bits"`
+				} `json:"sync_aggregate"`
 				ExecutionPayload *struct {
 					FeeRecipient byteArray `json:"fee_recipient"`
 					BlockNumber  uinteger  `json:"block_number"`
@@ -154,8 +162,12 @@ type CommitteesResponse struct {
 type Attestation struct {
 	AggregationBits string `json:"aggregation_bits"`
 	Data            struct {
-		Slot  uinteger `json:"slot"`
-		Index uinteger `json:"index"`
+		Slot            uinteger  `json:"slot"`
+		Index           uinteger  `json:"index"`
+		BeaconBlockRoot byteArray `json:"beacon_block_root"`
+		Target          struct {
+			Root byteArray `json:"root"`
+		} `json:"target"`
 	} `json:"data"`
 }
 
@@ -72,6 +72,10 @@ type AlertmanagerConfig struct {
 	AlertEnabled_MinipoolStaked              config.Parameter `yaml:"alertEnabled_MinipoolStaked,omitempty"`
 	AlertEnabled_ExecutionClientSyncComplete config.Parameter `yaml:"alertEnabled_ExecutionClientSyncComplete,omitempty"`
 	AlertEnabled_BeaconClientSyncComplete    config.Parameter `yaml:"alertEnabled_BeaconClientSyncComplete,omitempty"`
+	AlertEnabled_ProposalFeeRecipientWrong   config.Parameter `yaml:"alertEnabled_ProposalFeeRecipientWrong,omitempty"`
+	AlertEnabled_RewardsClaimed              config.Parameter `yaml:"alertEnabled_RewardsClaimed,omitempty"`
+	AlertEnabled_GovernanceDigest            config.Parameter `yaml:"alertEnabled_GovernanceDigest,omitempty"`
+	AlertEnabled_RescueNodeCredentialExpiry  config.Parameter `yaml:"alertEnabled_RescueNodeCredentialExpiry,omitempty"`
 }
 
 func NewAlertmanagerConfig(cfg *RocketPoolConfig) *AlertmanagerConfig {
@@ -157,6 +161,7 @@ func NewAlertmanagerConfig(cfg *RocketPoolConfig) *AlertmanagerConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Alertmanager},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 
 		AlertEnabled_ClientSyncStatusBeacon: createParameterForAlertEnablement(
@@ -219,6 +224,22 @@ func NewAlertmanagerConfig(cfg *RocketPoolConfig) *AlertmanagerConfig {
 			"MinipoolStaked",
 			"Minipool Staked"),
 
+		AlertEnabled_ProposalFeeRecipientWrong: createParameterForAlertEnablement(
+			"ProposalFeeRecipientWrong",
+			"Proposal Fee Recipient Wrong"),
+
+		AlertEnabled_RewardsClaimed: createParameterForAlertEnablement(
+			"RewardsClaimed",
+			"Rewards Claimed"),
+
+		AlertEnabled_GovernanceDigest: createParameterForAlertEnablement(
+			"GovernanceDigest",
+			"Governance Digest"),
+
+		AlertEnabled_RescueNodeCredentialExpiry: createParameterForAlertEnablement(
+			"RescueNodeCredentialExpiry",
+			"Rescue Node credential is expiring"),
+
 		AlertEnabled_ExecutionClientSyncComplete: createParameterForAlertEnablement(
 			"ExecutionClientSyncComplete",
 			"execution client is synced"),
@@ -269,6 +290,10 @@ func (cfg *AlertmanagerConfig) GetParameters() []*config.Parameter {
 		&cfg.AlertEnabled_MinipoolStaked,
 		&cfg.AlertEnabled_ExecutionClientSyncComplete,
 		&cfg.AlertEnabled_BeaconClientSyncComplete,
+		&cfg.AlertEnabled_ProposalFeeRecipientWrong,
+		&cfg.AlertEnabled_RewardsClaimed,
+		&cfg.AlertEnabled_GovernanceDigest,
+		&cfg.AlertEnabled_RescueNodeCredentialExpiry,
 	}
 }
 
@@ -287,25 +312,31 @@ func (cfg *AlertmanagerConfig) GetOpenPorts() string {
 
 // Load the alerting configuration templates, do the template variable substitutions, and save them.
 func (cfg *AlertmanagerConfig) UpdateConfigurationFiles(configPath string) error {
-	err := cfg.processTemplate(configPath, AlertmanagerConfigTemplate, AlertmanagerConfigFile, "{{", "}}")
+	return cfg.RenderConfigurationFiles(configPath, configPath)
+}
+
+// Load the alerting configuration templates from configPath, do the template variable substitutions, and save them
+// under outPath with the same layout.
+func (cfg *AlertmanagerConfig) RenderConfigurationFiles(configPath string, outPath string) error {
+	err := cfg.processTemplate(configPath, outPath, AlertmanagerConfigTemplate, AlertmanagerConfigFile, "{{", "}}")
 	if err != nil {
 		return fmt.Errorf("error processing alertmanager config template: %w", err)
 	}
 	// NOTE: we use unique delimiters here because there are nested go templates in the alert messages
-	err = cfg.processTemplate(configPath, AlertingRulesConfigTemplate, AlertingRulesConfigFile, "{{{", "}}}")
+	err = cfg.processTemplate(configPath, outPath, AlertingRulesConfigTemplate, AlertingRulesConfigFile, "{{{", "}}}")
 	if err != nil {
 		return fmt.Errorf("error processing alerting rules template: %w", err)
 	}
 	return nil
 }
 
-func (cfg *AlertmanagerConfig) processTemplate(configPath string, templateFileName string, configFileName string, leftDelim string, rightDelim string) error {
+func (cfg *AlertmanagerConfig) processTemplate(configPath string, outPath string, templateFileName string, configFileName string, leftDelim string, rightDelim string) error {
 	templatePath, err := homedir.Expand(fmt.Sprintf("%s/%s", configPath, templateFileName))
 	if err != nil {
 		return fmt.Errorf("error expanding alerting template path for file %s: %w", templateFileName, err)
 	}
 
-	configFile, err := homedir.Expand(fmt.Sprintf("%s/%s", configPath, configFileName))
+	configFile, err := homedir.Expand(fmt.Sprintf("%s/%s", outPath, configFileName))
 	if err != nil {
 		return fmt.Errorf("error expanding alerting file out path for file %s: %w", configFileName, err)
 	}
@@ -0,0 +1,281 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Constants
+const (
+	BackupFolder string = "backups"
+)
+
+// The places backups can be saved to
+type BackupDestination string
+
+const (
+	BackupDestination_Local BackupDestination = "local"
+	BackupDestination_S3    BackupDestination = "s3"
+)
+
+// Defaults
+const defaultBackupInterval uint64 = 24
+const defaultBackupRetention uint64 = 7
+
+// Configuration for the node's encrypted backups
+type BackupConfig struct {
+	Title string `yaml:"-"`
+
+	// Toggle for the node daemon's scheduled backups
+	Enabled config.Parameter `yaml:"enabled,omitempty"`
+
+	// The time between scheduled backups
+	Interval config.Parameter `yaml:"interval,omitempty"`
+
+	// The number of backups to keep
+	Retention config.Parameter `yaml:"retention,omitempty"`
+
+	// The passphrase the backups are encrypted with
+	Passphrase config.Parameter `yaml:"passphrase,omitempty"`
+
+	// Where to save the backups
+	Destination config.Parameter `yaml:"destination,omitempty"`
+
+	// The folder to save backups to when they're saved locally
+	LocalPath config.Parameter `yaml:"localPath,omitempty"`
+
+	// The URL of the S3-compatible endpoint
+	S3Endpoint config.Parameter `yaml:"s3Endpoint,omitempty"`
+
+	// The region of the S3 bucket
+	S3Region config.Parameter `yaml:"s3Region,omitempty"`
+
+	// The S3 bucket
+	S3Bucket config.Parameter `yaml:"s3Bucket,omitempty"`
+
+	// The prefix of the backups' keys in the S3 bucket
+	S3Prefix config.Parameter `yaml:"s3Prefix,omitempty"`
+
+	// The S3 access key ID
+	S3AccessKey config.Parameter `yaml:"s3AccessKey,omitempty"`
+
+	// The S3 secret access key
+	S3SecretKey config.Parameter `yaml:"s3SecretKey,omitempty"`
+
+	parent *RocketPoolConfig
+}
+
+// Generates a new backup configuration
+func NewBackupConfig(cfg *RocketPoolConfig) *BackupConfig {
+	return &BackupConfig{
+		Title:  "Backup Settings",
+		parent: cfg,
+
+		Enabled: config.Parameter{
+			ID:                 "enabled",
+			Name:               "Enable Scheduled Backups",
+			Description:        "Have the node daemon back up your node wallet, settings, validator keys, custom keys, rolling records, and rewards trees on a schedule.\n\nBackups are encrypted with your backup passphrase. You can also make one at any time with `rocketpool service backup create`, which also exports your slashing protection data into the backup; scheduled backups can't, since that needs the validator client to be stopped.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		Interval: config.Parameter{
+			ID:                 "interval",
+			Name:               "Backup Interval",
+			Description:        "The time, in hours, between scheduled backups.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultBackupInterval},
+			AffectsContainers:  []config.ContainerID{},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		Retention: config.Parameter{
+			ID:                 "retention",
+			Name:               "Backups to Keep",
+			Description:        "The number of backups to keep. Older ones are deleted after each new backup is made. Use 0 to keep all of them.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultBackupRetention},
+			AffectsContainers:  []config.ContainerID{},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		Passphrase: config.Parameter{
+			ID:                 "passphrase",
+			Name:               "Backup Passphrase",
+			Description:        "The passphrase to encrypt your backups with. You'll need it to restore them, so store it somewhere safe outside of this machine.\n\n[orange]NOTE: Backups contain your node wallet and validator keys. Use a long passphrase that you don't use anywhere else.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+			Sensitive:          true,
+		},
+
+		Destination: config.Parameter{
+			ID:                 "destination",
+			Name:               "Destination",
+			Description:        "Choose where to save your backups.",
+			Type:               config.ParameterType_Choice,
+			Default:            map[config.Network]interface{}{config.Network_All: BackupDestination_Local},
+			AffectsContainers:  []config.ContainerID{},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+			Options: []config.ParameterOption{{
+				Name:        "Local Folder",
+				Description: "Save backups to a folder on this machine. Copy them somewhere else regularly, or they won't help if this machine's disk fails.",
+				Value:       BackupDestination_Local,
+			}, {
+				Name:        "S3-Compatible Storage",
+				Description: "Upload backups to a bucket on AWS S3 or any S3-compatible service, such as Backblaze B2, Cloudflare R2, or MinIO.",
+				Value:       BackupDestination_S3,
+			}},
+		},
+
+		LocalPath: config.Parameter{
+			ID:                 "localPath",
+			Name:               "Backup Folder",
+			Description:        "The folder to save backups to.\n\n[orange]NOTE: In Docker mode, this must be inside your Smartnode's data folder, since that's the only folder the Smartnode's containers can access.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: getDefaultBackupDir(cfg)},
+			AffectsContainers:  []config.ContainerID{},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		S3Endpoint: config.Parameter{
+			ID:                 "s3Endpoint",
+			Name:               "S3 Endpoint",
+			Description:        "The URL of the S3-compatible service, such as `https://s3.us-east-1.amazonaws.com`.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		S3Region: config.Parameter{
+			ID:                 "s3Region",
+			Name:               "S3 Region",
+			Description:        "The region of the bucket. Leave this blank if your service doesn't use regions.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		S3Bucket: config.Parameter{
+			ID:                 "s3Bucket",
+			Name:               "S3 Bucket",
+			Description:        "The name of the bucket to upload backups to.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		S3Prefix: config.Parameter{
+			ID:                 "s3Prefix",
+			Name:               "S3 Key Prefix",
+			Description:        "The folder in the bucket to upload backups to, such as `rocketpool/`. Leave this blank to upload them to the top of the bucket.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		S3AccessKey: config.Parameter{
+			ID:                 "s3AccessKey",
+			Name:               "S3 Access Key ID",
+			Description:        "The ID of the access key to upload backups with. It needs permission to list, read, write, and delete objects in the bucket.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+			Sensitive:          true,
+		},
+
+		S3SecretKey: config.Parameter{
+			ID:                 "s3SecretKey",
+			Name:               "S3 Secret Access Key",
+			Description:        "The secret of the access key to upload backups with.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+			Sensitive:          true,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *BackupConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Enabled,
+		&cfg.Interval,
+		&cfg.Retention,
+		&cfg.Passphrase,
+		&cfg.Destination,
+		&cfg.LocalPath,
+		&cfg.S3Endpoint,
+		&cfg.S3Region,
+		&cfg.S3Bucket,
+		&cfg.S3Prefix,
+		&cfg.S3AccessKey,
+		&cfg.S3SecretKey,
+	}
+}
+
+// The the title for the config
+func (cfg *BackupConfig) GetConfigTitle() string {
+	return cfg.Title
+}
+
+// Get the folder to save local backups to
+func (cfg *BackupConfig) GetLocalPath(daemon bool) (string, error) {
+	localPath := filepath.Clean(cfg.LocalPath.Value.(string))
+	if !daemon || cfg.parent.IsNativeMode {
+		return localPath, nil
+	}
+
+	// The daemons only have access to the data folder
+	relPath, err := filepath.Rel(filepath.Clean(cfg.parent.Smartnode.DataPath.Value.(string)), localPath)
+	if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("the backup folder (%s) must be inside the Smartnode's data folder (%s)", localPath, cfg.parent.Smartnode.DataPath.Value.(string))
+	}
+	return filepath.Join(DaemonDataPath, relPath), nil
+}
+
+// Get the host and TLS setting of the S3 endpoint
+func (cfg *BackupConfig) GetS3Endpoint() (string, bool, error) {
+	endpoint := cfg.S3Endpoint.Value.(string)
+	endpointUrl, err := url.Parse(endpoint)
+	if err != nil {
+		return "", false, fmt.Errorf("the S3 endpoint (%s) isn't a valid URL: %w", endpoint, err)
+	}
+	switch endpointUrl.Scheme {
+	case "https":
+		return endpointUrl.Host, true, nil
+	case "http":
+		return endpointUrl.Host, false, nil
+	default:
+		return "", false, fmt.Errorf("the S3 endpoint (%s) must start with https:// or http://", endpoint)
+	}
+}
+
+func getDefaultBackupDir(config *RocketPoolConfig) string {
+	return filepath.Join(getDefaultDataDir(config), BackupFolder)
+}
@@ -130,6 +130,7 @@ func NewBesuConfig(cfg *RocketPoolConfig) *BesuConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth1},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 	}
 }
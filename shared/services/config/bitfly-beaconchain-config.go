@@ -38,6 +38,7 @@ func NewBitflyNodeMetricsConfig(cfg *RocketPoolConfig) *BitflyNodeMetricsConfig
 			Regex:              "^[A-Za-z0-9+/]{28}$",
 			CanBeBlank:         false,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 
 		Endpoint: config.Parameter{
@@ -75,6 +75,7 @@ func NewConsensusCommonConfig(cfg *RocketPoolConfig) *ConsensusCommonConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth2},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 
 		P2pPort: config.Parameter{
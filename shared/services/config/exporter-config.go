@@ -60,6 +60,7 @@ func NewExporterConfig(cfg *RocketPoolConfig) *ExporterConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Grafana},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 	}
 }
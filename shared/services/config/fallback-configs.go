@@ -43,6 +43,7 @@ func NewFallbackNormalConfig(cfg *RocketPoolConfig) *FallbackNormalConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
 			CanBeBlank:         false,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 
 		CcHttpUrl: config.Parameter{
@@ -54,6 +55,7 @@ func NewFallbackNormalConfig(cfg *RocketPoolConfig) *FallbackNormalConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator, config.ContainerID_Watchtower},
 			CanBeBlank:         false,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 	}
 }
@@ -72,6 +74,7 @@ func NewFallbackPrysmConfig(cfg *RocketPoolConfig) *FallbackPrysmConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Watchtower},
 			CanBeBlank:         false,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 
 		CcHttpUrl: config.Parameter{
@@ -83,6 +86,7 @@ func NewFallbackPrysmConfig(cfg *RocketPoolConfig) *FallbackPrysmConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Api, config.ContainerID_Node, config.ContainerID_Validator, config.ContainerID_Watchtower},
 			CanBeBlank:         false,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 
 		JsonRpcUrl: config.Parameter{
@@ -94,6 +98,7 @@ func NewFallbackPrysmConfig(cfg *RocketPoolConfig) *FallbackPrysmConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth1},
 			CanBeBlank:         false,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 	}
 }
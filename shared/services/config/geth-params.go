@@ -117,6 +117,7 @@ func NewGethConfig(cfg *RocketPoolConfig) *GethConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth1},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 	}
 }
@@ -0,0 +1,113 @@
+package config
+
+import (
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Constants
+const (
+	GraffitiFilename string = "rp-graffiti.txt"
+)
+
+// The graffiti providers
+type GraffitiProvider string
+
+const (
+	GraffitiProvider_Static             GraffitiProvider = "static"
+	GraffitiProvider_Template           GraffitiProvider = "template"
+	GraffitiProvider_GraffitiWallWriter GraffitiProvider = "gww"
+)
+
+// Defaults
+const defaultGraffitiTemplate string = "RP-{{.EC}}{{.CC}} {{.Version}}{{if .Message}} {{.Message}}{{end}}"
+const defaultGraffitiMessageInterval uint64 = 60
+
+// Configuration for rendering the validator graffiti from a template
+type GraffitiTemplateConfig struct {
+	Title string `yaml:"-"`
+
+	// Toggle for rendering the graffiti from a template instead of the static one
+	Enabled config.Parameter `yaml:"enabled,omitempty"`
+
+	// The graffiti template
+	Template config.Parameter `yaml:"template,omitempty"`
+
+	// The messages to rotate through
+	Messages config.Parameter `yaml:"messages,omitempty"`
+
+	// The time between messages
+	MessageInterval config.Parameter `yaml:"messageInterval,omitempty"`
+}
+
+// Generates a new graffiti template configuration
+func NewGraffitiTemplateConfig(cfg *RocketPoolConfig) *GraffitiTemplateConfig {
+	return &GraffitiTemplateConfig{
+		Title: "Graffiti Template Settings",
+
+		Enabled: config.Parameter{
+			ID:                 "enabled",
+			Name:               "Enable Graffiti Template",
+			Description:        "Render the graffiti of your validators from a template instead of using the static graffiti. The node daemon renders it for each validator and refreshes it as your node changes.\n\nThis is only supported by Lighthouse, Prysm, and Teku. Teku uses the same graffiti for every validator, and Prysm only reads it when the validator client starts.\n\n[orange]NOTE: This can't be used at the same time as the Graffiti Wall Writer addon, which writes its own graffiti.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Validator, config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		Template: config.Parameter{
+			ID:   "template",
+			Name: "Template",
+			Description: "The template for the graffiti, in Go's text/template format. The rendered graffiti can be up to 32 bytes long. It can use:\n\n" +
+				"{{.EC}} and {{.CC}}: short codes for your clients, such as G and L\n" +
+				"{{.ECName}} and {{.CCName}}: the full client names\n" +
+				"{{.Version}}: the Smartnode version\n" +
+				"{{.MinipoolCount}}: the number of minipools your node has\n" +
+				"{{.ENSName}}: your node's ENS name, if it has one; if it makes the graffiti too long, the static graffiti is used instead\n" +
+				"{{.Message}}: the current rotating message\n" +
+				"{{.ValidatorIndex}} and {{.Pubkey}}: the validator's index and the start of its pubkey",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultGraffitiTemplate},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		Messages: config.Parameter{
+			ID:                 "messages",
+			Name:               "Rotating Messages",
+			Description:        "Messages for the template's {{.Message}} value, separated by `|`. The node daemon moves to the next one on every Message Interval.",
+			Type:               config.ParameterType_String,
+			Default:            map[config.Network]interface{}{config.Network_All: ""},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         true,
+			OverwriteOnUpgrade: false,
+		},
+
+		MessageInterval: config.Parameter{
+			ID:                 "messageInterval",
+			Name:               "Message Interval",
+			Description:        "The time, in minutes, to show each of the rotating messages for.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: defaultGraffitiMessageInterval},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+	}
+}
+
+// Get the parameters for this config
+func (cfg *GraffitiTemplateConfig) GetParameters() []*config.Parameter {
+	return []*config.Parameter{
+		&cfg.Enabled,
+		&cfg.Template,
+		&cfg.Messages,
+		&cfg.MessageInterval,
+	}
+}
+
+// The the title for the config
+func (cfg *GraffitiTemplateConfig) GetConfigTitle() string {
+	return cfg.Title
+}
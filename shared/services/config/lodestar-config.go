@@ -70,6 +70,7 @@ func NewLodestarConfig(cfg *RocketPoolConfig) *LodestarConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth2},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 
 		AdditionalVcFlags: config.Parameter{
@@ -81,6 +82,7 @@ func NewLodestarConfig(cfg *RocketPoolConfig) *LodestarConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Validator},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 	}
 }
@@ -2,53 +2,128 @@ package migration
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/go-version"
 )
 
+// A settings migration for configs made by a Smartnode version at or below Version.
+// DowngradeFunc is optional; migrations without one can't be reversed.
 type ConfigUpgrader struct {
+	Version       *version.Version
+	Description   string
+	UpgradeFunc   func(serializedConfig map[string]map[string]string) error
+	DowngradeFunc func(serializedConfig map[string]map[string]string) error
+}
+
+// A single setting that a migration changed
+type SettingChange struct {
+	Section  string
+	Key      string
+	OldValue string
+	NewValue string
+	Added    bool
+	Removed  bool
+}
+
+// All of the migrations, in the order they're applied
+var upgraders = []ConfigUpgrader{
+	{
+		Version:       mustParseVersion("1.3.1"),
+		Description:   "Move the common Execution client settings out of the Geth section",
+		UpgradeFunc:   upgradeFromV131,
+		DowngradeFunc: downgradeToV131,
+	}, {
+		Version:       mustParseVersion("1.5.1"),
+		Description:   "Rename the Nimbus additional flags to the Beacon Node additional flags",
+		UpgradeFunc:   upgradeFromV151,
+		DowngradeFunc: downgradeToV151,
+	}, {
+		Version:       mustParseVersion("1.9.8"),
+		Description:   "Replace the boolean RPC port settings with RPC port modes",
+		UpgradeFunc:   upgradeFromV198,
+		DowngradeFunc: downgradeToV198,
+	},
+}
+
+// The settings a single migration changed
+type MigrationStep struct {
 	Version     *version.Version
-	UpgradeFunc func(serializedConfig map[string]map[string]string) error
+	Description string
+	Changes     []SettingChange
 }
 
 func UpdateConfig(serializedConfig map[string]map[string]string) error {
+	_, err := UpgradeConfig(serializedConfig)
+	return err
+}
 
-	// Get the config's version
-	configVersion, err := getVersionFromConfig(serializedConfig)
+// Apply every pending upgrade to the provided config in place, returning the settings each one changed
+func UpgradeConfig(serializedConfig map[string]map[string]string) ([]MigrationStep, error) {
+
+	// Get the upgraders that apply to the provided config
+	pending, err := GetPendingUpgraders(serializedConfig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Create versions
-	v131, err := parseVersion("1.3.1")
-	if err != nil {
-		return err
+	// Apply them all in series
+	steps := make([]MigrationStep, 0, len(pending))
+	for _, upgrader := range pending {
+		step, err := applyMigration(serializedConfig, upgrader, upgrader.UpgradeFunc)
+		if err != nil {
+			return nil, fmt.Errorf("error applying upgrade for config version %s: %w", upgrader.Version.String(), err)
+		}
+		steps = append(steps, step)
 	}
-	v151, err := parseVersion("1.5.1")
+
+	return steps, nil
+
+}
+
+// Reverse the upgrades applied to the provided config in place so it can be used by an older Smartnode version,
+// returning the settings each one changed
+func DowngradeConfig(serializedConfig map[string]map[string]string, target *version.Version) ([]MigrationStep, error) {
+
+	// Get the upgraders to reverse
+	applied, err := GetReversibleUpgraders(serializedConfig, target)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	v198, err := parseVersion("1.9.8")
-	if err != nil {
-		return err
+	for _, upgrader := range applied {
+		if upgrader.DowngradeFunc == nil {
+			return nil, fmt.Errorf("the upgrade for config version %s can't be reversed", upgrader.Version.String())
+		}
+	}
+
+	// Reverse them all in series
+	steps := make([]MigrationStep, 0, len(applied))
+	for _, upgrader := range applied {
+		step, err := applyMigration(serializedConfig, upgrader, upgrader.DowngradeFunc)
+		if err != nil {
+			return nil, fmt.Errorf("error reversing upgrade for config version %s: %w", upgrader.Version.String(), err)
+		}
+		steps = append(steps, step)
 	}
 
-	// Create the collection of upgraders
-	upgraders := []ConfigUpgrader{
-		{
-			Version:     v131,
-			UpgradeFunc: upgradeFromV131,
-		}, {
-			Version:     v151,
-			UpgradeFunc: upgradeFromV151,
-		}, {
-			Version:     v198,
-			UpgradeFunc: upgradeFromV198,
-		},
+	// Mark the config as belonging to the target version
+	serializedConfig["root"]["version"] = fmt.Sprintf("v%s", target.String())
+	return steps, nil
+
+}
+
+// Get the upgraders that need to be applied to the given config, in order
+func GetPendingUpgraders(serializedConfig map[string]map[string]string) ([]ConfigUpgrader, error) {
+
+	// Get the config's version
+	configVersion, err := getVersionFromConfig(serializedConfig)
+	if err != nil {
+		return nil, err
 	}
 
-	// Find the index of the provided config's version
+	// Find the index of the provided config's version. Like every earlier release, this is the last upgrader the
+	// config's version is at or below, so older configs only get the latest upgrade.
 	targetIndex := -1
 	for i, upgrader := range upgraders {
 		if configVersion.LessThanOrEqual(upgrader.Version) {
@@ -58,20 +133,96 @@ func UpdateConfig(serializedConfig map[string]map[string]string) error {
 
 	// If there are no upgrades to apply, return
 	if targetIndex == -1 {
-		return nil
+		return []ConfigUpgrader{}, nil
 	}
+	return upgraders[targetIndex:], nil
 
-	// If there are upgrades, start at the first applicable index and apply them all in series
-	for i := targetIndex; i < len(upgraders); i++ {
+}
+
+// Get the upgraders that have to be reversed to take the given config back to the target version, in the order
+// they need to be reversed
+func GetReversibleUpgraders(serializedConfig map[string]map[string]string, target *version.Version) ([]ConfigUpgrader, error) {
+
+	// Get the config's version
+	configVersion, err := getVersionFromConfig(serializedConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// Undo every upgrade the target version would need but the config already has
+	applied := []ConfigUpgrader{}
+	for i := len(upgraders) - 1; i >= 0; i-- {
 		upgrader := upgraders[i]
-		err = upgrader.UpgradeFunc(serializedConfig)
-		if err != nil {
-			return fmt.Errorf("error applying upgrade for config version %s: %w", upgrader.Version.String(), err)
+		if target.LessThanOrEqual(upgrader.Version) && configVersion.GreaterThan(upgrader.Version) {
+			applied = append(applied, upgrader)
 		}
 	}
+	return applied, nil
+
+}
 
-	return nil
+// Run one direction of a migration on the provided config and record what it changed
+func applyMigration(serializedConfig map[string]map[string]string, upgrader ConfigUpgrader, migrate func(map[string]map[string]string) error) (MigrationStep, error) {
+	oldConfig := CopyConfig(serializedConfig)
+	err := migrate(serializedConfig)
+	if err != nil {
+		return MigrationStep{}, err
+	}
+	return MigrationStep{
+		Version:     upgrader.Version,
+		Description: upgrader.Description,
+		Changes:     GetChanges(oldConfig, serializedConfig),
+	}, nil
+}
 
+// Make a deep copy of a serialized config
+func CopyConfig(serializedConfig map[string]map[string]string) map[string]map[string]string {
+	configCopy := make(map[string]map[string]string, len(serializedConfig))
+	for section, settings := range serializedConfig {
+		sectionCopy := make(map[string]string, len(settings))
+		for key, value := range settings {
+			sectionCopy[key] = value
+		}
+		configCopy[section] = sectionCopy
+	}
+	return configCopy
+}
+
+// Get the settings that differ between two serialized configs, sorted by section and key
+func GetChanges(oldConfig map[string]map[string]string, newConfig map[string]map[string]string) []SettingChange {
+	changes := []SettingChange{}
+	for section, newSettings := range newConfig {
+		oldSettings := oldConfig[section]
+		for key, newValue := range newSettings {
+			oldValue, exists := oldSettings[key]
+			if !exists {
+				changes = append(changes, SettingChange{Section: section, Key: key, NewValue: newValue, Added: true})
+			} else if oldValue != newValue {
+				changes = append(changes, SettingChange{Section: section, Key: key, OldValue: oldValue, NewValue: newValue})
+			}
+		}
+	}
+	for section, oldSettings := range oldConfig {
+		newSettings := newConfig[section]
+		for key, oldValue := range oldSettings {
+			if _, exists := newSettings[key]; !exists {
+				changes = append(changes, SettingChange{Section: section, Key: key, OldValue: oldValue, Removed: true})
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Section != changes[j].Section {
+			return changes[i].Section < changes[j].Section
+		}
+		return changes[i].Key < changes[j].Key
+	})
+	return changes
+}
+
+// Get the Smartnode version that the given config was built with
+func GetVersionFromConfig(serializedConfig map[string]map[string]string) (*version.Version, error) {
+	return getVersionFromConfig(serializedConfig)
 }
 
 // Get the Smartnode version that the given config was built with
@@ -102,3 +253,12 @@ func parseVersion(versionString string) (*version.Version, error) {
 	}
 	return parsedVersion, nil
 }
+
+// Parses a version string for a migration; the strings are constants, so any error is a programming mistake
+func mustParseVersion(versionString string) *version.Version {
+	parsedVersion, err := parseVersion(versionString)
+	if err != nil {
+		panic(err.Error())
+	}
+	return parsedVersion
+}
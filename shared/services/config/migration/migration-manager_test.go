@@ -0,0 +1,42 @@
+package migration
+
+import (
+	"testing"
+)
+
+func TestGetPendingUpgraders(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected []string
+	}{
+		// Older configs only get the latest upgrade, as in every earlier release
+		{"v1.2.0", []string{"1.9.8"}},
+		{"v1.5.1", []string{"1.9.8"}},
+		{"v1.9.8", []string{"1.9.8"}},
+		{"v1.9.9", []string{}},
+		{"v1.13.0", []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			pending, err := GetPendingUpgraders(map[string]map[string]string{
+				"root": {"version": test.version},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(pending) != len(test.expected) {
+				t.Fatalf("expected %d pending upgraders, got %d", len(test.expected), len(pending))
+			}
+			for i, upgrader := range pending {
+				if upgrader.Version.String() != test.expected[i] {
+					t.Fatalf("expected upgrader %s at position %d, got %s", test.expected[i], i, upgrader.Version.String())
+				}
+			}
+		})
+	}
+
+	if _, err := GetPendingUpgraders(map[string]map[string]string{}); err == nil {
+		t.Fatalf("expected a config without a version to be rejected")
+	}
+}
@@ -33,3 +33,25 @@ func upgradeFromV131(serializedConfig map[string]map[string]string) error {
 
 	return nil
 }
+
+func downgradeToV131(serializedConfig map[string]map[string]string) error {
+	// v1.3.1 read the common EC parameters from the Geth config
+	executionCommonSettings, exists := serializedConfig["executionCommon"]
+	if !exists {
+		return fmt.Errorf("expected a section called `executionCommon` but it didn't exist")
+	}
+	gethSettings, exists := serializedConfig["geth"]
+	if !exists {
+		gethSettings = map[string]string{}
+		serializedConfig["geth"] = gethSettings
+	}
+	for _, key := range []string{"p2pPort", "ethstatsLabel", "ethstatsLogin"} {
+		value, exists := executionCommonSettings[key]
+		if !exists {
+			return fmt.Errorf("expected a executionCommon setting named `%s` but it didn't exist", key)
+		}
+		gethSettings[key] = value
+	}
+
+	return nil
+}
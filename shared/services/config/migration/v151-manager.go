@@ -19,3 +19,19 @@ func upgradeFromV151(serializedConfig map[string]map[string]string) error {
 
 	return nil
 }
+
+func downgradeToV151(serializedConfig map[string]map[string]string) error {
+	// v1.5.1 read the Nimbus BN additional flags from the old name
+	nimbusSettings, exists := serializedConfig["nimbus"]
+	if !exists {
+		return fmt.Errorf("expected a section called `nimbus` but it didn't exist")
+	}
+	additionalBnFlags, exists := nimbusSettings["additionalBnFlags"]
+	if !exists {
+		return fmt.Errorf("expected a Nimbus setting named `additionalBnFlags` but it didn't exist")
+	}
+
+	// Update the config
+	nimbusSettings["additionalFlags"] = additionalBnFlags
+	return nil
+}
@@ -24,6 +24,34 @@ func upgradeFromV198(serializedConfig map[string]map[string]string) error {
 	return nil
 }
 
+func downgradeToV198(serializedConfig map[string]map[string]string) error {
+	// v1.9.8 had the BN API port mode as a boolean
+	for _, setting := range [][2]string{
+		{"consensusCommon", "openApiPort"},
+		{"prysm", "openRpcPort"},
+		{"executionCommon", "openRpcPorts"},
+		{"mevBoost", "openRpcPort"},
+		{"prometheus", "openPort"},
+	} {
+		configSection, exists := serializedConfig[setting[0]]
+		if !exists {
+			continue
+		}
+		portMode, exists := configSection[setting[1]]
+		if !exists {
+			continue
+		}
+
+		// Any mode that opens the port becomes true; it was only ever opened on localhost back then
+		if portMode == "" || portMode == config.RPC_Closed.String() {
+			configSection[setting[1]] = "false"
+		} else {
+			configSection[setting[1]] = "true"
+		}
+	}
+	return nil
+}
+
 func updateRPCPortConfig(serializedConfig map[string]map[string]string, configKeyString string, keyOpenPorts string) error {
 	// v1.9.8 had the API ports mode as a boolean
 	configSection, exists := serializedConfig[configKeyString]
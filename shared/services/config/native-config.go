@@ -41,6 +41,7 @@ func NewNativeConfig(cfg *RocketPoolConfig) *NativeConfig {
 			AffectsContainers:  []config.ContainerID{},
 			CanBeBlank:         false,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 
 		ConsensusClient: config.Parameter{
@@ -84,6 +85,7 @@ func NewNativeConfig(cfg *RocketPoolConfig) *NativeConfig {
 			AffectsContainers:  []config.ContainerID{},
 			CanBeBlank:         false,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 
 		ValidatorRestartCommand: config.Parameter{
@@ -148,6 +148,7 @@ func NewNethermindConfig(cfg *RocketPoolConfig) *NethermindConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth1},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 
 		ContainerTag: config.Parameter{
@@ -174,6 +175,7 @@ func NewNethermindConfig(cfg *RocketPoolConfig) *NethermindConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth1},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 	}
 }
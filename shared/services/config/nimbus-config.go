@@ -120,6 +120,7 @@ func NewNimbusConfig(cfg *RocketPoolConfig) *NimbusConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth2},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 
 		AdditionalVcFlags: config.Parameter{
@@ -131,6 +132,7 @@ func NewNimbusConfig(cfg *RocketPoolConfig) *NimbusConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Validator},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 	}
 }
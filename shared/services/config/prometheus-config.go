@@ -77,6 +77,7 @@ func NewPrometheusConfig(cfg *RocketPoolConfig) *PrometheusConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Grafana},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 	}
 }
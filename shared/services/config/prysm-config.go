@@ -125,6 +125,7 @@ func NewPrysmConfig(cfg *RocketPoolConfig) *PrysmConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth2},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 
 		AdditionalVcFlags: config.Parameter{
@@ -136,6 +137,7 @@ func NewPrysmConfig(cfg *RocketPoolConfig) *PrysmConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Validator},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 	}
 }
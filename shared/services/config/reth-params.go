@@ -118,6 +118,7 @@ func NewRethConfig(cfg *RocketPoolConfig) *RethConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth1},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 	}
 }
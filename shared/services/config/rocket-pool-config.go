@@ -13,11 +13,15 @@ import (
 
 	"github.com/alessio/shellescape"
 	externalip "github.com/glendc/go-external-ip"
+	"github.com/mitchellh/go-homedir"
 	"github.com/pbnjay/memory"
+	rptypes "github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/addons"
+	"github.com/rocket-pool/smartnode/addons/manifest"
 	"github.com/rocket-pool/smartnode/addons/rescue_node"
 	"github.com/rocket-pool/smartnode/shared"
 	"github.com/rocket-pool/smartnode/shared/services/config/migration"
+	"github.com/rocket-pool/smartnode/shared/services/graffiti"
 	addontypes "github.com/rocket-pool/smartnode/shared/types/addons"
 	"github.com/rocket-pool/smartnode/shared/types/config"
 	"gopkg.in/yaml.v2"
@@ -73,15 +77,16 @@ type RocketPoolConfig struct {
 	ExternalConsensusClient config.Parameter `yaml:"externalConsensusClient,omitempty"`
 
 	// Metrics settings
-	EnableMetrics           config.Parameter `yaml:"enableMetrics,omitempty"`
-	EnableODaoMetrics       config.Parameter `yaml:"enableODaoMetrics,omitempty"`
-	EcMetricsPort           config.Parameter `yaml:"ecMetricsPort,omitempty"`
-	BnMetricsPort           config.Parameter `yaml:"bnMetricsPort,omitempty"`
-	VcMetricsPort           config.Parameter `yaml:"vcMetricsPort,omitempty"`
-	NodeMetricsPort         config.Parameter `yaml:"nodeMetricsPort,omitempty"`
-	ExporterMetricsPort     config.Parameter `yaml:"exporterMetricsPort,omitempty"`
-	WatchtowerMetricsPort   config.Parameter `yaml:"watchtowerMetricsPort,omitempty"`
-	EnableBitflyNodeMetrics config.Parameter `yaml:"enableBitflyNodeMetrics,omitempty"`
+	EnableMetrics             config.Parameter `yaml:"enableMetrics,omitempty"`
+	EnableODaoMetrics         config.Parameter `yaml:"enableODaoMetrics,omitempty"`
+	EnablePdaoDelegateMetrics config.Parameter `yaml:"enablePdaoDelegateMetrics,omitempty"`
+	EcMetricsPort             config.Parameter `yaml:"ecMetricsPort,omitempty"`
+	BnMetricsPort             config.Parameter `yaml:"bnMetricsPort,omitempty"`
+	VcMetricsPort             config.Parameter `yaml:"vcMetricsPort,omitempty"`
+	NodeMetricsPort           config.Parameter `yaml:"nodeMetricsPort,omitempty"`
+	ExporterMetricsPort       config.Parameter `yaml:"exporterMetricsPort,omitempty"`
+	WatchtowerMetricsPort     config.Parameter `yaml:"watchtowerMetricsPort,omitempty"`
+	EnableBitflyNodeMetrics   config.Parameter `yaml:"enableBitflyNodeMetrics,omitempty"`
 
 	// The Smartnode configuration
 	Smartnode *SmartnodeConfig `yaml:"smartnode,omitempty"`
@@ -125,9 +130,25 @@ type RocketPoolConfig struct {
 	EnableMevBoost config.Parameter `yaml:"enableMevBoost,omitempty"`
 	MevBoost       *MevBoostConfig  `yaml:"mevBoost,omitempty"`
 
+	// Graffiti templates
+	GraffitiTemplate *GraffitiTemplateConfig `yaml:"graffitiTemplate,omitempty"`
+
+	// Backups
+	Backup *BackupConfig `yaml:"backup,omitempty"`
+
 	// Addons
 	GraffitiWallWriter addontypes.SmartnodeAddon `yaml:"addon-gww,omitempty"`
 	RescueNode         addontypes.SmartnodeAddon `yaml:"addon-rescue-node,omitempty"`
+
+	// Addons described by manifests in the user addons folder, and the reasons any of them couldn't be loaded
+	UserAddons      []*manifest.UserAddon `yaml:"-"`
+	UserAddonErrors []error               `yaml:"-"`
+}
+
+// The data used by text/template to format the compose template of a user addon
+type UserAddonTemplateData struct {
+	*RocketPoolConfig
+	Addon *manifest.UserAddon
 }
 
 // Get the external IP address. Try finding an IPv4 address first to:
@@ -158,15 +179,9 @@ func LoadFromFile(path string) (*RocketPoolConfig, error) {
 	}
 
 	// Read the file
-	configBytes, err := os.ReadFile(path)
+	settings, err := ReadSettingsFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("could not read Rocket Pool settings file at %s: %w", shellescape.Quote(path), err)
-	}
-
-	// Attempt to parse it out into a settings map
-	var settings map[string]map[string]string
-	if err := yaml.Unmarshal(configBytes, &settings); err != nil {
-		return nil, fmt.Errorf("could not parse settings file: %w", err)
+		return nil, err
 	}
 
 	// Deserialize it into a config object
@@ -180,6 +195,24 @@ func LoadFromFile(path string) (*RocketPoolConfig, error) {
 
 }
 
+// Read a settings file into a map of maps without deserializing it
+func ReadSettingsFile(path string) (map[string]map[string]string, error) {
+
+	// Read the file
+	configBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read Rocket Pool settings file at %s: %w", shellescape.Quote(path), err)
+	}
+
+	// Attempt to parse it out into a settings map
+	var settings map[string]map[string]string
+	if err := yaml.Unmarshal(configBytes, &settings); err != nil {
+		return nil, fmt.Errorf("could not parse settings file: %w", err)
+	}
+	return settings, nil
+
+}
+
 // Creates a new Rocket Pool configuration instance
 func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 
@@ -358,6 +391,17 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 			OverwriteOnUpgrade: false,
 		},
 
+		EnablePdaoDelegateMetrics: config.Parameter{
+			ID:                 "enablePdaoDelegateMetrics",
+			Name:               "Enable Protocol DAO Delegate Metrics",
+			Description:        "Enable the tracking of Protocol DAO delegate metrics, such as the voting power and participation of the top delegates.\n\n[orange]NOTE: each update builds a voting snapshot of the whole network and looks up every top delegate's vote on every proposal, which puts a noticeable load on your Execution client. Updates run every 6 hours.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
 		EnableBitflyNodeMetrics: config.Parameter{
 			ID:                 "enableBitflyNodeMetrics",
 			Name:               "Enable Beaconcha.in Node Metrics",
@@ -478,10 +522,13 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 	cfg.BitflyNodeMetrics = NewBitflyNodeMetricsConfig(cfg)
 	cfg.Native = NewNativeConfig(cfg)
 	cfg.MevBoost = NewMevBoostConfig(cfg)
+	cfg.GraffitiTemplate = NewGraffitiTemplateConfig(cfg)
+	cfg.Backup = NewBackupConfig(cfg)
 
 	// Addons
 	cfg.GraffitiWallWriter = addons.NewGraffitiWallWriter()
 	cfg.RescueNode = addons.NewRescueNode()
+	cfg.loadUserAddons()
 
 	// Apply the default values for mainnet
 	cfg.Smartnode.Network.Value = cfg.Smartnode.Network.Options[0].Value
@@ -490,6 +537,22 @@ func NewRocketPoolConfig(rpDir string, isNativeMode bool) *RocketPoolConfig {
 	return cfg
 }
 
+// Load the addons in the user addons folder. Native mode doesn't support addons, so they're only loaded in Docker mode.
+func (cfg *RocketPoolConfig) loadUserAddons() {
+	cfg.UserAddons = []*manifest.UserAddon{}
+	cfg.UserAddonErrors = nil
+	if cfg.IsNativeMode || cfg.RocketPoolDirectory == "" {
+		return
+	}
+
+	rpDir, err := homedir.Expand(cfg.RocketPoolDirectory)
+	if err != nil {
+		cfg.UserAddonErrors = []error{fmt.Errorf("error expanding Rocket Pool directory: %w", err)}
+		return
+	}
+	cfg.UserAddons, cfg.UserAddonErrors = addons.NewUserAddons(filepath.Join(rpDir, manifest.UserAddonsFolder))
+}
+
 // Get a more verbose client description, including warnings
 func getAugmentedEcDescription(client config.ExecutionClient, originalDescription string) string {
 
@@ -509,6 +572,13 @@ func getAugmentedEcDescription(client config.ExecutionClient, originalDescriptio
 func (cfg *RocketPoolConfig) CreateCopy() *RocketPoolConfig {
 	newConfig := NewRocketPoolConfig(cfg.RocketPoolDirectory, cfg.IsNativeMode)
 
+	// Use the same user addons so the subconfigs line up
+	newConfig.UserAddons = make([]*manifest.UserAddon, len(cfg.UserAddons))
+	for i, addon := range cfg.UserAddons {
+		newConfig.UserAddons[i] = addon.CreateCopy()
+	}
+	newConfig.UserAddonErrors = cfg.UserAddonErrors
+
 	// Set the network
 	network := cfg.Smartnode.Network.Value.(config.Network)
 	newConfig.Smartnode.Network.Value = network
@@ -543,6 +613,7 @@ func (cfg *RocketPoolConfig) GetParameters() []*config.Parameter {
 		&cfg.ExternalConsensusClient,
 		&cfg.EnableMetrics,
 		&cfg.EnableODaoMetrics,
+		&cfg.EnablePdaoDelegateMetrics,
 		&cfg.EnableBitflyNodeMetrics,
 		&cfg.EcMetricsPort,
 		&cfg.BnMetricsPort,
@@ -556,7 +627,7 @@ func (cfg *RocketPoolConfig) GetParameters() []*config.Parameter {
 
 // Get the subconfigurations for this config
 func (cfg *RocketPoolConfig) GetSubconfigs() map[string]config.Config {
-	return map[string]config.Config{
+	subconfigs := map[string]config.Config{
 		"smartnode":          cfg.Smartnode,
 		"executionCommon":    cfg.ExecutionCommon,
 		"geth":               cfg.Geth,
@@ -584,9 +655,35 @@ func (cfg *RocketPoolConfig) GetSubconfigs() map[string]config.Config {
 		"bitflyNodeMetrics":  cfg.BitflyNodeMetrics,
 		"native":             cfg.Native,
 		"mevBoost":           cfg.MevBoost,
+		"graffitiTemplate":   cfg.GraffitiTemplate,
+		"backup":             cfg.Backup,
 		"addons-gww":         cfg.GraffitiWallWriter.GetConfig(),
 		"addons-rescue-node": cfg.RescueNode.GetConfig(),
 	}
+	for _, addon := range cfg.UserAddons {
+		subconfigs[addon.GetSectionName()] = addon.GetConfig()
+	}
+	return subconfigs
+}
+
+// Get all of the addons, starting with the built-in ones
+func (cfg *RocketPoolConfig) GetAddons() []addontypes.SmartnodeAddon {
+	allAddons := []addontypes.SmartnodeAddon{cfg.GraffitiWallWriter, cfg.RescueNode}
+	for _, addon := range cfg.UserAddons {
+		allAddons = append(allAddons, addon)
+	}
+	return allAddons
+}
+
+// Get the user addons that are enabled
+func (cfg *RocketPoolConfig) GetEnabledUserAddons() []*manifest.UserAddon {
+	enabledAddons := []*manifest.UserAddon{}
+	for _, addon := range cfg.UserAddons {
+		if addon.GetEnabledParameter().Value == true {
+			enabledAddons = append(enabledAddons, addon)
+		}
+	}
+	return enabledAddons
 }
 
 // Handle a network change on all of the parameters
@@ -749,13 +846,24 @@ func (cfg *RocketPoolConfig) IsDoppelgangerEnabled() (bool, error) {
 
 // Serializes the configuration into a map of maps, compatible with a settings file
 func (cfg *RocketPoolConfig) Serialize() map[string]map[string]string {
+	return cfg.serialize((*config.Parameter).Serialize)
+}
+
+// Serializes the configuration like Serialize, but with the values of sensitive parameters (such as API keys and
+// client URLs) masked so the result can be shared
+func (cfg *RocketPoolConfig) SerializeRedacted() map[string]map[string]string {
+	return cfg.serialize((*config.Parameter).SerializeRedacted)
+}
+
+// Serializes the configuration into a map of maps, using the provided function to serialize each parameter
+func (cfg *RocketPoolConfig) serialize(serializeParam func(*config.Parameter, map[string]string)) map[string]map[string]string {
 
 	masterMap := map[string]map[string]string{}
 
 	// Serialize root params
 	rootParams := map[string]string{}
 	for _, param := range cfg.GetParameters() {
-		param.Serialize(rootParams)
+		serializeParam(param, rootParams)
 	}
 	masterMap[rootConfigName] = rootParams
 	masterMap[rootConfigName]["rpDir"] = cfg.RocketPoolDirectory
@@ -766,7 +874,7 @@ func (cfg *RocketPoolConfig) Serialize() map[string]map[string]string {
 	for name, subconfig := range cfg.GetSubconfigs() {
 		subconfigParams := map[string]string{}
 		for _, param := range subconfig.GetParameters() {
-			param.Serialize(subconfigParams)
+			serializeParam(param, subconfigParams)
 		}
 		masterMap[name] = subconfigParams
 	}
@@ -1008,27 +1116,66 @@ func (cfg *RocketPoolConfig) GraffitiPrefix() string {
 	identifier := ""
 	versionString := fmt.Sprintf("v%s", shared.RocketPoolVersion)
 	if len(versionString) < 8 {
-		var ecInitial string
-		if !cfg.ExecutionClientLocal() {
-			ecInitial = "X"
-		} else {
-			ecInitial = strings.ToUpper(string(cfg.ExecutionClient.Value.(config.ExecutionClient))[:1])
-		}
-
-		var ccInitial string
-		consensusClient, _ := cfg.GetSelectedConsensusClient()
-		switch consensusClient {
-		case config.ConsensusClient_Lodestar:
-			ccInitial = "S" // Lodestar is special because it conflicts with Lighthouse
-		default:
-			ccInitial = strings.ToUpper(string(consensusClient)[:1])
-		}
+		ecInitial, ccInitial := cfg.GetGraffitiClientCodes()
 		identifier = fmt.Sprintf("-%s%s", ecInitial, ccInitial)
 	}
 
 	return fmt.Sprintf("RP%s %s", identifier, versionString)
 }
 
+// Get the short codes for the selected clients that are used in graffiti
+func (cfg *RocketPoolConfig) GetGraffitiClientCodes() (string, string) {
+	var ecInitial string
+	if !cfg.ExecutionClientLocal() {
+		ecInitial = "X"
+	} else {
+		ecInitial = strings.ToUpper(string(cfg.ExecutionClient.Value.(config.ExecutionClient))[:1])
+	}
+
+	var ccInitial string
+	consensusClient, _ := cfg.GetSelectedConsensusClient()
+	switch consensusClient {
+	case config.ConsensusClient_Lodestar:
+		ccInitial = "S" // Lodestar is special because it conflicts with Lighthouse
+	default:
+		ccInitial = strings.ToUpper(string(consensusClient)[:1])
+	}
+	return ecInitial, ccInitial
+}
+
+// Get the provider of the validator graffiti
+func (cfg *RocketPoolConfig) GetGraffitiProvider() GraffitiProvider {
+	if cfg.GraffitiWallWriter.GetEnabledParameter().Value == true {
+		return GraffitiProvider_GraffitiWallWriter
+	}
+	cc, _ := cfg.GetSelectedConsensusClient()
+	if cfg.GraffitiTemplate.Enabled.Value == true && graffiti.IsFileSupported(cc) {
+		return GraffitiProvider_Template
+	}
+	return GraffitiProvider_Static
+}
+
+// Get the values for the graffiti template that come from the config; the rest depend on the node's state
+func (cfg *RocketPoolConfig) GetGraffitiTemplateData(now time.Time) graffiti.TemplateData {
+	ecCode, ccCode := cfg.GetGraffitiClientCodes()
+	ecName := "external"
+	if cfg.ExecutionClientLocal() {
+		ecName = string(cfg.ExecutionClient.Value.(config.ExecutionClient))
+	}
+	cc, _ := cfg.GetSelectedConsensusClient()
+	messages := graffiti.GetMessages(cfg.GraffitiTemplate.Messages.Value.(string))
+	interval := time.Duration(cfg.GraffitiTemplate.MessageInterval.Value.(uint64)) * time.Minute
+
+	return graffiti.TemplateData{
+		EC:      ecCode,
+		CC:      ccCode,
+		ECName:  ecName,
+		CCName:  string(cc),
+		Version: fmt.Sprintf("v%s", shared.RocketPoolVersion),
+		Message: graffiti.GetCurrentMessage(messages, interval, now),
+	}
+}
+
 // Used by text/template to format validator.yml
 func (cfg *RocketPoolConfig) Graffiti() (string, error) {
 	prefix := cfg.GraffitiPrefix()
@@ -1042,6 +1189,75 @@ func (cfg *RocketPoolConfig) Graffiti() (string, error) {
 	return fmt.Sprintf("%s (%s)", prefix, customGraffiti), nil
 }
 
+// Check that the graffiti template works with the selected client and renders a graffiti that fits in a block
+func (cfg *RocketPoolConfig) validateGraffitiTemplate() []string {
+	errors := []string{}
+	if cfg.GraffitiWallWriter.GetEnabledParameter().Value == true {
+		errors = append(errors, "The graffiti template and the Graffiti Wall Writer addon both write your validator graffiti. Please disable one of them.")
+	}
+	cc, _ := cfg.GetSelectedConsensusClient()
+	if !graffiti.IsFileSupported(cc) {
+		errors = append(errors, fmt.Sprintf("The graffiti template isn't supported by the %s validator client; it requires Lighthouse, Prysm, or Teku.", cc))
+	}
+
+	tmpl, err := graffiti.NewTemplate(cfg.GraffitiTemplate.Template.Value.(string))
+	if err != nil {
+		return append(errors, fmt.Sprintf("The graffiti template is invalid: %s", err.Error()))
+	}
+
+	// Try it with each of the messages and a validator shown the way the node daemon shows it. The node's ENS name isn't
+	// known here, so the node daemon warns when that's what makes the graffiti too long.
+	messages := graffiti.GetMessages(cfg.GraffitiTemplate.Messages.Value.(string))
+	if len(messages) == 0 {
+		messages = []string{""}
+	}
+	data := cfg.GetGraffitiTemplateData(time.Now())
+	data.MinipoolCount = 1
+	data.ValidatorIndex = "1000000"
+	data.Pubkey = graffiti.FormatPubkey(rptypes.ValidatorPubkey{})
+	for _, message := range messages {
+		data.Message = message
+		_, err = tmpl.Render(data)
+		if err != nil {
+			errors = append(errors, fmt.Sprintf("The graffiti template doesn't work with the message [%s]: %s", message, err.Error()))
+		}
+	}
+	return errors
+}
+
+// Check the backup settings for problems
+func (cfg *RocketPoolConfig) validateBackup() []string {
+	backup := cfg.Backup
+	errors := []string{}
+	if backup.Enabled.Value == true && backup.Passphrase.Value.(string) == "" {
+		errors = append(errors, "Scheduled backups are enabled, but the backup passphrase is blank.")
+	}
+	if backup.Enabled.Value == true && backup.Interval.Value.(uint64) == 0 {
+		errors = append(errors, "The backup interval must be at least 1 hour.")
+	}
+
+	switch backup.Destination.Value.(BackupDestination) {
+	case BackupDestination_Local:
+		if _, err := backup.GetLocalPath(true); err != nil {
+			errors = append(errors, fmt.Sprintf("The backup folder is invalid: %s.", err.Error()))
+		}
+	case BackupDestination_S3:
+		if backup.Enabled.Value != true && backup.S3Endpoint.Value.(string) == "" {
+			break
+		}
+		if _, _, err := backup.GetS3Endpoint(); err != nil {
+			errors = append(errors, fmt.Sprintf("The backup S3 endpoint is invalid: %s.", err.Error()))
+		}
+		if backup.S3Bucket.Value.(string) == "" {
+			errors = append(errors, "Backups are saved to S3, but the bucket is blank.")
+		}
+		if backup.S3AccessKey.Value.(string) == "" || backup.S3SecretKey.Value.(string) == "" {
+			errors = append(errors, "Backups are saved to S3, but the access key ID or secret is blank.")
+		}
+	}
+	return errors
+}
+
 // Used by text/template to format validator.yml
 func (cfg *RocketPoolConfig) RocketPoolVersion() string {
 	return shared.RocketPoolVersion
@@ -1109,6 +1325,32 @@ func (cfg *RocketPoolConfig) VcAdditionalFlags() (string, error) {
 		}
 		out = out + overrides.VcAdditionalFlags
 	}
+
+	// Point the validator client to the graffiti file
+	if cfg.GetGraffitiProvider() == GraffitiProvider_Template {
+		graffitiFlag, err := graffiti.GetFileFlag(cc, GraffitiFilename)
+		if err != nil {
+			return "", err
+		}
+		if out != "" {
+			out = out + " "
+		}
+		out = out + graffitiFlag
+	}
+
+	// Add the flags from user addons
+	for _, addon := range cfg.GetEnabledUserAddons() {
+		addonFlags, err := addon.GetValidatorFlags(cc)
+		if err != nil {
+			return "", err
+		}
+		if addonFlags != "" {
+			if out != "" {
+				out = out + " "
+			}
+			out = out + addonFlags
+		}
+	}
 	return out, nil
 }
 
@@ -1504,6 +1746,27 @@ func (cfg *RocketPoolConfig) Validate() []string {
 		}
 	}
 
+	// Make sure the graffiti template can be used
+	if cfg.GraffitiTemplate.Enabled.Value == true {
+		errors = append(errors, cfg.validateGraffitiTemplate()...)
+	}
+
+	// Make sure backups can be made with the backup settings
+	errors = append(errors, cfg.validateBackup()...)
+
+	// Make sure the enabled user addons have everything they need
+	for _, addon := range cfg.GetEnabledUserAddons() {
+		if cfg.IsNativeMode {
+			errors = append(errors, fmt.Sprintf("The %s add-on is incompatible with native mode.", addon.GetName()))
+			continue
+		}
+		for _, param := range addon.GetConfig().GetParameters() {
+			if !param.CanBeBlank && param.Value == "" {
+				errors = append(errors, fmt.Sprintf("The %s add-on requires a value for %s.", addon.GetName(), param.Name))
+			}
+		}
+	}
+
 	// Ensure the selected port numbers are unique. Keeps track of all the errors
 	portMap := make(map[interface{}]bool)
 	portMap, errors = addAndCheckForDuplicate(portMap, cfg.ConsensusCommon.ApiPort, errors)
@@ -1597,6 +1860,7 @@ func getChangedSettings(oldParams []*config.Parameter, newParams []*config.Param
 		newValString := fmt.Sprint(param.Value)
 		if oldValString != newValString {
 			changedSettings = append(changedSettings, config.ChangedSetting{
+				ID:                 param.ID,
 				Name:               param.Name,
 				OldValue:           oldValString,
 				NewValue:           newValString,
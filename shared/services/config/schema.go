@@ -0,0 +1,82 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/rocket-pool/smartnode/shared"
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Get a JSON Schema describing a settings file for this configuration, including the addon sections
+func (cfg *RocketPoolConfig) GetJsonSchema() map[string]interface{} {
+
+	// Get the networks the defaults can vary by
+	networks := []config.Network{}
+	for _, option := range cfg.Smartnode.Network.Options {
+		networks = append(networks, option.Value.(config.Network))
+	}
+
+	// Build the root section, which also holds the metadata
+	rootProperties := map[string]interface{}{
+		"rpDir": map[string]interface{}{
+			"title":       "Rocket Pool Directory",
+			"description": "The directory holding the Smartnode configuration. This is always replaced with the local directory when a settings file is applied.",
+			"type":        "string",
+		},
+		"isNative": map[string]interface{}{
+			"title":       "Native Mode",
+			"description": "Whether the Smartnode runs in Native mode. This is always replaced with the local mode when a settings file is applied.",
+			"type":        []string{"boolean", "string"},
+			"enum":        []interface{}{true, false, "true", "false"},
+		},
+		"version": map[string]interface{}{
+			"title":       "Version",
+			"description": "The Smartnode version the settings were written for. Older settings are upgraded when they're loaded.",
+			"type":        "string",
+			"pattern":     "^v[0-9]+\\.[0-9]+\\.[0-9]+",
+		},
+	}
+	for _, param := range cfg.GetParameters() {
+		rootProperties[param.ID] = param.GetJsonSchema(networks)
+	}
+	properties := map[string]interface{}{
+		rootConfigName: getSectionJsonSchema(cfg.Title, "", rootProperties),
+	}
+
+	// Build the subconfig sections
+	addonsBySection := map[string]string{}
+	for _, addon := range cfg.GetAddons() {
+		addonsBySection[addon.GetConfig().GetConfigTitle()] = addon.GetDescription()
+	}
+	for name, subconfig := range cfg.GetSubconfigs() {
+		sectionProperties := map[string]interface{}{}
+		for _, param := range subconfig.GetParameters() {
+			sectionProperties[param.ID] = param.GetJsonSchema(networks)
+		}
+		properties[name] = getSectionJsonSchema(subconfig.GetConfigTitle(), addonsBySection[subconfig.GetConfigTitle()], sectionProperties)
+	}
+
+	return map[string]interface{}{
+		"$schema":              config.JsonSchemaDialect,
+		"title":                "Rocket Pool Smartnode Settings",
+		"description":          fmt.Sprintf("The user-settings.yml file for Smartnode v%s", shared.RocketPoolVersion),
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+
+}
+
+// Get the JSON Schema of a single section of a settings file
+func getSectionJsonSchema(title string, description string, properties map[string]interface{}) map[string]interface{} {
+	schema := map[string]interface{}{
+		"title":                title,
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if description != "" {
+		schema["description"] = description
+	}
+	return schema
+}
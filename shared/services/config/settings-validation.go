@@ -0,0 +1,104 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/rocket-pool/smartnode/shared/services/config/migration"
+	"github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+// Keys in the root section of a settings file that aren't parameters
+var rootMetadataKeys = map[string]bool{
+	"rpDir":    true,
+	"isNative": true,
+	"version":  true,
+}
+
+// Checks every value in a settings map against the parameter it belongs to, then checks the resulting configuration
+// as a whole. Unknown sections and settings are rejected, just like they are by the settings file's JSON Schema.
+// Returns the deserialized configuration if every value could be parsed and the problems that prevent the settings
+// from being used. The provided map is not modified.
+func ValidateSettings(settings map[string]map[string]string) (*RocketPoolConfig, []string) {
+	errors := []string{}
+
+	// Work on a copy, since upgrading the settings modifies them
+	settingsCopy := map[string]map[string]string{}
+	for section, params := range settings {
+		sectionCopy := map[string]string{}
+		for key, value := range params {
+			sectionCopy[key] = value
+		}
+		settingsCopy[section] = sectionCopy
+	}
+	if err := migration.UpdateConfig(settingsCopy); err != nil {
+		return nil, append(errors, fmt.Sprintf("The settings could not be upgraded to the current version: %s", err.Error()))
+	}
+
+	// Check the root parameters; the defaults of some parameters depend on the Rocket Pool directory
+	isNative, _ := strconv.ParseBool(settingsCopy[rootConfigName]["isNative"])
+	cfg := NewRocketPoolConfig(settingsCopy[rootConfigName]["rpDir"], isNative)
+	rootParams := map[string]*config.Parameter{}
+	for _, param := range cfg.GetParameters() {
+		rootParams[param.ID] = param
+	}
+	errors = validateSection(rootConfigName, settingsCopy[rootConfigName], rootParams, errors)
+
+	// Check the subconfigs
+	subconfigs := cfg.GetSubconfigs()
+	sections := make([]string, 0, len(settingsCopy))
+	for section := range settingsCopy {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+	for _, section := range sections {
+		if section == rootConfigName {
+			continue
+		}
+		subconfig, exists := subconfigs[section]
+		if !exists {
+			errors = append(errors, fmt.Sprintf("[%s] is not a known section.", section))
+			continue
+		}
+		params := map[string]*config.Parameter{}
+		for _, param := range subconfig.GetParameters() {
+			params[param.ID] = param
+		}
+		errors = validateSection(section, settingsCopy[section], params, errors)
+	}
+	if len(errors) > 0 {
+		return nil, errors
+	}
+
+	// Deserialize the settings and check the configuration as a whole
+	if err := cfg.Deserialize(settingsCopy); err != nil {
+		return nil, append(errors, err.Error())
+	}
+	errors = append(errors, cfg.Validate()...)
+	return cfg, errors
+}
+
+// Check the values in a single section of a settings map
+func validateSection(section string, values map[string]string, params map[string]*config.Parameter, errors []string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if section == rootConfigName && rootMetadataKeys[key] {
+			continue
+		}
+		param, exists := params[key]
+		if !exists {
+			errors = append(errors, fmt.Sprintf("[%s.%s] is not a known setting.", section, key))
+			continue
+		}
+		if err := param.ValidateSerializedValue(values[key]); err != nil {
+			errors = append(errors, fmt.Sprintf("[%s.%s] (%s): %s", section, key, param.Name, err.Error()))
+		}
+	}
+	return errors
+}
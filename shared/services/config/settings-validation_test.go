@@ -0,0 +1,108 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+// Get the serialized settings of a fresh configuration; MEV-Boost is disabled since new configs don't have relays yet
+func newTestSettings(t *testing.T) map[string]map[string]string {
+	cfg := NewRocketPoolConfig(t.TempDir(), false)
+	settings := cfg.Serialize()
+	settings[rootConfigName]["enableMevBoost"] = "false"
+	return settings
+}
+
+func TestValidateSettings(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(settings map[string]map[string]string)
+		errors []string
+	}{
+		{
+			name:   "default settings",
+			modify: func(settings map[string]map[string]string) {},
+		},
+		{
+			name: "valid changes",
+			modify: func(settings map[string]map[string]string) {
+				settings["smartnode"][NetworkID] = "holesky"
+				settings["executionCommon"]["p2pPort"] = "30304"
+			},
+		},
+		{
+			name: "unknown section",
+			modify: func(settings map[string]map[string]string) {
+				settings["notASection"] = map[string]string{"foo": "bar"}
+			},
+			errors: []string{"[notASection] is not a known section."},
+		},
+		{
+			name: "unknown setting",
+			modify: func(settings map[string]map[string]string) {
+				settings["smartnode"]["notASetting"] = "foo"
+			},
+			errors: []string{"[smartnode.notASetting] is not a known setting."},
+		},
+		{
+			name: "invalid choice",
+			modify: func(settings map[string]map[string]string) {
+				settings["smartnode"][NetworkID] = "notANetwork"
+			},
+			errors: []string{"[smartnode.network]"},
+		},
+		{
+			name: "invalid number",
+			modify: func(settings map[string]map[string]string) {
+				settings["executionCommon"]["p2pPort"] = "70000"
+			},
+			errors: []string{"[executionCommon.p2pPort]"},
+		},
+		{
+			name: "every problem is reported",
+			modify: func(settings map[string]map[string]string) {
+				settings["executionCommon"]["p2pPort"] = "port"
+				settings[rootConfigName]["executionClientMode"] = "notAMode"
+			},
+			errors: []string{"[root.executionClientMode]", "[executionCommon.p2pPort]"},
+		},
+		{
+			name: "incompatible client modes",
+			modify: func(settings map[string]map[string]string) {
+				settings[rootConfigName]["executionClientMode"] = "local"
+				settings[rootConfigName]["consensusClientMode"] = "external"
+			},
+			errors: []string{"locally-managed Execution client and an externally-managed Consensus client"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			settings := newTestSettings(t)
+			test.modify(settings)
+			cfg, errors := ValidateSettings(settings)
+			if len(errors) != len(test.errors) {
+				t.Fatalf("expected %d errors, got %d: %v", len(test.errors), len(errors), errors)
+			}
+			for i, expected := range test.errors {
+				if !strings.Contains(errors[i], expected) {
+					t.Fatalf("expected error %d to contain [%s], got [%s]", i, expected, errors[i])
+				}
+			}
+			if len(test.errors) == 0 && cfg == nil {
+				t.Fatalf("expected the deserialized config for valid settings")
+			}
+		})
+	}
+}
+
+func TestValidateSettingsDoesNotModifyInput(t *testing.T) {
+	settings := newTestSettings(t)
+	settings["smartnode"]["notASetting"] = "foo"
+	before := len(settings["smartnode"])
+
+	_, _ = ValidateSettings(settings)
+	if len(settings["smartnode"]) != before || settings["smartnode"]["notASetting"] != "foo" {
+		t.Fatalf("expected the provided settings to be left alone")
+	}
+}
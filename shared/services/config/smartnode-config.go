@@ -33,6 +33,10 @@ const (
 	GithubRewardsFileUrl               string = "https://github.com/rocket-pool/rewards-trees/raw/main/%s/%s"
 	FeeRecipientFilename               string = "rp-fee-recipient.txt"
 	NativeFeeRecipientFilename         string = "rp-fee-recipient-env.txt"
+	VotingArtifactsFolder              string = "voting-artifacts"
+	VotingArtifactsFilenameFormat      string = "voting-artifacts-%s-%d.json.zst"
+	VotingArtifactsImportFilename      string = "import.json.zst"
+	BackupSlashingProtectionFilename   string = "backup-slashing-protection.json"
 )
 
 // Defaults
@@ -74,9 +78,33 @@ type SmartnodeConfig struct {
 	// Threshold for automatic transactions
 	AutoTxGasThreshold config.Parameter `yaml:"minipoolStakeGasThreshold,omitempty"`
 
+	// The highest threshold automatic transactions can escalate to as their deadlines approach
+	AutoTxGasHardCap config.Parameter `yaml:"autoTxGasHardCap,omitempty"`
+
+	// The percentage of an automatic transaction's window that passes before its threshold starts escalating
+	AutoTxEscalationStart config.Parameter `yaml:"autoTxEscalationStart,omitempty"`
+
 	// The amount of ETH in a minipool's balance before auto-distribute kicks in
 	DistributeThreshold config.Parameter `yaml:"distributeThreshold,omitempty"`
 
+	// The amount of ETH in the fee distributor before auto-distribute kicks in
+	FeeDistributorThreshold config.Parameter `yaml:"feeDistributorThreshold,omitempty"`
+
+	// Toggle for automatically claiming rewards
+	AutoClaimRewards config.Parameter `yaml:"autoClaimRewards,omitempty"`
+
+	// The value of unclaimed rewards (in ETH) that triggers an automatic claim
+	AutoClaimValueThreshold config.Parameter `yaml:"autoClaimValueThreshold,omitempty"`
+
+	// The gas price (in gwei) below which unclaimed rewards are automatically claimed
+	AutoClaimGasThreshold config.Parameter `yaml:"autoClaimGasThreshold,omitempty"`
+
+	// The number of days after an interval closes before its rewards are claimed regardless of value or gas
+	AutoClaimMaxDays config.Parameter `yaml:"autoClaimMaxDays,omitempty"`
+
+	// The percentage of claimed RPL to restake automatically
+	AutoClaimRestakePercent config.Parameter `yaml:"autoClaimRestakePercent,omitempty"`
+
 	// Mode for acquiring Merkle rewards trees
 	RewardsTreeMode config.Parameter `yaml:"rewardsTreeMode,omitempty"`
 
@@ -110,6 +138,21 @@ type SmartnodeConfig struct {
 	// The toggle for enabling pDAO proposal verification duties
 	VerifyProposals config.Parameter `yaml:"verifyProposals,omitempty"`
 
+	// Toggle for voting on pDAO proposals automatically with the local voting policy
+	EnablePdaoVotePolicy config.Parameter `yaml:"enablePdaoVotePolicy,omitempty"`
+
+	// The toggle for sending governance digests through the alerting system
+	EnableGovernanceDigest config.Parameter `yaml:"enableGovernanceDigest,omitempty"`
+
+	// The toggle for generating pDAO voting artifacts ahead of time
+	PregenerateVotingArtifacts config.Parameter `yaml:"pregenerateVotingArtifacts,omitempty"`
+
+	// The toggle for tracking the performance of the node's validators
+	TrackValidatorPerformance config.Parameter `yaml:"trackValidatorPerformance,omitempty"`
+
+	// The toggle for auditing the fee recipients of blocks proposed by the node's validators
+	AuditProposals config.Parameter `yaml:"auditProposals,omitempty"`
+
 	///////////////////////////
 	// Non-editable settings //
 	///////////////////////////
@@ -304,6 +347,29 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade: false,
 		},
 
+		AutoTxGasHardCap: config.Parameter{
+			ID:   "autoTxGasHardCap",
+			Name: "Automatic TX Gas Hard Cap",
+			Description: "Automatic transactions with a deadline (such as the second `stake` transaction, solo migration promotion, bond reduction, and auto-claiming once the Auto-Claim Max Days have passed) will wait for the gas price to drop below the Automatic TX Gas Threshold at first. If this hard cap (in gwei) is above that threshold, the threshold they'll accept rises gradually as their deadlines approach until it reaches the hard cap at the deadline.\n\nEscalation is disabled by default; leave this at 0 (or at anything up to the Automatic TX Gas Threshold) to keep it that way. Nothing escalates if the Automatic TX Gas Threshold is 0.\n\n" +
+				"NOTE: minipool staking and promotion will still be forced at the current gas price once their deadline has passed, because the minipool could be dissolved otherwise.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		AutoTxEscalationStart: config.Parameter{
+			ID:                 "autoTxEscalationStart",
+			Name:               "Automatic TX Escalation Start",
+			Description:        "The percentage (from 0 to 100) of an automatic transaction's window, from when it first becomes eligible until its deadline, that must pass before its gas threshold starts rising toward the Automatic TX Gas Hard Cap.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(50)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
 		DistributeThreshold: config.Parameter{
 			ID:                 "distributeThreshold",
 			Name:               "Auto-Distribute Threshold",
@@ -315,6 +381,72 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade: false,
 		},
 
+		FeeDistributorThreshold: config.Parameter{
+			ID:                 "feeDistributorThreshold",
+			Name:               "Fee Distributor Auto-Distribute Threshold",
+			Description:        "If your node is not in the Smoothing Pool, the priority fees and MEV from your proposals are collected in your node's fee distributor contract. The Smartnode will automatically distribute it once its balance is greater than this threshold (in ETH) and the network's gas price is below the Automatic TX Gas Threshold.\n\nSet this to 0 to disable automatic fee distributor distribution.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		AutoClaimRewards: config.Parameter{
+			ID:                 "autoClaimRewards",
+			Name:               "Auto-Claim Rewards",
+			Description:        "Enable this to have the Smartnode automatically claim your RPL and Smoothing Pool rewards from completed rewards intervals, according to the auto-claim policy below.\n\nRewards are claimed as soon as any one of the policy's conditions is met. Setting the Automatic TX Gas Threshold to 0 disables auto-claiming entirely.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		AutoClaimValueThreshold: config.Parameter{
+			ID:                 "autoClaimValueThreshold",
+			Name:               "Auto-Claim Value Threshold",
+			Description:        "Claim your rewards once your unclaimed ETH plus the value of your unclaimed RPL (in ETH) is at least this much, as long as the network's gas price is below the Automatic TX Gas Threshold.\n\nSet this to 0 to disable this condition.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(0.5)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		AutoClaimGasThreshold: config.Parameter{
+			ID:                 "autoClaimGasThreshold",
+			Name:               "Auto-Claim Gas Threshold",
+			Description:        "Claim your rewards, regardless of their value, whenever the network's gas price (in gwei) drops below this amount.\n\nSet this to 0 to disable this condition.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		AutoClaimMaxDays: config.Parameter{
+			ID:                 "autoClaimMaxDays",
+			Name:               "Auto-Claim Max Days",
+			Description:        "Claim your rewards, regardless of their value, once this many days have passed since the oldest unclaimed interval ended. As that deadline approaches, the gas price the claim will accept rises from the Automatic TX Gas Threshold toward the Automatic TX Gas Hard Cap.\n\nSet this to 0 to disable this condition.",
+			Type:               config.ParameterType_Uint,
+			Default:            map[config.Network]interface{}{config.Network_All: uint64(56)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		AutoClaimRestakePercent: config.Parameter{
+			ID:                 "autoClaimRestakePercent",
+			Name:               "Auto-Claim Restake Percent",
+			Description:        "The percentage of the claimed RPL to restake on your node automatically (from 0 to 100). The rest will be sent to your RPL withdrawal address.",
+			Type:               config.ParameterType_Float,
+			Default:            map[config.Network]interface{}{config.Network_All: float64(0)},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
 		VerifyProposals: config.Parameter{
 			ID:                 "verifyProposals",
 			Name:               "Enable PDAO Proposal Checker",
@@ -326,6 +458,61 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			OverwriteOnUpgrade: false,
 		},
 
+		EnablePdaoVotePolicy: config.Parameter{
+			ID:                 "enablePdaoVotePolicy",
+			Name:               "Enable PDAO Voting Policy",
+			Description:        "Enable this to have the Smartnode vote on Protocol DAO proposals automatically, according to the rules in the `pdao-vote-policy.yml` file in your Smartnode data folder.\n\nThe node will only report what it would vote until you review its decisions with `rocketpool pdao vote-policy status` and confirm the policy with `rocketpool pdao vote-policy confirm`. Any change to the policy file must be confirmed again.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		EnableGovernanceDigest: config.Parameter{
+			ID:                 "enableGovernanceDigest",
+			Name:               "Enable Governance Digest",
+			Description:        "Enable this to have the Smartnode watch for new Protocol DAO proposals, challenges against them, phase transitions, Oracle DAO proposals (for members) and Snapshot proposals, and send a digest through the alerting system when anything changes. The digest includes voting deadlines and whether your node or its delegate has voted.\n\nYou can view the current digest at any time with `rocketpool pdao digest`.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		PregenerateVotingArtifacts: config.Parameter{
+			ID:                 "pregenerateVotingArtifacts",
+			Name:               "Pre-generate Voting Artifacts",
+			Description:        "Enable this to have the Smartnode build the voting info snapshots and voting trees for every open Protocol DAO proposal as soon as it appears, instead of when your node needs to vote, verify, or answer a challenge. This makes challenge responses much faster on low-power hardware.\n\nArtifacts for proposals that have finished are deleted automatically.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		TrackValidatorPerformance: config.Parameter{
+			ID:                 "trackValidatorPerformance",
+			Name:               "Track Validator Performance",
+			Description:        "Enable this to have the Smartnode track the attestation, sync committee, and block proposal performance of your minipool validators as the Beacon Chain finalizes.\n\nThe results are available with `rocketpool minipool performance` and as Prometheus metrics if metrics are enabled.\n\n[orange]NOTE: This makes around a hundred extra requests to your Beacon Node every epoch (one for each slot's block header, plus the attestations and sync aggregates), regardless of how many validators you have.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
+		AuditProposals: config.Parameter{
+			ID:                 "auditProposals",
+			Name:               "Audit Proposals",
+			Description:        "Enable this to have the Smartnode check the fee recipient of every block your minipool validators propose once it's finalized, record the reward it paid, and send an alert if it went to an address that could get your node penalized.\n\nThe results are available with `rocketpool minipool proposals`.\n\n[orange]NOTE: This requests the header of every slot from your Beacon Node as it finalizes, regardless of how many validators you have.",
+			Type:               config.ParameterType_Bool,
+			Default:            map[config.Network]interface{}{config.Network_All: false},
+			AffectsContainers:  []config.ContainerID{config.ContainerID_Node},
+			CanBeBlank:         false,
+			OverwriteOnUpgrade: false,
+		},
+
 		RewardsTreeMode: config.Parameter{
 			ID:                 "rewardsTreeMode",
 			Name:               "Rewards Tree Mode",
@@ -366,6 +553,7 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 
 		ArchiveECUrl: config.Parameter{
@@ -377,6 +565,7 @@ func NewSmartnodeConfig(cfg *RocketPoolConfig) *SmartnodeConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Watchtower},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 
 		WatchtowerMaxFeeOverride: config.Parameter{
@@ -668,8 +857,21 @@ func (cfg *SmartnodeConfig) GetParameters() []*config.Parameter {
 		&cfg.ManualMaxFee,
 		&cfg.PriorityFee,
 		&cfg.AutoTxGasThreshold,
+		&cfg.AutoTxGasHardCap,
+		&cfg.AutoTxEscalationStart,
 		&cfg.DistributeThreshold,
+		&cfg.FeeDistributorThreshold,
+		&cfg.AutoClaimRewards,
+		&cfg.AutoClaimValueThreshold,
+		&cfg.AutoClaimGasThreshold,
+		&cfg.AutoClaimMaxDays,
+		&cfg.AutoClaimRestakePercent,
 		&cfg.VerifyProposals,
+		&cfg.EnablePdaoVotePolicy,
+		&cfg.EnableGovernanceDigest,
+		&cfg.PregenerateVotingArtifacts,
+		&cfg.TrackValidatorPerformance,
+		&cfg.AuditProposals,
 		&cfg.RewardsTreeMode,
 		&cfg.PriceBalanceSubmissionReferenceTimestamp,
 		&cfg.RewardsTreeCustomUrl,
@@ -737,6 +939,79 @@ func (cfg *SmartnodeConfig) GetVotingPath() string {
 	return filepath.Join(DaemonDataPath, "voting", string(cfg.Network.Value.(config.Network)))
 }
 
+func (cfg *SmartnodeConfig) GetValidatorPerformancePath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), "performance", "validator-performance.json")
+	}
+
+	return filepath.Join(DaemonDataPath, "performance", "validator-performance.json")
+}
+
+func (cfg *SmartnodeConfig) GetProposalAuditPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), "performance", "proposal-audit.json")
+	}
+
+	return filepath.Join(DaemonDataPath, "performance", "proposal-audit.json")
+}
+
+func (cfg *SmartnodeConfig) GetPdaoVotePolicyPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), "pdao-vote-policy.yml")
+	}
+
+	return filepath.Join(DaemonDataPath, "pdao-vote-policy.yml")
+}
+
+func (cfg *SmartnodeConfig) GetPdaoVotePolicyRecordPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), "pdao-vote-policy-record.json")
+	}
+
+	return filepath.Join(DaemonDataPath, "pdao-vote-policy-record.json")
+}
+
+func (cfg *SmartnodeConfig) GetPdaoVotePolicyConfirmationPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), "pdao-vote-policy-confirmation.json")
+	}
+
+	return filepath.Join(DaemonDataPath, "pdao-vote-policy-confirmation.json")
+}
+
+func (cfg *SmartnodeConfig) GetGovernanceDigestRecordPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), "governance-digest-record.json")
+	}
+
+	return filepath.Join(DaemonDataPath, "governance-digest-record.json")
+}
+
+func (cfg *SmartnodeConfig) GetChallengeRecordPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), "pdao-challenge-record.json")
+	}
+
+	return filepath.Join(DaemonDataPath, "pdao-challenge-record.json")
+}
+
+// Get the path of the record of the node's Oracle DAO duties
+func (cfg *SmartnodeConfig) GetOdaoDutyRecordPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), "odao-duty-record.json")
+	}
+
+	return filepath.Join(DaemonDataPath, "odao-duty-record.json")
+}
+
+func (cfg *SmartnodeConfig) GetAutoTxDelayRecordPath() string {
+	if cfg.parent.IsNativeMode {
+		return filepath.Join(cfg.DataPath.Value.(string), "auto-tx-delays.json")
+	}
+
+	return filepath.Join(DaemonDataPath, "auto-tx-delays.json")
+}
+
 func (cfg *SmartnodeConfig) GetWalletPathInCLI() string {
 	return filepath.Join(cfg.DataPath.Value.(string), "wallet")
 }
@@ -858,6 +1133,30 @@ func (cfg *SmartnodeConfig) GetRegenerateRewardsTreeRequestPath(interval uint64,
 	return filepath.Join(cfg.DataPath.Value.(string), WatchtowerFolder, fmt.Sprintf(RegenerateRewardsTreeRequestFormat, interval))
 }
 
+func (cfg *SmartnodeConfig) GetVotingArtifactsExportPath(blockNumber uint32, daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, VotingArtifactsFolder, fmt.Sprintf(VotingArtifactsFilenameFormat, string(cfg.Network.Value.(config.Network)), blockNumber))
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), VotingArtifactsFolder, fmt.Sprintf(VotingArtifactsFilenameFormat, string(cfg.Network.Value.(config.Network)), blockNumber))
+}
+
+func (cfg *SmartnodeConfig) GetVotingArtifactsImportPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, VotingArtifactsFolder, VotingArtifactsImportFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), VotingArtifactsFolder, VotingArtifactsImportFilename)
+}
+
+func (cfg *SmartnodeConfig) GetBackupSlashingProtectionPath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, BackupSlashingProtectionFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), BackupSlashingProtectionFilename)
+}
+
 func (cfg *SmartnodeConfig) GetWatchtowerFolder(daemon bool) string {
 	if daemon && !cfg.parent.IsNativeMode {
 		return filepath.Join(DaemonDataPath, WatchtowerFolder)
@@ -874,6 +1173,14 @@ func (cfg *SmartnodeConfig) GetFeeRecipientFilePath() string {
 	return filepath.Join(cfg.DataPath.Value.(string), "validators", NativeFeeRecipientFilename)
 }
 
+func (cfg *SmartnodeConfig) GetGraffitiFilePath(daemon bool) string {
+	if daemon && !cfg.parent.IsNativeMode {
+		return filepath.Join(DaemonDataPath, "validators", GraffitiFilename)
+	}
+
+	return filepath.Join(cfg.DataPath.Value.(string), "validators", GraffitiFilename)
+}
+
 func (cfg *SmartnodeConfig) GetV100RewardsPoolAddress() common.Address {
 	return common.HexToAddress(cfg.v1_0_0_RewardsPoolAddress[cfg.Network.Value.(config.Network)])
 }
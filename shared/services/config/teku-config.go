@@ -115,6 +115,7 @@ func NewTekuConfig(cfg *RocketPoolConfig) *TekuConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Eth2},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 
 		AdditionalVcFlags: config.Parameter{
@@ -126,6 +127,7 @@ func NewTekuConfig(cfg *RocketPoolConfig) *TekuConfig {
 			AffectsContainers:  []config.ContainerID{config.ContainerID_Validator},
 			CanBeBlank:         true,
 			OverwriteOnUpgrade: false,
+			Sensitive:          true,
 		},
 	}
 }
@@ -0,0 +1,343 @@
+package duties
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/utils/atomicfile"
+)
+
+// How long duty rounds are kept before they're pruned
+const RetentionPeriod time.Duration = 90 * 24 * time.Hour
+
+// A type of Oracle DAO duty
+type DutyType string
+
+const (
+	DutyType_Balances    DutyType = "balances"
+	DutyType_Prices      DutyType = "prices"
+	DutyType_RewardsTree DutyType = "rewards-tree"
+	DutyType_Scrub       DutyType = "scrub"
+)
+
+// All of the duty types, in display order
+var DutyTypes = []DutyType{
+	DutyType_Balances,
+	DutyType_Prices,
+	DutyType_RewardsTree,
+	DutyType_Scrub,
+}
+
+// The outcome of a duty round
+type DutyResult string
+
+const (
+	// Consensus hasn't been reached yet
+	DutyResult_Pending DutyResult = "pending"
+
+	// The node submitted the same values the Oracle DAO reached consensus on
+	DutyResult_Matched DutyResult = "matched"
+
+	// The node submitted different values from the ones the Oracle DAO reached consensus on
+	DutyResult_Mismatched DutyResult = "mismatched"
+
+	// The Oracle DAO reached consensus without a submission from the node
+	DutyResult_Missed DutyResult = "missed"
+
+	// The round was superseded by a later one before the Oracle DAO reached consensus on it
+	DutyResult_NoConsensus DutyResult = "no-consensus"
+
+	// The node submitted, but its values weren't recorded so they can't be compared
+	DutyResult_Unknown DutyResult = "unknown"
+)
+
+// All of the duty results, in display order
+var DutyResults = []DutyResult{
+	DutyResult_Pending,
+	DutyResult_Matched,
+	DutyResult_Mismatched,
+	DutyResult_Missed,
+	DutyResult_NoConsensus,
+	DutyResult_Unknown,
+}
+
+// The names of the values recorded for each duty
+const (
+	BalancesValue_TotalEth      string = "totalEth"
+	BalancesValue_StakingEth    string = "stakingEth"
+	BalancesValue_RethSupply    string = "rethSupply"
+	PricesValue_RplPrice        string = "rplPrice"
+	PricesValue_SlotTimestamp   string = "slotTimestamp"
+	RewardsTreeValue_MerkleRoot string = "merkleRoot"
+	RewardsTreeValue_Cid        string = "cid"
+	ScrubValue_Vote             string = "vote"
+	ScrubValue_Reason           string = "reason"
+)
+
+// Scrub votes and the reasons for them
+const (
+	ScrubVote_Scrub string = "scrub"
+	ScrubVote_Stake string = "stake"
+
+	ScrubReason_WithdrawalCredentials string = "withdrawal credentials on the Beacon Chain"
+	ScrubReason_PrestakeSignature     string = "prestake signature"
+	ScrubReason_DepositContract       string = "deposit contract"
+	ScrubReason_Safety                string = "safety"
+)
+
+// A single submission round of an Oracle DAO duty
+type DutyRound struct {
+	Type             DutyType          `json:"type"`
+	Round            string            `json:"round"`
+	Block            uint64            `json:"block"`
+	FirstSeen        time.Time         `json:"firstSeen"`
+	Submitted        bool              `json:"submitted"`
+	SubmissionTime   time.Time         `json:"submissionTime"`
+	SubmissionTx     common.Hash       `json:"submissionTx"`
+	SubmittedValues  map[string]string `json:"submittedValues"`
+	Failures         uint64            `json:"failures"`
+	LastError        string            `json:"lastError"`
+	LastErrorTime    time.Time         `json:"lastErrorTime"`
+	Resolved         bool              `json:"resolved"`
+	ResolvedTime     time.Time         `json:"resolvedTime"`
+	ConsensusReached bool              `json:"consensusReached"`
+	ConsensusValues  map[string]string `json:"consensusValues"`
+	Result           DutyResult        `json:"result"`
+}
+
+// Totals for a single duty type
+type DutySummary struct {
+	Type               DutyType           `json:"type"`
+	Rounds             uint64             `json:"rounds"`
+	Submitted          uint64             `json:"submitted"`
+	Failures           uint64             `json:"failures"`
+	Results            map[DutyResult]int `json:"results"`
+	LastSubmissionTime time.Time          `json:"lastSubmissionTime"`
+	LastResult         DutyResult         `json:"lastResult"`
+}
+
+// The history of the node's Oracle DAO duties
+type DutyRecord struct {
+	LastUpdateTime time.Time             `json:"lastUpdateTime"`
+	Rounds         map[string]*DutyRound `json:"rounds"`
+
+	// Internal fields
+	lock *sync.Mutex `json:"-"`
+}
+
+// Create a new, empty duty record
+func NewDutyRecord() *DutyRecord {
+	return &DutyRecord{
+		Rounds: map[string]*DutyRound{},
+		lock:   &sync.Mutex{},
+	}
+}
+
+// Load a duty record from disk, or create a new one if it doesn't exist yet
+func LoadDutyRecord(path string) (*DutyRecord, error) {
+	record := NewDutyRecord()
+	if _, err := atomicfile.LoadJson(path, record); err != nil {
+		return nil, fmt.Errorf("error loading Oracle DAO duty record: %w", err)
+	}
+	if record.Rounds == nil {
+		record.Rounds = map[string]*DutyRound{}
+	}
+	return record, nil
+}
+
+// Save the duty record to disk
+func (r *DutyRecord) Save(path string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if err := atomicfile.SaveJson(path, r); err != nil {
+		return fmt.Errorf("error saving Oracle DAO duty record: %w", err)
+	}
+	return nil
+}
+
+// Record a successful submission for a round
+func (r *DutyRecord) AddSubmission(dutyType DutyType, round string, block uint64, values map[string]string, txHash common.Hash) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	dutyRound := r.getOrCreateRound(dutyType, round, block)
+	dutyRound.Submitted = true
+	dutyRound.SubmissionTime = time.Now()
+	dutyRound.SubmissionTx = txHash
+	dutyRound.SubmittedValues = values
+	r.LastUpdateTime = time.Now()
+}
+
+// Record a failed submission attempt for a round
+func (r *DutyRecord) AddFailure(dutyType DutyType, round string, block uint64, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	dutyRound := r.getOrCreateRound(dutyType, round, block)
+	dutyRound.Failures++
+	dutyRound.LastError = err.Error()
+	dutyRound.LastErrorTime = time.Now()
+	r.LastUpdateTime = time.Now()
+}
+
+// Record the consensus outcome of a round. submitted is whether the contracts have a submission from the node for it,
+// which catches submissions that weren't recorded locally. Returns the result of the round.
+func (r *DutyRecord) Resolve(dutyType DutyType, round string, block uint64, submitted bool, consensusReached bool, consensusValues map[string]string) DutyResult {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	dutyRound := r.getOrCreateRound(dutyType, round, block)
+	if dutyRound.Resolved {
+		return dutyRound.Result
+	}
+	dutyRound.Submitted = dutyRound.Submitted || submitted
+	dutyRound.Resolved = true
+	dutyRound.ResolvedTime = time.Now()
+	dutyRound.ConsensusReached = consensusReached
+	dutyRound.ConsensusValues = consensusValues
+
+	switch {
+	case !consensusReached:
+		dutyRound.Result = DutyResult_NoConsensus
+	case !dutyRound.Submitted:
+		dutyRound.Result = DutyResult_Missed
+	case len(dutyRound.SubmittedValues) == 0:
+		dutyRound.Result = DutyResult_Unknown
+	case valuesMatch(dutyRound.SubmittedValues, consensusValues):
+		dutyRound.Result = DutyResult_Matched
+	default:
+		dutyRound.Result = DutyResult_Mismatched
+	}
+	r.LastUpdateTime = time.Now()
+	return dutyRound.Result
+}
+
+// Check if a round has already been resolved
+func (r *DutyRecord) IsResolved(dutyType DutyType, round string) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	dutyRound, exists := r.Rounds[getRoundKey(dutyType, round)]
+	return exists && dutyRound.Resolved
+}
+
+// Get copies of the unresolved rounds of a duty type
+func (r *DutyRecord) GetUnresolvedRounds(dutyType DutyType) []DutyRound {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	rounds := []DutyRound{}
+	for _, dutyRound := range r.Rounds {
+		if dutyRound.Type == dutyType && !dutyRound.Resolved {
+			rounds = append(rounds, *dutyRound)
+		}
+	}
+	return rounds
+}
+
+// Get copies of all of the rounds, newest first
+func (r *DutyRecord) GetRounds() []DutyRound {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	rounds := make([]DutyRound, 0, len(r.Rounds))
+	for _, dutyRound := range r.Rounds {
+		rounds = append(rounds, *dutyRound)
+	}
+	sort.Slice(rounds, func(i, j int) bool {
+		return rounds[i].FirstSeen.After(rounds[j].FirstSeen)
+	})
+	return rounds
+}
+
+// Get the totals for each duty type
+func (r *DutyRecord) GetSummaries() []DutySummary {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	summaries := make([]DutySummary, len(DutyTypes))
+	indices := map[DutyType]int{}
+	for i, dutyType := range DutyTypes {
+		summaries[i] = DutySummary{
+			Type:    dutyType,
+			Results: map[DutyResult]int{},
+		}
+		indices[dutyType] = i
+	}
+
+	lastResultTimes := make([]time.Time, len(DutyTypes))
+	for _, dutyRound := range r.Rounds {
+		i, exists := indices[dutyRound.Type]
+		if !exists {
+			continue
+		}
+		summary := &summaries[i]
+		summary.Rounds++
+		summary.Failures += dutyRound.Failures
+		if dutyRound.Submitted {
+			summary.Submitted++
+			if dutyRound.SubmissionTime.After(summary.LastSubmissionTime) {
+				summary.LastSubmissionTime = dutyRound.SubmissionTime
+			}
+		}
+
+		result := dutyRound.Result
+		if !dutyRound.Resolved {
+			result = DutyResult_Pending
+		}
+		summary.Results[result]++
+		if dutyRound.Resolved && dutyRound.ResolvedTime.After(lastResultTimes[i]) {
+			lastResultTimes[i] = dutyRound.ResolvedTime
+			summary.LastResult = result
+		}
+	}
+	return summaries
+}
+
+// Remove any rounds older than the retention period
+func (r *DutyRecord) Prune(now time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for key, dutyRound := range r.Rounds {
+		if now.Sub(dutyRound.FirstSeen) > RetentionPeriod {
+			delete(r.Rounds, key)
+		}
+	}
+}
+
+// Get a round from the record, adding it if it doesn't exist yet
+func (r *DutyRecord) getOrCreateRound(dutyType DutyType, round string, block uint64) *DutyRound {
+	key := getRoundKey(dutyType, round)
+	dutyRound, exists := r.Rounds[key]
+	if !exists {
+		dutyRound = &DutyRound{
+			Type:      dutyType,
+			Round:     round,
+			Block:     block,
+			FirstSeen: time.Now(),
+			Result:    DutyResult_Pending,
+		}
+		r.Rounds[key] = dutyRound
+	}
+	return dutyRound
+}
+
+// Get the key of a round in the record
+func getRoundKey(dutyType DutyType, round string) string {
+	return fmt.Sprintf("%s-%s", dutyType, round)
+}
+
+// Check if every consensus value matches the submitted one
+func valuesMatch(submitted map[string]string, consensus map[string]string) bool {
+	for key, value := range consensus {
+		if submitted[key] != value {
+			return false
+		}
+	}
+	return true
+}
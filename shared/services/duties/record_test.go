@@ -0,0 +1,148 @@
+package duties
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestValuesMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		submitted map[string]string
+		consensus map[string]string
+		expected  bool
+	}{
+		{
+			name:      "identical",
+			submitted: map[string]string{BalancesValue_TotalEth: "100", BalancesValue_RethSupply: "90"},
+			consensus: map[string]string{BalancesValue_TotalEth: "100", BalancesValue_RethSupply: "90"},
+			expected:  true,
+		},
+		{
+			name:      "different value",
+			submitted: map[string]string{BalancesValue_TotalEth: "100", BalancesValue_RethSupply: "91"},
+			consensus: map[string]string{BalancesValue_TotalEth: "100", BalancesValue_RethSupply: "90"},
+			expected:  false,
+		},
+		{
+			name:      "extra submitted values are ignored",
+			submitted: map[string]string{RewardsTreeValue_MerkleRoot: "0xabc", RewardsTreeValue_Cid: "cid"},
+			consensus: map[string]string{RewardsTreeValue_MerkleRoot: "0xabc"},
+			expected:  true,
+		},
+		{
+			name:      "missing submitted value",
+			submitted: map[string]string{PricesValue_RplPrice: "5"},
+			consensus: map[string]string{PricesValue_RplPrice: "5", PricesValue_SlotTimestamp: "1000"},
+			expected:  false,
+		},
+		{
+			name:      "no consensus values",
+			submitted: map[string]string{PricesValue_RplPrice: "5"},
+			consensus: map[string]string{},
+			expected:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if result := valuesMatch(test.submitted, test.consensus); result != test.expected {
+				t.Fatalf("expected %t, got %t", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	values := map[string]string{ScrubValue_Vote: ScrubVote_Stake}
+	otherValues := map[string]string{ScrubValue_Vote: ScrubVote_Scrub}
+	tests := []struct {
+		name             string
+		recordedValues   map[string]string
+		submitted        bool
+		consensusReached bool
+		consensusValues  map[string]string
+		expected         DutyResult
+	}{
+		{
+			name:             "matched",
+			recordedValues:   values,
+			submitted:        true,
+			consensusReached: true,
+			consensusValues:  values,
+			expected:         DutyResult_Matched,
+		},
+		{
+			name:             "mismatched",
+			recordedValues:   values,
+			submitted:        true,
+			consensusReached: true,
+			consensusValues:  otherValues,
+			expected:         DutyResult_Mismatched,
+		},
+		{
+			name:             "missed",
+			submitted:        false,
+			consensusReached: true,
+			consensusValues:  values,
+			expected:         DutyResult_Missed,
+		},
+		{
+			name:             "no consensus",
+			recordedValues:   values,
+			submitted:        true,
+			consensusReached: false,
+			expected:         DutyResult_NoConsensus,
+		},
+		{
+			name:             "submitted on chain but not recorded locally",
+			submitted:        true,
+			consensusReached: true,
+			consensusValues:  values,
+			expected:         DutyResult_Unknown,
+		},
+		{
+			name:             "recorded locally but not reported by the contracts",
+			recordedValues:   values,
+			submitted:        false,
+			consensusReached: true,
+			consensusValues:  values,
+			expected:         DutyResult_Matched,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			record := NewDutyRecord()
+			if test.recordedValues != nil {
+				record.AddSubmission(DutyType_Scrub, "0x01", 100, test.recordedValues, common.Hash{})
+			}
+			result := record.Resolve(DutyType_Scrub, "0x01", 100, test.submitted, test.consensusReached, test.consensusValues)
+			if result != test.expected {
+				t.Fatalf("expected %s, got %s", test.expected, result)
+			}
+			if !record.IsResolved(DutyType_Scrub, "0x01") {
+				t.Fatalf("round wasn't marked as resolved")
+			}
+		})
+	}
+}
+
+func TestResolveIsFinal(t *testing.T) {
+	record := NewDutyRecord()
+	record.AddFailure(DutyType_Balances, "1", 100, errors.New("test failure"))
+	if result := record.Resolve(DutyType_Balances, "1", 100, false, true, nil); result != DutyResult_Missed {
+		t.Fatalf("expected %s, got %s", DutyResult_Missed, result)
+	}
+
+	// A later submission doesn't change a resolved round
+	record.AddSubmission(DutyType_Balances, "1", 100, map[string]string{BalancesValue_TotalEth: "1"}, common.Hash{})
+	if result := record.Resolve(DutyType_Balances, "1", 100, true, true, map[string]string{BalancesValue_TotalEth: "1"}); result != DutyResult_Missed {
+		t.Fatalf("expected the resolved result %s to be kept, got %s", DutyResult_Missed, result)
+	}
+	if rounds := record.GetUnresolvedRounds(DutyType_Balances); len(rounds) != 0 {
+		t.Fatalf("expected no unresolved rounds, got %d", len(rounds))
+	}
+}
@@ -144,6 +144,18 @@ func (p *ExecutionClientManager) HeaderByNumber(ctx context.Context, number *big
 	return result.(*types.Header), err
 }
 
+// BlockByNumber returns a block from the current canonical chain. If number is nil, the
+// latest known block is returned.
+func (p *ExecutionClientManager) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return client.BlockByNumber(ctx, number)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*types.Block), err
+}
+
 // PendingCodeAt returns the code of the given account in the pending state.
 func (p *ExecutionClientManager) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
 	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
@@ -328,6 +340,17 @@ func (p *ExecutionClientManager) SyncProgress(ctx context.Context) (*ethereum.Sy
 	return result.(*ethereum.SyncProgress), err
 }
 
+// PeerCount returns the number of p2p peers as reported by the net_peerCount method.
+func (p *ExecutionClientManager) PeerCount(ctx context.Context) (uint64, error) {
+	result, err := p.runFunction(func(client *ethclient.Client) (interface{}, error) {
+		return client.PeerCount(ctx)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.(uint64), err
+}
+
 /// ==================
 /// Internal functions
 /// ==================
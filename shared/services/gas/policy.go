@@ -0,0 +1,193 @@
+package gas
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/utils/atomicfile"
+	"github.com/rocket-pool/smartnode/shared/utils/log"
+)
+
+// How long a transaction can go without being delayed again before it's dropped from the delay record
+const autoTxDelayStaleAge time.Duration = 24 * time.Hour
+
+// The automated transactions, which are part of the keys in the delay record
+const (
+	AutoTxAction_Stake          string = "stake"
+	AutoTxAction_Promote        string = "promote"
+	AutoTxAction_Distribute     string = "distribute"
+	AutoTxAction_ReduceBond     string = "reduce-bond"
+	AutoTxAction_DistributeFees string = "distribute-fees"
+	AutoTxAction_ClaimRewards   string = "claim-rewards"
+	AutoTxAction_Vote           string = "vote"
+)
+
+// An automated transaction and the window in which it should be executed
+type AutoTxDeadline struct {
+	Action   string
+	Subject  string
+	Eligible time.Time
+	Deadline time.Time
+}
+
+// A record of why an automated transaction was delayed
+type AutoTxDelay struct {
+	Action        string    `json:"action"`
+	Subject       string    `json:"subject"`
+	FirstDelayed  time.Time `json:"firstDelayed"`
+	LastDelayed   time.Time `json:"lastDelayed"`
+	DelayCount    uint64    `json:"delayCount"`
+	MaxFeeGwei    float64   `json:"maxFeeGwei"`
+	ThresholdGwei float64   `json:"thresholdGwei"`
+	Deadline      time.Time `json:"deadline,omitempty"`
+	Reason        string    `json:"reason"`
+}
+
+// The gas policy for automated transactions.
+// Transactions wait for the base threshold at first, then the acceptable fee rises linearly toward the hard cap as their deadline approaches.
+type AutoTxPolicy struct {
+	baseThresholdGwei float64
+	hardCapGwei       float64
+	escalationStart   float64
+	recordPath        string
+	lock              *sync.Mutex
+}
+
+// Create the automated transaction gas policy from the Smartnode config
+func NewAutoTxPolicy(cfg *config.RocketPoolConfig) *AutoTxPolicy {
+	escalationStart := cfg.Smartnode.AutoTxEscalationStart.Value.(float64) / 100
+	if escalationStart < 0 {
+		escalationStart = 0
+	} else if escalationStart > 1 {
+		escalationStart = 1
+	}
+	return &AutoTxPolicy{
+		baseThresholdGwei: cfg.Smartnode.AutoTxGasThreshold.Value.(float64),
+		hardCapGwei:       cfg.Smartnode.AutoTxGasHardCap.Value.(float64),
+		escalationStart:   escalationStart,
+		recordPath:        cfg.Smartnode.GetAutoTxDelayRecordPath(),
+		lock:              &sync.Mutex{},
+	}
+}
+
+// Get the base gas threshold (in gwei) that transactions use before they escalate
+func (p *AutoTxPolicy) GetBaseThreshold() float64 {
+	return p.baseThresholdGwei
+}
+
+// Get the gas threshold (in gwei) a transaction will accept at the given time, and a description of where it is in its window
+func (p *AutoTxPolicy) GetThreshold(deadline AutoTxDeadline, now time.Time) (float64, string) {
+
+	// A base threshold of 0 disables automatic transactions, so it never escalates
+	if p.baseThresholdGwei == 0 {
+		return 0, "automatic transactions are disabled"
+	}
+
+	// Transactions without a deadline, or policies without escalation, always use the base threshold
+	if deadline.Deadline.IsZero() || p.hardCapGwei <= p.baseThresholdGwei {
+		return p.baseThresholdGwei, "base threshold"
+	}
+	if !now.Before(deadline.Deadline) {
+		return p.hardCapGwei, "hard cap, deadline has passed"
+	}
+
+	// Wait for the base threshold until escalation starts
+	window := deadline.Deadline.Sub(deadline.Eligible)
+	if window <= 0 {
+		return p.hardCapGwei, fmt.Sprintf("hard cap, deadline in %s", deadline.Deadline.Sub(now).Round(time.Second))
+	}
+	escalationTime := deadline.Eligible.Add(time.Duration(float64(window) * p.escalationStart))
+	if now.Before(escalationTime) {
+		return p.baseThresholdGwei, fmt.Sprintf("base threshold, escalation starts in %s", escalationTime.Sub(now).Round(time.Second))
+	}
+
+	// Escalate linearly toward the hard cap
+	escalationLength := deadline.Deadline.Sub(escalationTime)
+	progress := float64(now.Sub(escalationTime)) / float64(escalationLength)
+	threshold := p.baseThresholdGwei + (p.hardCapGwei-p.baseThresholdGwei)*progress
+	return threshold, fmt.Sprintf("escalated, deadline in %s", deadline.Deadline.Sub(now).Round(time.Second))
+
+}
+
+// Log why a transaction was delayed and add it to the delay record
+func (p *AutoTxPolicy) RecordDelay(deadline AutoTxDeadline, maxFee *big.Int, thresholdGwei float64, stage string, logger *log.ColorLogger) {
+	maxFeeGwei := eth.WeiToGwei(maxFee)
+	reason := fmt.Sprintf("max fee of %.2f gwei is not lower than the threshold of %.2f gwei (%s)", maxFeeGwei, thresholdGwei, stage)
+	logger.Printlnf("Delaying %s for %s: %s.", deadline.Action, deadline.Subject, reason)
+
+	now := time.Now()
+	err := p.updateRecord(func(delays map[string]*AutoTxDelay) {
+		key := getAutoTxDelayKey(deadline)
+		delay, exists := delays[key]
+		if !exists {
+			delay = &AutoTxDelay{
+				Action:       deadline.Action,
+				Subject:      deadline.Subject,
+				FirstDelayed: now,
+			}
+			delays[key] = delay
+		}
+		delay.LastDelayed = now
+		delay.DelayCount++
+		delay.MaxFeeGwei = maxFeeGwei
+		delay.ThresholdGwei = thresholdGwei
+		delay.Deadline = deadline.Deadline
+		delay.Reason = reason
+	})
+	if err != nil {
+		logger.Printlnf("WARNING: couldn't save the automatic transaction delay record: %s", err.Error())
+	}
+}
+
+// Remove a transaction from the delay record once it has been submitted
+func (p *AutoTxPolicy) ClearDelay(deadline AutoTxDeadline, logger *log.ColorLogger) {
+	err := p.updateRecord(func(delays map[string]*AutoTxDelay) {
+		delete(delays, getAutoTxDelayKey(deadline))
+	})
+	if err != nil {
+		logger.Printlnf("WARNING: couldn't save the automatic transaction delay record: %s", err.Error())
+	}
+}
+
+// Load the delay record from disk
+func LoadAutoTxDelays(path string) (map[string]*AutoTxDelay, error) {
+	delays := map[string]*AutoTxDelay{}
+	if _, err := atomicfile.LoadJson(path, &delays); err != nil {
+		return nil, fmt.Errorf("error loading automatic transaction delay record: %w", err)
+	}
+	if delays == nil {
+		delays = map[string]*AutoTxDelay{}
+	}
+	return delays, nil
+}
+
+// Load the delay record, modify it, and save it back to disk
+func (p *AutoTxPolicy) updateRecord(update func(delays map[string]*AutoTxDelay)) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delays, err := LoadAutoTxDelays(p.recordPath)
+	if err != nil {
+		return err
+	}
+	update(delays)
+	cutoff := time.Now().Add(-autoTxDelayStaleAge)
+	for key, delay := range delays {
+		if delay.LastDelayed.Before(cutoff) {
+			delete(delays, key)
+		}
+	}
+	if err := atomicfile.SaveJson(p.recordPath, delays); err != nil {
+		return fmt.Errorf("error saving automatic transaction delay record: %w", err)
+	}
+	return nil
+}
+
+// Get the key of a transaction in the delay record
+func getAutoTxDelayKey(deadline AutoTxDeadline) string {
+	return deadline.Action + ":" + deadline.Subject
+}
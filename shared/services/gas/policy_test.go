@@ -0,0 +1,127 @@
+package gas
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetThreshold(t *testing.T) {
+	eligible := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	deadline := AutoTxDeadline{
+		Action:   "test",
+		Subject:  "subject",
+		Eligible: eligible,
+		Deadline: eligible.Add(100 * time.Hour),
+	}
+	tests := []struct {
+		name            string
+		baseThreshold   float64
+		hardCap         float64
+		escalationStart float64
+		deadline        AutoTxDeadline
+		now             time.Time
+		expected        float64
+		expectedStage   string
+	}{
+		{
+			name:            "disabled",
+			baseThreshold:   0,
+			hardCap:         200,
+			escalationStart: 0.5,
+			deadline:        deadline,
+			now:             eligible.Add(99 * time.Hour),
+			expected:        0,
+			expectedStage:   "automatic transactions are disabled",
+		},
+		{
+			name:            "no deadline",
+			baseThreshold:   50,
+			hardCap:         200,
+			escalationStart: 0.5,
+			deadline:        AutoTxDeadline{Action: "test", Subject: "subject"},
+			now:             eligible,
+			expected:        50,
+			expectedStage:   "base threshold",
+		},
+		{
+			name:            "no escalation",
+			baseThreshold:   50,
+			hardCap:         50,
+			escalationStart: 0.5,
+			deadline:        deadline,
+			now:             eligible.Add(99 * time.Hour),
+			expected:        50,
+			expectedStage:   "base threshold",
+		},
+		{
+			name:            "before escalation",
+			baseThreshold:   50,
+			hardCap:         200,
+			escalationStart: 0.5,
+			deadline:        deadline,
+			now:             eligible.Add(10 * time.Hour),
+			expected:        50,
+			expectedStage:   "base threshold, escalation starts in 40h0m0s",
+		},
+		{
+			name:            "halfway through escalation",
+			baseThreshold:   50,
+			hardCap:         200,
+			escalationStart: 0.5,
+			deadline:        deadline,
+			now:             eligible.Add(75 * time.Hour),
+			expected:        125,
+			expectedStage:   "escalated, deadline in 25h0m0s",
+		},
+		{
+			name:            "escalation from the start",
+			baseThreshold:   50,
+			hardCap:         150,
+			escalationStart: 0,
+			deadline:        deadline,
+			now:             eligible.Add(10 * time.Hour),
+			expected:        60,
+			expectedStage:   "escalated, deadline in 90h0m0s",
+		},
+		{
+			name:            "deadline passed",
+			baseThreshold:   50,
+			hardCap:         200,
+			escalationStart: 0.5,
+			deadline:        deadline,
+			now:             eligible.Add(101 * time.Hour),
+			expected:        200,
+			expectedStage:   "hard cap, deadline has passed",
+		},
+		{
+			name:            "empty window",
+			baseThreshold:   50,
+			hardCap:         200,
+			escalationStart: 0.5,
+			deadline:        AutoTxDeadline{Action: "test", Subject: "subject", Eligible: eligible, Deadline: eligible},
+			now:             eligible.Add(-time.Hour),
+			expected:        200,
+			expectedStage:   "hard cap, deadline in 1h0m0s",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := &AutoTxPolicy{
+				baseThresholdGwei: test.baseThreshold,
+				hardCapGwei:       test.hardCap,
+				escalationStart:   test.escalationStart,
+				lock:              &sync.Mutex{},
+			}
+			threshold, stage := policy.GetThreshold(test.deadline, test.now)
+			if math.Abs(threshold-test.expected) > 1e-9 {
+				t.Fatalf("expected a threshold of %.2f, got %.2f", test.expected, threshold)
+			}
+			if stage != test.expectedStage {
+				t.Fatalf("expected stage '%s', got '%s'", test.expectedStage, stage)
+			}
+		})
+	}
+}
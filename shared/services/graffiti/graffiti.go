@@ -0,0 +1,222 @@
+package graffiti
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+	"gopkg.in/yaml.v2"
+
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"github.com/rocket-pool/smartnode/shared/utils/atomicfile"
+)
+
+const (
+	// The most bytes a block's graffiti can hold
+	MaxGraffitiLength int = 32
+
+	// The separator between the messages that are rotated through
+	MessageSeparator string = "|"
+
+	// The number of pubkey characters a graffiti shows after the 0x prefix
+	PubkeyLength int = 8
+
+	// The folder the validator client can read the graffiti file from
+	validatorsFolderInVc string = "/validators"
+)
+
+// The values a graffiti template can use
+type TemplateData struct {
+	// Short codes for the clients, such as G for Geth or L for Lighthouse
+	EC string
+	CC string
+
+	// The full client names
+	ECName string
+	CCName string
+
+	// The Smartnode version, such as v1.13.0
+	Version string
+
+	// The number of minipools the node has
+	MinipoolCount int
+
+	// The node's primary ENS name, if it has one
+	ENSName string
+
+	// The current message from the list of rotating messages
+	Message string
+
+	// The validator the graffiti is for; these are blank for the default graffiti
+	ValidatorIndex string
+	Pubkey         string
+}
+
+// The graffiti to use for a single validator
+type ValidatorGraffiti struct {
+	Pubkey   types.ValidatorPubkey
+	Index    string
+	Graffiti string
+}
+
+// A parsed graffiti template
+type Template struct {
+	tmpl *template.Template
+}
+
+// Parse a graffiti template
+func NewTemplate(text string) (*Template, error) {
+	tmpl, err := template.New("graffiti").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing graffiti template: %w", err)
+	}
+	return &Template{
+		tmpl: tmpl,
+	}, nil
+}
+
+// Render the template, making sure the result fits in a block
+func (t *Template) Render(data TemplateData) (string, error) {
+	output := &bytes.Buffer{}
+	err := t.tmpl.Execute(output, data)
+	if err != nil {
+		return "", fmt.Errorf("error rendering graffiti template: %w", err)
+	}
+	graffiti := strings.TrimSpace(output.String())
+	err = Validate(graffiti)
+	if err != nil {
+		return "", err
+	}
+	return graffiti, nil
+}
+
+// Check that a graffiti fits in a block and can be written to a graffiti file
+func Validate(graffiti string) error {
+	if len(graffiti) > MaxGraffitiLength {
+		return fmt.Errorf("graffiti [%s] is %d bytes, but the limit is %d", graffiti, len(graffiti), MaxGraffitiLength)
+	}
+	if strings.ContainsAny(graffiti, "\r\n") {
+		return fmt.Errorf("graffiti [%s] can't span multiple lines", graffiti)
+	}
+	return nil
+}
+
+// Split the rotating messages setting into the individual messages
+func GetMessages(messages string) []string {
+	list := []string{}
+	for _, message := range strings.Split(messages, MessageSeparator) {
+		message = strings.TrimSpace(message)
+		if message != "" {
+			list = append(list, message)
+		}
+	}
+	return list
+}
+
+// Get the message to use at the provided time, moving to the next one every interval
+func GetCurrentMessage(messages []string, interval time.Duration, now time.Time) string {
+	if len(messages) == 0 {
+		return ""
+	}
+	if interval <= 0 {
+		return messages[0]
+	}
+	return messages[(now.Unix()/int64(interval.Seconds()))%int64(len(messages))]
+}
+
+// Get the start of a validator's pubkey the way the {{.Pubkey}} value shows it
+func FormatPubkey(pubkey types.ValidatorPubkey) string {
+	return "0x" + pubkey.Hex()[:PubkeyLength]
+}
+
+// Check if a Consensus Client's validator client can read a graffiti file
+func IsFileSupported(client cfgtypes.ConsensusClient) bool {
+	switch client {
+	case cfgtypes.ConsensusClient_Lighthouse, cfgtypes.ConsensusClient_Prysm, cfgtypes.ConsensusClient_Teku:
+		return true
+	}
+	return false
+}
+
+// Get the validator client flag that points it to the graffiti file
+func GetFileFlag(client cfgtypes.ConsensusClient, filename string) (string, error) {
+	path := filepath.Join(validatorsFolderInVc, filename)
+	switch client {
+	case cfgtypes.ConsensusClient_Lighthouse, cfgtypes.ConsensusClient_Prysm:
+		return fmt.Sprintf("--graffiti-file=%s", path), nil
+	case cfgtypes.ConsensusClient_Teku:
+		return fmt.Sprintf("--validators-graffiti-file=%s", path), nil
+	}
+	return "", fmt.Errorf("the %s validator client doesn't support graffiti files", client)
+}
+
+// Format the graffiti of each validator as a graffiti file for the provided Consensus Client
+func FormatFile(client cfgtypes.ConsensusClient, defaultGraffiti string, validators []ValidatorGraffiti) ([]byte, error) {
+	// Sort the validators so the file only changes when the graffiti does
+	validators = append([]ValidatorGraffiti{}, validators...)
+	sort.Slice(validators, func(i, j int) bool {
+		return validators[i].Pubkey.Hex() < validators[j].Pubkey.Hex()
+	})
+
+	switch client {
+	case cfgtypes.ConsensusClient_Lighthouse:
+		// One `key: graffiti` line per validator, keyed by pubkey
+		builder := &strings.Builder{}
+		fmt.Fprintf(builder, "default: %s\n", defaultGraffiti)
+		for _, validator := range validators {
+			fmt.Fprintf(builder, "0x%s: %s\n", validator.Pubkey.Hex(), validator.Graffiti)
+		}
+		return []byte(builder.String()), nil
+
+	case cfgtypes.ConsensusClient_Prysm:
+		// YAML, with the specific graffiti keyed by validator index
+		file := prysmGraffitiFile{
+			Default:  defaultGraffiti,
+			Specific: yaml.MapSlice{},
+		}
+		for _, validator := range validators {
+			// Validators that aren't on the Beacon Chain yet don't have an index
+			index, err := strconv.ParseUint(validator.Index, 10, 64)
+			if err != nil {
+				continue
+			}
+			file.Specific = append(file.Specific, yaml.MapItem{Key: index, Value: validator.Graffiti})
+		}
+		return yaml.Marshal(file)
+
+	case cfgtypes.ConsensusClient_Teku:
+		// Teku only reads a single graffiti from the file
+		return []byte(defaultGraffiti), nil
+	}
+	return nil, fmt.Errorf("the %s validator client doesn't support graffiti files", client)
+}
+
+// Write a graffiti file if its contents changed, returning whether it was written
+func WriteFile(path string, contents []byte) (bool, error) {
+	existing, err := os.ReadFile(path)
+	if err == nil && bytes.Equal(existing, contents) {
+		return false, nil
+	}
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("error reading graffiti file %s: %w", path, err)
+	}
+
+	// The validator client must never read a partial file
+	if err := atomicfile.Write(path, contents, 0644); err != nil {
+		return false, fmt.Errorf("error writing graffiti file: %w", err)
+	}
+	return true, nil
+}
+
+// The format of Prysm's graffiti file
+type prysmGraffitiFile struct {
+	Default  string        `yaml:"default"`
+	Specific yaml.MapSlice `yaml:"specific,omitempty"`
+}
@@ -0,0 +1,185 @@
+package graffiti
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+func TestRender(t *testing.T) {
+	data := TemplateData{
+		EC:             "G",
+		CC:             "L",
+		ECName:         "Geth",
+		CCName:         "Lighthouse",
+		Version:        "v1.13.0",
+		MinipoolCount:  3,
+		ENSName:        "node.eth",
+		Message:        "gm",
+		ValidatorIndex: "123456",
+		Pubkey:         "0x12345678",
+	}
+	tests := []struct {
+		name     string
+		template string
+		expected string
+		fails    bool
+	}{
+		{
+			name:     "client codes",
+			template: "RP-{{.EC}}{{.CC}} {{.Version}}",
+			expected: "RP-GL v1.13.0",
+		},
+		{
+			name:     "surrounding whitespace is trimmed",
+			template: "  {{.ENSName}} ({{.MinipoolCount}})  ",
+			expected: "node.eth (3)",
+		},
+		{
+			name:     "conditional message",
+			template: "{{.ECName}}{{if .Message}} {{.Message}}{{end}}",
+			expected: "Geth gm",
+		},
+		{
+			name:     "exactly 32 bytes",
+			template: "{{.CCName}} {{.ValidatorIndex}} {{.Pubkey}} 123",
+			expected: "Lighthouse 123456 0x12345678 123",
+		},
+		{
+			name:     "too long",
+			template: "{{.CCName}} {{.ValidatorIndex}} {{.Pubkey}} 1234",
+			fails:    true,
+		},
+		{
+			name:     "multiple lines",
+			template: "{{.EC}}\n{{.CC}}",
+			fails:    true,
+		},
+		{
+			name:     "unknown field",
+			template: "{{.Nickname}}",
+			fails:    true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmpl, err := NewTemplate(test.template)
+			if err != nil {
+				t.Fatal(err)
+			}
+			graffiti, err := tmpl.Render(data)
+			if test.fails {
+				if err == nil {
+					t.Fatalf("expected rendering to fail, got [%s]", graffiti)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if graffiti != test.expected {
+				t.Fatalf("expected [%s], got [%s]", test.expected, graffiti)
+			}
+		})
+	}
+
+	if _, err := NewTemplate("{{.EC"); err == nil {
+		t.Fatalf("expected an unterminated action to be rejected")
+	}
+}
+
+func TestFormatPubkey(t *testing.T) {
+	pubkey := FormatPubkey(types.ValidatorPubkey{0xab, 0xcd, 0xef, 0x01, 0x23})
+	if pubkey != "0xabcdef01" {
+		t.Fatalf("expected 0xabcdef01, got %s", pubkey)
+	}
+}
+
+func TestGetCurrentMessage(t *testing.T) {
+	messages := GetMessages(" first | second || third ")
+	if strings.Join(messages, ",") != "first,second,third" {
+		t.Fatalf("unexpected messages %v", messages)
+	}
+
+	start := time.Unix(0, 0)
+	tests := []struct {
+		interval time.Duration
+		now      time.Time
+		expected string
+	}{
+		{time.Hour, start, "first"},
+		{time.Hour, start.Add(59 * time.Minute), "first"},
+		{time.Hour, start.Add(time.Hour), "second"},
+		{time.Hour, start.Add(5 * time.Hour), "third"},
+		{0, start.Add(5 * time.Hour), "first"},
+	}
+	for _, test := range tests {
+		if message := GetCurrentMessage(messages, test.interval, test.now); message != test.expected {
+			t.Fatalf("expected message %s at %s with interval %s, got %s", test.expected, test.now.UTC(), test.interval, message)
+		}
+	}
+	if message := GetCurrentMessage([]string{}, time.Hour, start); message != "" {
+		t.Fatalf("expected no message, got %s", message)
+	}
+}
+
+func TestFormatFile(t *testing.T) {
+	validators := []ValidatorGraffiti{
+		{Pubkey: types.ValidatorPubkey{0x02}, Index: "200", Graffiti: "second"},
+		{Pubkey: types.ValidatorPubkey{0x01}, Index: "100", Graffiti: "first"},
+		{Pubkey: types.ValidatorPubkey{0x03}, Index: "", Graffiti: "pending"},
+	}
+	pubkey1 := "0x" + types.ValidatorPubkey{0x01}.Hex()
+	pubkey2 := "0x" + types.ValidatorPubkey{0x02}.Hex()
+	pubkey3 := "0x" + types.ValidatorPubkey{0x03}.Hex()
+
+	tests := []struct {
+		client   cfgtypes.ConsensusClient
+		expected string
+	}{
+		{
+			client: cfgtypes.ConsensusClient_Lighthouse,
+			expected: "default: RP default\n" +
+				pubkey1 + ": first\n" +
+				pubkey2 + ": second\n" +
+				pubkey3 + ": pending\n",
+		},
+		{
+			client: cfgtypes.ConsensusClient_Prysm,
+			expected: "default: RP default\n" +
+				"specific:\n" +
+				"  100: first\n" +
+				"  200: second\n",
+		},
+		{
+			client:   cfgtypes.ConsensusClient_Teku,
+			expected: "RP default",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.client), func(t *testing.T) {
+			contents, err := FormatFile(test.client, "RP default", validators)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(contents) != test.expected {
+				t.Fatalf("expected:\n%s\ngot:\n%s", test.expected, string(contents))
+			}
+		})
+	}
+
+	// The input order must be left alone
+	if validators[0].Index != "200" {
+		t.Fatalf("the validators were sorted in place")
+	}
+
+	if _, err := FormatFile(cfgtypes.ConsensusClient_Nimbus, "RP default", validators); err == nil {
+		t.Fatalf("expected Nimbus to be rejected")
+	}
+}
@@ -0,0 +1,33 @@
+package performance
+
+const (
+	// The maximum number of epochs to process in a single run so the other node tasks aren't held up
+	MaxEpochsPerRun uint64 = 10
+
+	// The maximum number of epochs to look back when starting fresh or after a long downtime (roughly one day)
+	MaxBacklog uint64 = 225
+
+	// The number of concurrent requests to make when fetching per-slot or per-transaction data
+	ThreadLimit int = 12
+)
+
+// Get the range of epochs to process next, given the last one that was processed and the latest one that can be.
+// Returns false if there's nothing new to process.
+func GetEpochRange(lastEpoch uint64, targetEpoch uint64) (uint64, uint64, bool) {
+	if lastEpoch != 0 && lastEpoch >= targetEpoch {
+		return 0, 0, false
+	}
+	startEpoch := lastEpoch + 1
+	if lastEpoch == 0 || targetEpoch-startEpoch > MaxBacklog {
+		if targetEpoch > MaxBacklog {
+			startEpoch = targetEpoch - MaxBacklog
+		} else {
+			startEpoch = 0
+		}
+	}
+	endEpoch := targetEpoch
+	if endEpoch-startEpoch >= MaxEpochsPerRun {
+		endEpoch = startEpoch + MaxEpochsPerRun - 1
+	}
+	return startEpoch, endEpoch, true
+}
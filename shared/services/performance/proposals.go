@@ -0,0 +1,113 @@
+package performance
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/utils/atomicfile"
+)
+
+// How long proposal audits are kept before they're pruned
+const ProposalAuditRetentionPeriod time.Duration = 365 * 24 * time.Hour
+
+// The result of auditing a block proposed by one of the node's validators
+type ProposalAudit struct {
+	Slot                 uint64         `json:"slot"`
+	ExecutionBlockNumber uint64         `json:"executionBlockNumber"`
+	Time                 time.Time      `json:"time"`
+	ValidatorIndex       string         `json:"validatorIndex"`
+	MinipoolAddress      common.Address `json:"minipoolAddress"`
+	IsInSmoothingPool    bool           `json:"isInSmoothingPool"`
+	ExpectedRecipient    common.Address `json:"expectedRecipient"`
+	FeeRecipient         common.Address `json:"feeRecipient"`
+	IsMevBoost           bool           `json:"isMevBoost"`
+	ActualRecipient      common.Address `json:"actualRecipient"`
+	Reward               *big.Int       `json:"reward"`
+	IsCorrect            bool           `json:"isCorrect"`
+}
+
+// The audit history of all of the blocks proposed by the node's validators
+type ProposalAuditRecord struct {
+	LastProcessedEpoch uint64          `json:"lastProcessedEpoch"`
+	Proposals          []ProposalAudit `json:"proposals"`
+
+	// Internal fields
+	lock *sync.Mutex `json:"-"`
+}
+
+// Create a new, empty proposal audit record
+func NewProposalAuditRecord() *ProposalAuditRecord {
+	return &ProposalAuditRecord{
+		Proposals: []ProposalAudit{},
+		lock:      &sync.Mutex{},
+	}
+}
+
+// Load a proposal audit record from disk, or create a new one if it doesn't exist yet
+func LoadProposalAuditRecord(path string) (*ProposalAuditRecord, error) {
+	record := NewProposalAuditRecord()
+	if _, err := atomicfile.LoadJson(path, record); err != nil {
+		return nil, fmt.Errorf("error loading proposal audit record: %w", err)
+	}
+	if record.Proposals == nil {
+		record.Proposals = []ProposalAudit{}
+	}
+	return record, nil
+}
+
+// Save the proposal audit record to disk
+func (r *ProposalAuditRecord) Save(path string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if err := atomicfile.SaveJson(path, r); err != nil {
+		return fmt.Errorf("error saving proposal audit record: %w", err)
+	}
+	return nil
+}
+
+// Add the audits for an epoch to the record
+func (r *ProposalAuditRecord) AddEpoch(epoch uint64, audits []ProposalAudit) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.Proposals = append(r.Proposals, audits...)
+	r.LastProcessedEpoch = epoch
+}
+
+// Remove any audits of blocks older than the retention period
+func (r *ProposalAuditRecord) Prune(now time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	cutoff := now.Add(-ProposalAuditRetentionPeriod)
+	proposals := make([]ProposalAudit, 0, len(r.Proposals))
+	for _, proposal := range r.Proposals {
+		if !proposal.Time.Before(cutoff) {
+			proposals = append(proposals, proposal)
+		}
+	}
+	r.Proposals = proposals
+}
+
+// Get a copy of the audited proposals, most recent first
+func (r *ProposalAuditRecord) GetProposals() []ProposalAudit {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	proposals := make([]ProposalAudit, len(r.Proposals))
+	for i, proposal := range r.Proposals {
+		proposals[len(r.Proposals)-1-i] = proposal
+	}
+	return proposals
+}
+
+// Get the last epoch that was processed
+func (r *ProposalAuditRecord) GetLastProcessedEpoch() uint64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.LastProcessedEpoch
+}
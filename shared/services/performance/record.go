@@ -0,0 +1,255 @@
+package performance
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/utils/atomicfile"
+)
+
+// The width of the buckets that per-epoch performance is aggregated into
+const BucketSize time.Duration = time.Hour
+
+// The time windows that performance summaries are reported for
+var Windows = []string{"1d", "7d", "30d"}
+
+// The duration of each performance window
+var WindowDurations = map[string]time.Duration{
+	"1d":  24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+}
+
+// How long performance buckets are kept before they're pruned
+const RetentionPeriod time.Duration = 30 * 24 * time.Hour
+
+// Duty and participation counters for a validator over a period of time
+type PerformanceCounters struct {
+	AttestationDuties       uint64 `json:"attestationDuties"`
+	AttestationsIncluded    uint64 `json:"attestationsIncluded"`
+	CorrectHeadVotes        uint64 `json:"correctHeadVotes"`
+	CorrectTargetVotes      uint64 `json:"correctTargetVotes"`
+	TotalInclusionDelay     uint64 `json:"totalInclusionDelay"`
+	SyncCommitteeDuties     uint64 `json:"syncCommitteeDuties"`
+	SyncCommitteeSignatures uint64 `json:"syncCommitteeSignatures"`
+	ProposalDuties          uint64 `json:"proposalDuties"`
+	ProposedBlocks          uint64 `json:"proposedBlocks"`
+
+	// The number of epochs whose proposer duties weren't available, so missed proposals in them aren't counted
+	EpochsWithoutProposerDuties uint64 `json:"epochsWithoutProposerDuties"`
+}
+
+// The performance history of a single validator
+type ValidatorRecord struct {
+	MinipoolAddress common.Address                 `json:"minipoolAddress"`
+	ValidatorIndex  string                         `json:"validatorIndex"`
+	Buckets         map[int64]*PerformanceCounters `json:"buckets"`
+}
+
+// The performance history of all of the node's validators
+type PerformanceRecord struct {
+	LastProcessedEpoch uint64                      `json:"lastProcessedEpoch"`
+	Validators         map[string]*ValidatorRecord `json:"validators"`
+
+	// Proposer duties saved while their epochs were current, keyed by epoch then validator index, until the epochs are processed
+	ProposerDuties map[uint64]map[string]uint64 `json:"proposerDuties,omitempty"`
+
+	// Internal fields
+	lock *sync.Mutex `json:"-"`
+}
+
+// Add another set of counters to this one
+func (c *PerformanceCounters) Add(other *PerformanceCounters) {
+	c.AttestationDuties += other.AttestationDuties
+	c.AttestationsIncluded += other.AttestationsIncluded
+	c.CorrectHeadVotes += other.CorrectHeadVotes
+	c.CorrectTargetVotes += other.CorrectTargetVotes
+	c.TotalInclusionDelay += other.TotalInclusionDelay
+	c.SyncCommitteeDuties += other.SyncCommitteeDuties
+	c.SyncCommitteeSignatures += other.SyncCommitteeSignatures
+	c.ProposalDuties += other.ProposalDuties
+	c.ProposedBlocks += other.ProposedBlocks
+	c.EpochsWithoutProposerDuties += other.EpochsWithoutProposerDuties
+}
+
+// The fraction of attestation duties that were included on-chain
+func (c *PerformanceCounters) GetInclusionRate() float64 {
+	return ratio(c.AttestationsIncluded, c.AttestationDuties)
+}
+
+// The fraction of included attestations that voted for the correct head and target
+func (c *PerformanceCounters) GetCorrectnessRate() float64 {
+	if c.CorrectHeadVotes < c.CorrectTargetVotes {
+		return ratio(c.CorrectHeadVotes, c.AttestationsIncluded)
+	}
+	return ratio(c.CorrectTargetVotes, c.AttestationsIncluded)
+}
+
+// The average number of slots it took for attestations to be included
+func (c *PerformanceCounters) GetAverageInclusionDelay() float64 {
+	return ratio(c.TotalInclusionDelay, c.AttestationsIncluded)
+}
+
+// The fraction of sync committee duties that were fulfilled
+func (c *PerformanceCounters) GetSyncParticipationRate() float64 {
+	return ratio(c.SyncCommitteeSignatures, c.SyncCommitteeDuties)
+}
+
+// The number of block proposals that were assigned but not proposed
+func (c *PerformanceCounters) GetMissedProposals() uint64 {
+	if c.ProposedBlocks > c.ProposalDuties {
+		return 0
+	}
+	return c.ProposalDuties - c.ProposedBlocks
+}
+
+// Create a new, empty performance record
+func NewPerformanceRecord() *PerformanceRecord {
+	return &PerformanceRecord{
+		Validators:     map[string]*ValidatorRecord{},
+		ProposerDuties: map[uint64]map[string]uint64{},
+		lock:           &sync.Mutex{},
+	}
+}
+
+// Load a performance record from disk, or create a new one if it doesn't exist yet
+func LoadPerformanceRecord(path string) (*PerformanceRecord, error) {
+	record := NewPerformanceRecord()
+	if _, err := atomicfile.LoadJson(path, record); err != nil {
+		return nil, fmt.Errorf("error loading validator performance record: %w", err)
+	}
+	if record.Validators == nil {
+		record.Validators = map[string]*ValidatorRecord{}
+	}
+	if record.ProposerDuties == nil {
+		record.ProposerDuties = map[uint64]map[string]uint64{}
+	}
+	return record, nil
+}
+
+// Save the performance record to disk
+func (r *PerformanceRecord) Save(path string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if err := atomicfile.SaveJson(path, r); err != nil {
+		return fmt.Errorf("error saving validator performance record: %w", err)
+	}
+	return nil
+}
+
+// Merge the counters for an epoch into the record
+func (r *PerformanceRecord) AddEpoch(epoch uint64, epochTime time.Time, counters map[string]*PerformanceCounters, minipools map[string]common.Address) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	bucket := epochTime.Truncate(BucketSize).Unix()
+	for index, epochCounters := range counters {
+		validator, exists := r.Validators[index]
+		if !exists {
+			validator = &ValidatorRecord{
+				MinipoolAddress: minipools[index],
+				ValidatorIndex:  index,
+				Buckets:         map[int64]*PerformanceCounters{},
+			}
+			r.Validators[index] = validator
+		}
+		bucketCounters, exists := validator.Buckets[bucket]
+		if !exists {
+			bucketCounters = &PerformanceCounters{}
+			validator.Buckets[bucket] = bucketCounters
+		}
+		bucketCounters.Add(epochCounters)
+	}
+	r.LastProcessedEpoch = epoch
+
+	// The saved proposer duties aren't needed once their epochs are processed
+	for dutiesEpoch := range r.ProposerDuties {
+		if dutiesEpoch <= epoch {
+			delete(r.ProposerDuties, dutiesEpoch)
+		}
+	}
+}
+
+// Save the proposer duties of an epoch so they can be used when it's processed
+func (r *PerformanceRecord) SaveProposerDuties(epoch uint64, duties map[string]uint64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if duties == nil {
+		duties = map[string]uint64{}
+	}
+	r.ProposerDuties[epoch] = duties
+}
+
+// Get the proposer duties that were saved for an epoch, or nil if there aren't any
+func (r *PerformanceRecord) GetProposerDuties(epoch uint64) map[string]uint64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.ProposerDuties[epoch]
+}
+
+// Remove any buckets older than the retention period
+func (r *PerformanceRecord) Prune(now time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	cutoff := now.Add(-RetentionPeriod).Unix()
+	for index, validator := range r.Validators {
+		for bucket := range validator.Buckets {
+			if bucket < cutoff {
+				delete(validator.Buckets, bucket)
+			}
+		}
+		if len(validator.Buckets) == 0 {
+			delete(r.Validators, index)
+		}
+	}
+}
+
+// Get the last epoch that was processed
+func (r *PerformanceRecord) GetLastProcessedEpoch() uint64 {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.LastProcessedEpoch
+}
+
+// Get the total counters of each validator over the given window, keyed by validator index
+func (r *PerformanceRecord) GetSummaries(window time.Duration, now time.Time) map[string]PerformanceCounters {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	cutoff := now.Add(-window).Truncate(BucketSize).Unix()
+	summaries := map[string]PerformanceCounters{}
+	for index, validator := range r.Validators {
+		summary := PerformanceCounters{}
+		for bucket, counters := range validator.Buckets {
+			if bucket >= cutoff {
+				summary.Add(counters)
+			}
+		}
+		summaries[index] = summary
+	}
+	return summaries
+}
+
+// Get the minipool address of each validator in the record, keyed by validator index
+func (r *PerformanceRecord) GetMinipoolAddresses() map[string]common.Address {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	addresses := map[string]common.Address{}
+	for index, validator := range r.Validators {
+		addresses[index] = validator.MinipoolAddress
+	}
+	return addresses
+}
+
+// Divide two counters, returning 0 if the denominator is 0
+func ratio(numerator uint64, denominator uint64) float64 {
+	if denominator == 0 {
+		return 0
+	}
+	return float64(numerator) / float64(denominator)
+}
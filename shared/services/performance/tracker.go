@@ -0,0 +1,281 @@
+package performance
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/rewards"
+	"golang.org/x/sync/errgroup"
+)
+
+// Processes finalized epochs into per-validator performance counters
+type Tracker struct {
+	bc         beacon.Client
+	eth2Config beacon.Eth2Config
+}
+
+// Create a new performance tracker
+func NewTracker(bc beacon.Client, eth2Config beacon.Eth2Config) *Tracker {
+	return &Tracker{
+		bc:         bc,
+		eth2Config: eth2Config,
+	}
+}
+
+// Get the performance counters of the provided validators for an epoch.
+// Attestations for the epoch can be included up to the end of the next epoch, so that epoch should be finalized before calling this.
+// Proposer duties saved while the epoch was current can be provided, since some Beacon Nodes only serve recent ones; if they're nil,
+// the Beacon Node is asked for them instead.
+func (t *Tracker) ProcessEpoch(epoch uint64, validatorIndices []string, proposerDuties map[string]uint64) (map[string]*PerformanceCounters, error) {
+	slotsPerEpoch := t.eth2Config.SlotsPerEpoch
+	counters := map[string]*PerformanceCounters{}
+	for _, index := range validatorIndices {
+		counters[index] = &PerformanceCounters{}
+	}
+	if len(validatorIndices) == 0 {
+		return counters, nil
+	}
+
+	// Get the attestation duties of the validators
+	duties, err := t.getAttestationDuties(epoch, counters)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the canonical block roots so the attestation votes can be checked
+	headers, err := GetHeadersForEpoch(t.bc, epoch, slotsPerEpoch)
+	if err != nil {
+		return nil, err
+	}
+	roots, err := t.getCanonicalRoots(epoch, headers)
+	if err != nil {
+		return nil, err
+	}
+	targetRoot := roots[0]
+
+	// Process the attestations included in this epoch and the next one
+	for _, inclusionEpoch := range []uint64{epoch, epoch + 1} {
+		attestationsPerSlot, err := rewards.GetAttestationsForEpoch(t.bc, inclusionEpoch, slotsPerEpoch)
+		if err != nil {
+			return nil, err
+		}
+		for i, attestations := range attestationsPerSlot {
+			inclusionSlot := inclusionEpoch*slotsPerEpoch + uint64(i)
+			for _, attestation := range attestations {
+				// Since EIP-7045, attestations can be included until the end of the epoch after the one they're for
+				if attestation.SlotIndex/slotsPerEpoch != epoch {
+					continue
+				}
+				committees, exists := duties[attestation.SlotIndex]
+				if !exists {
+					continue
+				}
+				positions, exists := committees[attestation.CommitteeIndex]
+				if !exists {
+					continue
+				}
+				for position, index := range positions {
+					if !attestation.AggregationBits.BitAt(uint64(position)) {
+						continue
+					}
+					// Only count the first inclusion of each attestation
+					delete(positions, position)
+					validatorCounters := counters[index]
+					validatorCounters.AttestationsIncluded++
+					validatorCounters.TotalInclusionDelay += inclusionSlot - attestation.SlotIndex
+					if attestation.BeaconBlockRoot == roots[attestation.SlotIndex-epoch*slotsPerEpoch] {
+						validatorCounters.CorrectHeadVotes++
+					}
+					if attestation.TargetRoot == targetRoot {
+						validatorCounters.CorrectTargetVotes++
+					}
+				}
+			}
+		}
+	}
+
+	// Process block proposals
+	t.processProposals(epoch, validatorIndices, headers, proposerDuties, counters)
+
+	// Process sync committee participation
+	err = t.processSyncCommittee(epoch, validatorIndices, headers, counters)
+	if err != nil {
+		return nil, err
+	}
+
+	return counters, nil
+}
+
+// Get the attestation committee positions of the validators for an epoch, keyed by slot then committee index then position
+func (t *Tracker) getAttestationDuties(epoch uint64, counters map[string]*PerformanceCounters) (map[uint64]map[uint64]map[int]string, error) {
+	committees, err := t.bc.GetCommitteesForEpoch(&epoch)
+	if err != nil {
+		return nil, fmt.Errorf("error getting committees for epoch %d: %w", epoch, err)
+	}
+	defer committees.Release()
+
+	duties := map[uint64]map[uint64]map[int]string{}
+	for i := 0; i < committees.Count(); i++ {
+		slot := committees.Slot(i)
+		committeeIndex := committees.Index(i)
+		for position, index := range committees.Validators(i) {
+			validatorCounters, exists := counters[index]
+			if !exists {
+				continue
+			}
+			validatorCounters.AttestationDuties++
+
+			slotDuties, exists := duties[slot]
+			if !exists {
+				slotDuties = map[uint64]map[int]string{}
+				duties[slot] = slotDuties
+			}
+			committeeDuties, exists := slotDuties[committeeIndex]
+			if !exists {
+				committeeDuties = map[int]string{}
+				slotDuties[committeeIndex] = committeeDuties
+			}
+			committeeDuties[position] = index
+		}
+	}
+	return duties, nil
+}
+
+// Get the block headers for each slot in an epoch; missed slots are left as nil
+func GetHeadersForEpoch(bc beacon.Client, epoch uint64, slotsPerEpoch uint64) ([]*beacon.BeaconBlockHeader, error) {
+	headers := make([]*beacon.BeaconBlockHeader, slotsPerEpoch)
+
+	var wg errgroup.Group
+	wg.SetLimit(ThreadLimit)
+	for i := uint64(0); i < slotsPerEpoch; i++ {
+		i := i
+		slot := epoch*slotsPerEpoch + i
+		wg.Go(func() error {
+			header, found, err := bc.GetBeaconBlockHeader(fmt.Sprint(slot))
+			if err != nil {
+				return fmt.Errorf("error getting block header for slot %d: %w", slot, err)
+			}
+			if found {
+				headers[i] = &header
+			}
+			return nil
+		})
+	}
+
+	err := wg.Wait()
+	if err != nil {
+		return nil, fmt.Errorf("error getting block headers for epoch %d: %w", epoch, err)
+	}
+	return headers, nil
+}
+
+// Get the canonical block root for each slot in an epoch; missed slots use the root of the most recent block before them
+func (t *Tracker) getCanonicalRoots(epoch uint64, headers []*beacon.BeaconBlockHeader) ([]common.Hash, error) {
+	slotsPerEpoch := t.eth2Config.SlotsPerEpoch
+	roots := make([]common.Hash, slotsPerEpoch)
+
+	// If the first slot was missed, find the latest block before the epoch
+	var previousRoot common.Hash
+	if headers[0] == nil {
+		for slot := epoch * slotsPerEpoch; slot > 0 && epoch*slotsPerEpoch-slot < slotsPerEpoch; slot-- {
+			header, found, err := t.bc.GetBeaconBlockHeader(fmt.Sprint(slot - 1))
+			if err != nil {
+				return nil, fmt.Errorf("error getting block header for slot %d: %w", slot-1, err)
+			}
+			if found {
+				previousRoot = header.Root
+				break
+			}
+		}
+	}
+
+	for i, header := range headers {
+		if header != nil {
+			previousRoot = header.Root
+		}
+		roots[i] = previousRoot
+	}
+	return roots, nil
+}
+
+// Get the number of blocks each of the provided validators is scheduled to propose in an epoch, keyed by validator index.
+// Beacon Nodes can always provide these for the current epoch, but not all of them can for older ones.
+func (t *Tracker) GetProposerDuties(epoch uint64, validatorIndices []string) (map[string]uint64, error) {
+	duties, err := t.bc.GetValidatorProposerDuties(validatorIndices, epoch)
+	if err != nil {
+		return nil, fmt.Errorf("error getting proposer duties for epoch %d: %w", epoch, err)
+	}
+	return duties, nil
+}
+
+// Count the block proposal duties and proposed blocks of the validators for an epoch.
+// If the proposer duties aren't available, the proposed blocks are still counted but the epoch is flagged in the counters,
+// since missed proposals can't be detected without them.
+func (t *Tracker) processProposals(epoch uint64, validatorIndices []string, headers []*beacon.BeaconBlockHeader, proposerDuties map[string]uint64, counters map[string]*PerformanceCounters) {
+	dutiesAvailable := true
+	if proposerDuties == nil {
+		var err error
+		proposerDuties, err = t.GetProposerDuties(epoch, validatorIndices)
+		if err != nil {
+			dutiesAvailable = false
+		}
+	}
+
+	for _, header := range headers {
+		if header == nil {
+			continue
+		}
+		if validatorCounters, exists := counters[header.ProposerIndex]; exists {
+			validatorCounters.ProposedBlocks++
+		}
+	}
+
+	for index, validatorCounters := range counters {
+		if !dutiesAvailable {
+			validatorCounters.EpochsWithoutProposerDuties = 1
+		}
+		validatorCounters.ProposalDuties = proposerDuties[index]
+		if validatorCounters.ProposalDuties < validatorCounters.ProposedBlocks {
+			validatorCounters.ProposalDuties = validatorCounters.ProposedBlocks
+		}
+	}
+}
+
+// Count the sync committee duties and signatures of the validators for an epoch
+func (t *Tracker) processSyncCommittee(epoch uint64, validatorIndices []string, headers []*beacon.BeaconBlockHeader, counters map[string]*PerformanceCounters) error {
+	positions, err := t.bc.GetValidatorSyncCommitteePositions(validatorIndices, epoch)
+	if err != nil {
+		return fmt.Errorf("error getting sync committee positions for epoch %d: %w", epoch, err)
+	}
+	if len(positions) == 0 {
+		return nil
+	}
+
+	// Check the sync aggregate of every block in the epoch
+	for _, header := range headers {
+		if header == nil {
+			continue
+		}
+		block, found, err := t.bc.GetBeaconBlock(fmt.Sprint(header.Slot))
+		if err != nil {
+			return fmt.Errorf("error getting block for slot %d: %w", header.Slot, err)
+		}
+		if !found {
+			continue
+		}
+		for index, validatorPositions := range positions {
+			validatorCounters, exists := counters[index]
+			if !exists {
+				continue
+			}
+			for _, position := range validatorPositions {
+				validatorCounters.SyncCommitteeDuties++
+				if block.SyncCommitteeBits.BitAt(position) {
+					validatorCounters.SyncCommitteeSignatures++
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package proposals
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/goccy/go-json"
+	"github.com/klauspost/compress/zstd"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/smartnode/shared"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+const (
+	// The current version of the voting artifact bundle format
+	VotingArtifactBundleVersion uint64 = 1
+)
+
+// A portable copy of the voting artifacts for a block, used to seed another node's trees.
+// Only the voting info snapshot is needed to rebuild the trees; the root is included so the importer can check the bundle
+// before doing any work.
+type VotingArtifactBundle struct {
+	Version          uint64               `json:"version"`
+	SmartnodeVersion string               `json:"smartnodeVersion"`
+	Network          cfgtypes.Network     `json:"network"`
+	BlockNumber      uint32               `json:"blockNumber"`
+	NetworkRoot      types.VotingTreeNode `json:"networkRoot"`
+	Snapshot         *VotingInfoSnapshot  `json:"snapshot"`
+}
+
+// Generate and save the voting info snapshot, network tree, and the node's own node tree for a block ahead of time,
+// so voting and challenge responses don't have to wait for them
+func (m *ProposalManager) PregenerateArtifacts(blockNumber uint32, nodeAddress common.Address) error {
+	snapshot, err := m.GetVotingInfoSnapshot(blockNumber)
+	if err != nil {
+		return err
+	}
+	_, err = m.GetNetworkTree(blockNumber, snapshot)
+	if err != nil {
+		return err
+	}
+
+	// The node tree is only needed for voting, so skip it if the node wasn't registered at the block
+	nodeIndex, err := getRPNodeIndexFromSnapshot(snapshot, nodeAddress)
+	if err != nil {
+		return nil
+	}
+	_, err = m.GetNodeTree(blockNumber, nodeIndex, snapshot)
+	return err
+}
+
+// Delete the voting artifacts for the provided blocks. Returns the number of deleted files.
+func (m *ProposalManager) PruneArtifacts(blockNumbers []uint32) (int, error) {
+	blocks := make(map[uint32]bool, len(blockNumbers))
+	for _, blockNumber := range blockNumbers {
+		blocks[blockNumber] = true
+	}
+	shouldPrune := func(blockNumber uint32) bool {
+		return blocks[blockNumber]
+	}
+
+	total := 0
+	pruned, err := m.nodeTreeMgr.Prune(shouldPrune)
+	total += pruned
+	if err != nil {
+		return total, fmt.Errorf("error pruning node trees: %w", err)
+	}
+	pruned, err = m.networkTreeMgr.Prune(shouldPrune)
+	total += pruned
+	if err != nil {
+		return total, fmt.Errorf("error pruning network trees: %w", err)
+	}
+	pruned, err = m.viSnapshotMgr.Prune(shouldPrune)
+	total += pruned
+	if err != nil {
+		return total, fmt.Errorf("error pruning voting info snapshots: %w", err)
+	}
+	return total, nil
+}
+
+// Create a bundle of the voting artifacts for a block, generating them first if necessary
+func (m *ProposalManager) CreateArtifactBundle(blockNumber uint32) (*VotingArtifactBundle, error) {
+	snapshot, err := m.GetVotingInfoSnapshot(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := m.GetNetworkTree(blockNumber, snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VotingArtifactBundle{
+		Version:          VotingArtifactBundleVersion,
+		SmartnodeVersion: shared.RocketPoolVersion,
+		Network:          snapshot.Network,
+		BlockNumber:      blockNumber,
+		NetworkRoot:      *tree.Nodes[0],
+		Snapshot:         snapshot,
+	}, nil
+}
+
+// Rebuild the network tree from a bundle's snapshot and make sure its root hash matches the expected one; since each
+// hash commits to the sums below it, this verifies every leaf of the network tree. The snapshot and tree are only saved if the roots match.
+func (m *ProposalManager) ImportArtifactBundle(bundle *VotingArtifactBundle, expectedRootHash common.Hash) error {
+	// Check the bundle itself
+	network := m.cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	err := bundle.verify(network, expectedRootHash)
+	if err != nil {
+		return err
+	}
+
+	// Rebuild the tree
+	depthPerRound, err := protocol.GetDepthPerRound(m.rp, nil)
+	if err != nil {
+		return err
+	}
+	tree := m.networkTreeMgr.CreateNetworkVotingTree(bundle.Snapshot, depthPerRound)
+	err = verifyNetworkTreeRoot(tree, expectedRootHash)
+	if err != nil {
+		return err
+	}
+
+	// Save the verified artifacts
+	err = m.viSnapshotMgr.SaveToFile(bundle.Snapshot)
+	if err != nil {
+		return fmt.Errorf("error saving voting info snapshot for block %d: %w", bundle.BlockNumber, err)
+	}
+	err = m.networkTreeMgr.SaveToFile(tree)
+	if err != nil {
+		return fmt.Errorf("error saving network tree for block %d: %w", bundle.BlockNumber, err)
+	}
+	return nil
+}
+
+// Check that a bundle is for the provided network, is internally consistent, and claims the expected root
+func (b *VotingArtifactBundle) verify(network cfgtypes.Network, expectedRootHash common.Hash) error {
+	if b.Version != VotingArtifactBundleVersion {
+		return fmt.Errorf("bundle version %d is not supported (expected %d)", b.Version, VotingArtifactBundleVersion)
+	}
+	if b.Network != network || b.Snapshot == nil || b.Snapshot.Network != network {
+		return fmt.Errorf("bundle is for network %s instead of %s", b.Network, network)
+	}
+	if b.Snapshot.BlockNumber != b.BlockNumber {
+		return fmt.Errorf("bundle is for block %d but its snapshot is for block %d", b.BlockNumber, b.Snapshot.BlockNumber)
+	}
+	if len(b.Snapshot.Info) == 0 {
+		return fmt.Errorf("bundle's snapshot is empty")
+	}
+	if b.NetworkRoot.Hash != expectedRootHash {
+		return fmt.Errorf("the bundle's root %s doesn't match the expected root %s", b.NetworkRoot.Hash.Hex(), expectedRootHash.Hex())
+	}
+	return nil
+}
+
+// Make sure a network tree rebuilt from a bundle has the expected root
+func verifyNetworkTreeRoot(tree *NetworkVotingTree, expectedRootHash common.Hash) error {
+	if len(tree.Nodes) == 0 {
+		return fmt.Errorf("the network tree built from the bundle is empty")
+	}
+	root := tree.Nodes[0]
+	if root.Hash != expectedRootHash {
+		return fmt.Errorf("the network tree built from the bundle has root %s, but the expected root is %s", root.Hash.Hex(), expectedRootHash.Hex())
+	}
+	return nil
+}
+
+// Save a voting artifact bundle as compressed JSON
+func SaveArtifactBundle(bundle *VotingArtifactBundle, path string) error {
+	bytes, err := json.Marshal(bundle)
+	if err != nil {
+		return fmt.Errorf("error serializing voting artifact bundle: %w", err)
+	}
+	compressor, err := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBestCompression))
+	if err != nil {
+		return fmt.Errorf("error creating zstd compressor: %w", err)
+	}
+	defer compressor.Close()
+	compressedBytes := compressor.EncodeAll(bytes, make([]byte, 0, len(bytes)))
+
+	err = os.MkdirAll(filepath.Dir(path), 0775)
+	if err != nil {
+		return fmt.Errorf("error creating folder for voting artifact bundle: %w", err)
+	}
+	err = os.WriteFile(path, compressedBytes, 0664)
+	if err != nil {
+		return fmt.Errorf("error writing voting artifact bundle to [%s]: %w", path, err)
+	}
+	return nil
+}
+
+// Load a voting artifact bundle from disk
+func LoadArtifactBundle(path string) (*VotingArtifactBundle, error) {
+	compressedBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading voting artifact bundle from [%s]: %w", path, err)
+	}
+	decompressor, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating zstd decompressor: %w", err)
+	}
+	defer decompressor.Close()
+	bytes, err := decompressor.DecodeAll(compressedBytes, []byte{})
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing voting artifact bundle: %w", err)
+	}
+
+	var bundle VotingArtifactBundle
+	err = json.Unmarshal(bytes, &bundle)
+	if err != nil {
+		return nil, fmt.Errorf("error deserializing voting artifact bundle: %w", err)
+	}
+	return &bundle, nil
+}
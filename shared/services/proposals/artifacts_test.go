@@ -0,0 +1,212 @@
+package proposals
+
+import (
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+const testDepthPerRound uint64 = 5
+
+// Create a voting info snapshot where the third node delegates to the first
+func newTestVotingInfoSnapshot() *VotingInfoSnapshot {
+	nodes := []common.Address{
+		common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		common.HexToAddress("0x2222222222222222222222222222222222222222"),
+		common.HexToAddress("0x3333333333333333333333333333333333333333"),
+	}
+	return &VotingInfoSnapshot{
+		Network:     cfgtypes.Network_Mainnet,
+		BlockNumber: 100,
+		Info: []types.NodeVotingInfo{
+			{NodeAddress: nodes[0], VotingPower: eth.EthToWei(10), Delegate: nodes[0]},
+			{NodeAddress: nodes[1], VotingPower: eth.EthToWei(20), Delegate: nodes[1]},
+			{NodeAddress: nodes[2], VotingPower: eth.EthToWei(30), Delegate: nodes[0]},
+		},
+	}
+}
+
+// Create a bundle for the test snapshot, along with its network root
+func newTestArtifactBundle() (*VotingArtifactBundle, common.Hash) {
+	snapshot := newTestVotingInfoSnapshot()
+	tree := createNetworkVotingTree(snapshot, snapshot.Network, testDepthPerRound)
+	return &VotingArtifactBundle{
+		Version:     VotingArtifactBundleVersion,
+		Network:     snapshot.Network,
+		BlockNumber: snapshot.BlockNumber,
+		NetworkRoot: *tree.Nodes[0],
+		Snapshot:    snapshot,
+	}, tree.Nodes[0].Hash
+}
+
+func TestVotingArtifactBundleVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(bundle *VotingArtifactBundle, root *common.Hash)
+		err    string
+	}{
+		{
+			name:   "valid bundle",
+			modify: func(bundle *VotingArtifactBundle, root *common.Hash) {},
+		},
+		{
+			name: "unsupported version",
+			modify: func(bundle *VotingArtifactBundle, root *common.Hash) {
+				bundle.Version = VotingArtifactBundleVersion + 1
+			},
+			err: "is not supported",
+		},
+		{
+			name:   "bundle for another network",
+			modify: func(bundle *VotingArtifactBundle, root *common.Hash) { bundle.Network = cfgtypes.Network_Holesky },
+			err:    "instead of mainnet",
+		},
+		{
+			name: "snapshot for another network",
+			modify: func(bundle *VotingArtifactBundle, root *common.Hash) {
+				bundle.Snapshot.Network = cfgtypes.Network_Holesky
+			},
+			err: "instead of mainnet",
+		},
+		{
+			name:   "missing snapshot",
+			modify: func(bundle *VotingArtifactBundle, root *common.Hash) { bundle.Snapshot = nil },
+			err:    "instead of mainnet",
+		},
+		{
+			name:   "snapshot for another block",
+			modify: func(bundle *VotingArtifactBundle, root *common.Hash) { bundle.Snapshot.BlockNumber = 101 },
+			err:    "its snapshot is for block 101",
+		},
+		{
+			name:   "empty snapshot",
+			modify: func(bundle *VotingArtifactBundle, root *common.Hash) { bundle.Snapshot.Info = nil },
+			err:    "snapshot is empty",
+		},
+		{
+			name:   "unexpected root",
+			modify: func(bundle *VotingArtifactBundle, root *common.Hash) { *root = common.HexToHash("0x01") },
+			err:    "doesn't match the expected root",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bundle, root := newTestArtifactBundle()
+			test.modify(bundle, &root)
+			err := bundle.verify(cfgtypes.Network_Mainnet, root)
+			if test.err == "" {
+				if err != nil {
+					t.Fatalf("expected the bundle to be valid, got %s", err.Error())
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected an error containing [%s]", test.err)
+			}
+			if !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("expected an error containing [%s], got [%s]", test.err, err.Error())
+			}
+		})
+	}
+}
+
+func TestVerifyNetworkTreeRoot(t *testing.T) {
+	// The bundle's claimed root is checked separately, so these modify the snapshot the tree is rebuilt from while
+	// keeping the original root
+	tests := []struct {
+		name   string
+		modify func(snapshot *VotingInfoSnapshot)
+		valid  bool
+	}{
+		{
+			name:   "unmodified snapshot",
+			modify: func(snapshot *VotingInfoSnapshot) {},
+			valid:  true,
+		},
+		{
+			name:   "changed voting power",
+			modify: func(snapshot *VotingInfoSnapshot) { snapshot.Info[1].VotingPower = eth.EthToWei(21) },
+		},
+		{
+			name:   "changed delegate",
+			modify: func(snapshot *VotingInfoSnapshot) { snapshot.Info[2].Delegate = snapshot.Info[1].NodeAddress },
+		},
+		{
+			name: "reordered nodes",
+			modify: func(snapshot *VotingInfoSnapshot) {
+				snapshot.Info[0], snapshot.Info[1] = snapshot.Info[1], snapshot.Info[0]
+			},
+		},
+		{
+			name:   "missing node",
+			modify: func(snapshot *VotingInfoSnapshot) { snapshot.Info = snapshot.Info[:2] },
+		},
+	}
+
+	_, root := newTestArtifactBundle()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			snapshot := newTestVotingInfoSnapshot()
+			test.modify(snapshot)
+			tree := createNetworkVotingTree(snapshot, snapshot.Network, testDepthPerRound)
+			err := verifyNetworkTreeRoot(tree, root)
+			if test.valid && err != nil {
+				t.Fatalf("expected the tree to match the root, got %s", err.Error())
+			}
+			if !test.valid && err == nil {
+				t.Fatalf("expected the tree not to match the root")
+			}
+		})
+	}
+}
+
+func TestCreateNetworkVotingTreeDelegation(t *testing.T) {
+	snapshot := newTestVotingInfoSnapshot()
+	tree := createNetworkVotingTree(snapshot, snapshot.Network, testDepthPerRound)
+
+	// The root holds the network's total voting power
+	if tree.Nodes[0].Sum.Cmp(eth.EthToWei(60)) != 0 {
+		t.Fatalf("expected a total of 60 ETH of voting power, got %s", tree.Nodes[0].Sum)
+	}
+
+	// Leaves hold the power delegated to each node, so the delegating node's leaf is empty
+	leaves := tree.Nodes[len(tree.Nodes)/2:]
+	expected := []*big.Int{eth.EthToWei(40), eth.EthToWei(20), big.NewInt(0)}
+	for i, sum := range expected {
+		if leaves[i].Sum.Cmp(sum) != 0 {
+			t.Fatalf("expected leaf %d to have %s, got %s", i, sum, leaves[i].Sum)
+		}
+	}
+}
+
+func TestArtifactBundleSaveAndLoad(t *testing.T) {
+	bundle, root := newTestArtifactBundle()
+	path := filepath.Join(t.TempDir(), "artifacts", "voting-artifacts-100.json.zst")
+	if err := SaveArtifactBundle(bundle, path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadArtifactBundle(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := loaded.verify(cfgtypes.Network_Mainnet, root); err != nil {
+		t.Fatalf("expected the loaded bundle to be valid, got %s", err.Error())
+	}
+	tree := createNetworkVotingTree(loaded.Snapshot, loaded.Network, testDepthPerRound)
+	if err := verifyNetworkTreeRoot(tree, root); err != nil {
+		t.Fatalf("expected the loaded snapshot to rebuild the same tree, got %s", err.Error())
+	}
+
+	if _, err := LoadArtifactBundle(filepath.Join(t.TempDir(), "missing.json.zst")); err == nil {
+		t.Fatalf("expected an error loading a missing bundle")
+	}
+}
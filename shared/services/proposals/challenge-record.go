@@ -0,0 +1,374 @@
+package proposals
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/utils/atomicfile"
+)
+
+// How long finished proposals are kept in the challenge record
+const ChallengeRecordRetentionPeriod time.Duration = 90 * 24 * time.Hour
+
+// A RootSubmitted event seen by the node
+type RecordedRootSubmission struct {
+	Index     uint64         `json:"index"`
+	Submitter common.Address `json:"submitter"`
+	Timestamp time.Time      `json:"timestamp"`
+}
+
+// A challenge against a proposal that the node issued or had to answer
+type RecordedChallenge struct {
+	Index          uint64               `json:"index"`
+	Challenger     common.Address       `json:"challenger"`
+	IsOwn          bool                 `json:"isOwn"`
+	State          types.ChallengeState `json:"state"`
+	ChallengeTime  time.Time            `json:"challengeTime"`
+	ChallengeTx    common.Hash          `json:"challengeTx"`
+	ResponseTime   time.Time            `json:"responseTime"`
+	ResponseTx     common.Hash          `json:"responseTx"`
+	LastUpdateTime time.Time            `json:"lastUpdateTime"`
+}
+
+// A proposal the node has monitored during its challenge phase
+type MonitoredProposal struct {
+	ID                   uint64                             `json:"id"`
+	Proposer             common.Address                     `json:"proposer"`
+	IsOwn                bool                               `json:"isOwn"`
+	State                types.ProtocolDaoProposalState     `json:"state"`
+	CreatedTime          time.Time                          `json:"createdTime"`
+	ChallengeWindowEnd   time.Time                          `json:"challengeWindowEnd"`
+	ProposalBond         *big.Int                           `json:"proposalBond"`
+	ChallengeBond        *big.Int                           `json:"challengeBond"`
+	RootChecked          bool                               `json:"rootChecked"`
+	RootMatchesLocalTree bool                               `json:"rootMatchesLocalTree"`
+	DefeatIndex          uint64                             `json:"defeatIndex"`
+	DefeatTx             common.Hash                        `json:"defeatTx"`
+	RootSubmissions      map[uint64]*RecordedRootSubmission `json:"rootSubmissions"`
+	Challenges           map[uint64]*RecordedChallenge      `json:"challenges"`
+}
+
+// A challenge that hasn't been answered or defeated yet
+type OpenChallenge struct {
+	ProposalID uint64 `json:"proposalId"`
+	Index      uint64 `json:"index"`
+}
+
+// Totals derived from the challenge record
+type ChallengeSummary struct {
+	MonitoredProposals   int      `json:"monitoredProposals"`
+	RootSubmissions      int      `json:"rootSubmissions"`
+	MismatchedProposals  int      `json:"mismatchedProposals"`
+	ChallengesIssued     int      `json:"challengesIssued"`
+	ChallengesReceived   int      `json:"challengesReceived"`
+	ChallengesAnswered   int      `json:"challengesAnswered"`
+	ChallengesUnanswered int      `json:"challengesUnanswered"`
+	ProposalsDefeated    int      `json:"proposalsDefeated"`
+	ProposalBondsAtRisk  *big.Int `json:"proposalBondsAtRisk"`
+	ChallengeBondsAtRisk *big.Int `json:"challengeBondsAtRisk"`
+}
+
+// The node's history of pDAO root submissions and challenges
+type ChallengeRecord struct {
+	Proposals      map[uint64]*MonitoredProposal `json:"proposals"`
+	LastUpdateTime time.Time                     `json:"lastUpdateTime"`
+
+	lock *sync.Mutex `json:"-"`
+}
+
+// Create a new, empty challenge record
+func NewChallengeRecord() *ChallengeRecord {
+	return &ChallengeRecord{
+		Proposals: map[uint64]*MonitoredProposal{},
+		lock:      &sync.Mutex{},
+	}
+}
+
+// Load the challenge record from disk, or create a new one if it doesn't exist yet
+func LoadChallengeRecord(path string) (*ChallengeRecord, error) {
+	record := NewChallengeRecord()
+	if _, err := atomicfile.LoadJson(path, record); err != nil {
+		return nil, fmt.Errorf("error loading challenge record: %w", err)
+	}
+	if record.Proposals == nil {
+		record.Proposals = map[uint64]*MonitoredProposal{}
+	}
+	for _, prop := range record.Proposals {
+		if prop.RootSubmissions == nil {
+			prop.RootSubmissions = map[uint64]*RecordedRootSubmission{}
+		}
+		if prop.Challenges == nil {
+			prop.Challenges = map[uint64]*RecordedChallenge{}
+		}
+	}
+	return record, nil
+}
+
+// Save the challenge record to disk
+func (r *ChallengeRecord) Save(path string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if err := atomicfile.SaveJson(path, r); err != nil {
+		return fmt.Errorf("error saving challenge record: %w", err)
+	}
+	return nil
+}
+
+// Add a proposal to the record or refresh its details
+func (r *ChallengeRecord) UpdateProposal(details *protocol.ProtocolDaoProposalDetails, nodeAddress common.Address) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	prop := r.getOrCreateProposal(details, nodeAddress)
+	prop.State = details.State
+	r.LastUpdateTime = time.Now()
+}
+
+// Refresh the states of the proposals already in the record
+func (r *ChallengeRecord) UpdateProposalStates(props []protocol.ProtocolDaoProposalDetails) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, details := range props {
+		if prop, exists := r.Proposals[details.ID]; exists {
+			prop.State = details.State
+		}
+	}
+	r.LastUpdateTime = time.Now()
+}
+
+// Record the result of comparing a proposal's root against the local voting tree
+func (r *ChallengeRecord) SetRootCheck(details *protocol.ProtocolDaoProposalDetails, nodeAddress common.Address, matches bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	prop := r.getOrCreateProposal(details, nodeAddress)
+	prop.RootChecked = true
+	prop.RootMatchesLocalTree = matches
+}
+
+// Record a RootSubmitted event
+func (r *ChallengeRecord) AddRootSubmission(details *protocol.ProtocolDaoProposalDetails, nodeAddress common.Address, event *protocol.RootSubmitted) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	prop := r.getOrCreateProposal(details, nodeAddress)
+	index := event.Index.Uint64()
+	if _, exists := prop.RootSubmissions[index]; exists {
+		return
+	}
+	prop.RootSubmissions[index] = &RecordedRootSubmission{
+		Index:     index,
+		Submitter: event.Proposer,
+		Timestamp: event.Timestamp,
+	}
+}
+
+// Record a challenge against a proposal, or refresh its state if it's already known
+func (r *ChallengeRecord) AddChallenge(details *protocol.ProtocolDaoProposalDetails, nodeAddress common.Address, index uint64, challenger common.Address, challengeTime time.Time, state types.ChallengeState, txHash common.Hash) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	prop := r.getOrCreateProposal(details, nodeAddress)
+	challenge, exists := prop.Challenges[index]
+	if !exists {
+		challenge = &RecordedChallenge{
+			Index:         index,
+			Challenger:    challenger,
+			IsOwn:         challenger == nodeAddress,
+			ChallengeTime: challengeTime,
+		}
+		prop.Challenges[index] = challenge
+	}
+	if txHash != (common.Hash{}) {
+		challenge.ChallengeTx = txHash
+	}
+	challenge.State = state
+	challenge.LastUpdateTime = time.Now()
+}
+
+// Record the node's response to a challenge against one of its proposals
+func (r *ChallengeRecord) SetChallengeResponse(propID uint64, index uint64, submitter common.Address, txHash common.Hash) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	prop, exists := r.Proposals[propID]
+	if !exists {
+		return
+	}
+	now := time.Now()
+	if challenge, exists := prop.Challenges[index]; exists {
+		challenge.State = types.ChallengeState_Responded
+		challenge.ResponseTime = now
+		challenge.ResponseTx = txHash
+		challenge.LastUpdateTime = now
+	}
+	prop.RootSubmissions[index] = &RecordedRootSubmission{
+		Index:     index,
+		Submitter: submitter,
+		Timestamp: now,
+	}
+}
+
+// Update the state of a recorded challenge
+func (r *ChallengeRecord) SetChallengeState(propID uint64, index uint64, state types.ChallengeState) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	prop, exists := r.Proposals[propID]
+	if !exists {
+		return
+	}
+	if challenge, exists := prop.Challenges[index]; exists {
+		challenge.State = state
+		challenge.LastUpdateTime = time.Now()
+	}
+}
+
+// Record that the node defeated a proposal
+func (r *ChallengeRecord) SetDefeated(propID uint64, index uint64, txHash common.Hash) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	prop, exists := r.Proposals[propID]
+	if !exists {
+		return
+	}
+	prop.DefeatIndex = index
+	prop.DefeatTx = txHash
+}
+
+// Get the recorded challenges that are still waiting for a response
+func (r *ChallengeRecord) GetOpenChallenges() []OpenChallenge {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	openChallenges := []OpenChallenge{}
+	for _, prop := range r.Proposals {
+		for _, challenge := range prop.Challenges {
+			if challenge.State == types.ChallengeState_Challenged {
+				openChallenges = append(openChallenges, OpenChallenge{
+					ProposalID: prop.ID,
+					Index:      challenge.Index,
+				})
+			}
+		}
+	}
+	return openChallenges
+}
+
+// Get a copy of the monitored proposals, newest first
+func (r *ChallengeRecord) GetProposals() []MonitoredProposal {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	props := make([]MonitoredProposal, 0, len(r.Proposals))
+	for _, prop := range r.Proposals {
+		propCopy := *prop
+		propCopy.RootSubmissions = make(map[uint64]*RecordedRootSubmission, len(prop.RootSubmissions))
+		for index, submission := range prop.RootSubmissions {
+			submissionCopy := *submission
+			propCopy.RootSubmissions[index] = &submissionCopy
+		}
+		propCopy.Challenges = make(map[uint64]*RecordedChallenge, len(prop.Challenges))
+		for index, challenge := range prop.Challenges {
+			challengeCopy := *challenge
+			propCopy.Challenges[index] = &challengeCopy
+		}
+		props = append(props, propCopy)
+	}
+	sort.Slice(props, func(i, j int) bool {
+		return props[i].ID > props[j].ID
+	})
+	return props
+}
+
+// Get the totals for the record.
+// A proposal bond is at risk while one of the node's pending proposals has an unanswered challenge, and a challenge bond
+// is at risk while one of the node's challenges is waiting on a pending proposal.
+func (r *ChallengeRecord) GetSummary() ChallengeSummary {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	summary := ChallengeSummary{
+		MonitoredProposals:   len(r.Proposals),
+		ProposalBondsAtRisk:  big.NewInt(0),
+		ChallengeBondsAtRisk: big.NewInt(0),
+	}
+	for _, prop := range r.Proposals {
+		summary.RootSubmissions += len(prop.RootSubmissions)
+		if prop.RootChecked && !prop.RootMatchesLocalTree {
+			summary.MismatchedProposals++
+		}
+		if prop.DefeatIndex != 0 {
+			summary.ProposalsDefeated++
+		}
+
+		isPending := prop.State == types.ProtocolDaoProposalState_Pending
+		proposalAtRisk := false
+		for _, challenge := range prop.Challenges {
+			if challenge.IsOwn {
+				summary.ChallengesIssued++
+				if isPending && challenge.State == types.ChallengeState_Challenged && prop.ChallengeBond != nil {
+					summary.ChallengeBondsAtRisk.Add(summary.ChallengeBondsAtRisk, prop.ChallengeBond)
+				}
+			}
+			if prop.IsOwn {
+				summary.ChallengesReceived++
+				if challenge.State == types.ChallengeState_Challenged {
+					summary.ChallengesUnanswered++
+					proposalAtRisk = proposalAtRisk || isPending
+				} else {
+					summary.ChallengesAnswered++
+				}
+			}
+		}
+		if proposalAtRisk && prop.ProposalBond != nil {
+			summary.ProposalBondsAtRisk.Add(summary.ProposalBondsAtRisk, prop.ProposalBond)
+		}
+	}
+	return summary
+}
+
+// Remove finished proposals that are older than the retention period
+func (r *ChallengeRecord) Prune(now time.Time) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for id, prop := range r.Proposals {
+		if prop.State == types.ProtocolDaoProposalState_Pending {
+			continue
+		}
+		if now.Sub(prop.CreatedTime) > ChallengeRecordRetentionPeriod {
+			delete(r.Proposals, id)
+		}
+	}
+}
+
+// Get a proposal from the record, adding it if it isn't there yet
+func (r *ChallengeRecord) getOrCreateProposal(details *protocol.ProtocolDaoProposalDetails, nodeAddress common.Address) *MonitoredProposal {
+	prop, exists := r.Proposals[details.ID]
+	if !exists {
+		prop = &MonitoredProposal{
+			ID:                 details.ID,
+			Proposer:           details.ProposerAddress,
+			IsOwn:              details.ProposerAddress == nodeAddress,
+			State:              details.State,
+			CreatedTime:        details.CreatedTime,
+			ChallengeWindowEnd: details.CreatedTime.Add(details.ChallengeWindow),
+			ProposalBond:       details.ProposalBond,
+			ChallengeBond:      details.ChallengeBond,
+			RootSubmissions:    map[uint64]*RecordedRootSubmission{},
+			Challenges:         map[uint64]*RecordedChallenge{},
+		}
+		r.Proposals[details.ID] = prop
+	}
+	return prop
+}
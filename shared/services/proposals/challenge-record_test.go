@@ -0,0 +1,296 @@
+package proposals
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+var (
+	testNodeAddress  = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	testOtherAddress = common.HexToAddress("0x2222222222222222222222222222222222222222")
+)
+
+// Create the details of a pending pDAO proposal
+func newTestProposalDetails(id uint64, proposer common.Address, created time.Time) *protocol.ProtocolDaoProposalDetails {
+	return &protocol.ProtocolDaoProposalDetails{
+		ID:              id,
+		ProposerAddress: proposer,
+		CreatedTime:     created,
+		ChallengeWindow: 30 * time.Minute,
+		State:           types.ProtocolDaoProposalState_Pending,
+		ProposalBond:    eth.EthToWei(100),
+		ChallengeBond:   eth.EthToWei(10),
+	}
+}
+
+func TestChallengeRecordChallenges(t *testing.T) {
+	created := time.Unix(1700000000, 0)
+	ownProp := newTestProposalDetails(1, testNodeAddress, created)
+	otherProp := newTestProposalDetails(2, testOtherAddress, created)
+	record := NewChallengeRecord()
+
+	// Someone challenges two indices of the node's proposal, and the node challenges someone else's
+	record.AddChallenge(ownProp, testNodeAddress, 2, testOtherAddress, created, types.ChallengeState_Challenged, common.Hash{})
+	record.AddChallenge(ownProp, testNodeAddress, 3, testOtherAddress, created, types.ChallengeState_Challenged, common.Hash{})
+	record.AddChallenge(otherProp, testNodeAddress, 4, testNodeAddress, created, types.ChallengeState_Challenged, common.HexToHash("0x01"))
+
+	prop := record.Proposals[1]
+	if prop == nil || !prop.IsOwn {
+		t.Fatalf("expected proposal 1 to be recorded as the node's own")
+	}
+	if !prop.ChallengeWindowEnd.Equal(created.Add(30 * time.Minute)) {
+		t.Fatalf("expected the challenge window to end at %s, got %s", created.Add(30*time.Minute), prop.ChallengeWindowEnd)
+	}
+	if record.Proposals[2].IsOwn || !record.Proposals[2].Challenges[4].IsOwn {
+		t.Fatalf("expected the node's challenge on proposal 2 to be recorded as its own")
+	}
+	if len(record.GetOpenChallenges()) != 3 {
+		t.Fatalf("expected 3 open challenges, got %v", record.GetOpenChallenges())
+	}
+
+	// Seeing a challenge again refreshes its state without replacing its details or tx
+	record.AddChallenge(otherProp, testNodeAddress, 4, testOtherAddress, created.Add(time.Hour), types.ChallengeState_Challenged, common.Hash{})
+	challenge := record.Proposals[2].Challenges[4]
+	if !challenge.IsOwn || !challenge.ChallengeTime.Equal(created) || challenge.ChallengeTx != common.HexToHash("0x01") {
+		t.Fatalf("expected the original challenge details to be kept, got %+v", challenge)
+	}
+
+	// Responding answers the challenge and records the node's root submission
+	record.SetChallengeResponse(1, 2, testNodeAddress, common.HexToHash("0x02"))
+	challenge = record.Proposals[1].Challenges[2]
+	if challenge.State != types.ChallengeState_Responded || challenge.ResponseTx != common.HexToHash("0x02") {
+		t.Fatalf("expected challenge 2 to be answered, got %+v", challenge)
+	}
+	if submission := record.Proposals[1].RootSubmissions[2]; submission == nil || submission.Submitter != testNodeAddress {
+		t.Fatalf("expected the response to be recorded as a root submission")
+	}
+	openChallenges := record.GetOpenChallenges()
+	if len(openChallenges) != 2 {
+		t.Fatalf("expected 2 open challenges, got %v", openChallenges)
+	}
+	for _, open := range openChallenges {
+		if open.ProposalID == 1 && open.Index == 2 {
+			t.Fatalf("didn't expect the answered challenge to be open")
+		}
+	}
+
+	// Updates for unknown proposals or challenges are ignored
+	record.SetChallengeResponse(9, 2, testNodeAddress, common.Hash{})
+	record.SetChallengeState(1, 9, types.ChallengeState_Paid)
+	record.SetDefeated(9, 2, common.Hash{})
+	if len(record.Proposals) != 2 || len(record.Proposals[1].Challenges) != 2 {
+		t.Fatalf("didn't expect updates for unknown proposals or challenges to add anything")
+	}
+}
+
+func TestChallengeRecordRootSubmissions(t *testing.T) {
+	created := time.Unix(1700000000, 0)
+	details := newTestProposalDetails(1, testOtherAddress, created)
+	record := NewChallengeRecord()
+
+	record.AddRootSubmission(details, testNodeAddress, &protocol.RootSubmitted{Index: big.NewInt(2), Proposer: testOtherAddress, Timestamp: created})
+	record.AddRootSubmission(details, testNodeAddress, &protocol.RootSubmitted{Index: big.NewInt(2), Proposer: testNodeAddress, Timestamp: created.Add(time.Hour)})
+	record.AddRootSubmission(details, testNodeAddress, &protocol.RootSubmitted{Index: big.NewInt(5), Proposer: testOtherAddress, Timestamp: created})
+
+	submissions := record.Proposals[1].RootSubmissions
+	if len(submissions) != 2 {
+		t.Fatalf("expected 2 root submissions, got %d", len(submissions))
+	}
+	if submissions[2].Submitter != testOtherAddress || !submissions[2].Timestamp.Equal(created) {
+		t.Fatalf("expected the first submission for an index to be kept, got %+v", submissions[2])
+	}
+
+	// State refreshes only apply to proposals that are already recorded
+	details.State = types.ProtocolDaoProposalState_ActivePhase1
+	unknown := newTestProposalDetails(3, testOtherAddress, created)
+	record.UpdateProposalStates([]protocol.ProtocolDaoProposalDetails{*details, *unknown})
+	if record.Proposals[1].State != types.ProtocolDaoProposalState_ActivePhase1 {
+		t.Fatalf("expected proposal 1 to be active, got %d", record.Proposals[1].State)
+	}
+	if _, exists := record.Proposals[3]; exists {
+		t.Fatalf("didn't expect a state refresh to add a proposal")
+	}
+}
+
+func TestChallengeRecordGetSummary(t *testing.T) {
+	created := time.Unix(1700000000, 0)
+
+	tests := []struct {
+		name     string
+		setup    func(record *ChallengeRecord)
+		expected ChallengeSummary
+	}{
+		{
+			name:  "empty record",
+			setup: func(record *ChallengeRecord) {},
+			expected: ChallengeSummary{
+				ProposalBondsAtRisk:  big.NewInt(0),
+				ChallengeBondsAtRisk: big.NewInt(0),
+			},
+		},
+		{
+			name: "unanswered challenges against a pending proposal",
+			setup: func(record *ChallengeRecord) {
+				details := newTestProposalDetails(1, testNodeAddress, created)
+				record.AddChallenge(details, testNodeAddress, 2, testOtherAddress, created, types.ChallengeState_Challenged, common.Hash{})
+				record.AddChallenge(details, testNodeAddress, 3, testOtherAddress, created, types.ChallengeState_Challenged, common.Hash{})
+				record.AddChallenge(details, testNodeAddress, 4, testOtherAddress, created, types.ChallengeState_Responded, common.Hash{})
+			},
+			expected: ChallengeSummary{
+				MonitoredProposals:   1,
+				ChallengesReceived:   3,
+				ChallengesAnswered:   1,
+				ChallengesUnanswered: 2,
+				ProposalBondsAtRisk:  eth.EthToWei(100),
+				ChallengeBondsAtRisk: big.NewInt(0),
+			},
+		},
+		{
+			name: "unanswered challenge against a finished proposal",
+			setup: func(record *ChallengeRecord) {
+				details := newTestProposalDetails(1, testNodeAddress, created)
+				details.State = types.ProtocolDaoProposalState_Destroyed
+				record.UpdateProposal(details, testNodeAddress)
+				record.AddChallenge(details, testNodeAddress, 2, testOtherAddress, created, types.ChallengeState_Challenged, common.Hash{})
+			},
+			expected: ChallengeSummary{
+				MonitoredProposals:   1,
+				ChallengesReceived:   1,
+				ChallengesUnanswered: 1,
+				ProposalBondsAtRisk:  big.NewInt(0),
+				ChallengeBondsAtRisk: big.NewInt(0),
+			},
+		},
+		{
+			name: "node's challenges against other proposals",
+			setup: func(record *ChallengeRecord) {
+				pending := newTestProposalDetails(1, testOtherAddress, created)
+				record.AddChallenge(pending, testNodeAddress, 2, testNodeAddress, created, types.ChallengeState_Challenged, common.Hash{})
+				record.AddChallenge(pending, testNodeAddress, 3, testNodeAddress, created, types.ChallengeState_Challenged, common.Hash{})
+				record.AddChallenge(pending, testNodeAddress, 4, testNodeAddress, created, types.ChallengeState_Responded, common.Hash{})
+				defeated := newTestProposalDetails(5, testOtherAddress, created)
+				defeated.State = types.ProtocolDaoProposalState_Destroyed
+				record.AddChallenge(defeated, testNodeAddress, 6, testNodeAddress, created, types.ChallengeState_Challenged, common.Hash{})
+				record.SetDefeated(5, 6, common.HexToHash("0x01"))
+			},
+			expected: ChallengeSummary{
+				MonitoredProposals:   2,
+				ChallengesIssued:     4,
+				ProposalsDefeated:    1,
+				ProposalBondsAtRisk:  big.NewInt(0),
+				ChallengeBondsAtRisk: eth.EthToWei(20),
+			},
+		},
+		{
+			name: "root checks and submissions",
+			setup: func(record *ChallengeRecord) {
+				matching := newTestProposalDetails(1, testOtherAddress, created)
+				record.SetRootCheck(matching, testNodeAddress, true)
+				record.AddRootSubmission(matching, testNodeAddress, &protocol.RootSubmitted{Index: big.NewInt(1), Proposer: testOtherAddress, Timestamp: created})
+				mismatched := newTestProposalDetails(2, testOtherAddress, created)
+				record.SetRootCheck(mismatched, testNodeAddress, false)
+				record.AddRootSubmission(mismatched, testNodeAddress, &protocol.RootSubmitted{Index: big.NewInt(1), Proposer: testOtherAddress, Timestamp: created})
+				record.AddRootSubmission(mismatched, testNodeAddress, &protocol.RootSubmitted{Index: big.NewInt(2), Proposer: testOtherAddress, Timestamp: created})
+				unchecked := newTestProposalDetails(3, testOtherAddress, created)
+				record.UpdateProposal(unchecked, testNodeAddress)
+			},
+			expected: ChallengeSummary{
+				MonitoredProposals:   3,
+				RootSubmissions:      3,
+				MismatchedProposals:  1,
+				ProposalBondsAtRisk:  big.NewInt(0),
+				ChallengeBondsAtRisk: big.NewInt(0),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			record := NewChallengeRecord()
+			test.setup(record)
+			summary := record.GetSummary()
+			if summary.ProposalBondsAtRisk.Cmp(test.expected.ProposalBondsAtRisk) != 0 {
+				t.Fatalf("expected %s proposal bond wei at risk, got %s", test.expected.ProposalBondsAtRisk, summary.ProposalBondsAtRisk)
+			}
+			if summary.ChallengeBondsAtRisk.Cmp(test.expected.ChallengeBondsAtRisk) != 0 {
+				t.Fatalf("expected %s challenge bond wei at risk, got %s", test.expected.ChallengeBondsAtRisk, summary.ChallengeBondsAtRisk)
+			}
+			summary.ProposalBondsAtRisk = nil
+			summary.ChallengeBondsAtRisk = nil
+			test.expected.ProposalBondsAtRisk = nil
+			test.expected.ChallengeBondsAtRisk = nil
+			if summary != test.expected {
+				t.Fatalf("expected %+v, got %+v", test.expected, summary)
+			}
+		})
+	}
+}
+
+func TestChallengeRecordPrune(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	old := now.Add(-ChallengeRecordRetentionPeriod - time.Hour)
+	record := NewChallengeRecord()
+
+	record.UpdateProposal(newTestProposalDetails(1, testOtherAddress, old), testNodeAddress)
+	finishedOld := newTestProposalDetails(2, testOtherAddress, old)
+	finishedOld.State = types.ProtocolDaoProposalState_Executed
+	record.UpdateProposal(finishedOld, testNodeAddress)
+	finishedRecent := newTestProposalDetails(3, testOtherAddress, now.Add(-time.Hour))
+	finishedRecent.State = types.ProtocolDaoProposalState_Defeated
+	record.UpdateProposal(finishedRecent, testNodeAddress)
+
+	record.Prune(now)
+	if _, exists := record.Proposals[2]; exists {
+		t.Fatalf("expected the old finished proposal to be pruned")
+	}
+	if _, exists := record.Proposals[1]; !exists {
+		t.Fatalf("expected the pending proposal to be kept")
+	}
+	if _, exists := record.Proposals[3]; !exists {
+		t.Fatalf("expected the recent proposal to be kept")
+	}
+
+	props := record.GetProposals()
+	if len(props) != 2 || props[0].ID != 3 || props[1].ID != 1 {
+		t.Fatalf("expected the remaining proposals newest first, got %v", props)
+	}
+}
+
+func TestChallengeRecordSaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "challenge-record.json")
+	created := time.Unix(1700000000, 0)
+
+	// A missing file loads as an empty record
+	record, err := LoadChallengeRecord(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(record.Proposals) != 0 {
+		t.Fatalf("expected an empty record, got %v", record.Proposals)
+	}
+
+	details := newTestProposalDetails(1, testNodeAddress, created)
+	record.AddChallenge(details, testNodeAddress, 2, testOtherAddress, created, types.ChallengeState_Challenged, common.Hash{})
+	if err := record.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadChallengeRecord(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prop := loaded.Proposals[1]
+	if prop == nil || prop.ProposalBond.Cmp(eth.EthToWei(100)) != 0 || prop.Challenges[2].State != types.ChallengeState_Challenged {
+		t.Fatalf("expected the saved proposal to be loaded, got %+v", prop)
+	}
+	if prop.RootSubmissions == nil {
+		t.Fatalf("expected the loaded proposal to have a root submission map")
+	}
+}
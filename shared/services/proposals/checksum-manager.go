@@ -202,6 +202,52 @@ func LoadFromFile[ContextType any, DataType IDataType](m *ChecksumManager[Contex
 	return nil, "", nil
 }
 
+// Delete the files that the provided function selects, removing them from the checksum table. Returns the number of deleted files.
+func PruneFiles[ContextType any, DataType IDataType](m *ChecksumManager[ContextType, DataType], shouldPrune func(filename string) (bool, error)) (int, error) {
+	// Parse the checksum file
+	exists, lines, err := parseChecksumFile(m.checksumFilename)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing checksum file: %w", err)
+	}
+	if !exists {
+		return 0, nil
+	}
+
+	// Delete the selected files
+	dataFolder := filepath.Dir(m.checksumFilename)
+	remainingLines := make([]string, 0, len(lines))
+	pruned := 0
+	for _, line := range lines {
+		_, filename, found := strings.Cut(line, "  ")
+		if !found {
+			return pruned, fmt.Errorf("error parsing checkpoint line (%s): invalid format", line)
+		}
+		prune, err := shouldPrune(filename)
+		if err != nil {
+			return pruned, err
+		}
+		if !prune {
+			remainingLines = append(remainingLines, line)
+			continue
+		}
+		err = os.Remove(filepath.Join(dataFolder, filename))
+		if err != nil && !os.IsNotExist(err) {
+			return pruned, fmt.Errorf("error deleting file [%s]: %w", filename, err)
+		}
+		pruned++
+	}
+	if pruned == 0 {
+		return 0, nil
+	}
+
+	// Save the new checksum file
+	err = os.WriteFile(m.checksumFilename, []byte(strings.Join(remainingLines, "\n")), 0644)
+	if err != nil {
+		return pruned, fmt.Errorf("error writing checksum file: %w", err)
+	}
+	return pruned, nil
+}
+
 // Get the lines from the checksum file
 func parseChecksumFile(checksumFilename string) (bool, []string, error) {
 	// Check if the file exists
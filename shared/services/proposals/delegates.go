@@ -0,0 +1,266 @@
+package proposals
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/node"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// The number of vote direction lookups to run at once
+	delegateVoteBatchSize int = 100
+)
+
+// A delegate's vote on a single proposal
+type DelegateVote struct {
+	ProposalID uint64                         `json:"proposalId"`
+	State      types.ProtocolDaoProposalState `json:"state"`
+	Direction  types.VoteDirection            `json:"direction"`
+}
+
+// Voting power and on-chain voting history of a single delegate
+type DelegateInsight struct {
+	Address           common.Address `json:"address"`
+	Rank              int            `json:"rank"`
+	VotingPower       *big.Int       `json:"votingPower"`
+	OwnVotingPower    *big.Int       `json:"ownVotingPower"`
+	SelfDelegated     bool           `json:"selfDelegated"`
+	Delegators        int            `json:"delegators"`
+	RegistrationTime  time.Time      `json:"registrationTime"`
+	EligibleProposals int            `json:"eligibleProposals"`
+	ProposalsVoted    int            `json:"proposalsVoted"`
+	ParticipationRate float64        `json:"participationRate"`
+	Votes             []DelegateVote `json:"votes"`
+}
+
+// Delegation statistics for the whole network, built from a voting info snapshot
+type DelegateInsights struct {
+	BlockNumber       uint32             `json:"blockNumber"`
+	TotalVotingPower  *big.Int           `json:"totalVotingPower"`
+	NodeCount         int                `json:"nodeCount"`
+	DelegateCount     int                `json:"delegateCount"`
+	SelfDelegated     int                `json:"selfDelegated"`
+	EligibleProposals int                `json:"eligibleProposals"`
+	Delegates         []*DelegateInsight `json:"delegates"`
+}
+
+// Get delegation statistics from a snapshot of the latest finalized block. Delegates are ranked by the voting power
+// delegated to them; the vote history is only loaded for the top ones (all of them if limit is 0) and any delegates
+// in include.
+func (m *ProposalManager) GetDelegateInsights(props []protocol.ProtocolDaoProposalDetails, limit int, include ...common.Address) (*DelegateInsights, error) {
+	// Get a snapshot of the latest finalized block; it's only used once, so it isn't saved
+	block, err := m.stateMgr.GetLatestFinalizedBeaconBlock()
+	if err != nil {
+		return nil, fmt.Errorf("error determining latest finalized block: %w", err)
+	}
+	blockNumber := uint32(block.ExecutionBlockNumber)
+	snapshot, err := m.viSnapshotMgr.CreateVotingInfoSnapshot(blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("error creating voting info snapshot for block %d: %w", blockNumber, err)
+	}
+	return m.getDelegateInsights(snapshot, props, limit, include...)
+}
+
+// Get delegation statistics from the provided snapshot
+func (m *ProposalManager) getDelegateInsights(snapshot *VotingInfoSnapshot, props []protocol.ProtocolDaoProposalDetails, limit int, include ...common.Address) (*DelegateInsights, error) {
+	insights, votedProps := rankDelegates(snapshot, props, limit, include...)
+
+	// Load the vote history
+	err := m.loadDelegateVotes(insights.Delegates, votedProps)
+	if err != nil {
+		return nil, err
+	}
+	return insights, nil
+}
+
+// Rank the delegates in the snapshot by the voting power delegated to them and pick the ones to report on.
+// Returns the insights along with the proposals that were put to a vote, newest first.
+func rankDelegates(snapshot *VotingInfoSnapshot, props []protocol.ProtocolDaoProposalDetails, limit int, include ...common.Address) (*DelegateInsights, []protocol.ProtocolDaoProposalDetails) {
+	insights := &DelegateInsights{
+		BlockNumber:      snapshot.BlockNumber,
+		TotalVotingPower: big.NewInt(0),
+		NodeCount:        len(snapshot.Info),
+		Delegates:        []*DelegateInsight{},
+	}
+
+	// Sum up the voting power delegated to each address
+	delegates := map[common.Address]*DelegateInsight{}
+	for _, info := range snapshot.Info {
+		if info.Delegate == (common.Address{}) {
+			continue
+		}
+		votingPower := info.VotingPower
+		if votingPower == nil {
+			votingPower = big.NewInt(0)
+		}
+		insights.TotalVotingPower.Add(insights.TotalVotingPower, votingPower)
+
+		delegate, exists := delegates[info.Delegate]
+		if !exists {
+			delegate = &DelegateInsight{
+				Address:        info.Delegate,
+				VotingPower:    big.NewInt(0),
+				OwnVotingPower: big.NewInt(0),
+				Votes:          []DelegateVote{},
+			}
+			delegates[info.Delegate] = delegate
+		}
+		delegate.VotingPower.Add(delegate.VotingPower, votingPower)
+		if info.NodeAddress == info.Delegate {
+			delegate.SelfDelegated = true
+			delegate.OwnVotingPower.Set(votingPower)
+			insights.SelfDelegated++
+		} else {
+			delegate.Delegators++
+		}
+	}
+
+	// Ignore nodes that only vote for themselves; they aren't delegates anyone can pick
+	ranked := make([]*DelegateInsight, 0, len(delegates))
+	for _, delegate := range delegates {
+		if delegate.Delegators > 0 {
+			ranked = append(ranked, delegate)
+		}
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		comparison := ranked[i].VotingPower.Cmp(ranked[j].VotingPower)
+		if comparison != 0 {
+			return comparison > 0
+		}
+		return ranked[i].Delegators > ranked[j].Delegators
+	})
+	for i, delegate := range ranked {
+		delegate.Rank = i + 1
+	}
+	insights.DelegateCount = len(ranked)
+
+	// Pick the delegates to report on
+	if limit <= 0 || limit > len(ranked) {
+		limit = len(ranked)
+	}
+	insights.Delegates = append(insights.Delegates, ranked[:limit]...)
+	for _, address := range include {
+		delegate, exists := delegates[address]
+		if !exists {
+			delegate = &DelegateInsight{
+				Address:        address,
+				VotingPower:    big.NewInt(0),
+				OwnVotingPower: big.NewInt(0),
+				Votes:          []DelegateVote{},
+			}
+		} else if delegate.Rank > 0 && delegate.Rank <= limit {
+			continue
+		}
+		insights.Delegates = append(insights.Delegates, delegate)
+	}
+
+	// Only proposals that made it to a vote count towards participation
+	votedProps := []protocol.ProtocolDaoProposalDetails{}
+	for _, prop := range props {
+		if prop.State == types.ProtocolDaoProposalState_Pending || prop.State == types.ProtocolDaoProposalState_Destroyed {
+			continue
+		}
+		votedProps = append(votedProps, prop)
+	}
+	sort.Slice(votedProps, func(i, j int) bool {
+		return votedProps[i].ID > votedProps[j].ID
+	})
+	insights.EligibleProposals = len(votedProps)
+	return insights, votedProps
+}
+
+// Get the vote direction of each delegate on each proposal and work out their participation
+func (m *ProposalManager) loadDelegateVotes(delegates []*DelegateInsight, props []protocol.ProtocolDaoProposalDetails) error {
+	// Get the registration times, since proposals from before a delegate registered don't count against it
+	for bsi := 0; bsi < len(delegates); bsi += delegateVoteBatchSize {
+		bei := bsi + delegateVoteBatchSize
+		if bei > len(delegates) {
+			bei = len(delegates)
+		}
+
+		var wg errgroup.Group
+		for i := bsi; i < bei; i++ {
+			delegate := delegates[i]
+			wg.Go(func() error {
+				registrationTime, err := node.GetNodeRegistrationTime(m.rp, delegate.Address, nil)
+				if err != nil {
+					return fmt.Errorf("error getting registration time of %s: %w", delegate.Address.Hex(), err)
+				}
+				delegate.RegistrationTime = registrationTime
+				return nil
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return err
+		}
+	}
+
+	// Get the vote directions
+	propCount := len(props)
+	directions := make([]types.VoteDirection, len(delegates)*propCount)
+	for bsi := 0; bsi < len(directions); bsi += delegateVoteBatchSize {
+		bei := bsi + delegateVoteBatchSize
+		if bei > len(directions) {
+			bei = len(directions)
+		}
+
+		var wg errgroup.Group
+		for i := bsi; i < bei; i++ {
+			i := i
+			wg.Go(func() error {
+				delegate := delegates[i/propCount]
+				prop := props[i%propCount]
+				direction, err := protocol.GetAddressVoteDirection(m.rp, prop.ID, delegate.Address, nil)
+				if err != nil {
+					return fmt.Errorf("error getting vote of %s on proposal %d: %w", delegate.Address.Hex(), prop.ID, err)
+				}
+				directions[i] = direction
+				return nil
+			})
+		}
+		if err := wg.Wait(); err != nil {
+			return err
+		}
+	}
+
+	for i, delegate := range delegates {
+		delegate.setVotes(props, directions[i*propCount:(i+1)*propCount])
+	}
+	return nil
+}
+
+// Record a delegate's votes and work out its participation. Only proposals whose voting has ended and that were
+// created after the delegate registered count towards it.
+func (d *DelegateInsight) setVotes(props []protocol.ProtocolDaoProposalDetails, directions []types.VoteDirection) {
+	d.Votes = make([]DelegateVote, len(props))
+	d.EligibleProposals = 0
+	d.ProposalsVoted = 0
+	d.ParticipationRate = 0
+	for i, prop := range props {
+		d.Votes[i] = DelegateVote{
+			ProposalID: prop.ID,
+			State:      prop.State,
+			Direction:  directions[i],
+		}
+		if prop.State == types.ProtocolDaoProposalState_ActivePhase1 || prop.State == types.ProtocolDaoProposalState_ActivePhase2 {
+			continue
+		}
+		if prop.CreatedTime.Before(d.RegistrationTime) {
+			continue
+		}
+		d.EligibleProposals++
+		if directions[i] != types.VoteDirection_NoVote {
+			d.ProposalsVoted++
+		}
+	}
+	if d.EligibleProposals > 0 {
+		d.ParticipationRate = float64(d.ProposalsVoted) / float64(d.EligibleProposals)
+	}
+}
@@ -0,0 +1,165 @@
+package proposals
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/dao/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+func TestRankDelegates(t *testing.T) {
+	address := func(i byte) common.Address {
+		return common.BytesToAddress([]byte{i})
+	}
+	delegate1 := address(1)
+	delegate2 := address(0xd2)
+	delegate3 := address(0xd3)
+	selfOnly := address(5)
+	unknown := address(0xff)
+
+	snapshot := &VotingInfoSnapshot{
+		BlockNumber: 1000,
+		Info: []types.NodeVotingInfo{
+			{NodeAddress: delegate1, VotingPower: big.NewInt(100), Delegate: delegate1},
+			{NodeAddress: address(2), VotingPower: big.NewInt(50), Delegate: delegate1},
+			{NodeAddress: address(3), VotingPower: big.NewInt(50), Delegate: delegate1},
+			{NodeAddress: address(4), VotingPower: big.NewInt(150), Delegate: delegate2},
+			{NodeAddress: selfOnly, VotingPower: big.NewInt(80), Delegate: selfOnly},
+			{NodeAddress: address(6), VotingPower: big.NewInt(500)},
+			{NodeAddress: address(7), VotingPower: big.NewInt(75), Delegate: delegate3},
+			{NodeAddress: address(8), VotingPower: big.NewInt(75), Delegate: delegate3},
+		},
+	}
+	props := []protocol.ProtocolDaoProposalDetails{
+		{ID: 1, State: types.ProtocolDaoProposalState_Executed},
+		{ID: 2, State: types.ProtocolDaoProposalState_Pending},
+		{ID: 3, State: types.ProtocolDaoProposalState_Destroyed},
+		{ID: 4, State: types.ProtocolDaoProposalState_Defeated},
+		{ID: 5, State: types.ProtocolDaoProposalState_ActivePhase1},
+	}
+
+	insights, votedProps := rankDelegates(snapshot, props, 2, delegate2, selfOnly, delegate1, unknown)
+	if insights.BlockNumber != 1000 || insights.NodeCount != 8 {
+		t.Fatalf("expected block 1000 and 8 nodes, got block %d and %d nodes", insights.BlockNumber, insights.NodeCount)
+	}
+	if insights.TotalVotingPower.Cmp(big.NewInt(580)) != 0 {
+		t.Fatalf("expected a total voting power of 580, got %s", insights.TotalVotingPower.String())
+	}
+	if insights.DelegateCount != 3 {
+		t.Fatalf("expected 3 delegates, got %d", insights.DelegateCount)
+	}
+	if insights.SelfDelegated != 2 {
+		t.Fatalf("expected 2 self-delegated nodes, got %d", insights.SelfDelegated)
+	}
+
+	// The proposals that were put to a vote, newest first
+	if insights.EligibleProposals != 3 || len(votedProps) != 3 {
+		t.Fatalf("expected 3 proposals put to a vote, got %d", len(votedProps))
+	}
+	for i, id := range []uint64{5, 4, 1} {
+		if votedProps[i].ID != id {
+			t.Fatalf("expected proposal %d at position %d, got %d", id, i, votedProps[i].ID)
+		}
+	}
+
+	// Ties on voting power are broken by the number of delegators
+	expected := []struct {
+		address     common.Address
+		rank        int
+		votingPower int64
+		delegators  int
+	}{
+		{delegate1, 1, 200, 2},
+		{delegate3, 2, 150, 2},
+		{delegate2, 3, 150, 1},
+		{selfOnly, 0, 80, 0},
+		{unknown, 0, 0, 0},
+	}
+	if len(insights.Delegates) != len(expected) {
+		t.Fatalf("expected %d delegates in the report, got %d", len(expected), len(insights.Delegates))
+	}
+	for i, expectedDelegate := range expected {
+		delegate := insights.Delegates[i]
+		if delegate.Address != expectedDelegate.address {
+			t.Fatalf("expected delegate %s at position %d, got %s", expectedDelegate.address.Hex(), i, delegate.Address.Hex())
+		}
+		if delegate.Rank != expectedDelegate.rank {
+			t.Fatalf("expected delegate %s to have rank %d, got %d", delegate.Address.Hex(), expectedDelegate.rank, delegate.Rank)
+		}
+		if delegate.VotingPower.Cmp(big.NewInt(expectedDelegate.votingPower)) != 0 {
+			t.Fatalf("expected delegate %s to have %d voting power, got %s", delegate.Address.Hex(), expectedDelegate.votingPower, delegate.VotingPower.String())
+		}
+		if delegate.Delegators != expectedDelegate.delegators {
+			t.Fatalf("expected delegate %s to have %d delegators, got %d", delegate.Address.Hex(), expectedDelegate.delegators, delegate.Delegators)
+		}
+	}
+	if !insights.Delegates[0].SelfDelegated || insights.Delegates[0].OwnVotingPower.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("expected delegate %s to vote with its own 100 voting power", delegate1.Hex())
+	}
+}
+
+func TestDelegateParticipation(t *testing.T) {
+	registrationTime := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	props := []protocol.ProtocolDaoProposalDetails{
+		{ID: 5, State: types.ProtocolDaoProposalState_ActivePhase2, CreatedTime: registrationTime.Add(60 * 24 * time.Hour)},
+		{ID: 4, State: types.ProtocolDaoProposalState_ActivePhase1, CreatedTime: registrationTime.Add(50 * 24 * time.Hour)},
+		{ID: 3, State: types.ProtocolDaoProposalState_Executed, CreatedTime: registrationTime.Add(30 * 24 * time.Hour)},
+		{ID: 2, State: types.ProtocolDaoProposalState_Defeated, CreatedTime: registrationTime.Add(10 * 24 * time.Hour)},
+		{ID: 1, State: types.ProtocolDaoProposalState_Succeeded, CreatedTime: registrationTime.Add(-10 * 24 * time.Hour)},
+	}
+	tests := []struct {
+		name       string
+		directions []types.VoteDirection
+		voted      int
+		eligible   int
+		rate       float64
+	}{
+		{
+			name:       "votes on open proposals and proposals from before registration don't count",
+			directions: []types.VoteDirection{types.VoteDirection_For, types.VoteDirection_For, types.VoteDirection_Against, types.VoteDirection_NoVote, types.VoteDirection_For},
+			voted:      1,
+			eligible:   2,
+			rate:       0.5,
+		},
+		{
+			name:       "missed proposals from before registration don't count",
+			directions: []types.VoteDirection{types.VoteDirection_NoVote, types.VoteDirection_NoVote, types.VoteDirection_Abstain, types.VoteDirection_AgainstWithVeto, types.VoteDirection_NoVote},
+			voted:      2,
+			eligible:   2,
+			rate:       1,
+		},
+		{
+			name:       "no votes",
+			directions: []types.VoteDirection{types.VoteDirection_NoVote, types.VoteDirection_NoVote, types.VoteDirection_NoVote, types.VoteDirection_NoVote, types.VoteDirection_NoVote},
+			voted:      0,
+			eligible:   2,
+			rate:       0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			delegate := &DelegateInsight{RegistrationTime: registrationTime}
+			delegate.setVotes(props, test.directions)
+			if len(delegate.Votes) != len(props) {
+				t.Fatalf("expected %d votes in the history, got %d", len(props), len(delegate.Votes))
+			}
+			if delegate.ProposalsVoted != test.voted || delegate.EligibleProposals != test.eligible {
+				t.Fatalf("expected %d of %d proposals voted, got %d of %d", test.voted, test.eligible, delegate.ProposalsVoted, delegate.EligibleProposals)
+			}
+			if delegate.ParticipationRate != test.rate {
+				t.Fatalf("expected a participation rate of %f, got %f", test.rate, delegate.ParticipationRate)
+			}
+		})
+	}
+
+	// A delegate with no eligible proposals has no participation rate
+	delegate := &DelegateInsight{RegistrationTime: registrationTime.Add(365 * 24 * time.Hour)}
+	delegate.setVotes(props, make([]types.VoteDirection, len(props)))
+	if delegate.EligibleProposals != 0 || delegate.ParticipationRate != 0 {
+		t.Fatalf("expected no eligible proposals, got %d", delegate.EligibleProposals)
+	}
+}
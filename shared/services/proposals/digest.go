@@ -0,0 +1,398 @@
+package proposals
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/utils/atomicfile"
+)
+
+// How long before a voting deadline the digest reminds the node operator to vote
+const DigestReminderWindow time.Duration = 24 * time.Hour
+
+// The kinds of events a governance digest can report
+type DigestEventKind string
+
+const (
+	DigestEventKind_NewPdaoProposal     DigestEventKind = "new-pdao-proposal"
+	DigestEventKind_PdaoPhaseChange     DigestEventKind = "pdao-phase-change"
+	DigestEventKind_PdaoChallenge       DigestEventKind = "pdao-challenge"
+	DigestEventKind_NewOdaoProposal     DigestEventKind = "new-odao-proposal"
+	DigestEventKind_OdaoStateChange     DigestEventKind = "odao-state-change"
+	DigestEventKind_NewSnapshotProposal DigestEventKind = "new-snapshot-proposal"
+	DigestEventKind_VoteReminder        DigestEventKind = "vote-reminder"
+)
+
+// Something that changed in the DAOs since the last digest was sent
+type DigestEvent struct {
+	Kind        DigestEventKind `json:"kind"`
+	Subject     string          `json:"subject"`
+	Description string          `json:"description"`
+}
+
+// A challenge issued against a pDAO proposal
+type DigestChallenge struct {
+	Index      uint64               `json:"index"`
+	Challenger common.Address       `json:"challenger"`
+	State      types.ChallengeState `json:"state"`
+	Time       time.Time            `json:"time"`
+}
+
+// An on-chain pDAO proposal and how the node is represented on it
+type DigestPdaoProposal struct {
+	ID           uint64                         `json:"id"`
+	Message      string                         `json:"message"`
+	Proposer     common.Address                 `json:"proposer"`
+	State        types.ProtocolDaoProposalState `json:"state"`
+	PhaseEnd     time.Time                      `json:"phaseEnd"`
+	Delegate     common.Address                 `json:"delegate"`
+	NodeVote     types.VoteDirection            `json:"nodeVote"`
+	DelegateVote types.VoteDirection            `json:"delegateVote"`
+	Challenges   []DigestChallenge              `json:"challenges"`
+}
+
+// An oDAO proposal and whether the node has voted on it
+type DigestOdaoProposal struct {
+	ID              uint64              `json:"id"`
+	Message         string              `json:"message"`
+	Proposer        common.Address      `json:"proposer"`
+	State           types.ProposalState `json:"state"`
+	EndTime         time.Time           `json:"endTime"`
+	MemberVoted     bool                `json:"memberVoted"`
+	MemberSupported bool                `json:"memberSupported"`
+}
+
+// An active Snapshot proposal and whether the node or its signalling address has voted on it
+type DigestSnapshotProposal struct {
+	ID    string    `json:"id"`
+	Title string    `json:"title"`
+	State string    `json:"state"`
+	End   time.Time `json:"end"`
+	Link  string    `json:"link"`
+	Voted bool      `json:"voted"`
+}
+
+// A snapshot of the governance activity relevant to the node
+type GovernanceDigest struct {
+	NodeAddress       common.Address           `json:"nodeAddress"`
+	OnchainDelegate   common.Address           `json:"onchainDelegate"`
+	IsOdaoMember      bool                     `json:"isOdaoMember"`
+	BlockNumber       uint64                   `json:"blockNumber"`
+	GeneratedTime     time.Time                `json:"generatedTime"`
+	PdaoProposals     []DigestPdaoProposal     `json:"pdaoProposals"`
+	OdaoProposals     []DigestOdaoProposal     `json:"odaoProposals"`
+	SnapshotProposals []DigestSnapshotProposal `json:"snapshotProposals"`
+	SnapshotError     string                   `json:"snapshotError"`
+}
+
+// Check if a pDAO proposal can still be voted on, challenged, or executed
+func (p *DigestPdaoProposal) IsOpen() bool {
+	switch p.State {
+	case types.ProtocolDaoProposalState_Pending,
+		types.ProtocolDaoProposalState_ActivePhase1,
+		types.ProtocolDaoProposalState_ActivePhase2,
+		types.ProtocolDaoProposalState_Succeeded:
+		return true
+	}
+	return false
+}
+
+// Check if the node is represented on a pDAO proposal, either by its own vote or its delegate's
+func (p *DigestPdaoProposal) IsRepresented() bool {
+	return p.NodeVote != types.VoteDirection_NoVote || p.DelegateVote != types.VoteDirection_NoVote
+}
+
+// Check if an oDAO proposal can still be voted on or executed
+func (p *DigestOdaoProposal) IsOpen() bool {
+	switch p.State {
+	case types.Pending, types.Active, types.Succeeded:
+		return true
+	}
+	return false
+}
+
+// The governance state the node last sent a digest for
+type GovernanceDigestRecord struct {
+	PdaoStates        map[uint64]types.ProtocolDaoProposalState `json:"pdaoStates"`
+	Challenges        map[string]types.ChallengeState           `json:"challenges"`
+	OdaoStates        map[uint64]types.ProposalState            `json:"odaoStates"`
+	SnapshotProposals map[string]string                         `json:"snapshotProposals"`
+	Reminders         map[string]time.Time                      `json:"reminders"`
+	LastSentTime      time.Time                                 `json:"lastSentTime"`
+
+	lock *sync.Mutex `json:"-"`
+}
+
+// Create a new, empty digest record
+func NewGovernanceDigestRecord() *GovernanceDigestRecord {
+	return &GovernanceDigestRecord{
+		PdaoStates:        map[uint64]types.ProtocolDaoProposalState{},
+		Challenges:        map[string]types.ChallengeState{},
+		OdaoStates:        map[uint64]types.ProposalState{},
+		SnapshotProposals: map[string]string{},
+		Reminders:         map[string]time.Time{},
+		lock:              &sync.Mutex{},
+	}
+}
+
+// Load the digest record from disk, or create a new one if it doesn't exist yet
+func LoadGovernanceDigestRecord(path string) (*GovernanceDigestRecord, error) {
+	record := NewGovernanceDigestRecord()
+	if _, err := atomicfile.LoadJson(path, record); err != nil {
+		return nil, fmt.Errorf("error loading governance digest record: %w", err)
+	}
+	if record.PdaoStates == nil {
+		record.PdaoStates = map[uint64]types.ProtocolDaoProposalState{}
+	}
+	if record.Challenges == nil {
+		record.Challenges = map[string]types.ChallengeState{}
+	}
+	if record.OdaoStates == nil {
+		record.OdaoStates = map[uint64]types.ProposalState{}
+	}
+	if record.SnapshotProposals == nil {
+		record.SnapshotProposals = map[string]string{}
+	}
+	if record.Reminders == nil {
+		record.Reminders = map[string]time.Time{}
+	}
+	return record, nil
+}
+
+// Save the digest record to disk
+func (r *GovernanceDigestRecord) Save(path string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if err := atomicfile.SaveJson(path, r); err != nil {
+		return fmt.Errorf("error saving governance digest record: %w", err)
+	}
+	return nil
+}
+
+// Get the events that happened since the record was last updated.
+// Proposals that were already closed when they were first seen aren't reported, so a new record doesn't flood the digest with history.
+func (r *GovernanceDigestRecord) GetEvents(digest *GovernanceDigest) []DigestEvent {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	events := []DigestEvent{}
+	now := digest.GeneratedTime
+
+	// pDAO proposals
+	for _, prop := range digest.PdaoProposals {
+		subject := fmt.Sprintf("pDAO proposal %d", prop.ID)
+		stateName := types.ProtocolDaoProposalStates[prop.State]
+		oldState, exists := r.PdaoStates[prop.ID]
+		if !exists {
+			if prop.IsOpen() {
+				events = append(events, DigestEvent{
+					Kind:        DigestEventKind_NewPdaoProposal,
+					Subject:     subject,
+					Description: fmt.Sprintf("%s was created by %s: \"%s\" (%s until %s)", subject, prop.Proposer.Hex(), prop.Message, stateName, formatDigestTime(prop.PhaseEnd)),
+				})
+			}
+		} else if oldState != prop.State {
+			events = append(events, DigestEvent{
+				Kind:        DigestEventKind_PdaoPhaseChange,
+				Subject:     subject,
+				Description: fmt.Sprintf("%s moved from %s to %s", subject, types.ProtocolDaoProposalStates[oldState], stateName),
+			})
+		}
+
+		// Challenges
+		for _, challenge := range prop.Challenges {
+			key := getDigestChallengeKey(prop.ID, challenge.Index)
+			oldChallengeState, exists := r.Challenges[key]
+			if exists && oldChallengeState == challenge.State {
+				continue
+			}
+			events = append(events, DigestEvent{
+				Kind:        DigestEventKind_PdaoChallenge,
+				Subject:     subject,
+				Description: fmt.Sprintf("%s, tree index %d challenged by %s is now %s", subject, challenge.Index, challenge.Challenger.Hex(), GetChallengeStateName(challenge.State)),
+			})
+		}
+
+		// Reminders for active phases the node isn't represented in yet
+		if key := prop.getReminderKey(now); key != "" {
+			if _, exists := r.Reminders[key]; !exists {
+				events = append(events, DigestEvent{
+					Kind:        DigestEventKind_VoteReminder,
+					Subject:     subject,
+					Description: fmt.Sprintf("Neither the node nor its delegate has voted on %s, and %s ends at %s", subject, stateName, formatDigestTime(prop.PhaseEnd)),
+				})
+			}
+		}
+	}
+
+	// oDAO proposals
+	for _, prop := range digest.OdaoProposals {
+		subject := fmt.Sprintf("oDAO proposal %d", prop.ID)
+		oldState, exists := r.OdaoStates[prop.ID]
+		if !exists {
+			if prop.IsOpen() {
+				events = append(events, DigestEvent{
+					Kind:        DigestEventKind_NewOdaoProposal,
+					Subject:     subject,
+					Description: fmt.Sprintf("%s was created by %s: \"%s\" (voting ends %s)", subject, prop.Proposer.Hex(), prop.Message, formatDigestTime(prop.EndTime)),
+				})
+			}
+		} else if oldState != prop.State {
+			events = append(events, DigestEvent{
+				Kind:        DigestEventKind_OdaoStateChange,
+				Subject:     subject,
+				Description: fmt.Sprintf("%s moved from %s to %s", subject, oldState.String(), prop.State.String()),
+			})
+		}
+
+		if key := prop.getReminderKey(now); key != "" {
+			if _, exists := r.Reminders[key]; !exists {
+				events = append(events, DigestEvent{
+					Kind:        DigestEventKind_VoteReminder,
+					Subject:     subject,
+					Description: fmt.Sprintf("The node hasn't voted on %s, and voting ends at %s", subject, formatDigestTime(prop.EndTime)),
+				})
+			}
+		}
+	}
+
+	// Snapshot proposals
+	for _, prop := range digest.SnapshotProposals {
+		subject := fmt.Sprintf("Snapshot proposal \"%s\"", prop.Title)
+		if _, exists := r.SnapshotProposals[prop.ID]; !exists {
+			events = append(events, DigestEvent{
+				Kind:        DigestEventKind_NewSnapshotProposal,
+				Subject:     subject,
+				Description: fmt.Sprintf("%s is open for voting until %s (%s)", subject, formatDigestTime(prop.End), prop.Link),
+			})
+		}
+		if key := prop.getReminderKey(now); key != "" {
+			if _, exists := r.Reminders[key]; !exists {
+				events = append(events, DigestEvent{
+					Kind:        DigestEventKind_VoteReminder,
+					Subject:     subject,
+					Description: fmt.Sprintf("Neither the node nor its signalling address has voted on %s, and voting ends at %s", subject, formatDigestTime(prop.End)),
+				})
+			}
+		}
+	}
+
+	return events
+}
+
+// Update the record with the state in the digest
+func (r *GovernanceDigestRecord) Update(digest *GovernanceDigest, events []DigestEvent) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := digest.GeneratedTime
+	for _, prop := range digest.PdaoProposals {
+		r.PdaoStates[prop.ID] = prop.State
+		for _, challenge := range prop.Challenges {
+			r.Challenges[getDigestChallengeKey(prop.ID, challenge.Index)] = challenge.State
+		}
+		r.addReminder(prop.getReminderKey(now), now)
+	}
+	for _, prop := range digest.OdaoProposals {
+		r.OdaoStates[prop.ID] = prop.State
+		r.addReminder(prop.getReminderKey(now), now)
+	}
+
+	// Snapshot proposals are only tracked while they're active
+	activeSnapshotProposals := map[string]string{}
+	for _, prop := range digest.SnapshotProposals {
+		activeSnapshotProposals[prop.ID] = prop.State
+		r.addReminder(prop.getReminderKey(now), now)
+	}
+	if digest.SnapshotError == "" {
+		r.SnapshotProposals = activeSnapshotProposals
+	} else {
+		for id, state := range activeSnapshotProposals {
+			r.SnapshotProposals[id] = state
+		}
+	}
+
+	if len(events) > 0 {
+		r.LastSentTime = now
+	}
+}
+
+// Sort the digest's proposals for display; Snapshot proposals are sorted by deadline
+func (d *GovernanceDigest) Sort() {
+	sort.SliceStable(d.PdaoProposals, func(i, j int) bool {
+		return d.PdaoProposals[i].ID < d.PdaoProposals[j].ID
+	})
+	sort.SliceStable(d.OdaoProposals, func(i, j int) bool {
+		return d.OdaoProposals[i].ID < d.OdaoProposals[j].ID
+	})
+	sort.SliceStable(d.SnapshotProposals, func(i, j int) bool {
+		return d.SnapshotProposals[i].End.Before(d.SnapshotProposals[j].End)
+	})
+}
+
+// Mark a reminder as sent, keeping the time it was first sent
+func (r *GovernanceDigestRecord) addReminder(key string, now time.Time) {
+	if key == "" {
+		return
+	}
+	if _, exists := r.Reminders[key]; !exists {
+		r.Reminders[key] = now
+	}
+}
+
+// Get the reminder key for a pDAO proposal, or an empty string if the node doesn't need a reminder to vote on it
+func (p *DigestPdaoProposal) getReminderKey(now time.Time) string {
+	if p.State != types.ProtocolDaoProposalState_ActivePhase1 && p.State != types.ProtocolDaoProposalState_ActivePhase2 {
+		return ""
+	}
+	if p.IsRepresented() || p.PhaseEnd.Sub(now) >= DigestReminderWindow {
+		return ""
+	}
+	return fmt.Sprintf("pdao:%d:%d", p.ID, p.State)
+}
+
+// Get the reminder key for an oDAO proposal, or an empty string if the node doesn't need a reminder to vote on it
+func (p *DigestOdaoProposal) getReminderKey(now time.Time) string {
+	if p.State != types.Active || p.MemberVoted || p.EndTime.Sub(now) >= DigestReminderWindow {
+		return ""
+	}
+	return fmt.Sprintf("odao:%d", p.ID)
+}
+
+// Get the reminder key for a Snapshot proposal, or an empty string if the node doesn't need a reminder to vote on it
+func (p *DigestSnapshotProposal) getReminderKey(now time.Time) string {
+	if p.Voted || p.End.Sub(now) >= DigestReminderWindow {
+		return ""
+	}
+	return "snapshot:" + p.ID
+}
+
+// Get a readable name for a challenge state
+func GetChallengeStateName(state types.ChallengeState) string {
+	switch state {
+	case types.ChallengeState_Unchallenged:
+		return "unchallenged"
+	case types.ChallengeState_Challenged:
+		return "challenged"
+	case types.ChallengeState_Responded:
+		return "responded"
+	case types.ChallengeState_Paid:
+		return "paid"
+	}
+	return fmt.Sprintf("unknown (%d)", state)
+}
+
+// Get the key of a challenge in the digest record
+func getDigestChallengeKey(proposalID uint64, index uint64) string {
+	return fmt.Sprintf("%d:%d", proposalID, index)
+}
+
+// Format a deadline for a digest event
+func formatDigestTime(t time.Time) string {
+	return t.UTC().Format(time.RFC822)
+}
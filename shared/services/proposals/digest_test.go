@@ -0,0 +1,291 @@
+package proposals
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rocket-pool/rocketpool-go/types"
+)
+
+// Create a digest generated at the given time with the provided proposals
+func newTestDigest(now time.Time, pdao []DigestPdaoProposal, odao []DigestOdaoProposal, snapshot []DigestSnapshotProposal) *GovernanceDigest {
+	return &GovernanceDigest{
+		GeneratedTime:     now,
+		PdaoProposals:     pdao,
+		OdaoProposals:     odao,
+		SnapshotProposals: snapshot,
+	}
+}
+
+func TestGovernanceDigestGetEvents(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	farEnd := now.Add(7 * 24 * time.Hour)
+	soonEnd := now.Add(time.Hour)
+
+	tests := []struct {
+		name     string
+		previous *GovernanceDigest
+		digest   *GovernanceDigest
+		expected []DigestEventKind
+	}{
+		{
+			name:     "empty digest",
+			digest:   newTestDigest(now, nil, nil, nil),
+			expected: []DigestEventKind{},
+		},
+		{
+			name: "new open proposals",
+			digest: newTestDigest(now,
+				[]DigestPdaoProposal{{ID: 1, State: types.ProtocolDaoProposalState_ActivePhase1, PhaseEnd: farEnd}},
+				[]DigestOdaoProposal{{ID: 2, State: types.Active, EndTime: farEnd}},
+				[]DigestSnapshotProposal{{ID: "0xabc", State: "active", End: farEnd}},
+			),
+			expected: []DigestEventKind{DigestEventKind_NewPdaoProposal, DigestEventKind_NewOdaoProposal, DigestEventKind_NewSnapshotProposal},
+		},
+		{
+			name: "proposals already closed when first seen",
+			digest: newTestDigest(now,
+				[]DigestPdaoProposal{{ID: 1, State: types.ProtocolDaoProposalState_Executed}},
+				[]DigestOdaoProposal{{ID: 2, State: types.Defeated}},
+				nil,
+			),
+			expected: []DigestEventKind{},
+		},
+		{
+			name: "unchanged proposals",
+			previous: newTestDigest(now.Add(-time.Hour),
+				[]DigestPdaoProposal{{ID: 1, State: types.ProtocolDaoProposalState_ActivePhase1, PhaseEnd: farEnd}},
+				[]DigestOdaoProposal{{ID: 2, State: types.Active, EndTime: farEnd}},
+				[]DigestSnapshotProposal{{ID: "0xabc", State: "active", End: farEnd}},
+			),
+			digest: newTestDigest(now,
+				[]DigestPdaoProposal{{ID: 1, State: types.ProtocolDaoProposalState_ActivePhase1, PhaseEnd: farEnd}},
+				[]DigestOdaoProposal{{ID: 2, State: types.Active, EndTime: farEnd}},
+				[]DigestSnapshotProposal{{ID: "0xabc", State: "active", End: farEnd}},
+			),
+			expected: []DigestEventKind{},
+		},
+		{
+			name: "state changes",
+			previous: newTestDigest(now.Add(-time.Hour),
+				[]DigestPdaoProposal{{ID: 1, State: types.ProtocolDaoProposalState_ActivePhase1, PhaseEnd: farEnd}},
+				[]DigestOdaoProposal{{ID: 2, State: types.Active, EndTime: farEnd}},
+				nil,
+			),
+			digest: newTestDigest(now,
+				[]DigestPdaoProposal{{ID: 1, State: types.ProtocolDaoProposalState_Defeated}},
+				[]DigestOdaoProposal{{ID: 2, State: types.Executed}},
+				nil,
+			),
+			expected: []DigestEventKind{DigestEventKind_PdaoPhaseChange, DigestEventKind_OdaoStateChange},
+		},
+		{
+			name: "new challenge",
+			previous: newTestDigest(now.Add(-time.Hour),
+				[]DigestPdaoProposal{{ID: 1, State: types.ProtocolDaoProposalState_Pending, PhaseEnd: farEnd}},
+				nil, nil,
+			),
+			digest: newTestDigest(now,
+				[]DigestPdaoProposal{{ID: 1, State: types.ProtocolDaoProposalState_Pending, PhaseEnd: farEnd, Challenges: []DigestChallenge{
+					{Index: 2, State: types.ChallengeState_Challenged},
+				}}},
+				nil, nil,
+			),
+			expected: []DigestEventKind{DigestEventKind_PdaoChallenge},
+		},
+		{
+			name: "challenge response",
+			previous: newTestDigest(now.Add(-time.Hour),
+				[]DigestPdaoProposal{{ID: 1, State: types.ProtocolDaoProposalState_Pending, PhaseEnd: farEnd, Challenges: []DigestChallenge{
+					{Index: 2, State: types.ChallengeState_Challenged},
+					{Index: 3, State: types.ChallengeState_Challenged},
+				}}},
+				nil, nil,
+			),
+			digest: newTestDigest(now,
+				[]DigestPdaoProposal{{ID: 1, State: types.ProtocolDaoProposalState_Pending, PhaseEnd: farEnd, Challenges: []DigestChallenge{
+					{Index: 2, State: types.ChallengeState_Responded},
+					{Index: 3, State: types.ChallengeState_Challenged},
+				}}},
+				nil, nil,
+			),
+			expected: []DigestEventKind{DigestEventKind_PdaoChallenge},
+		},
+		{
+			name: "same challenge index on another proposal",
+			previous: newTestDigest(now.Add(-time.Hour),
+				[]DigestPdaoProposal{{ID: 1, State: types.ProtocolDaoProposalState_Pending, PhaseEnd: farEnd, Challenges: []DigestChallenge{
+					{Index: 2, State: types.ChallengeState_Challenged},
+				}}},
+				nil, nil,
+			),
+			digest: newTestDigest(now,
+				[]DigestPdaoProposal{
+					{ID: 1, State: types.ProtocolDaoProposalState_Pending, PhaseEnd: farEnd, Challenges: []DigestChallenge{
+						{Index: 2, State: types.ChallengeState_Challenged},
+					}},
+					{ID: 4, State: types.ProtocolDaoProposalState_Destroyed, Challenges: []DigestChallenge{
+						{Index: 2, State: types.ChallengeState_Challenged},
+					}},
+				},
+				nil, nil,
+			),
+			expected: []DigestEventKind{DigestEventKind_PdaoChallenge},
+		},
+		{
+			name: "reminders once proposals are about to end",
+			previous: newTestDigest(now.Add(-48*time.Hour),
+				[]DigestPdaoProposal{{ID: 1, State: types.ProtocolDaoProposalState_ActivePhase1, PhaseEnd: soonEnd}},
+				[]DigestOdaoProposal{{ID: 2, State: types.Active, EndTime: soonEnd}},
+				[]DigestSnapshotProposal{{ID: "0xabc", State: "active", End: soonEnd}},
+			),
+			digest: newTestDigest(now,
+				[]DigestPdaoProposal{{ID: 1, State: types.ProtocolDaoProposalState_ActivePhase1, PhaseEnd: soonEnd}},
+				[]DigestOdaoProposal{{ID: 2, State: types.Active, EndTime: soonEnd}},
+				[]DigestSnapshotProposal{{ID: "0xabc", State: "active", End: soonEnd}},
+			),
+			expected: []DigestEventKind{DigestEventKind_VoteReminder, DigestEventKind_VoteReminder, DigestEventKind_VoteReminder},
+		},
+		{
+			name: "no reminders once the node is represented",
+			previous: newTestDigest(now.Add(-time.Hour),
+				[]DigestPdaoProposal{{ID: 1, State: types.ProtocolDaoProposalState_ActivePhase1, PhaseEnd: farEnd}},
+				[]DigestOdaoProposal{{ID: 2, State: types.Active, EndTime: farEnd}},
+				[]DigestSnapshotProposal{{ID: "0xabc", State: "active", End: farEnd}},
+			),
+			digest: newTestDigest(now,
+				[]DigestPdaoProposal{{ID: 1, State: types.ProtocolDaoProposalState_ActivePhase1, PhaseEnd: soonEnd, DelegateVote: types.VoteDirection_For}},
+				[]DigestOdaoProposal{{ID: 2, State: types.Active, EndTime: soonEnd, MemberVoted: true}},
+				[]DigestSnapshotProposal{{ID: "0xabc", State: "active", End: soonEnd, Voted: true}},
+			),
+			expected: []DigestEventKind{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			record := NewGovernanceDigestRecord()
+			if test.previous != nil {
+				record.Update(test.previous, record.GetEvents(test.previous))
+			}
+			events := record.GetEvents(test.digest)
+			if len(events) != len(test.expected) {
+				t.Fatalf("expected %d events, got %d: %v", len(test.expected), len(events), events)
+			}
+			for i, kind := range test.expected {
+				if events[i].Kind != kind {
+					t.Fatalf("expected event %d to be %s, got %s (%s)", i, kind, events[i].Kind, events[i].Description)
+				}
+			}
+		})
+	}
+}
+
+func TestGovernanceDigestReminderKeys(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	phase1End := now.Add(time.Hour)
+	record := NewGovernanceDigestRecord()
+
+	// The first digest in the reminder window sends a reminder
+	digest := newTestDigest(now,
+		[]DigestPdaoProposal{{ID: 1, State: types.ProtocolDaoProposalState_ActivePhase1, PhaseEnd: phase1End}},
+		nil, nil,
+	)
+	events := record.GetEvents(digest)
+	if len(events) != 2 || events[1].Kind != DigestEventKind_VoteReminder {
+		t.Fatalf("expected a new proposal event and a reminder, got %v", events)
+	}
+	record.Update(digest, events)
+	if sent, exists := record.Reminders["pdao:1:1"]; !exists || !sent.Equal(now) {
+		t.Fatalf("expected the phase 1 reminder to be recorded at %s, got %v", now, record.Reminders)
+	}
+
+	// Later digests in the same phase don't repeat it, and keep the time it was first sent
+	later := now.Add(30 * time.Minute)
+	digest = newTestDigest(later,
+		[]DigestPdaoProposal{{ID: 1, State: types.ProtocolDaoProposalState_ActivePhase1, PhaseEnd: phase1End}},
+		nil, nil,
+	)
+	events = record.GetEvents(digest)
+	if len(events) != 0 {
+		t.Fatalf("expected no events, got %v", events)
+	}
+	record.Update(digest, events)
+	if sent := record.Reminders["pdao:1:1"]; !sent.Equal(now) {
+		t.Fatalf("expected the reminder time to stay at %s, got %s", now, sent)
+	}
+
+	// Phase 2 has its own deadline, so it gets its own reminder
+	phase2Start := phase1End.Add(time.Minute)
+	digest = newTestDigest(phase2Start,
+		[]DigestPdaoProposal{{ID: 1, State: types.ProtocolDaoProposalState_ActivePhase2, PhaseEnd: phase2Start.Add(time.Hour)}},
+		nil, nil,
+	)
+	events = record.GetEvents(digest)
+	if len(events) != 2 || events[0].Kind != DigestEventKind_PdaoPhaseChange || events[1].Kind != DigestEventKind_VoteReminder {
+		t.Fatalf("expected a phase change and a reminder, got %v", events)
+	}
+	record.Update(digest, events)
+	if _, exists := record.Reminders["pdao:1:2"]; !exists {
+		t.Fatalf("expected the phase 2 reminder to be recorded, got %v", record.Reminders)
+	}
+	if !record.LastSentTime.Equal(phase2Start) {
+		t.Fatalf("expected the last sent time to be %s, got %s", phase2Start, record.LastSentTime)
+	}
+}
+
+func TestGovernanceDigestUpdateSnapshotProposals(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	end := now.Add(7 * 24 * time.Hour)
+
+	tests := []struct {
+		name          string
+		snapshot      []DigestSnapshotProposal
+		snapshotError string
+		expected      []string
+	}{
+		{
+			name:     "finished proposals are dropped",
+			snapshot: []DigestSnapshotProposal{{ID: "0xbbb", State: "active", End: end}},
+			expected: []string{"0xbbb"},
+		},
+		{
+			name:          "records are kept when Snapshot couldn't be reached",
+			snapshotError: "error getting Snapshot proposals",
+			expected:      []string{"0xaaa"},
+		},
+		{
+			name:          "proposals seen before the error are added",
+			snapshot:      []DigestSnapshotProposal{{ID: "0xbbb", State: "active", End: end}},
+			snapshotError: "error getting Snapshot votes",
+			expected:      []string{"0xaaa", "0xbbb"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			record := NewGovernanceDigestRecord()
+			record.Update(newTestDigest(now.Add(-time.Hour), nil, nil, []DigestSnapshotProposal{{ID: "0xaaa", State: "active", End: end}}), nil)
+
+			digest := newTestDigest(now, nil, nil, test.snapshot)
+			digest.SnapshotError = test.snapshotError
+			record.Update(digest, record.GetEvents(digest))
+			if len(record.SnapshotProposals) != len(test.expected) {
+				t.Fatalf("expected %d Snapshot proposals, got %v", len(test.expected), record.SnapshotProposals)
+			}
+			for _, id := range test.expected {
+				if _, exists := record.SnapshotProposals[id]; !exists {
+					t.Fatalf("expected Snapshot proposal %s to be recorded, got %v", id, record.SnapshotProposals)
+				}
+			}
+
+			// A proposal that was kept isn't reported as new again once Snapshot is back
+			if test.snapshotError != "" {
+				digest = newTestDigest(now.Add(time.Hour), nil, nil, []DigestSnapshotProposal{{ID: "0xaaa", State: "active", End: end}})
+				if events := record.GetEvents(digest); len(events) != 0 {
+					t.Fatalf("expected no events, got %v", events)
+				}
+			}
+		})
+	}
+}
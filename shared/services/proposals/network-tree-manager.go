@@ -83,6 +83,12 @@ func NewNetworkTreeManager(log *log.ColorLogger, cfg *config.RocketPoolConfig) (
 
 // Create a network voting tree from a voting info snapshot
 func (m *NetworkTreeManager) CreateNetworkVotingTree(snapshot *VotingInfoSnapshot, depthPerRound uint64) *NetworkVotingTree {
+	network := m.cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	return createNetworkVotingTree(snapshot, network, depthPerRound)
+}
+
+// Build a network voting tree from a voting info snapshot, with each node's leaf holding the voting power delegated to it
+func createNetworkVotingTree(snapshot *VotingInfoSnapshot, network cfgtypes.Network, depthPerRound uint64) *NetworkVotingTree {
 	// Create a map of the voting power of each node, accounting for delegation
 	votingPower := map[common.Address]*big.Int{}
 	for _, info := range snapshot.Info {
@@ -113,7 +119,6 @@ func (m *NetworkTreeManager) CreateNetworkVotingTree(snapshot *VotingInfoSnapsho
 	}
 
 	// Make the tree
-	network := m.cfg.Smartnode.Network.Value.(cfgtypes.Network)
 	tree := CreateTreeFromLeaves(snapshot.BlockNumber, network, leaves, 1, depthPerRound)
 	return &NetworkVotingTree{
 		VotingTree: tree,
@@ -141,6 +146,17 @@ func (m *NetworkTreeManager) LoadFromDisk(blockNumber uint32) (*NetworkVotingTre
 	return tree, nil
 }
 
+// Delete the network trees for the blocks selected by the provided function
+func (m *NetworkTreeManager) Prune(shouldPrune func(blockNumber uint32) bool) (int, error) {
+	return PruneFiles(m.checksumManager, func(filename string) (bool, error) {
+		blockNumber, err := m.getBlockNumberFromFilename(filename)
+		if err != nil {
+			return false, err
+		}
+		return shouldPrune(blockNumber), nil
+	})
+}
+
 // Return true if the first filename represents a block number that's lower than the second filename's block number
 func (m *NetworkTreeManager) Less(firstFilename string, secondFilename string) (bool, error) {
 	firstBlock, err := m.getBlockNumberFromFilename(firstFilename)
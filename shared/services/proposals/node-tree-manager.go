@@ -146,6 +146,17 @@ func (m *NodeTreeManager) LoadFromDisk(blockNumber uint32, rpIndex uint64) (*Nod
 	return tree, nil
 }
 
+// Delete the node trees for the blocks selected by the provided function
+func (m *NodeTreeManager) Prune(shouldPrune func(blockNumber uint32) bool) (int, error) {
+	return PruneFiles(m.checksumManager, func(filename string) (bool, error) {
+		blockNumber, _, err := m.getInfoFromFilename(filename)
+		if err != nil {
+			return false, err
+		}
+		return shouldPrune(blockNumber), nil
+	})
+}
+
 // Sort the checksum file entries by their block number
 func (m *NodeTreeManager) Less(firstFilename string, secondFilename string) (bool, error) {
 	firstBlock, firstNodeIndex, err := m.getInfoFromFilename(firstFilename)
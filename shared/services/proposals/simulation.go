@@ -0,0 +1,245 @@
+package proposals
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// A node's RPL collateral relative to the minimum and maximum bounds
+type CollateralStatus string
+
+const (
+	CollateralStatus_NoMinipools  CollateralStatus = "no-eligible-minipools"
+	CollateralStatus_BelowMinimum CollateralStatus = "below-minimum"
+	CollateralStatus_Within       CollateralStatus = "within-bounds"
+	CollateralStatus_AboveMaximum CollateralStatus = "above-maximum"
+)
+
+// The node commission bounds for new minipools
+type NodeFeeBounds struct {
+	Minimum float64 `json:"minimum"`
+	Target  float64 `json:"target"`
+	Maximum float64 `json:"maximum"`
+}
+
+// The network-wide numbers a proposal can change
+type SimulatedNetwork struct {
+	MinCollateralFraction             *big.Int      `json:"minCollateralFraction"`
+	MaxCollateralFraction             *big.Int      `json:"maxCollateralFraction"`
+	NodeFeeBounds                     NodeFeeBounds `json:"nodeFeeBounds"`
+	NodeFee                           float64       `json:"nodeFee"`
+	NodeOperatorRewardsPercent        *big.Int      `json:"nodeOperatorRewardsPercent"`
+	TrustedNodeOperatorRewardsPercent *big.Int      `json:"trustedNodeOperatorRewardsPercent"`
+	ProtocolDaoRewardsPercent         *big.Int      `json:"protocolDaoRewardsPercent"`
+	TotalEffectiveStake               *big.Int      `json:"totalEffectiveStake"`
+	NodesBelowMinimum                 int           `json:"nodesBelowMinimum"`
+	NodesAboveMaximum                 int           `json:"nodesAboveMaximum"`
+}
+
+// The numbers for a single node that a proposal can change
+type SimulatedNode struct {
+	EffectiveStake      *big.Int         `json:"effectiveStake"`
+	CollateralStatus    CollateralStatus `json:"collateralStatus"`
+	EstimatedRplRewards *big.Int         `json:"estimatedRplRewards"`
+}
+
+// A node and how a proposal would affect it
+type SimulatedNodeChange struct {
+	NodeAddress     common.Address `json:"nodeAddress"`
+	RplStake        *big.Int       `json:"rplStake"`
+	BorrowedEth     *big.Int       `json:"borrowedEth"`
+	CollateralRatio float64        `json:"collateralRatio"`
+	Before          SimulatedNode  `json:"before"`
+	After           SimulatedNode  `json:"after"`
+}
+
+// The result of applying a proposal to a copy of the network state
+type ProposalSimulation struct {
+	SettingChanges      []ProposalSettingChange     `json:"settingChanges"`
+	RewardsPercentages  *ProposalRewardsPercentages `json:"rewardsPercentages"`
+	UnsupportedSettings []string                    `json:"unsupportedSettings"`
+	Before              SimulatedNetwork            `json:"before"`
+	After               SimulatedNetwork            `json:"after"`
+	NodeCount           int                         `json:"nodeCount"`
+	AffectedNodes       []SimulatedNodeChange       `json:"affectedNodes"`
+	LocalNode           *SimulatedNodeChange        `json:"localNode"`
+}
+
+// Check if the proposal would change anything about the node
+func (c *SimulatedNodeChange) IsAffected() bool {
+	return c.Before.EffectiveStake.Cmp(c.After.EffectiveStake) != 0 ||
+		c.Before.EstimatedRplRewards.Cmp(c.After.EstimatedRplRewards) != 0 ||
+		c.Before.CollateralStatus != c.After.CollateralStatus
+}
+
+// Apply a proposal's payload to an in-memory copy of the network state and compare the derived numbers before and after.
+// Setting changes whose effects aren't modelled are listed in UnsupportedSettings.
+func SimulateProposal(networkState *state.NetworkState, feeBounds NodeFeeBounds, nodeAddress common.Address, info *ProposalPayloadInfo) (*ProposalSimulation, error) {
+	simulation := &ProposalSimulation{
+		SettingChanges:      info.SettingChanges,
+		RewardsPercentages:  info.RewardsPercentages,
+		UnsupportedSettings: []string{},
+		NodeCount:           len(networkState.NodeDetails),
+		AffectedNodes:       []SimulatedNodeChange{},
+	}
+	if simulation.SettingChanges == nil {
+		simulation.SettingChanges = []ProposalSettingChange{}
+	}
+
+	// Copy the state; only the network details are modified, so the node and minipool details can be shared
+	forkedState := *networkState
+	forkedDetails := *networkState.NetworkDetails
+	forkedState.NetworkDetails = &forkedDetails
+	forkedFeeBounds := feeBounds
+
+	// Apply the changes
+	for _, change := range info.SettingChanges {
+		var target **big.Int
+		var feeTarget *float64
+		switch {
+		case change.Contract == protocol.NodeSettingsContractName && change.Path == protocol.MinimumPerMinipoolStakeSettingPath:
+			target = &forkedDetails.MinCollateralFraction
+		case change.Contract == protocol.NodeSettingsContractName && change.Path == protocol.MaximumPerMinipoolStakeSettingPath:
+			target = &forkedDetails.MaxCollateralFraction
+		case change.Contract == protocol.NetworkSettingsContractName && change.Path == protocol.MinimumNodeFeeSettingPath:
+			feeTarget = &forkedFeeBounds.Minimum
+		case change.Contract == protocol.NetworkSettingsContractName && change.Path == protocol.TargetNodeFeeSettingPath:
+			feeTarget = &forkedFeeBounds.Target
+		case change.Contract == protocol.NetworkSettingsContractName && change.Path == protocol.MaximumNodeFeeSettingPath:
+			feeTarget = &forkedFeeBounds.Maximum
+		default:
+			simulation.UnsupportedSettings = append(simulation.UnsupportedSettings, fmt.Sprintf("%s: %s", change.Contract, change.Path))
+			continue
+		}
+
+		if change.UintValue == nil {
+			return nil, fmt.Errorf("setting %s must be a uint256 value", change.Path)
+		}
+		if target != nil {
+			*target = change.UintValue
+		} else {
+			*feeTarget = eth.WeiToEth(change.UintValue)
+		}
+	}
+	if info.RewardsPercentages != nil {
+		forkedDetails.TrustedNodeOperatorRewardsPercent = info.RewardsPercentages.OdaoPercent
+		forkedDetails.ProtocolDaoRewardsPercent = info.RewardsPercentages.PdaoPercent
+		forkedDetails.NodeOperatorRewardsPercent = info.RewardsPercentages.NodePercent
+	}
+
+	// Calculate the derived numbers for both states
+	before, beforeNodes, err := simulateNetwork(networkState, feeBounds)
+	if err != nil {
+		return nil, fmt.Errorf("error calculating the current network state: %w", err)
+	}
+	after, afterNodes, err := simulateNetwork(&forkedState, forkedFeeBounds)
+	if err != nil {
+		return nil, fmt.Errorf("error calculating the simulated network state: %w", err)
+	}
+	simulation.Before = *before
+	simulation.After = *after
+
+	// Find the affected nodes
+	rplPrice := eth.WeiToEth(networkState.NetworkDetails.RplPrice)
+	for i := range networkState.NodeDetails {
+		node := &networkState.NodeDetails[i]
+		change := SimulatedNodeChange{
+			NodeAddress: node.NodeAddress,
+			RplStake:    node.RplStake,
+			BorrowedEth: networkState.GetEligibleBorrowedEth(node),
+			Before:      beforeNodes[node.NodeAddress],
+			After:       afterNodes[node.NodeAddress],
+		}
+		if change.BorrowedEth.Sign() > 0 {
+			change.CollateralRatio = eth.WeiToEth(node.RplStake) * rplPrice / eth.WeiToEth(change.BorrowedEth)
+		}
+		if node.NodeAddress == nodeAddress {
+			localNode := change
+			simulation.LocalNode = &localNode
+		}
+		if change.IsAffected() {
+			simulation.AffectedNodes = append(simulation.AffectedNodes, change)
+		}
+	}
+
+	// Put the largest changes to estimated rewards first
+	sort.SliceStable(simulation.AffectedNodes, func(i, j int) bool {
+		first := simulation.AffectedNodes[i]
+		second := simulation.AffectedNodes[j]
+		firstDelta := big.NewInt(0).Sub(first.After.EstimatedRplRewards, first.Before.EstimatedRplRewards)
+		secondDelta := big.NewInt(0).Sub(second.After.EstimatedRplRewards, second.Before.EstimatedRplRewards)
+		return firstDelta.CmpAbs(secondDelta) > 0
+	})
+
+	return simulation, nil
+}
+
+// Calculate the derived network and node numbers for a state
+func simulateNetwork(networkState *state.NetworkState, feeBounds NodeFeeBounds) (*SimulatedNetwork, map[common.Address]SimulatedNode, error) {
+	details := networkState.NetworkDetails
+	network := &SimulatedNetwork{
+		MinCollateralFraction:             details.MinCollateralFraction,
+		MaxCollateralFraction:             details.MaxCollateralFraction,
+		NodeFeeBounds:                     feeBounds,
+		NodeFee:                           details.NodeFee,
+		NodeOperatorRewardsPercent:        details.NodeOperatorRewardsPercent,
+		TrustedNodeOperatorRewardsPercent: details.TrustedNodeOperatorRewardsPercent,
+		ProtocolDaoRewardsPercent:         details.ProtocolDaoRewardsPercent,
+	}
+
+	// The network fee always lies within the bounds
+	if network.NodeFee < feeBounds.Minimum {
+		network.NodeFee = feeBounds.Minimum
+	} else if network.NodeFee > feeBounds.Maximum {
+		network.NodeFee = feeBounds.Maximum
+	}
+
+	// Get the effective stakes without participation scaling, so the collateral bounds can be read from them directly
+	effectiveStakes, totalEffectiveStake, err := networkState.CalculateTrueEffectiveStakes(false, true)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error calculating effective RPL stakes: %w", err)
+	}
+	network.TotalEffectiveStake = totalEffectiveStake
+
+	// Get the RPL available to node operators this interval
+	nodeOperatorRewards := big.NewInt(0).Mul(details.PendingRPLRewards, details.NodeOperatorRewardsPercent)
+	nodeOperatorRewards.Div(nodeOperatorRewards, eth.EthToWei(1))
+
+	nodes := make(map[common.Address]SimulatedNode, len(networkState.NodeDetails))
+	for _, node := range networkState.NodeDetails {
+		effectiveStake := effectiveStakes[node.NodeAddress]
+		simulatedNode := SimulatedNode{
+			EffectiveStake:      effectiveStake,
+			EstimatedRplRewards: big.NewInt(0),
+		}
+
+		// Infer the collateral status from how the stake was clamped
+		switch {
+		case networkState.GetEligibleBorrowedEth(&node).Sign() == 0:
+			simulatedNode.CollateralStatus = CollateralStatus_NoMinipools
+		case effectiveStake.Sign() == 0 && node.RplStake.Sign() > 0:
+			simulatedNode.CollateralStatus = CollateralStatus_BelowMinimum
+			network.NodesBelowMinimum++
+		case effectiveStake.Cmp(node.RplStake) < 0:
+			simulatedNode.CollateralStatus = CollateralStatus_AboveMaximum
+			network.NodesAboveMaximum++
+		default:
+			simulatedNode.CollateralStatus = CollateralStatus_Within
+		}
+
+		// Estimate the node's share of this interval's rewards
+		if totalEffectiveStake.Sign() > 0 {
+			simulatedNode.EstimatedRplRewards.Mul(nodeOperatorRewards, effectiveStake)
+			simulatedNode.EstimatedRplRewards.Div(simulatedNode.EstimatedRplRewards, totalEffectiveStake)
+		}
+		nodes[node.NodeAddress] = simulatedNode
+	}
+
+	return network, nodes, nil
+}
@@ -0,0 +1,223 @@
+package proposals
+
+import (
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/settings/protocol"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	rpstate "github.com/rocket-pool/rocketpool-go/utils/state"
+
+	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/state"
+)
+
+// Build a network with one 8 ETH minipool per node and an RPL price of 0.01 ETH, so each node needs between 240 and
+// 1200 RPL at the default collateral bounds of 10% and 150%
+func createTestNetworkState(rplStakes map[common.Address]float64) *state.NetworkState {
+	networkState := &state.NetworkState{
+		BeaconSlotNumber: 3200,
+		BeaconConfig: beacon.Eth2Config{
+			SlotsPerEpoch:  32,
+			SecondsPerSlot: 12,
+		},
+		NetworkDetails: &rpstate.NetworkDetails{
+			RplPrice:                          eth.EthToWei(0.01),
+			MinCollateralFraction:             eth.EthToWei(0.1),
+			MaxCollateralFraction:             eth.EthToWei(1.5),
+			IntervalDuration:                  28 * 24 * time.Hour,
+			PendingRPLRewards:                 eth.EthToWei(1000),
+			NodeFee:                           0.14,
+			NodeOperatorRewardsPercent:        eth.EthToWei(0.7),
+			TrustedNodeOperatorRewardsPercent: eth.EthToWei(0.025),
+			ProtocolDaoRewardsPercent:         eth.EthToWei(0.275),
+		},
+		MinipoolDetailsByNode: map[common.Address][]*rpstate.NativeMinipoolDetails{},
+		ValidatorDetails:      map[types.ValidatorPubkey]beacon.ValidatorStatus{},
+	}
+
+	i := byte(0)
+	for address, stake := range rplStakes {
+		i++
+		pubkey := types.ValidatorPubkey{i}
+		networkState.NodeDetails = append(networkState.NodeDetails, rpstate.NativeNodeDetails{
+			Exists:           true,
+			NodeAddress:      address,
+			RegistrationTime: big.NewInt(0),
+			RplStake:         eth.EthToWei(stake),
+		})
+		networkState.MinipoolDetailsByNode[address] = []*rpstate.NativeMinipoolDetails{{
+			Exists:             true,
+			Status:             types.Staking,
+			Pubkey:             pubkey,
+			NodeAddress:        address,
+			UserDepositBalance: eth.EthToWei(24),
+			NodeDepositBalance: eth.EthToWei(8),
+		}}
+		networkState.ValidatorDetails[pubkey] = beacon.ValidatorStatus{
+			Pubkey:    pubkey,
+			Exists:    true,
+			ExitEpoch: math.MaxUint64,
+		}
+	}
+	return networkState
+}
+
+func TestSimulateMinimumStake(t *testing.T) {
+	within := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	above := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	below := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	networkState := createTestNetworkState(map[common.Address]float64{
+		within: 300,
+		above:  2000,
+		below:  100,
+	})
+	feeBounds := NodeFeeBounds{Minimum: 0.05, Target: 0.1, Maximum: 0.2}
+
+	// Raise the minimum to 15%, which needs 360 RPL
+	info := &ProposalPayloadInfo{
+		Type:     ProposalType_Setting,
+		Settings: []string{protocol.MinimumPerMinipoolStakeSettingPath, protocol.DepositFeeSettingPath},
+		SettingChanges: []ProposalSettingChange{
+			{
+				Contract:  protocol.NodeSettingsContractName,
+				Path:      protocol.MinimumPerMinipoolStakeSettingPath,
+				Type:      types.ProposalSettingType_Uint256,
+				UintValue: eth.EthToWei(0.15),
+			},
+			{
+				Contract:  protocol.DepositSettingsContractName,
+				Path:      protocol.DepositFeeSettingPath,
+				Type:      types.ProposalSettingType_Uint256,
+				UintValue: big.NewInt(1),
+			},
+		},
+	}
+	simulation, err := SimulateProposal(networkState, feeBounds, within, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The original state must not be modified
+	if networkState.NetworkDetails.MinCollateralFraction.Cmp(eth.EthToWei(0.1)) != 0 {
+		t.Fatalf("the simulation modified the original network state")
+	}
+
+	if len(simulation.UnsupportedSettings) != 1 {
+		t.Fatalf("expected 1 unsupported setting, got %v", simulation.UnsupportedSettings)
+	}
+	if simulation.Before.NodesBelowMinimum != 1 || simulation.After.NodesBelowMinimum != 2 {
+		t.Fatalf("expected nodes below the minimum to go from 1 to 2, got %d to %d", simulation.Before.NodesBelowMinimum, simulation.After.NodesBelowMinimum)
+	}
+	if simulation.Before.NodesAboveMaximum != 1 || simulation.After.NodesAboveMaximum != 1 {
+		t.Fatalf("expected 1 node above the maximum, got %d to %d", simulation.Before.NodesAboveMaximum, simulation.After.NodesAboveMaximum)
+	}
+	if simulation.Before.TotalEffectiveStake.Cmp(eth.EthToWei(1500)) != 0 || simulation.After.TotalEffectiveStake.Cmp(eth.EthToWei(1200)) != 0 {
+		t.Fatalf("expected the total effective stake to go from 1500 to 1200, got %.2f to %.2f", eth.WeiToEth(simulation.Before.TotalEffectiveStake), eth.WeiToEth(simulation.After.TotalEffectiveStake))
+	}
+
+	// The node that falls below the minimum and the node whose share grows are affected
+	if len(simulation.AffectedNodes) != 2 {
+		t.Fatalf("expected 2 affected nodes, got %d", len(simulation.AffectedNodes))
+	}
+	for _, change := range simulation.AffectedNodes {
+		if change.NodeAddress == below {
+			t.Fatalf("node %s was already below the minimum and shouldn't be affected", below.Hex())
+		}
+	}
+
+	// Check the local node
+	localNode := simulation.LocalNode
+	if localNode == nil {
+		t.Fatalf("expected the local node to be included")
+	}
+	if localNode.Before.CollateralStatus != CollateralStatus_Within || localNode.After.CollateralStatus != CollateralStatus_BelowMinimum {
+		t.Fatalf("expected the local node to go from %s to %s, got %s to %s", CollateralStatus_Within, CollateralStatus_BelowMinimum, localNode.Before.CollateralStatus, localNode.After.CollateralStatus)
+	}
+	if localNode.Before.EstimatedRplRewards.Cmp(eth.EthToWei(140)) != 0 || localNode.After.EstimatedRplRewards.Sign() != 0 {
+		t.Fatalf("expected the local node's rewards to go from 140 to 0, got %.2f to %.2f", eth.WeiToEth(localNode.Before.EstimatedRplRewards), eth.WeiToEth(localNode.After.EstimatedRplRewards))
+	}
+	if math.Abs(localNode.CollateralRatio-0.125) > 1e-9 {
+		t.Fatalf("expected a collateral ratio of 0.125, got %f", localNode.CollateralRatio)
+	}
+}
+
+func TestSimulateNodeFeeBounds(t *testing.T) {
+	node := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	networkState := createTestNetworkState(map[common.Address]float64{node: 300})
+	feeBounds := NodeFeeBounds{Minimum: 0.05, Target: 0.1, Maximum: 0.2}
+
+	info := &ProposalPayloadInfo{
+		Type: ProposalType_Setting,
+		SettingChanges: []ProposalSettingChange{
+			{
+				Contract:  protocol.NetworkSettingsContractName,
+				Path:      protocol.MaximumNodeFeeSettingPath,
+				Type:      types.ProposalSettingType_Uint256,
+				UintValue: eth.EthToWei(0.1),
+			},
+		},
+	}
+	simulation, err := SimulateProposal(networkState, feeBounds, common.Address{}, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if simulation.Before.NodeFee != 0.14 {
+		t.Fatalf("expected the current node fee to be 0.14, got %f", simulation.Before.NodeFee)
+	}
+	if simulation.After.NodeFeeBounds.Maximum != 0.1 || simulation.After.NodeFee != 0.1 {
+		t.Fatalf("expected the node fee to be clamped to the new maximum of 0.1, got %f", simulation.After.NodeFee)
+	}
+	if len(simulation.AffectedNodes) != 0 {
+		t.Fatalf("expected no affected nodes, got %d", len(simulation.AffectedNodes))
+	}
+	if simulation.LocalNode != nil {
+		t.Fatalf("expected no local node")
+	}
+}
+
+func TestSimulateRewardsPercentages(t *testing.T) {
+	node := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	networkState := createTestNetworkState(map[common.Address]float64{node: 300})
+
+	info := &ProposalPayloadInfo{
+		Type: ProposalType_RewardsPercentages,
+		RewardsPercentages: &ProposalRewardsPercentages{
+			OdaoPercent: eth.EthToWei(0.025),
+			PdaoPercent: eth.EthToWei(0.175),
+			NodePercent: eth.EthToWei(0.8),
+		},
+	}
+	simulation, err := SimulateProposal(networkState, NodeFeeBounds{Maximum: 1}, node, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if simulation.LocalNode == nil {
+		t.Fatalf("expected the local node to be included")
+	}
+	if simulation.LocalNode.Before.EstimatedRplRewards.Cmp(eth.EthToWei(700)) != 0 || simulation.LocalNode.After.EstimatedRplRewards.Cmp(eth.EthToWei(800)) != 0 {
+		t.Fatalf("expected the node's rewards to go from 700 to 800, got %.2f to %.2f", eth.WeiToEth(simulation.LocalNode.Before.EstimatedRplRewards), eth.WeiToEth(simulation.LocalNode.After.EstimatedRplRewards))
+	}
+}
+
+func TestSimulateInvalidValue(t *testing.T) {
+	networkState := createTestNetworkState(map[common.Address]float64{})
+	info := &ProposalPayloadInfo{
+		Type: ProposalType_Setting,
+		SettingChanges: []ProposalSettingChange{
+			{
+				Contract:  protocol.NodeSettingsContractName,
+				Path:      protocol.MaximumPerMinipoolStakeSettingPath,
+				Type:      types.ProposalSettingType_Bool,
+				BoolValue: true,
+			},
+		},
+	}
+	if _, err := SimulateProposal(networkState, NodeFeeBounds{}, common.Address{}, info); err == nil {
+		t.Fatalf("expected a boolean value for a uint256 setting to be rejected")
+	}
+}
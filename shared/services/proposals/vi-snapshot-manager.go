@@ -136,6 +136,17 @@ func (m *VotingInfoSnapshotManager) LoadFromDisk(blockNumber uint32) (*VotingInf
 	return tree, nil
 }
 
+// Delete the voting info snapshots for the blocks selected by the provided function
+func (m *VotingInfoSnapshotManager) Prune(shouldPrune func(blockNumber uint32) bool) (int, error) {
+	return PruneFiles(m.checksumManager, func(filename string) (bool, error) {
+		blockNumber, err := m.getBlockNumberFromFilename(filename)
+		if err != nil {
+			return false, err
+		}
+		return shouldPrune(blockNumber), nil
+	})
+}
+
 // Return true if the first filename represents a block number that's lower than the second filename's block number
 func (m *VotingInfoSnapshotManager) Less(firstFilename string, secondFilename string) (bool, error) {
 	firstBlock, err := m.getBlockNumberFromFilename(firstFilename)
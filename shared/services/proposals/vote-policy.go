@@ -0,0 +1,510 @@
+package proposals
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/rocketpool"
+	"github.com/rocket-pool/rocketpool-go/types"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+	"gopkg.in/yaml.v2"
+
+	"github.com/rocket-pool/smartnode/shared/utils/atomicfile"
+)
+
+// The action a voting policy rule takes on the proposals it matches
+type VotePolicyAction string
+
+const (
+	VotePolicyAction_For            VotePolicyAction = "for"
+	VotePolicyAction_Against        VotePolicyAction = "against"
+	VotePolicyAction_Veto           VotePolicyAction = "veto"
+	VotePolicyAction_Abstain        VotePolicyAction = "abstain"
+	VotePolicyAction_FollowDelegate VotePolicyAction = "follow-delegate"
+	VotePolicyAction_Skip           VotePolicyAction = "skip"
+)
+
+// The type of a pDAO proposal, as determined by its payload
+type ProposalType string
+
+const (
+	ProposalType_Setting            ProposalType = "setting"
+	ProposalType_RewardsPercentages ProposalType = "rewards-percentages"
+	ProposalType_OneTimeSpend       ProposalType = "one-time-spend"
+	ProposalType_RecurringSpend     ProposalType = "recurring-spend"
+	ProposalType_SecurityCouncil    ProposalType = "security-council"
+	ProposalType_Unknown            ProposalType = "unknown"
+)
+
+// The status of a voting policy decision on a proposal
+type VotePolicyStatus string
+
+const (
+	VotePolicyStatus_NoMatch               VotePolicyStatus = "no-match"
+	VotePolicyStatus_Skipped               VotePolicyStatus = "skipped"
+	VotePolicyStatus_DryRun                VotePolicyStatus = "dry-run"
+	VotePolicyStatus_Queued                VotePolicyStatus = "queued"
+	VotePolicyStatus_WaitingForDelegate    VotePolicyStatus = "waiting-for-delegate"
+	VotePolicyStatus_DelegateMissed        VotePolicyStatus = "delegate-missed"
+	VotePolicyStatus_RepresentedByDelegate VotePolicyStatus = "represented-by-delegate"
+	VotePolicyStatus_NoVotingPower         VotePolicyStatus = "no-voting-power"
+	VotePolicyStatus_Voted                 VotePolicyStatus = "voted"
+)
+
+// The default amount of time before a phase ends that the policy stops waiting for a delegate to vote
+const defaultVotePolicyLeadTime time.Duration = 24 * time.Hour
+
+// The conditions a proposal must meet for a rule to apply to it; empty conditions match everything
+type VotePolicyMatch struct {
+	Types       []ProposalType `yaml:"types,omitempty"`
+	Settings    []string       `yaml:"settings,omitempty"`
+	Proposers   []string       `yaml:"proposers,omitempty"`
+	MinSpendRpl float64        `yaml:"minSpendRpl,omitempty"`
+	MaxSpendRpl float64        `yaml:"maxSpendRpl,omitempty"`
+}
+
+// A single rule of the voting policy
+type VotePolicyRule struct {
+	Name     string           `yaml:"name"`
+	Match    VotePolicyMatch  `yaml:"match"`
+	Action   VotePolicyAction `yaml:"action"`
+	Delegate string           `yaml:"delegate,omitempty"`
+}
+
+// A local voting policy for on-chain pDAO proposals.
+// Rules are evaluated in order and the first one that matches a proposal decides the vote. For example:
+//
+//	leadTime: 48h
+//	rules:
+//	  - name: small-spends
+//	    match:
+//	      types: [one-time-spend, recurring-spend]
+//	      maxSpendRpl: 1000
+//	    action: abstain
+//	  - name: deposit-pool
+//	    match:
+//	      settings: [deposit.pool.maximum]
+//	    action: against
+//	  - name: everything-else
+//	    action: follow-delegate
+//	    delegate: "0x..."
+type VotePolicy struct {
+	DryRun   bool             `yaml:"dryRun"`
+	LeadTime string           `yaml:"leadTime,omitempty"`
+	Rules    []VotePolicyRule `yaml:"rules"`
+
+	// Internal fields
+	leadTime time.Duration `yaml:"-"`
+}
+
+// The details of a proposal's payload that the voting policy can match against
+type ProposalPayloadInfo struct {
+	Method             string
+	Type               ProposalType
+	Settings           []string
+	SpendAmount        *big.Int
+	SettingChanges     []ProposalSettingChange
+	RewardsPercentages *ProposalRewardsPercentages
+}
+
+// A setting a proposal would change, and its new value
+type ProposalSettingChange struct {
+	Contract     string                    `json:"contract"`
+	Path         string                    `json:"path"`
+	Type         types.ProposalSettingType `json:"type"`
+	UintValue    *big.Int                  `json:"uintValue,omitempty"`
+	BoolValue    bool                      `json:"boolValue"`
+	AddressValue common.Address            `json:"addressValue"`
+}
+
+// The rewards percentages a proposal would set
+type ProposalRewardsPercentages struct {
+	OdaoPercent *big.Int `json:"odaoPercent"`
+	PdaoPercent *big.Int `json:"pdaoPercent"`
+	NodePercent *big.Int `json:"nodePercent"`
+}
+
+// The voting policy's decision on a proposal
+type VotePolicyDecision struct {
+	ProposalID  uint64                         `json:"proposalId"`
+	PolicyHash  string                         `json:"policyHash"`
+	State       types.ProtocolDaoProposalState `json:"state"`
+	Type        ProposalType                   `json:"type"`
+	Rule        string                         `json:"rule"`
+	Action      VotePolicyAction               `json:"action"`
+	Direction   types.VoteDirection            `json:"direction"`
+	Status      VotePolicyStatus               `json:"status"`
+	Reason      string                         `json:"reason"`
+	Deadline    time.Time                      `json:"deadline"`
+	UpdatedTime time.Time                      `json:"updatedTime"`
+	TxHash      common.Hash                    `json:"txHash"`
+}
+
+// The record of the voting policy's decisions, written by the node daemon
+type VotePolicyRecord struct {
+	Decisions map[uint64]*VotePolicyDecision `json:"decisions"`
+
+	// Internal fields
+	lock *sync.Mutex `json:"-"`
+}
+
+// The version of the voting policy that has been confirmed for live voting.
+// This is only written by `rocketpool pdao vote-policy confirm`, so the daemon saving its record can't overwrite it.
+type VotePolicyConfirmation struct {
+	PolicyHash    string    `json:"policyHash"`
+	ConfirmedTime time.Time `json:"confirmedTime"`
+}
+
+// Load a voting policy from disk, returning it along with the hash of its contents
+func LoadVotePolicy(path string) (*VotePolicy, string, error) {
+	bytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading voting policy from %s: %w", path, err)
+	}
+	hash := sha256.Sum256(bytes)
+
+	policy := new(VotePolicy)
+	if err := yaml.Unmarshal(bytes, policy); err != nil {
+		return nil, "", fmt.Errorf("error deserializing voting policy: %w", err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, "", fmt.Errorf("voting policy %s is invalid: %w", path, err)
+	}
+	return policy, hex.EncodeToString(hash[:]), nil
+}
+
+// Make sure the policy's rules are well-formed
+func (p *VotePolicy) validate() error {
+	p.leadTime = defaultVotePolicyLeadTime
+	if p.LeadTime != "" {
+		leadTime, err := time.ParseDuration(p.LeadTime)
+		if err != nil {
+			return fmt.Errorf("invalid lead time '%s': %w", p.LeadTime, err)
+		}
+		p.leadTime = leadTime
+	}
+
+	for i, rule := range p.Rules {
+		name := rule.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+			p.Rules[i].Name = name
+		}
+		switch rule.Action {
+		case VotePolicyAction_For, VotePolicyAction_Against, VotePolicyAction_Veto, VotePolicyAction_Abstain, VotePolicyAction_Skip:
+		case VotePolicyAction_FollowDelegate:
+			if !common.IsHexAddress(rule.Delegate) {
+				return fmt.Errorf("rule %s follows a delegate but '%s' is not a valid delegate address", name, rule.Delegate)
+			}
+		default:
+			return fmt.Errorf("rule %s has unknown action '%s'", name, rule.Action)
+		}
+		for _, proposalType := range rule.Match.Types {
+			switch proposalType {
+			case ProposalType_Setting, ProposalType_RewardsPercentages, ProposalType_OneTimeSpend, ProposalType_RecurringSpend, ProposalType_SecurityCouncil, ProposalType_Unknown:
+			default:
+				return fmt.Errorf("rule %s matches unknown proposal type '%s'", name, proposalType)
+			}
+		}
+		for _, proposer := range rule.Match.Proposers {
+			if !common.IsHexAddress(proposer) {
+				return fmt.Errorf("rule %s matches proposer '%s', which is not a valid address", name, proposer)
+			}
+		}
+	}
+	return nil
+}
+
+// Get the amount of time before a phase ends that the policy stops waiting for a delegate to vote
+func (p *VotePolicy) GetLeadTime() time.Duration {
+	return p.leadTime
+}
+
+// Get the first rule that matches the proposal, or nil if none of them do
+func (p *VotePolicy) Evaluate(proposer common.Address, info *ProposalPayloadInfo) *VotePolicyRule {
+	for i, rule := range p.Rules {
+		if rule.Match.matches(proposer, info) {
+			return &p.Rules[i]
+		}
+	}
+	return nil
+}
+
+// Check if a proposal meets all of the match conditions
+func (m *VotePolicyMatch) matches(proposer common.Address, info *ProposalPayloadInfo) bool {
+	if len(m.Types) > 0 {
+		found := false
+		for _, proposalType := range m.Types {
+			if proposalType == info.Type {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(m.Settings) > 0 {
+		found := false
+		for _, setting := range m.Settings {
+			for _, proposedSetting := range info.Settings {
+				if strings.EqualFold(setting, proposedSetting) {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if len(m.Proposers) > 0 {
+		found := false
+		for _, address := range m.Proposers {
+			if common.HexToAddress(address) == proposer {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	if m.MinSpendRpl > 0 || m.MaxSpendRpl > 0 {
+		if info.SpendAmount == nil {
+			return false
+		}
+		amount := eth.WeiToEth(info.SpendAmount)
+		if m.MinSpendRpl > 0 && amount < m.MinSpendRpl {
+			return false
+		}
+		if m.MaxSpendRpl > 0 && amount >= m.MaxSpendRpl {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Get the vote direction for a fixed voting policy action
+func (a VotePolicyAction) GetVoteDirection() types.VoteDirection {
+	switch a {
+	case VotePolicyAction_For:
+		return types.VoteDirection_For
+	case VotePolicyAction_Against:
+		return types.VoteDirection_Against
+	case VotePolicyAction_Veto:
+		return types.VoteDirection_AgainstWithVeto
+	case VotePolicyAction_Abstain:
+		return types.VoteDirection_Abstain
+	default:
+		return types.VoteDirection_NoVote
+	}
+}
+
+// Decode a pDAO proposal's payload into the details the voting policy can match against
+func GetProposalPayloadInfo(rp *rocketpool.RocketPool, payload []byte) (*ProposalPayloadInfo, error) {
+	info := &ProposalPayloadInfo{
+		Type:     ProposalType_Unknown,
+		Settings: []string{},
+	}
+
+	// Get the payload method and arguments
+	proposalsAbi, err := rp.GetABI("rocketDAOProtocolProposals", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error getting pDAO proposals contract ABI: %w", err)
+	}
+	if len(payload) < 4 {
+		return info, nil
+	}
+	method, err := proposalsAbi.MethodById(payload)
+	if err != nil {
+		return info, nil
+	}
+	info.Method = method.RawName
+	args, err := method.Inputs.UnpackValues(payload[4:])
+	if err != nil {
+		return nil, fmt.Errorf("error decoding %s payload arguments: %w", method.RawName, err)
+	}
+
+	// Classify the proposal
+	switch method.RawName {
+	case "proposalSettingUint", "proposalSettingBool", "proposalSettingAddress":
+		info.Type = ProposalType_Setting
+		info.Settings = append(info.Settings, getStringArg(args, 1))
+		change := ProposalSettingChange{
+			Contract: getStringArg(args, 0),
+			Path:     getStringArg(args, 1),
+		}
+		if len(args) > 2 {
+			switch value := args[2].(type) {
+			case *big.Int:
+				change.Type = types.ProposalSettingType_Uint256
+				change.UintValue = value
+			case bool:
+				change.Type = types.ProposalSettingType_Bool
+				change.BoolValue = value
+			case common.Address:
+				change.Type = types.ProposalSettingType_Address
+				change.AddressValue = value
+			}
+		}
+		info.SettingChanges = append(info.SettingChanges, change)
+	case "proposalSettingMulti":
+		info.Type = ProposalType_Setting
+		if len(args) > 3 {
+			contracts, _ := args[0].([]string)
+			paths, _ := args[1].([]string)
+			settingTypes, _ := args[2].([]uint8)
+			values, _ := args[3].([][]byte)
+			info.Settings = append(info.Settings, paths...)
+			if len(contracts) != len(paths) || len(settingTypes) != len(paths) || len(values) != len(paths) {
+				return nil, fmt.Errorf("proposalSettingMulti payload has mismatched argument lengths")
+			}
+			for i, path := range paths {
+				change := ProposalSettingChange{
+					Contract: contracts[i],
+					Path:     path,
+					Type:     types.ProposalSettingType(settingTypes[i]),
+				}
+				value := big.NewInt(0).SetBytes(values[i])
+				switch change.Type {
+				case types.ProposalSettingType_Uint256:
+					change.UintValue = value
+				case types.ProposalSettingType_Bool:
+					change.BoolValue = (value.Sign() != 0)
+				case types.ProposalSettingType_Address:
+					change.AddressValue = common.BytesToAddress(values[i])
+				}
+				info.SettingChanges = append(info.SettingChanges, change)
+			}
+		}
+	case "proposalSettingRewardsClaimers":
+		info.Type = ProposalType_RewardsPercentages
+		info.RewardsPercentages = &ProposalRewardsPercentages{
+			OdaoPercent: getBigIntArg(args, 0),
+			PdaoPercent: getBigIntArg(args, 1),
+			NodePercent: getBigIntArg(args, 2),
+		}
+	case "proposalTreasuryOneTimeSpend":
+		info.Type = ProposalType_OneTimeSpend
+		info.SpendAmount = getBigIntArg(args, 2)
+	case "proposalTreasuryNewContract", "proposalTreasuryUpdateContract":
+		info.Type = ProposalType_RecurringSpend
+		info.SpendAmount = getBigIntArg(args, 2)
+	case "proposalSecurityInvite", "proposalSecurityKick", "proposalSecurityKickMulti", "proposalSecurityReplace":
+		info.Type = ProposalType_SecurityCouncil
+	}
+	return info, nil
+}
+
+// Get the new value of a setting change as a string
+func (c *ProposalSettingChange) GetValueString() string {
+	switch c.Type {
+	case types.ProposalSettingType_Uint256:
+		if c.UintValue == nil {
+			return "0"
+		}
+		return c.UintValue.String()
+	case types.ProposalSettingType_Bool:
+		return fmt.Sprint(c.BoolValue)
+	case types.ProposalSettingType_Address:
+		return c.AddressValue.Hex()
+	}
+	return ""
+}
+
+// Get a string argument from a decoded payload
+func getStringArg(args []interface{}, index int) string {
+	if index >= len(args) {
+		return ""
+	}
+	value, _ := args[index].(string)
+	return value
+}
+
+// Get a big integer argument from a decoded payload
+func getBigIntArg(args []interface{}, index int) *big.Int {
+	if index >= len(args) {
+		return nil
+	}
+	value, _ := args[index].(*big.Int)
+	return value
+}
+
+// Create a new, empty voting policy record
+func NewVotePolicyRecord() *VotePolicyRecord {
+	return &VotePolicyRecord{
+		Decisions: map[uint64]*VotePolicyDecision{},
+		lock:      &sync.Mutex{},
+	}
+}
+
+// Load a voting policy record from disk, or create a new one if it doesn't exist yet
+func LoadVotePolicyRecord(path string) (*VotePolicyRecord, error) {
+	record := NewVotePolicyRecord()
+	if _, err := atomicfile.LoadJson(path, record); err != nil {
+		return nil, fmt.Errorf("error loading voting policy record: %w", err)
+	}
+	if record.Decisions == nil {
+		record.Decisions = map[uint64]*VotePolicyDecision{}
+	}
+	return record, nil
+}
+
+// Save the voting policy record to disk
+func (r *VotePolicyRecord) Save(path string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if err := atomicfile.SaveJson(path, r); err != nil {
+		return fmt.Errorf("error saving voting policy record: %w", err)
+	}
+	return nil
+}
+
+// Set the decision for a proposal
+func (r *VotePolicyRecord) SetDecision(decision *VotePolicyDecision) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.Decisions[decision.ProposalID] = decision
+}
+
+// Get the decision for a proposal, if there is one
+func (r *VotePolicyRecord) GetDecision(proposalID uint64) (*VotePolicyDecision, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	decision, exists := r.Decisions[proposalID]
+	return decision, exists
+}
+
+// Load the voting policy confirmation from disk, or create an empty one if the policy hasn't been confirmed yet
+func LoadVotePolicyConfirmation(path string) (*VotePolicyConfirmation, error) {
+	confirmation := &VotePolicyConfirmation{}
+	if _, err := atomicfile.LoadJson(path, confirmation); err != nil {
+		return nil, fmt.Errorf("error loading voting policy confirmation: %w", err)
+	}
+	return confirmation, nil
+}
+
+// Save the voting policy confirmation to disk
+func (c *VotePolicyConfirmation) Save(path string) error {
+	if err := atomicfile.SaveJson(path, c); err != nil {
+		return fmt.Errorf("error saving voting policy confirmation: %w", err)
+	}
+	return nil
+}
+
+// Check if the policy with the given hash has been confirmed for live voting
+func (c *VotePolicyConfirmation) IsConfirmed(policyHash string) bool {
+	return c.PolicyHash != "" && c.PolicyHash == policyHash
+}
@@ -0,0 +1,162 @@
+package proposals
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/rocket-pool/rocketpool-go/utils/eth"
+)
+
+const testVotePolicy string = `leadTime: 48h
+rules:
+  - name: small-spends
+    match:
+      types: [one-time-spend, recurring-spend]
+      maxSpendRpl: 1000
+    action: abstain
+  - name: trusted-proposer
+    match:
+      proposers: ["0x1111111111111111111111111111111111111111"]
+    action: for
+  - name: deposit-pool
+    match:
+      settings: [deposit.pool.maximum]
+    action: against
+  - name: big-spends
+    match:
+      minSpendRpl: 50000
+    action: veto
+  - name: everything-else
+    action: follow-delegate
+    delegate: "0x2222222222222222222222222222222222222222"
+`
+
+func TestVotePolicyEvaluate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pdao-vote-policy.yml")
+	if err := os.WriteFile(path, []byte(testVotePolicy), 0600); err != nil {
+		t.Fatal(err)
+	}
+	policy, hash, err := LoadVotePolicy(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash == "" {
+		t.Fatalf("expected a policy hash")
+	}
+	if policy.GetLeadTime().Hours() != 48 {
+		t.Fatalf("expected a lead time of 48h, got %s", policy.GetLeadTime())
+	}
+
+	trusted := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	other := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	tests := []struct {
+		name     string
+		proposer common.Address
+		info     *ProposalPayloadInfo
+		expected string
+	}{
+		{
+			name:     "small one-time spend",
+			proposer: other,
+			info:     &ProposalPayloadInfo{Type: ProposalType_OneTimeSpend, SpendAmount: eth.EthToWei(999)},
+			expected: "small-spends",
+		},
+		{
+			name:     "spend at the maximum is not small",
+			proposer: other,
+			info:     &ProposalPayloadInfo{Type: ProposalType_RecurringSpend, SpendAmount: eth.EthToWei(1000)},
+			expected: "everything-else",
+		},
+		{
+			name:     "spend from a trusted proposer",
+			proposer: trusted,
+			info:     &ProposalPayloadInfo{Type: ProposalType_OneTimeSpend, SpendAmount: eth.EthToWei(5000)},
+			expected: "trusted-proposer",
+		},
+		{
+			name:     "setting names are case insensitive",
+			proposer: other,
+			info:     &ProposalPayloadInfo{Type: ProposalType_Setting, Settings: []string{"deposit.fee", "Deposit.Pool.Maximum"}},
+			expected: "deposit-pool",
+		},
+		{
+			name:     "big spend",
+			proposer: other,
+			info:     &ProposalPayloadInfo{Type: ProposalType_OneTimeSpend, SpendAmount: eth.EthToWei(50000)},
+			expected: "big-spends",
+		},
+		{
+			name:     "spend rules don't match proposals without an amount",
+			proposer: other,
+			info:     &ProposalPayloadInfo{Type: ProposalType_SecurityCouncil},
+			expected: "everything-else",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rule := policy.Evaluate(test.proposer, test.info)
+			if rule == nil {
+				t.Fatalf("expected rule %s to match, but none did", test.expected)
+			}
+			if rule.Name != test.expected {
+				t.Fatalf("expected rule %s to match, got %s", test.expected, rule.Name)
+			}
+		})
+	}
+}
+
+func TestVotePolicyNoMatch(t *testing.T) {
+	policy := &VotePolicy{
+		Rules: []VotePolicyRule{
+			{Name: "settings", Match: VotePolicyMatch{Types: []ProposalType{ProposalType_Setting}}, Action: VotePolicyAction_For},
+		},
+	}
+	if err := policy.validate(); err != nil {
+		t.Fatal(err)
+	}
+	if rule := policy.Evaluate(common.Address{}, &ProposalPayloadInfo{Type: ProposalType_OneTimeSpend, SpendAmount: big.NewInt(1)}); rule != nil {
+		t.Fatalf("expected no rule to match, got %s", rule.Name)
+	}
+}
+
+func TestVotePolicyValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules []VotePolicyRule
+	}{
+		{
+			name:  "unknown action",
+			rules: []VotePolicyRule{{Name: "bad", Action: "maybe"}},
+		},
+		{
+			name:  "follow-delegate without a delegate",
+			rules: []VotePolicyRule{{Name: "bad", Action: VotePolicyAction_FollowDelegate}},
+		},
+		{
+			name:  "unknown proposal type",
+			rules: []VotePolicyRule{{Name: "bad", Match: VotePolicyMatch{Types: []ProposalType{"upgrade"}}, Action: VotePolicyAction_For}},
+		},
+		{
+			name:  "invalid proposer",
+			rules: []VotePolicyRule{{Name: "bad", Match: VotePolicyMatch{Proposers: []string{"node.eth"}}, Action: VotePolicyAction_For}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			policy := &VotePolicy{Rules: test.rules}
+			if err := policy.validate(); err == nil {
+				t.Fatalf("expected the policy to be rejected")
+			}
+		})
+	}
+
+	policy := &VotePolicy{LeadTime: "soon"}
+	if err := policy.validate(); err == nil {
+		t.Fatalf("expected an invalid lead time to be rejected")
+	}
+}
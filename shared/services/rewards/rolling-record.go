@@ -13,11 +13,6 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/state"
 	"github.com/rocket-pool/smartnode/shared/utils/log"
-	"golang.org/x/sync/errgroup"
-)
-
-const (
-	threadLimit int = 12
 )
 
 type RollingRecord struct {
@@ -311,32 +306,9 @@ func (r *RollingRecord) getDutiesForEpoch(epoch uint64, endSlot uint64, state *s
 func (r *RollingRecord) processAttestationsInEpoch(epoch uint64, state *state.NetworkState) error {
 
 	slotsPerEpoch := r.beaconConfig.SlotsPerEpoch
-	var wg errgroup.Group
-	wg.SetLimit(threadLimit)
-	attestationsPerSlot := make([][]beacon.AttestationInfo, r.beaconConfig.SlotsPerEpoch)
-
-	// Get the attestation records for this epoch
-	for i := uint64(0); i < slotsPerEpoch; i++ {
-		i := i
-		slot := epoch*slotsPerEpoch + i
-		wg.Go(func() error {
-			attestations, found, err := r.bc.GetAttestations(fmt.Sprint(slot))
-			if err != nil {
-				return fmt.Errorf("error getting attestations for slot %d: %w", slot, err)
-			}
-			if found {
-				attestationsPerSlot[i] = attestations
-			} else {
-				attestationsPerSlot[i] = []beacon.AttestationInfo{}
-			}
-
-			return nil
-		})
-	}
-
-	err := wg.Wait()
+	attestationsPerSlot, err := GetAttestationsForEpoch(r.bc, epoch, slotsPerEpoch)
 	if err != nil {
-		return fmt.Errorf("error getting attestation records for epoch %d: %w", epoch, err)
+		return err
 	}
 
 	// Process all of the slots in the epoch
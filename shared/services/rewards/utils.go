@@ -26,6 +26,11 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	threadLimit int = 12
 )
 
 // Simple container for the zero value so it doesn't have to be recreated over and over
@@ -149,6 +154,61 @@ func GetIntervalInfo(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, no
 	return
 }
 
+// Gets the arguments required to claim the node's rewards for the provided intervals
+func GetClaimDataForIntervals(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, nodeAddress common.Address, intervals []uint64) ([]*big.Int, []*big.Int, []*big.Int, [][]common.Hash, error) {
+
+	// Ignore duplicates
+	seenIndices := map[uint64]bool{}
+	indices := []*big.Int{}
+	for _, interval := range intervals {
+		_, exists := seenIndices[interval]
+		if !exists {
+			indices = append(indices, big.NewInt(0).SetUint64(interval))
+			seenIndices[interval] = true
+		}
+	}
+
+	// Read the tree files to get the details
+	amountRPL := []*big.Int{}
+	amountETH := []*big.Int{}
+	merkleProofs := [][]common.Hash{}
+
+	// Populate the interval info for each one
+	for _, index := range indices {
+
+		intervalInfo, err := GetIntervalInfo(rp, cfg, nodeAddress, index.Uint64(), nil)
+		if err != nil {
+			return nil, nil, nil, nil, err
+		}
+
+		// Validate
+		if !intervalInfo.TreeFileExists {
+			return nil, nil, nil, nil, fmt.Errorf("rewards tree file '%s' doesn't exist", intervalInfo.TreeFilePath)
+		}
+		if !intervalInfo.MerkleRootValid {
+			return nil, nil, nil, nil, fmt.Errorf("merkle root for rewards tree file '%s' doesn't match the canonical merkle root for interval %d", intervalInfo.TreeFilePath, index.Uint64())
+		}
+
+		// Get the rewards from it
+		if intervalInfo.NodeExists {
+			rplForInterval := big.NewInt(0)
+			rplForInterval.Add(rplForInterval, &intervalInfo.CollateralRplAmount.Int)
+			rplForInterval.Add(rplForInterval, &intervalInfo.ODaoRplAmount.Int)
+
+			ethForInterval := big.NewInt(0)
+			ethForInterval.Add(ethForInterval, &intervalInfo.SmoothingPoolEthAmount.Int)
+
+			amountRPL = append(amountRPL, rplForInterval)
+			amountETH = append(amountETH, ethForInterval)
+			merkleProofs = append(merkleProofs, intervalInfo.MerkleProof)
+		}
+	}
+
+	// Return
+	return indices, amountRPL, amountETH, merkleProofs, nil
+
+}
+
 // Get the event for a rewards snapshot
 func GetRewardSnapshotEvent(rp *rocketpool.RocketPool, cfg *config.RocketPoolConfig, interval uint64, opts *bind.CallOpts) (rewards.RewardsEvent, error) {
 
@@ -243,6 +303,38 @@ func GetELBlockHeaderForTime(targetTime time.Time, rp *rocketpool.RocketPool) (*
 	}
 }
 
+// Get the attestations included in each slot of the given epoch, fetching the slots in parallel
+func GetAttestationsForEpoch(bc beacon.Client, epoch uint64, slotsPerEpoch uint64) ([][]beacon.AttestationInfo, error) {
+	var wg errgroup.Group
+	wg.SetLimit(threadLimit)
+	attestationsPerSlot := make([][]beacon.AttestationInfo, slotsPerEpoch)
+
+	// Get the attestation records for this epoch
+	for i := uint64(0); i < slotsPerEpoch; i++ {
+		i := i
+		slot := epoch*slotsPerEpoch + i
+		wg.Go(func() error {
+			attestations, found, err := bc.GetAttestations(fmt.Sprint(slot))
+			if err != nil {
+				return fmt.Errorf("error getting attestations for slot %d: %w", slot, err)
+			}
+			if found {
+				attestationsPerSlot[i] = attestations
+			} else {
+				attestationsPerSlot[i] = []beacon.AttestationInfo{}
+			}
+
+			return nil
+		})
+	}
+
+	err := wg.Wait()
+	if err != nil {
+		return nil, fmt.Errorf("error getting attestation records for epoch %d: %w", epoch, err)
+	}
+	return attestationsPerSlot, nil
+}
+
 // Downloads the rewards file for this interval
 func (i *IntervalInfo) DownloadRewardsFile(cfg *config.RocketPoolConfig, isDaemon bool) error {
 	interval := i.Index
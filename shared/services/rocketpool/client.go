@@ -26,6 +26,7 @@ import (
 	"github.com/mitchellh/go-homedir"
 	"github.com/rocket-pool/smartnode/addons/graffiti_wall_writer"
 	"github.com/rocket-pool/smartnode/shared/services/config"
+	"github.com/rocket-pool/smartnode/shared/services/graffiti"
 	"github.com/rocket-pool/smartnode/shared/services/rocketpool/template"
 	"github.com/rocket-pool/smartnode/shared/types/api"
 	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
@@ -254,6 +255,42 @@ func (c *Client) SaveConfig(cfg *config.RocketPoolConfig) error {
 	return rp.SaveConfig(cfg, settingsFileDirectoryPath, SettingsFile)
 }
 
+// Save serialized settings as-is, without loading them into a config first
+func (c *Client) SaveSettings(settings map[string]map[string]string) error {
+	settingsFileDirectoryPath, err := homedir.Expand(c.configPath)
+	if err != nil {
+		return err
+	}
+	return rp.SaveSettings(settings, settingsFileDirectoryPath, SettingsFile)
+}
+
+// Get the path of the settings file
+func (c *Client) SettingsFilePath() (string, error) {
+	expandedPath, err := homedir.Expand(filepath.Join(c.configPath, SettingsFile))
+	if err != nil {
+		return "", fmt.Errorf("error expanding settings file path: %w", err)
+	}
+	return expandedPath, nil
+}
+
+// Get the versioned backups of the settings file, newest first
+func (c *Client) GetConfigBackups() ([]rp.SettingsBackup, error) {
+	settingsFileDirectoryPath, err := homedir.Expand(c.configPath)
+	if err != nil {
+		return nil, err
+	}
+	return rp.GetSettingsBackups(settingsFileDirectoryPath, SettingsFile)
+}
+
+// Replace the settings file with one of its versioned backups
+func (c *Client) RestoreConfigBackup(backupName string) error {
+	settingsFileDirectoryPath, err := homedir.Expand(c.configPath)
+	if err != nil {
+		return err
+	}
+	return rp.RestoreSettingsBackup(settingsFileDirectoryPath, SettingsFile, backupName)
+}
+
 // Remove the upgrade flag file
 func (c *Client) RemoveUpgradeFlagFile() error {
 	expandedPath, err := homedir.Expand(c.configPath)
@@ -274,12 +311,17 @@ func (c *Client) IsFirstRun() (bool, error) {
 
 // Load the Prometheus template, do an template variable substitution, and save it
 func (c *Client) UpdatePrometheusConfiguration(config *config.RocketPoolConfig) error {
+	return c.RenderPrometheusConfiguration(config, c.configPath)
+}
+
+// Load the Prometheus template, do an template variable substitution, and save it in outPath
+func (c *Client) RenderPrometheusConfiguration(config *config.RocketPoolConfig, outPath string) error {
 	prometheusTemplatePath, err := homedir.Expand(fmt.Sprintf("%s/%s", c.configPath, PrometheusConfigTemplate))
 	if err != nil {
 		return fmt.Errorf("Error expanding Prometheus template path: %w", err)
 	}
 
-	prometheusConfigPath, err := homedir.Expand(fmt.Sprintf("%s/%s", c.configPath, PrometheusFile))
+	prometheusConfigPath, err := homedir.Expand(fmt.Sprintf("%s/%s", outPath, PrometheusFile))
 	if err != nil {
 		return fmt.Errorf("Error expanding Prometheus config file path: %w", err)
 	}
@@ -485,6 +527,15 @@ func (c *Client) StartService(composeFiles []string) error {
 	return c.printOutput(cmd)
 }
 
+// Create or recreate the Rocket Pool service's containers for the current config without starting them
+func (c *Client) CreateService(composeFiles []string) error {
+	cmd, err := c.compose(composeFiles, "up --no-start --remove-orphans --quiet-pull")
+	if err != nil {
+		return err
+	}
+	return c.printOutput(cmd)
+}
+
 // Pause the Rocket Pool service
 func (c *Client) PauseService(composeFiles []string) error {
 	cmd, err := c.compose(composeFiles, "stop")
@@ -779,6 +830,53 @@ func (c *Client) GetComposeImages(composeFiles []string) ([]string, error) {
 	return strings.Fields(string(output)), nil
 }
 
+// Get the names of the containers in the compose project, including stopped ones
+func (c *Client) GetComposeContainers(composeFiles []string) ([]string, error) {
+	cmd, err := c.compose(composeFiles, "ps -a -q")
+	if err != nil {
+		return nil, err
+	}
+	output, err := c.readOutput(cmd)
+	if err != nil {
+		return nil, err
+	}
+	containerIds := strings.Fields(string(output))
+	if len(containerIds) == 0 {
+		return []string{}, nil
+	}
+
+	// Resolve the IDs to names
+	output, err = c.readOutput(fmt.Sprintf("docker container inspect --format={{.Name}} %s", strings.Join(containerIds, " ")))
+	if err != nil {
+		return nil, err
+	}
+	containers := []string{}
+	for _, name := range strings.Fields(string(output)) {
+		containers = append(containers, strings.TrimPrefix(name, "/"))
+	}
+	return containers, nil
+}
+
+// Get the most recent log lines of the given container, with timestamps
+func (c *Client) GetContainerLogs(container string, tail string) ([]byte, error) {
+	cmd := fmt.Sprintf("docker logs --timestamps --tail %s %s 2>&1", shellescape.Quote(tail), shellescape.Quote(container))
+	return c.readOutput(cmd)
+}
+
+// Get the repository digests of the given Docker image
+func (c *Client) GetDockerImageDigests(image string) ([]string, error) {
+	cmd := fmt.Sprintf("docker image inspect --format='{{json .RepoDigests}}' %s", shellescape.Quote(image))
+	output, err := c.readOutput(cmd)
+	if err != nil {
+		return nil, err
+	}
+	var digests []string
+	if err := json.Unmarshal(output, &digests); err != nil {
+		return nil, fmt.Errorf("could not decode digests of image %s: %w", image, err)
+	}
+	return digests, nil
+}
+
 type DockerImage struct {
 	Repository string `json:"Repository"`
 	Tag        string `json:"Tag"`
@@ -1109,6 +1207,51 @@ func (c *Client) compose(composeFiles []string, args string) (string, error) {
 
 }
 
+// Renders all of the templates that `service start` deploys for the provided configuration into outDir, using the same
+// layout as the Rocket Pool directory, without touching the deployed files. Returns the compose files that make up the
+// project, including the overrides.
+func (c *Client) RenderTemplates(cfg *config.RocketPoolConfig, outDir string) ([]string, error) {
+
+	// Cancel if running in non-docker mode
+	if c.daemonPath != "" {
+		return nil, errors.New("command unavailable in Native Mode (with '--daemon-path' option specified)")
+	}
+
+	// Check for the folders
+	expandedConfigPath, err := homedir.Expand(c.configPath)
+	if err != nil {
+		return nil, err
+	}
+	templatesFolder := filepath.Join(expandedConfigPath, templatesDir)
+	_, err = os.Stat(templatesFolder)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("templates folder [%s] does not exist", templatesFolder)
+	}
+
+	// Render the compose files
+	composeFiles, err := c.renderComposeTemplates(cfg, expandedConfigPath, filepath.Join(outDir, runtimeDir))
+	if err != nil {
+		return nil, err
+	}
+
+	// Render the metrics and alerting configuration
+	if cfg.EnableMetrics.Value == true {
+		err = c.RenderPrometheusConfiguration(cfg, outDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.Alertmanager.EnableAlerting.Value == true {
+		err = cfg.Alertmanager.RenderConfigurationFiles(expandedConfigPath, outDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return composeFiles, nil
+
+}
+
 // Deploys all of the appropriate docker compose template files and provisions them based on the provided configuration
 func (c *Client) deployTemplates(cfg *config.RocketPoolConfig, rocketpoolDir string) ([]string, error) {
 
@@ -1135,10 +1278,86 @@ func (c *Client) deployTemplates(cfg *config.RocketPoolConfig, rocketpoolDir str
 		return []string{}, fmt.Errorf("error creating runtime folder [%s]: %w", runtimeFolder, err)
 	}
 
+	// Read and substitute the templates
+	deployedContainers, err := c.renderComposeTemplates(cfg, rocketpoolDir, runtimeFolder)
+	if err != nil {
+		return []string{}, err
+	}
+
+	// Create the custom keys dir
+	customKeyDir, err := homedir.Expand(filepath.Join(cfg.Smartnode.DataPath.Value.(string), "custom-keys"))
+	if err != nil {
+		fmt.Printf("%sWARNING: Couldn't expand the custom validator key directory (%s). You will not be able to recover any minipool keys you created outside of the Smart Node until you create the folder manually.%s\n", colorYellow, err.Error(), colorReset)
+		return deployedContainers, nil
+	}
+	err = os.MkdirAll(customKeyDir, 0775)
+	if err != nil {
+		fmt.Printf("%sWARNING: Couldn't create the custom validator key directory (%s). You will not be able to recover any minipool keys you created outside of the Smart Node until you create the folder [%s] manually.%s\n", colorYellow, err.Error(), customKeyDir, colorReset)
+	}
+
+	// Create the rewards file dir
+	rewardsFilePath, err := homedir.Expand(cfg.Smartnode.GetRewardsTreePath(0, false))
+	if err != nil {
+		fmt.Printf("%sWARNING: Couldn't expand the rewards tree file directory (%s). You will not be able to view or claim your rewards until you create the folder manually.%s\n", colorYellow, err.Error(), colorReset)
+		return deployedContainers, nil
+	}
+	rewardsFileDir := filepath.Dir(rewardsFilePath)
+	err = os.MkdirAll(rewardsFileDir, 0775)
+	if err != nil {
+		fmt.Printf("%sWARNING: Couldn't create the rewards tree file directory (%s). You will not be able to view or claim your rewards until you create the folder [%s] manually.%s\n", colorYellow, err.Error(), rewardsFileDir, colorReset)
+	}
+
+	// Create the graffiti file so the validator client can start before the node daemon renders the template
+	if cfg.GetGraffitiProvider() == config.GraffitiProvider_Template {
+		err = createInitialGraffitiFile(cfg)
+		if err != nil {
+			fmt.Printf("%sWARNING: Couldn't create the graffiti file (%s). Your validator client may not start until the node daemon creates it.%s\n", colorYellow, err.Error(), colorReset)
+		}
+	}
+
+	return deployedContainers, nil
+
+}
+
+// Write a graffiti file with the static graffiti if there isn't one yet
+func createInitialGraffitiFile(cfg *config.RocketPoolConfig) error {
+	path, err := homedir.Expand(cfg.Smartnode.GetGraffitiFilePath(false))
+	if err != nil {
+		return err
+	}
+	_, err = os.Stat(path)
+	if err == nil {
+		return nil
+	}
+	if !os.IsNotExist(err) {
+		return err
+	}
+
+	staticGraffiti, err := cfg.Graffiti()
+	if err != nil {
+		return err
+	}
+	cc, _ := cfg.GetSelectedConsensusClient()
+	contents, err := graffiti.FormatFile(cc, staticGraffiti, nil)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0775)
+	if err != nil {
+		return err
+	}
+	_, err = graffiti.WriteFile(path, contents)
+	return err
+}
+
+// Renders the docker compose templates for the provided configuration into the runtime folder, returning the compose
+// files (including the overrides) that make up the project
+func (c *Client) renderComposeTemplates(cfg *config.RocketPoolConfig, rocketpoolDir string, runtimeFolder string) ([]string, error) {
+
 	composePaths := template.ComposePaths{
 		RuntimePath:  runtimeFolder,
-		TemplatePath: templatesFolder,
-		OverridePath: overrideFolder,
+		TemplatePath: filepath.Join(rocketpoolDir, templatesDir),
+		OverridePath: filepath.Join(rocketpoolDir, overrideDir),
 	}
 
 	// Read and substitute the templates
@@ -1191,41 +1410,18 @@ func (c *Client) deployTemplates(cfg *config.RocketPoolConfig, rocketpoolDir str
 		deployedContainers = append(deployedContainers, containers...)
 	}
 
-	// Create the custom keys dir
-	customKeyDir, err := homedir.Expand(filepath.Join(cfg.Smartnode.DataPath.Value.(string), "custom-keys"))
-	if err != nil {
-		fmt.Printf("%sWARNING: Couldn't expand the custom validator key directory (%s). You will not be able to recover any minipool keys you created outside of the Smart Node until you create the folder manually.%s\n", colorYellow, err.Error(), colorReset)
-		return deployedContainers, nil
-	}
-	err = os.MkdirAll(customKeyDir, 0775)
-	if err != nil {
-		fmt.Printf("%sWARNING: Couldn't create the custom validator key directory (%s). You will not be able to recover any minipool keys you created outside of the Smart Node until you create the folder [%s] manually.%s\n", colorYellow, err.Error(), customKeyDir, colorReset)
-	}
-
-	// Create the rewards file dir
-	rewardsFilePath, err := homedir.Expand(cfg.Smartnode.GetRewardsTreePath(0, false))
-	if err != nil {
-		fmt.Printf("%sWARNING: Couldn't expand the rewards tree file directory (%s). You will not be able to view or claim your rewards until you create the folder manually.%s\n", colorYellow, err.Error(), colorReset)
-		return deployedContainers, nil
-	}
-	rewardsFileDir := filepath.Dir(rewardsFilePath)
-	err = os.MkdirAll(rewardsFileDir, 0775)
-	if err != nil {
-		fmt.Printf("%sWARNING: Couldn't create the rewards tree file directory (%s). You will not be able to view or claim your rewards until you create the folder [%s] manually.%s\n", colorYellow, err.Error(), rewardsFileDir, colorReset)
-	}
-
-	return c.composeAddons(cfg, rocketpoolDir, deployedContainers)
+	return c.composeAddons(cfg, rocketpoolDir, runtimeFolder, deployedContainers)
 
 }
 
 // Handle composing for addons
-func (c *Client) composeAddons(cfg *config.RocketPoolConfig, rocketpoolDir string, deployedContainers []string) ([]string, error) {
+func (c *Client) composeAddons(cfg *config.RocketPoolConfig, rocketpoolDir string, runtimeFolder string, deployedContainers []string) ([]string, error) {
 
 	// GWW
 	if cfg.GraffitiWallWriter.GetEnabledParameter().Value == true {
 
 		composePaths := template.ComposePaths{
-			RuntimePath:  filepath.Join(rocketpoolDir, runtimeDir, "addons", "gww"),
+			RuntimePath:  filepath.Join(runtimeFolder, "addons", "gww"),
 			TemplatePath: filepath.Join(rocketpoolDir, templatesDir, "addons", "gww"),
 			OverridePath: filepath.Join(rocketpoolDir, overrideDir, "addons", "gww"),
 		}
@@ -1243,6 +1439,39 @@ func (c *Client) composeAddons(cfg *config.RocketPoolConfig, rocketpoolDir strin
 		deployedContainers = append(deployedContainers, containers...)
 	}
 
+	// User addons
+	for _, addon := range cfg.GetEnabledUserAddons() {
+
+		composePaths := template.ComposePaths{
+			RuntimePath:  filepath.Join(runtimeFolder, "addons", addon.GetID()),
+			TemplatePath: addon.GetPath(),
+			OverridePath: filepath.Join(rocketpoolDir, overrideDir, "addons", addon.GetID()),
+		}
+
+		// Make the addon folder
+		err := os.MkdirAll(composePaths.RuntimePath, 0775)
+		if err != nil {
+			return []string{}, fmt.Errorf("error creating addon runtime folder (%s): %w", composePaths.RuntimePath, err)
+		}
+
+		data := config.UserAddonTemplateData{
+			RocketPoolConfig: cfg,
+			Addon:            addon,
+		}
+		containers, err := composePaths.File(addon.GetComposeTemplateName()).Write(data)
+		if err != nil {
+			return []string{}, fmt.Errorf("could not create %s container definition: %w", addon.GetName(), err)
+		}
+
+		// The installers don't know about user addons, so they only have an override file if the user made one
+		for _, container := range containers {
+			_, err = os.Stat(container)
+			if err == nil {
+				deployedContainers = append(deployedContainers, container)
+			}
+		}
+	}
+
 	return deployedContainers, nil
 
 }
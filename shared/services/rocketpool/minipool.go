@@ -56,6 +56,62 @@ func (c *Client) MinipoolStatus() (api.MinipoolStatusResponse, error) {
 	return response, nil
 }
 
+// Get the lifecycle history of a minipool
+func (c *Client) MinipoolHistory(address common.Address) (api.MinipoolHistoryResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool history %s", address.Hex()))
+	if err != nil {
+		return api.MinipoolHistoryResponse{}, fmt.Errorf("Could not get minipool history: %w", err)
+	}
+	var response api.MinipoolHistoryResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolHistoryResponse{}, fmt.Errorf("Could not decode minipool history response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolHistoryResponse{}, fmt.Errorf("Could not get minipool history: %s", response.Error)
+	}
+	if response.TotalGasCost == nil {
+		response.TotalGasCost = big.NewInt(0)
+	}
+	return response, nil
+}
+
+// Get the tracked performance of the node's validators over a time window
+func (c *Client) MinipoolPerformance(window string) (api.MinipoolPerformanceResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("minipool performance %s", window))
+	if err != nil {
+		return api.MinipoolPerformanceResponse{}, fmt.Errorf("Could not get minipool performance: %w", err)
+	}
+	var response api.MinipoolPerformanceResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolPerformanceResponse{}, fmt.Errorf("Could not decode minipool performance response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolPerformanceResponse{}, fmt.Errorf("Could not get minipool performance: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the fee recipient audits of the blocks proposed by the node's validators
+func (c *Client) MinipoolProposals() (api.MinipoolProposalsResponse, error) {
+	responseBytes, err := c.callAPI("minipool proposals")
+	if err != nil {
+		return api.MinipoolProposalsResponse{}, fmt.Errorf("Could not get minipool proposals: %w", err)
+	}
+	var response api.MinipoolProposalsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.MinipoolProposalsResponse{}, fmt.Errorf("Could not decode minipool proposals response: %w", err)
+	}
+	if response.Error != "" {
+		return api.MinipoolProposalsResponse{}, fmt.Errorf("Could not get minipool proposals: %s", response.Error)
+	}
+	for i := range response.Proposals {
+		if response.Proposals[i].Reward == nil {
+			response.Proposals[i].Reward = big.NewInt(0)
+		}
+	}
+	return response, nil
+}
+
 // Check whether a minipool is eligible for a refund
 func (c *Client) CanRefundMinipool(address common.Address) (api.CanRefundMinipoolResponse, error) {
 	responseBytes, err := c.callAPI(fmt.Sprintf("minipool can-refund %s", address.Hex()))
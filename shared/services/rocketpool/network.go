@@ -160,3 +160,19 @@ func (c *Client) GetLatestDelegate() (api.GetLatestDelegateResponse, error) {
 	}
 	return response, nil
 }
+
+// Get the current head of the Beacon Chain
+func (c *Client) BeaconHead() (api.BeaconHeadResponse, error) {
+	responseBytes, err := c.callAPI("network beacon-head")
+	if err != nil {
+		return api.BeaconHeadResponse{}, fmt.Errorf("could not get Beacon head: %w", err)
+	}
+	var response api.BeaconHeadResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.BeaconHeadResponse{}, fmt.Errorf("could not decode beacon-head response: %w", err)
+	}
+	if response.Error != "" {
+		return api.BeaconHeadResponse{}, fmt.Errorf("could not get Beacon head: %s", response.Error)
+	}
+	return response, nil
+}
@@ -1175,3 +1175,19 @@ func (c *Client) SendMessage(address common.Address, message []byte) (api.NodeSe
 	}
 	return response, nil
 }
+
+// Get the automatic transactions the node daemon is holding back because of the gas price
+func (c *Client) NodeAutoTxDelays() (api.NodeAutoTxDelaysResponse, error) {
+	responseBytes, err := c.callAPI("node auto-tx-delays")
+	if err != nil {
+		return api.NodeAutoTxDelaysResponse{}, fmt.Errorf("Could not get automatic transaction delays: %w", err)
+	}
+	var response api.NodeAutoTxDelaysResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.NodeAutoTxDelaysResponse{}, fmt.Errorf("Could not decode automatic transaction delays response: %w", err)
+	}
+	if response.Error != "" {
+		return api.NodeAutoTxDelaysResponse{}, fmt.Errorf("Could not get automatic transaction delays: %s", response.Error)
+	}
+	return response, nil
+}
@@ -27,6 +27,22 @@ func (c *Client) TNDAOStatus() (api.TNDAOStatusResponse, error) {
 	return response, nil
 }
 
+// Get the record of the node's oracle DAO duties
+func (c *Client) TNDAODuties(limit uint64) (api.TNDAODutiesResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("odao duties %d", limit))
+	if err != nil {
+		return api.TNDAODutiesResponse{}, fmt.Errorf("Could not get oracle DAO duties: %w", err)
+	}
+	var response api.TNDAODutiesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.TNDAODutiesResponse{}, fmt.Errorf("Could not decode oracle DAO duties response: %w", err)
+	}
+	if response.Error != "" {
+		return api.TNDAODutiesResponse{}, fmt.Errorf("Could not get oracle DAO duties: %s", response.Error)
+	}
+	return response, nil
+}
+
 // Get oracle DAO members
 func (c *Client) TNDAOMembers() (api.TNDAOMembersResponse, error) {
 	responseBytes, err := c.callAPI("odao members")
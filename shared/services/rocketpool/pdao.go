@@ -798,3 +798,147 @@ func (c *Client) PDAOStatus() (api.PDAOStatusResponse, error) {
 	}
 	return response, nil
 }
+
+// Get the status of the local voting policy and its decisions
+func (c *Client) PDAOVotePolicyStatus() (api.PDAOVotePolicyStatusResponse, error) {
+	responseBytes, err := c.callAPI("pdao vote-policy-status")
+	if err != nil {
+		return api.PDAOVotePolicyStatusResponse{}, fmt.Errorf("Could not get voting policy status: %w", err)
+	}
+	var response api.PDAOVotePolicyStatusResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PDAOVotePolicyStatusResponse{}, fmt.Errorf("Could not decode voting policy status response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PDAOVotePolicyStatusResponse{}, fmt.Errorf("Could not get voting policy status: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Confirm the local voting policy with the given hash so the node will start casting votes with it
+func (c *Client) PDAOConfirmVotePolicy(policyHash string) (api.PDAOConfirmVotePolicyResponse, error) {
+	responseBytes, err := c.callAPI("pdao confirm-vote-policy", policyHash)
+	if err != nil {
+		return api.PDAOConfirmVotePolicyResponse{}, fmt.Errorf("Could not confirm voting policy: %w", err)
+	}
+	var response api.PDAOConfirmVotePolicyResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PDAOConfirmVotePolicyResponse{}, fmt.Errorf("Could not decode confirm voting policy response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PDAOConfirmVotePolicyResponse{}, fmt.Errorf("Could not confirm voting policy: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get a digest of the governance activity relevant to the node
+func (c *Client) PDAODigest() (api.PDAODigestResponse, error) {
+	responseBytes, err := c.callAPI("pdao digest")
+	if err != nil {
+		return api.PDAODigestResponse{}, fmt.Errorf("Could not get governance digest: %w", err)
+	}
+	var response api.PDAODigestResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PDAODigestResponse{}, fmt.Errorf("Could not decode governance digest response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PDAODigestResponse{}, fmt.Errorf("Could not get governance digest: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the node's record of root submissions, challenges, and bonds
+func (c *Client) PDAOChallenges() (api.PDAOChallengesResponse, error) {
+	responseBytes, err := c.callAPI("pdao challenges")
+	if err != nil {
+		return api.PDAOChallengesResponse{}, fmt.Errorf("Could not get challenges: %w", err)
+	}
+	var response api.PDAOChallengesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PDAOChallengesResponse{}, fmt.Errorf("Could not decode challenges response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PDAOChallengesResponse{}, fmt.Errorf("Could not get challenges: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Get the top voting delegates and their on-chain voting history
+func (c *Client) PDAODelegates(limit uint64) (api.PDAODelegatesResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao delegates %d", limit))
+	if err != nil {
+		return api.PDAODelegatesResponse{}, fmt.Errorf("Could not get delegates: %w", err)
+	}
+	var response api.PDAODelegatesResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PDAODelegatesResponse{}, fmt.Errorf("Could not decode delegates response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PDAODelegatesResponse{}, fmt.Errorf("Could not get delegates: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Export the voting artifacts for a block into the node's data folder
+func (c *Client) PDAOExportVotingArtifacts(blockNumber uint32) (api.PDAOExportVotingArtifactsResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao export-voting-artifacts %d", blockNumber))
+	if err != nil {
+		return api.PDAOExportVotingArtifactsResponse{}, fmt.Errorf("Could not export voting artifacts: %w", err)
+	}
+	var response api.PDAOExportVotingArtifactsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PDAOExportVotingArtifactsResponse{}, fmt.Errorf("Could not decode export voting artifacts response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PDAOExportVotingArtifactsResponse{}, fmt.Errorf("Could not export voting artifacts: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Verify and import the voting artifact bundle in the node's import path
+func (c *Client) PDAOImportVotingArtifacts(rootHash string) (api.PDAOImportVotingArtifactsResponse, error) {
+	responseBytes, err := c.callAPI("pdao import-voting-artifacts --root", rootHash)
+	if err != nil {
+		return api.PDAOImportVotingArtifactsResponse{}, fmt.Errorf("Could not import voting artifacts: %w", err)
+	}
+	var response api.PDAOImportVotingArtifactsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PDAOImportVotingArtifactsResponse{}, fmt.Errorf("Could not decode import voting artifacts response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PDAOImportVotingArtifactsResponse{}, fmt.Errorf("Could not import voting artifacts: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Simulate the effects of a proposal against the current network state
+func (c *Client) PDAOSimulateProposal(proposalID uint64) (api.PDAOSimulateProposalResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("pdao simulate-proposal %d", proposalID))
+	if err != nil {
+		return api.PDAOSimulateProposalResponse{}, fmt.Errorf("Could not simulate proposal: %w", err)
+	}
+	var response api.PDAOSimulateProposalResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PDAOSimulateProposalResponse{}, fmt.Errorf("Could not decode simulate proposal response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PDAOSimulateProposalResponse{}, fmt.Errorf("Could not simulate proposal: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Simulate the effects of a draft setting change against the current network state
+func (c *Client) PDAOSimulateSetting(contractName string, settingName string, value string) (api.PDAOSimulateProposalResponse, error) {
+	responseBytes, err := c.callAPI("pdao simulate-setting", contractName, settingName, value)
+	if err != nil {
+		return api.PDAOSimulateProposalResponse{}, fmt.Errorf("Could not simulate setting change: %w", err)
+	}
+	var response api.PDAOSimulateProposalResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.PDAOSimulateProposalResponse{}, fmt.Errorf("Could not decode simulate setting change response: %w", err)
+	}
+	if response.Error != "" {
+		return api.PDAOSimulateProposalResponse{}, fmt.Errorf("Could not simulate setting change: %s", response.Error)
+	}
+	return response, nil
+}
@@ -3,6 +3,7 @@ package rocketpool
 import (
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/goccy/go-json"
 
 	"github.com/rocket-pool/smartnode/shared/types/api"
@@ -56,3 +57,68 @@ func (c *Client) RestartVc() (api.RestartVcResponse, error) {
 	}
 	return response, nil
 }
+
+// Runs the service doctor checks that need the node's clients and data folder
+func (c *Client) RunDoctor() (api.ServiceDoctorResponse, error) {
+	responseBytes, err := c.callAPI("service doctor")
+	if err != nil {
+		return api.ServiceDoctorResponse{}, fmt.Errorf("Could not run service doctor: %w", err)
+	}
+	var response api.ServiceDoctorResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ServiceDoctorResponse{}, fmt.Errorf("Could not decode service doctor response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ServiceDoctorResponse{}, fmt.Errorf("Could not run service doctor: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Creates an encrypted backup of the node and saves it to the configured destination. If includeSlashingProtection is
+// set, the slashing protection data must already be exported to the config's backup slashing protection path.
+func (c *Client) CreateBackup(includeSlashingProtection bool) (api.CreateBackupResponse, error) {
+	responseBytes, err := c.callAPI(fmt.Sprintf("service create-backup %t", includeSlashingProtection))
+	if err != nil {
+		return api.CreateBackupResponse{}, fmt.Errorf("Could not create backup: %w", err)
+	}
+	var response api.CreateBackupResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.CreateBackupResponse{}, fmt.Errorf("Could not decode create-backup response: %w", err)
+	}
+	if response.Error != "" {
+		return api.CreateBackupResponse{}, fmt.Errorf("Could not create backup: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Lists the backups in the configured destination
+func (c *Client) ListBackups() (api.ListBackupsResponse, error) {
+	responseBytes, err := c.callAPI("service list-backups")
+	if err != nil {
+		return api.ListBackupsResponse{}, fmt.Errorf("Could not list backups: %w", err)
+	}
+	var response api.ListBackupsResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ListBackupsResponse{}, fmt.Errorf("Could not decode list-backups response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ListBackupsResponse{}, fmt.Errorf("Could not list backups: %s", response.Error)
+	}
+	return response, nil
+}
+
+// Restores a backup into the data folder if its wallet is for the expected node address
+func (c *Client) RestoreBackup(name string, expectedAddress common.Address) (api.RestoreBackupResponse, error) {
+	responseBytes, err := c.callAPI("service restore-backup", name, expectedAddress.Hex())
+	if err != nil {
+		return api.RestoreBackupResponse{}, fmt.Errorf("Could not restore backup: %w", err)
+	}
+	var response api.RestoreBackupResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.RestoreBackupResponse{}, fmt.Errorf("Could not decode restore-backup response: %w", err)
+	}
+	if response.Error != "" {
+		return api.RestoreBackupResponse{}, fmt.Errorf("Could not restore backup: %s", response.Error)
+	}
+	return response, nil
+}
@@ -0,0 +1,230 @@
+package rocketpool
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alessio/shellescape"
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/rocket-pool/smartnode/shared/services/config"
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lodestar"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/nimbus"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/prysm"
+	"github.com/rocket-pool/smartnode/shared/services/wallet/keystore/teku"
+	cfgtypes "github.com/rocket-pool/smartnode/shared/types/config"
+)
+
+const (
+	// The folder the validator keychain is mounted to in the slashing protection container
+	slashingProtectionValidatorsPath string = "/validators"
+
+	// The folder the interchange file is mounted to in the slashing protection container
+	slashingProtectionExportPath string = "/export"
+)
+
+// Builds the command to export or import a client's slashing protection database
+type slashingProtectionCommandBuilder func(validatorsPath string, file string, network string, beaconNodeUrl string) keystore.SlashingProtectionCommand
+
+// Exports the slashing protection database of the selected validator client in the EIP-3076 interchange format.
+// The validator client must be stopped before calling this.
+func (c *Client) ExportSlashingProtection(cfg *config.RocketPoolConfig) ([]byte, error) {
+	cc, _ := cfg.GetSelectedConsensusClient()
+	return c.ExportSlashingProtectionForClient(cfg, cc)
+}
+
+// Exports the slashing protection database of the given validator client in the EIP-3076 interchange format.
+// The validator client must be stopped before calling this.
+func (c *Client) ExportSlashingProtectionForClient(cfg *config.RocketPoolConfig, cc cfgtypes.ConsensusClient) ([]byte, error) {
+	var builder slashingProtectionCommandBuilder
+	switch cc {
+	case cfgtypes.ConsensusClient_Lighthouse:
+		builder = lighthouse.GetSlashingProtectionExportCommand
+	case cfgtypes.ConsensusClient_Lodestar:
+		builder = lodestar.GetSlashingProtectionExportCommand
+	case cfgtypes.ConsensusClient_Nimbus:
+		builder = nimbus.GetSlashingProtectionExportCommand
+	case cfgtypes.ConsensusClient_Prysm:
+		builder = prysm.GetSlashingProtectionExportCommand
+	case cfgtypes.ConsensusClient_Teku:
+		builder = teku.GetSlashingProtectionExportCommand
+	default:
+		return nil, fmt.Errorf("unknown consensus client [%v]", cc)
+	}
+
+	// Get the command to run with root privileges
+	rootCmd, err := c.getEscalationCommand()
+	if err != nil {
+		return nil, fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+
+	// Make a folder for the interchange file
+	exportDir, err := os.MkdirTemp("", "rocketpool-slashing-protection-")
+	if err != nil {
+		return nil, fmt.Errorf("error creating temporary folder: %w", err)
+	}
+	defer func() {
+		_, _ = c.readOutput(fmt.Sprintf("%s rm -rf %s", rootCmd, shellescape.Quote(exportDir)))
+	}()
+
+	// Run the export
+	if err := c.runSlashingProtectionCommand(cfg, cc, builder, exportDir); err != nil {
+		return nil, fmt.Errorf("error exporting slashing protection database: %w", err)
+	}
+
+	// Read the file; the client may have written it as root
+	exportFile := filepath.Join(exportDir, keystore.SlashingProtectionFile)
+	data, err := c.readOutput(fmt.Sprintf("%s cat %s", rootCmd, shellescape.Quote(exportFile)))
+	if err != nil {
+		return nil, fmt.Errorf("error reading slashing protection interchange file: %w", err)
+	}
+	return data, nil
+}
+
+// Imports an EIP-3076 interchange file into the slashing protection database of the selected validator client.
+// The validator client must be stopped before calling this.
+func (c *Client) ImportSlashingProtection(cfg *config.RocketPoolConfig, data []byte) error {
+	cc, _ := cfg.GetSelectedConsensusClient()
+	return c.ImportSlashingProtectionForClient(cfg, cc, data)
+}
+
+// Imports an EIP-3076 interchange file into the slashing protection database of the given validator client.
+// The validator client must be stopped before calling this.
+func (c *Client) ImportSlashingProtectionForClient(cfg *config.RocketPoolConfig, cc cfgtypes.ConsensusClient, data []byte) error {
+	var builder slashingProtectionCommandBuilder
+	switch cc {
+	case cfgtypes.ConsensusClient_Lighthouse:
+		builder = lighthouse.GetSlashingProtectionImportCommand
+	case cfgtypes.ConsensusClient_Lodestar:
+		builder = lodestar.GetSlashingProtectionImportCommand
+	case cfgtypes.ConsensusClient_Nimbus:
+		builder = nimbus.GetSlashingProtectionImportCommand
+	case cfgtypes.ConsensusClient_Prysm:
+		builder = prysm.GetSlashingProtectionImportCommand
+	case cfgtypes.ConsensusClient_Teku:
+		builder = teku.GetSlashingProtectionImportCommand
+	default:
+		return fmt.Errorf("unknown consensus client [%v]", cc)
+	}
+
+	// Get the command to run with root privileges
+	rootCmd, err := c.getEscalationCommand()
+	if err != nil {
+		return fmt.Errorf("could not get privilege escalation command: %w", err)
+	}
+
+	// Write the interchange file
+	importDir, err := os.MkdirTemp("", "rocketpool-slashing-protection-")
+	if err != nil {
+		return fmt.Errorf("error creating temporary folder: %w", err)
+	}
+	defer func() {
+		_, _ = c.readOutput(fmt.Sprintf("%s rm -rf %s", rootCmd, shellescape.Quote(importDir)))
+	}()
+	err = os.WriteFile(filepath.Join(importDir, keystore.SlashingProtectionFile), data, 0600)
+	if err != nil {
+		return fmt.Errorf("error writing slashing protection interchange file: %w", err)
+	}
+
+	// Run the import
+	if err := c.runSlashingProtectionCommand(cfg, cc, builder, importDir); err != nil {
+		return fmt.Errorf("error importing slashing protection database: %w", err)
+	}
+	return nil
+}
+
+// Runs a slashing protection command in a temporary container built from the validator client's image
+func (c *Client) runSlashingProtectionCommand(cfg *config.RocketPoolConfig, cc cfgtypes.ConsensusClient, builder slashingProtectionCommandBuilder, hostDir string) error {
+	// Check for Native mode
+	if cfg.IsNativeMode {
+		return fmt.Errorf("this function is not supported in Native Mode; please use your validator client's own slashing protection commands instead")
+	}
+
+	// Get the image to run
+	image, err := getSlashingProtectionImage(cfg, cc)
+	if err != nil {
+		return err
+	}
+
+	// Get the paths and network
+	validatorsPath, err := homedir.Expand(cfg.Smartnode.GetValidatorKeychainPathInCLI())
+	if err != nil {
+		return fmt.Errorf("error loading validators folder path: %w", err)
+	}
+	beaconNodeUrl, err := cfg.ConsensusClientApiUrl()
+	if err != nil {
+		return fmt.Errorf("error getting Beacon Node URL: %w", err)
+	}
+	network := cfg.Smartnode.Network.Value.(cfgtypes.Network)
+	if network == cfgtypes.Network_Devnet {
+		// The devnet runs on Holesky
+		network = cfgtypes.Network_Holesky
+	}
+	command := builder(slashingProtectionValidatorsPath, filepath.Join(slashingProtectionExportPath, keystore.SlashingProtectionFile), string(network), beaconNodeUrl)
+
+	// Build the docker command
+	args := []string{
+		"docker", "run", "--rm",
+		"-v", shellescape.Quote(validatorsPath + ":" + slashingProtectionValidatorsPath),
+		"-v", shellescape.Quote(hostDir + ":" + slashingProtectionExportPath),
+	}
+	if cc == cfgtypes.ConsensusClient_Lodestar {
+		// Lodestar needs to reach the Beacon Node on the Smartnode's Docker network
+		args = append(args, "--network", shellescape.Quote(cfg.Smartnode.ProjectName.Value.(string)+"_net"))
+	}
+	if command.Entrypoint != "" {
+		args = append(args, "--entrypoint", shellescape.Quote(command.Entrypoint))
+	}
+	args = append(args, shellescape.Quote(image))
+	for _, arg := range command.Args {
+		args = append(args, shellescape.Quote(arg))
+	}
+
+	// Run it
+	output, err := c.readOutput(strings.Join(args, " "))
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Get the Docker image that has the slashing protection tooling for the given client
+func getSlashingProtectionImage(cfg *config.RocketPoolConfig, cc cfgtypes.ConsensusClient) (string, error) {
+	_, mode := cfg.GetSelectedConsensusClient()
+	local := (mode == cfgtypes.Mode_Local)
+	switch cc {
+	case cfgtypes.ConsensusClient_Lighthouse:
+		if local {
+			return cfg.Lighthouse.GetValidatorImage(), nil
+		}
+		return cfg.ExternalLighthouse.ContainerTag.Value.(string), nil
+	case cfgtypes.ConsensusClient_Lodestar:
+		if local {
+			return cfg.Lodestar.GetValidatorImage(), nil
+		}
+		return cfg.ExternalLodestar.ContainerTag.Value.(string), nil
+	case cfgtypes.ConsensusClient_Nimbus:
+		// The slashing database tooling only ships with the Beacon Node image
+		if local {
+			return cfg.Nimbus.GetBeaconNodeImage(), nil
+		}
+		vcImage := cfg.ExternalNimbus.ContainerTag.Value.(string)
+		return strings.Replace(vcImage, "nimbus-validator-client", "nimbus-eth2", 1), nil
+	case cfgtypes.ConsensusClient_Prysm:
+		if local {
+			return cfg.Prysm.GetValidatorImage(), nil
+		}
+		return cfg.ExternalPrysm.ContainerTag.Value.(string), nil
+	case cfgtypes.ConsensusClient_Teku:
+		if local {
+			return cfg.Teku.GetValidatorImage(), nil
+		}
+		return cfg.ExternalTeku.ContainerTag.Value.(string), nil
+	default:
+		return "", fmt.Errorf("unknown consensus client [%v]", cc)
+	}
+}
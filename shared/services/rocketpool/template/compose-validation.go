@@ -0,0 +1,496 @@
+package template
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// text/template prints this for values that don't exist
+const missingTemplateValue string = "<no value>"
+
+// Environment variables the Smartnode sets when it runs docker compose
+var composeEnvVars = map[string]bool{
+	"COMPOSE_PROJECT_NAME": true,
+}
+
+// The top-level elements of a compose file
+var composeTopLevelKeys = map[string]bool{
+	"version":  true,
+	"name":     true,
+	"include":  true,
+	"services": true,
+	"networks": true,
+	"volumes":  true,
+	"secrets":  true,
+	"configs":  true,
+}
+
+// The elements of a service in a compose file
+var composeServiceKeys = map[string]bool{
+	"annotations": true, "attach": true, "blkio_config": true, "build": true, "cap_add": true, "cap_drop": true,
+	"cgroup": true, "cgroup_parent": true, "command": true, "configs": true, "container_name": true,
+	"cpu_count": true, "cpu_percent": true, "cpu_period": true, "cpu_quota": true, "cpu_rt_period": true,
+	"cpu_rt_runtime": true, "cpu_shares": true, "cpus": true, "cpuset": true, "credential_spec": true,
+	"depends_on": true, "deploy": true, "develop": true, "device_cgroup_rules": true, "devices": true, "dns": true,
+	"dns_opt": true, "dns_search": true, "domainname": true, "driver_opts": true, "entrypoint": true, "env_file": true,
+	"environment": true, "expose": true, "extends": true, "external_links": true, "extra_hosts": true,
+	"group_add": true, "healthcheck": true, "hostname": true, "image": true, "init": true, "ipc": true,
+	"isolation": true, "labels": true, "links": true, "logging": true, "mac_address": true, "mem_limit": true,
+	"mem_reservation": true, "mem_swappiness": true, "memswap_limit": true, "network_mode": true, "networks": true,
+	"oom_kill_disable": true, "oom_score_adj": true, "pid": true, "pids_limit": true, "platform": true,
+	"ports": true, "post_start": true, "pre_stop": true, "privileged": true, "profiles": true, "pull_policy": true,
+	"read_only": true, "restart": true, "runtime": true, "scale": true, "secrets": true, "security_opt": true,
+	"shm_size": true, "stdin_open": true, "stop_grace_period": true, "stop_signal": true, "storage_opt": true,
+	"sysctls": true, "tmpfs": true, "tty": true, "ulimits": true, "user": true, "userns_mode": true, "uts": true,
+	"volumes": true, "volumes_from": true, "working_dir": true,
+}
+
+var (
+	// [host_ip:][published:]target[/protocol], where the ports can be ranges and the host IP can be IPv6 in brackets
+	composePortPattern = regexp.MustCompile(`^(?:(\[[0-9a-fA-F:.]+\]|\d{1,3}(?:\.\d{1,3}){3}):)?(?:(\d+(?:-\d+)?):)?(\d+(?:-\d+)?)(?:/(tcp|udp|sctp))?$`)
+
+	// The name of an environment variable in a service's environment
+	composeEnvVarNamePattern = regexp.MustCompile(`^[^=\s]+$`)
+
+	// A variable reference that docker compose interpolates
+	composeInterpolationPattern = regexp.MustCompile(`\$(\$|\{([^}]*)\}|[A-Za-z_][A-Za-z0-9_]*|\{[^}]*$)`)
+
+	// The name of a variable in an interpolation
+	composeInterpolationNamePattern = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)((:?[-?+]).*)?$`)
+)
+
+// A service in a compose project, merged from all of the files that define it
+type composeService struct {
+	files      []string
+	hasImage   bool
+	networks   map[string]string
+	volumes    map[string]string
+	dependsOn  map[string]string
+	ports      map[string]string
+	networkSet bool
+}
+
+// A docker compose project, merged from all of its files
+type composeProject struct {
+	services map[string]*composeService
+	networks map[string]bool
+	volumes  map[string]bool
+	errors   []string
+	warnings []string
+}
+
+// Check the docker compose files that make up a project the way `docker compose` would read them: each file has to be
+// valid YAML that only uses elements from the compose specification, every network, named volume, and dependency a
+// service refers to has to exist in the merged project, published ports can't collide, and environment variables have
+// to be well-formed. Returns the errors and warnings found.
+func ValidateComposeProject(composeFiles []string) ([]string, []string) {
+	project := &composeProject{
+		services: map[string]*composeService{},
+		networks: map[string]bool{"default": true},
+		volumes:  map[string]bool{},
+		errors:   []string{},
+		warnings: []string{},
+	}
+
+	// Read each file
+	for _, path := range composeFiles {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			project.addError(path, "", "could not be read: %s", err.Error())
+			continue
+		}
+		project.checkFile(path, contents)
+	}
+
+	// Check the references between the files now that every service, network, and volume is known
+	project.checkReferences()
+	return project.errors, project.warnings
+}
+
+// Check a single compose file and add its definitions to the project
+func (p *composeProject) checkFile(path string, contents []byte) {
+
+	// Look for template values that didn't exist and bad interpolations
+	for i, line := range strings.Split(string(contents), "\n") {
+		if strings.Contains(line, missingTemplateValue) {
+			p.addError(path, "", "line %d uses a template value that doesn't exist", i+1)
+		}
+		p.checkInterpolation(path, i+1, line)
+	}
+
+	// Parse it
+	file := map[string]interface{}{}
+	err := yaml.Unmarshal(contents, &file)
+	if err != nil {
+		p.addError(path, "", "is not valid YAML: %s", err.Error())
+		return
+	}
+	for key := range file {
+		if !composeTopLevelKeys[key] && !strings.HasPrefix(key, "x-") {
+			p.addError(path, "", "has an unknown top-level element `%s`", key)
+		}
+	}
+
+	// Get the top-level networks and volumes
+	for _, name := range p.getNames(path, "", "networks", file["networks"], false) {
+		p.networks[name] = true
+	}
+	for _, name := range p.getNames(path, "", "volumes", file["volumes"], false) {
+		p.volumes[name] = true
+	}
+
+	// Get the services
+	if file["services"] == nil {
+		return
+	}
+	services, ok := file["services"].(map[interface{}]interface{})
+	if !ok {
+		p.addError(path, "", "`services` must be a map of service names to definitions")
+		return
+	}
+	for rawName, rawService := range services {
+		name := fmt.Sprint(rawName)
+		service, exists := p.services[name]
+		if !exists {
+			service = &composeService{
+				networks:  map[string]string{},
+				volumes:   map[string]string{},
+				dependsOn: map[string]string{},
+				ports:     map[string]string{},
+			}
+			p.services[name] = service
+		}
+		service.files = append(service.files, path)
+		if rawService == nil {
+			continue
+		}
+		definition, ok := rawService.(map[interface{}]interface{})
+		if !ok {
+			p.addError(path, name, "must be a map of service elements")
+			continue
+		}
+		p.checkService(path, name, service, definition)
+	}
+
+}
+
+// Check a service definition and add its references to the merged service
+func (p *composeProject) checkService(path string, name string, service *composeService, definition map[interface{}]interface{}) {
+
+	for rawKey := range definition {
+		key := fmt.Sprint(rawKey)
+		if !composeServiceKeys[key] && !strings.HasPrefix(key, "x-") {
+			p.addError(path, name, "has an unknown element `%s`", key)
+		}
+	}
+	if definition["image"] != nil || definition["build"] != nil {
+		service.hasImage = true
+	}
+
+	// Networks
+	if definition["network_mode"] != nil {
+		service.networkSet = true
+	}
+	for _, network := range p.getNames(path, name, "networks", definition["networks"], true) {
+		service.networks[network] = path
+	}
+
+	// Dependencies
+	for _, dependency := range p.getNames(path, name, "depends_on", definition["depends_on"], true) {
+		service.dependsOn[dependency] = path
+	}
+
+	// Volumes
+	for _, volume := range p.getList(path, name, "volumes", definition["volumes"]) {
+		source := ""
+		switch volume := volume.(type) {
+		case string:
+			parts := strings.Split(volume, ":")
+			if len(parts) > 1 {
+				source = parts[0]
+			}
+			if len(parts) > 3 || parts[len(parts)-1] == "" {
+				p.addError(path, name, "has an invalid volume `%s`", volume)
+				continue
+			}
+		case map[interface{}]interface{}:
+			if volume["target"] == nil {
+				p.addError(path, name, "has a volume without a `target`")
+				continue
+			}
+			if volume["type"] == nil || volume["type"] == "volume" {
+				source = fmt.Sprint(volume["source"])
+				if volume["source"] == nil {
+					source = ""
+				}
+			}
+		default:
+			p.addError(path, name, "has a volume that isn't a string or a map")
+			continue
+		}
+
+		// Anything that isn't a path is a named volume
+		if source != "" && !strings.HasPrefix(source, "/") && !strings.HasPrefix(source, ".") && !strings.HasPrefix(source, "~") && !strings.HasPrefix(source, "$") {
+			service.volumes[source] = path
+		}
+	}
+
+	// Ports
+	for _, port := range p.getList(path, name, "ports", definition["ports"]) {
+		switch port := port.(type) {
+		case int:
+		case string:
+			matches := composePortPattern.FindStringSubmatch(port)
+			if matches == nil {
+				p.addError(path, name, "has an invalid port mapping `%s`", port)
+				continue
+			}
+			if matches[2] != "" {
+				protocol := matches[4]
+				if protocol == "" {
+					protocol = "tcp"
+				}
+				service.ports[fmt.Sprintf("%s:%s/%s", matches[1], matches[2], protocol)] = path
+			}
+		case map[interface{}]interface{}:
+			if port["target"] == nil {
+				p.addError(path, name, "has a port without a `target`")
+				continue
+			}
+			if port["published"] != nil {
+				if !isPortRange(fmt.Sprint(port["published"])) {
+					p.addError(path, name, "has an invalid published port `%v`", port["published"])
+					continue
+				}
+				protocol := "tcp"
+				if port["protocol"] != nil {
+					protocol = fmt.Sprint(port["protocol"])
+				}
+				hostIp := ""
+				if port["host_ip"] != nil {
+					hostIp = fmt.Sprint(port["host_ip"])
+				}
+				service.ports[fmt.Sprintf("%s:%v/%s", hostIp, port["published"], protocol)] = path
+			}
+		default:
+			p.addError(path, name, "has a port that isn't a number, a string, or a map")
+		}
+	}
+
+	// Environment variables
+	switch environment := definition["environment"].(type) {
+	case nil:
+	case []interface{}:
+		for _, variable := range environment {
+			variableString, ok := variable.(string)
+			if !ok {
+				p.addError(path, name, "has an environment variable that isn't a string: %v", variable)
+				continue
+			}
+			variableName := strings.SplitN(variableString, "=", 2)[0]
+			if !composeEnvVarNamePattern.MatchString(variableName) {
+				p.addError(path, name, "has an invalid environment variable name `%s`", variableName)
+			}
+		}
+	case map[interface{}]interface{}:
+		for rawVariableName, value := range environment {
+			variableName := fmt.Sprint(rawVariableName)
+			if !composeEnvVarNamePattern.MatchString(variableName) {
+				p.addError(path, name, "has an invalid environment variable name `%s`", variableName)
+			}
+			switch value.(type) {
+			case nil, string, int, float64, bool:
+			default:
+				p.addError(path, name, "has a value for environment variable `%s` that isn't a string, number, or boolean", variableName)
+			}
+		}
+	default:
+		p.addError(path, name, "`environment` must be a list or a map")
+	}
+
+}
+
+// Check the references between services, networks, and volumes across the whole project
+func (p *composeProject) checkReferences() {
+
+	serviceNames := make([]string, 0, len(p.services))
+	for name := range p.services {
+		serviceNames = append(serviceNames, name)
+	}
+	sort.Strings(serviceNames)
+
+	publishedPorts := map[string]string{}
+	for _, name := range serviceNames {
+		service := p.services[name]
+		if !service.hasImage {
+			p.addServiceError(service, name, "doesn't have an `image` or `build` in any file")
+		}
+		if service.networkSet && len(service.networks) > 0 {
+			p.addServiceError(service, name, "sets both `network_mode` and `networks`")
+		}
+		for _, network := range sortedKeys(service.networks) {
+			if !p.networks[network] {
+				p.addError(service.networks[network], name, "uses the network `%s`, but it isn't defined in the top-level `networks`", network)
+			}
+		}
+		for _, volume := range sortedKeys(service.volumes) {
+			if !p.volumes[volume] {
+				p.addError(service.volumes[volume], name, "uses the named volume `%s`, but it isn't defined in the top-level `volumes`", volume)
+			}
+		}
+		for _, dependency := range sortedKeys(service.dependsOn) {
+			if _, exists := p.services[dependency]; !exists {
+				p.addError(service.dependsOn[dependency], name, "depends on the service `%s`, but it isn't part of the project", dependency)
+			}
+		}
+		for _, port := range sortedKeys(service.ports) {
+			if other, exists := publishedPorts[port]; exists {
+				p.addError(service.ports[port], name, "publishes port `%s`, which is already published by `%s`", strings.TrimPrefix(port, ":"), other)
+				continue
+			}
+			publishedPorts[port] = name
+		}
+	}
+
+}
+
+// Check the variable references docker compose would interpolate on a line
+func (p *composeProject) checkInterpolation(path string, lineNumber int, line string) {
+	// Comments aren't interpolated
+	if strings.HasPrefix(strings.TrimSpace(line), "#") {
+		return
+	}
+	for _, match := range composeInterpolationPattern.FindAllStringSubmatch(line, -1) {
+		reference := match[1]
+		switch {
+		case reference == "$":
+			// An escaped dollar sign
+		case strings.HasPrefix(reference, "{") && !strings.HasSuffix(reference, "}"):
+			p.addError(path, "", "line %d has an unterminated variable reference `$%s`", lineNumber, reference)
+		case strings.HasPrefix(reference, "{"):
+			nameMatch := composeInterpolationNamePattern.FindStringSubmatch(match[2])
+			if nameMatch == nil {
+				p.addError(path, "", "line %d has an invalid variable reference `$%s`", lineNumber, reference)
+				continue
+			}
+			if nameMatch[2] == "" {
+				p.checkVariableSet(path, lineNumber, nameMatch[1])
+			}
+		default:
+			p.checkVariableSet(path, lineNumber, reference)
+		}
+	}
+}
+
+// Warn about variables that docker compose will replace with an empty string
+func (p *composeProject) checkVariableSet(path string, lineNumber int, name string) {
+	if composeEnvVars[name] {
+		return
+	}
+	if _, exists := os.LookupEnv(name); !exists {
+		p.addWarning(path, "line %d uses the variable `%s`, which isn't set, so it will be blank", lineNumber, name)
+	}
+}
+
+// Get the names from an element that can be a list of names or a map keyed by name
+func (p *composeProject) getNames(path string, service string, element string, value interface{}, allowList bool) []string {
+	names := []string{}
+	switch value := value.(type) {
+	case nil:
+	case map[interface{}]interface{}:
+		for name := range value {
+			names = append(names, fmt.Sprint(name))
+		}
+	case []interface{}:
+		if !allowList {
+			p.addError(path, service, "`%s` must be a map", element)
+			break
+		}
+		for _, name := range value {
+			names = append(names, fmt.Sprint(name))
+		}
+	default:
+		p.addError(path, service, "`%s` must be a list or a map", element)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get the entries of an element that has to be a list
+func (p *composeProject) getList(path string, service string, element string, value interface{}) []interface{} {
+	switch value := value.(type) {
+	case nil:
+		return []interface{}{}
+	case []interface{}:
+		return value
+	default:
+		p.addError(path, service, "`%s` must be a list", element)
+		return []interface{}{}
+	}
+}
+
+// Record an error in a file
+func (p *composeProject) addError(path string, service string, format string, args ...interface{}) {
+	p.errors = append(p.errors, formatComposeProblem(getComposeFileName(path), service, format, args...))
+}
+
+// Record an error in a service that isn't tied to one of the files that define it
+func (p *composeProject) addServiceError(service *composeService, name string, format string, args ...interface{}) {
+	files := make([]string, len(service.files))
+	for i, path := range service.files {
+		files[i] = getComposeFileName(path)
+	}
+	p.errors = append(p.errors, formatComposeProblem(strings.Join(files, ", "), name, format, args...))
+}
+
+// Record a warning in a file
+func (p *composeProject) addWarning(path string, format string, args ...interface{}) {
+	p.warnings = append(p.warnings, formatComposeProblem(getComposeFileName(path), "", format, args...))
+}
+
+// Get a short name for a compose file that still tells the rendered files and the overrides apart
+func getComposeFileName(path string) string {
+	return filepath.Join(filepath.Base(filepath.Dir(path)), filepath.Base(path))
+}
+
+// Describe a problem in a compose file
+func formatComposeProblem(location string, service string, format string, args ...interface{}) string {
+	if service != "" {
+		location = fmt.Sprintf("%s (service `%s`)", location, service)
+	}
+	return fmt.Sprintf("%s %s", location, fmt.Sprintf(format, args...))
+}
+
+// Get the keys of a map in order
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Check if a published port is a port number or an ordered range of them
+func isPortRange(value string) bool {
+	parts := strings.Split(value, "-")
+	if len(parts) > 2 {
+		return false
+	}
+	start, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return false
+	}
+	if len(parts) == 1 {
+		return true
+	}
+	end, err := strconv.ParseUint(parts[1], 10, 16)
+	return err == nil && end >= start
+}
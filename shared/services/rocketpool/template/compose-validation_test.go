@@ -0,0 +1,197 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testComposeRuntime string = `version: "3.7"
+services:
+  eth1:
+    image: ethereum/client-go:v1.13.0
+    container_name: ${COMPOSE_PROJECT_NAME}_eth1
+    networks:
+      - net
+    volumes:
+      - eth1clientdata:/ethclient
+      - ./scripts:/setup:ro
+    ports:
+      - "30303:30303/tcp"
+      - "30303:30303/udp"
+    environment:
+      - CLIENT=geth
+x-rp-comment: Rendered by the Smartnode
+networks:
+  net:
+volumes:
+  eth1clientdata:
+`
+
+func TestValidateComposeProject(t *testing.T) {
+	tests := []struct {
+		name     string
+		override string
+		errors   []string
+		warnings []string
+	}{
+		{
+			name: "valid override",
+			override: `services:
+  eth1:
+    environment:
+      EXTRA: "$$HOME"
+    x-custom: true
+`,
+		},
+		{
+			name: "unknown elements",
+			override: `service:
+  eth1:
+services:
+  eth1:
+    imgae: geth
+`,
+			errors: []string{
+				"override/eth1.yml has an unknown top-level element `service`",
+				"override/eth1.yml (service `eth1`) has an unknown element `imgae`",
+			},
+		},
+		{
+			name: "undefined references",
+			override: `services:
+  eth1:
+    networks:
+      - other
+    volumes:
+      - extra:/extra
+    depends_on:
+      - eth2
+`,
+			errors: []string{
+				"uses the network `other`",
+				"uses the named volume `extra`",
+				"depends on the service `eth2`",
+			},
+		},
+		{
+			name: "service without an image",
+			override: `services:
+  sidecar:
+    restart: unless-stopped
+`,
+			errors: []string{"override/eth1.yml (service `sidecar`) doesn't have an `image` or `build` in any file"},
+		},
+		{
+			name: "ports",
+			override: `services:
+  sidecar:
+    image: busybox
+    ports:
+      - "30303:30303"
+      - "127.0.0.1:30303:30303"
+      - "8545:8545:8545"
+      - target: 9000
+        published: "70000"
+`,
+			errors: []string{
+				"(service `sidecar`) publishes port `30303/tcp`, which is already published by `eth1`",
+				"has an invalid port mapping `8545:8545:8545`",
+				"has an invalid published port `70000`",
+			},
+		},
+		{
+			name: "network mode and networks",
+			override: `services:
+  eth1:
+    network_mode: host
+`,
+			errors: []string{"sets both `network_mode` and `networks`"},
+		},
+		{
+			name: "environment",
+			override: `services:
+  eth1:
+    environment:
+      BAD NAME: 1
+      NESTED:
+        key: value
+`,
+			errors: []string{
+				"has an invalid environment variable name `BAD NAME`",
+				"has a value for environment variable `NESTED` that isn't a string, number, or boolean",
+			},
+		},
+		{
+			name: "template values and interpolation",
+			override: `# ${IGNORED_IN_COMMENTS
+services:
+  eth1:
+    command: "--datadir <no value> ${UNTERMINATED"
+    labels:
+      - "a=${RP_TEST_UNSET_VARIABLE}"
+      - "b=${RP_TEST_UNSET_DEFAULT:-default}"
+      - "c=${1BAD}"
+`,
+			errors: []string{
+				"line 4 uses a template value that doesn't exist",
+				"line 4 has an unterminated variable reference `${UNTERMINATED\"`",
+				"line 8 has an invalid variable reference `${1BAD}`",
+			},
+			warnings: []string{"line 6 uses the variable `RP_TEST_UNSET_VARIABLE`, which isn't set, so it will be blank"},
+		},
+		{
+			name:     "invalid YAML",
+			override: "services:\n  eth1: [\n",
+			errors:   []string{"override/eth1.yml is not valid YAML"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			directory := t.TempDir()
+			runtimePath := filepath.Join(directory, "runtime", "eth1.yml")
+			overridePath := filepath.Join(directory, "override", "eth1.yml")
+			for path, contents := range map[string]string{runtimePath: testComposeRuntime, overridePath: test.override} {
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(contents), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			errors, warnings := ValidateComposeProject([]string{runtimePath, overridePath})
+			checkComposeProblems(t, "error", errors, test.errors)
+			checkComposeProblems(t, "warning", warnings, test.warnings)
+		})
+	}
+}
+
+func TestValidateComposeProjectMissingFile(t *testing.T) {
+	errors, _ := ValidateComposeProject([]string{filepath.Join(t.TempDir(), "runtime", "eth1.yml")})
+	if len(errors) != 1 || !strings.HasPrefix(errors[0], "runtime/eth1.yml could not be read") {
+		t.Fatalf("expected a single read error, got %v", errors)
+	}
+}
+
+// Make sure every expected problem was reported, and nothing else
+func checkComposeProblems(t *testing.T, kind string, actual []string, expected []string) {
+	t.Helper()
+	if len(actual) != len(expected) {
+		t.Fatalf("expected %d %ss, got %d: %v", len(expected), kind, len(actual), actual)
+	}
+	for _, problem := range expected {
+		found := false
+		for _, actualProblem := range actual {
+			if strings.Contains(actualProblem, problem) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Fatalf("expected a %s containing [%s], got %v", kind, problem, actual)
+		}
+	}
+}
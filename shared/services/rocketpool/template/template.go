@@ -1,6 +1,7 @@
 package template
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -22,38 +23,53 @@ func (t Template) Write(data any) error {
 }
 
 func (t Template) WriteWithDelims(data any, leftDelim string, rightDelim string) error {
+	// Render the template first so a bad template doesn't leave a partially written file behind
+	contents, err := t.RenderWithDelims(data, leftDelim, rightDelim)
+	if err != nil {
+		return err
+	}
+
 	// Create the destination folder if it doesn't exist
 	destPath := filepath.Dir(t.Dst)
-	err := os.MkdirAll(destPath, 0775)
+	err = os.MkdirAll(destPath, 0775)
 	if err != nil {
 		return fmt.Errorf("error creating destination directory [%s]: %w", destPath, err)
 	}
 
-	// Open the output file, creating it if it doesn't exist
-	runtimeFile, err := os.OpenFile(t.Dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0664)
+	// Write the result, creating the file if it doesn't exist
+	err = os.WriteFile(t.Dst, contents, 0664)
 	if err != nil {
-		return fmt.Errorf("could not open templated file %s for writing: %w", shellescape.Quote(t.Dst), err)
+		return fmt.Errorf("could not write templated file %s: %w", shellescape.Quote(t.Dst), err)
 	}
-	defer runtimeFile.Close()
 
-	// Parse the template
-	baseName := filepath.Base(t.Src)
-	tmpl, err := template.New(baseName).Delims(leftDelim, rightDelim).ParseFiles(t.Src)
+	// If the file was newly created, 0664 may have been altered by umask, so chmod back to 0664.
+	err = os.Chmod(t.Dst, 0664)
 	if err != nil {
-		return fmt.Errorf("error reading template file %s: %w", shellescape.Quote(t.Src), err)
+		return fmt.Errorf("could not set templated file (%s) permissions: %w", shellescape.Quote(t.Dst), err)
 	}
 
-	// Replace template variables and write the result
-	err = tmpl.Execute(runtimeFile, data)
+	return nil
+}
+
+func (t Template) Render(data any) ([]byte, error) {
+	return t.RenderWithDelims(data, "{{", "}}")
+}
+
+// Replace the template variables in Src and return the result without writing it to Dst
+func (t Template) RenderWithDelims(data any, leftDelim string, rightDelim string) ([]byte, error) {
+	// Parse the template
+	baseName := filepath.Base(t.Src)
+	tmpl, err := template.New(baseName).Delims(leftDelim, rightDelim).ParseFiles(t.Src)
 	if err != nil {
-		return fmt.Errorf("error writing and substituting template: %w", err)
+		return nil, fmt.Errorf("error reading template file %s: %w", shellescape.Quote(t.Src), err)
 	}
 
-	// If the file was newly created, 0664 may have been altered by umask, so chmod back to 0664.
-	err = os.Chmod(t.Dst, 0664)
+	// Replace template variables
+	contents := &bytes.Buffer{}
+	err = tmpl.Execute(contents, data)
 	if err != nil {
-		return fmt.Errorf("could not set templated file (%s) permissions: %w", shellescape.Quote(t.Dst), err)
+		return nil, fmt.Errorf("error writing and substituting template: %w", err)
 	}
 
-	return nil
+	return contents.Bytes(), nil
 }
@@ -212,3 +212,19 @@ func (c *Client) ExportWallet() (api.ExportWalletResponse, error) {
 	}
 	return response, nil
 }
+
+// Import a node wallet file from a migration bundle
+func (c *Client) ImportMigrationWallet(walletJson string) (api.ImportMigrationWalletResponse, error) {
+	responseBytes, err := c.callAPI("wallet import-migration", walletJson)
+	if err != nil {
+		return api.ImportMigrationWalletResponse{}, fmt.Errorf("Could not import wallet: %w", err)
+	}
+	var response api.ImportMigrationWalletResponse
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return api.ImportMigrationWalletResponse{}, fmt.Errorf("Could not decode import wallet response: %w", err)
+	}
+	if response.Error != "" {
+		return api.ImportMigrationWalletResponse{}, fmt.Errorf("Could not import wallet: %s", response.Error)
+	}
+	return response, nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
 	"github.com/rocket-pool/smartnode/shared/services/config"
 	"github.com/rocket-pool/smartnode/shared/services/contracts"
+	"github.com/rocket-pool/smartnode/shared/services/gas"
 	"github.com/rocket-pool/smartnode/shared/services/passwords"
 	"github.com/rocket-pool/smartnode/shared/services/wallet"
 	lhkeystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore/lighthouse"
@@ -43,6 +44,7 @@ var (
 	rocketSignerRegistry *contracts.RocketSignerRegistry
 	beaconClient         beacon.Client
 	docker               *client.Client
+	autoTxPolicy         *gas.AutoTxPolicy
 
 	initCfg                  sync.Once
 	initPasswordManager      sync.Once
@@ -55,6 +57,7 @@ var (
 	initRocketSignerRegistry sync.Once
 	initBeaconClient         sync.Once
 	initDocker               sync.Once
+	initAutoTxPolicy         sync.Once
 )
 
 //
@@ -65,6 +68,14 @@ func GetConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
 	return getConfig(c)
 }
 
+func GetAutoTxPolicy(c *cli.Context) (*gas.AutoTxPolicy, error) {
+	cfg, err := getConfig(c)
+	if err != nil {
+		return nil, err
+	}
+	return getAutoTxPolicy(cfg), nil
+}
+
 func GetPasswordManager(c *cli.Context) (*passwords.PasswordManager, error) {
 	cfg, err := getConfig(c)
 	if err != nil {
@@ -169,6 +180,13 @@ func getConfig(c *cli.Context) (*config.RocketPoolConfig, error) {
 	return cfg, err
 }
 
+func getAutoTxPolicy(cfg *config.RocketPoolConfig) *gas.AutoTxPolicy {
+	initAutoTxPolicy.Do(func() {
+		autoTxPolicy = gas.NewAutoTxPolicy(cfg)
+	})
+	return autoTxPolicy
+}
+
 func getPasswordManager(cfg *config.RocketPoolConfig) *passwords.PasswordManager {
 	initPasswordManager.Do(func() {
 		passwordManager = passwords.NewPasswordManager(os.ExpandEnv(cfg.Smartnode.GetPasswordPath()))
@@ -1,6 +1,9 @@
 package keystore
 
 import (
+	"fmt"
+	"os"
+
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/sethvargo/go-password/password"
 	eth2types "github.com/wealdtech/go-eth2-types/v2"
@@ -23,4 +26,30 @@ type Keystore interface {
 	StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPath string) error
 	LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error)
 	GetKeystoreDir() string
+	HasValidatorKey(pubkey types.ValidatorPubkey) (bool, error)
+}
+
+// Check if all of the provided files exist
+func FilesExist(paths ...string) (bool, error) {
+	for _, path := range paths {
+		_, err := os.Stat(path)
+		if os.IsNotExist(err) {
+			return false, nil
+		} else if err != nil {
+			return false, fmt.Errorf("couldn't check file %s: %w", path, err)
+		}
+	}
+	return true, nil
+}
+
+// The name of the EIP-3076 interchange file used by the slashing protection commands
+const SlashingProtectionFile string = "slashing_protection.json"
+
+// A command that runs a validator client's slashing protection tooling inside its Docker image
+type SlashingProtectionCommand struct {
+	// Overrides the image's entrypoint if set
+	Entrypoint string
+
+	// The arguments to pass to the entrypoint
+	Args []string
 }
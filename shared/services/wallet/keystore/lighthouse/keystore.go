@@ -120,6 +120,14 @@ func (ks *Keystore) StoreValidatorKey(key *eth2types.BLSPrivateKey, derivationPa
 
 }
 
+// Check if the key and secret files for a validator exist, without decrypting the key
+func (ks *Keystore) HasValidatorKey(pubkey types.ValidatorPubkey) (bool, error) {
+	return keystore.FilesExist(
+		filepath.Join(ks.keystorePath, KeystoreDir, ValidatorsDir, hexutil.AddPrefix(pubkey.Hex()), KeyFileName),
+		filepath.Join(ks.keystorePath, KeystoreDir, SecretsDir, hexutil.AddPrefix(pubkey.Hex())),
+	)
+}
+
 // Load a private key
 func (ks *Keystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
 
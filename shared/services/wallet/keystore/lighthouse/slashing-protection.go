@@ -0,0 +1,31 @@
+package lighthouse
+
+import (
+	"path/filepath"
+
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+)
+
+// Get the command that exports Lighthouse's slashing protection database to an EIP-3076 interchange file
+func GetSlashingProtectionExportCommand(validatorsPath string, file string, network string, beaconNodeUrl string) keystore.SlashingProtectionCommand {
+	return keystore.SlashingProtectionCommand{
+		Entrypoint: "lighthouse",
+		Args: []string{
+			"account", "validator", "slashing-protection", "export", file,
+			"--datadir", filepath.Join(validatorsPath, KeystoreDir),
+			"--network", network,
+		},
+	}
+}
+
+// Get the command that imports an EIP-3076 interchange file into Lighthouse's slashing protection database
+func GetSlashingProtectionImportCommand(validatorsPath string, file string, network string, beaconNodeUrl string) keystore.SlashingProtectionCommand {
+	return keystore.SlashingProtectionCommand{
+		Entrypoint: "lighthouse",
+		Args: []string{
+			"account", "validator", "slashing-protection", "import", file,
+			"--datadir", filepath.Join(validatorsPath, KeystoreDir),
+			"--network", network,
+		},
+	}
+}
@@ -0,0 +1,34 @@
+package lodestar
+
+import (
+	"path/filepath"
+
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+)
+
+// Get the command that exports Lodestar's slashing protection database to an EIP-3076 interchange file.
+// Lodestar gets the genesis validators root for the interchange file from the Beacon Node.
+func GetSlashingProtectionExportCommand(validatorsPath string, file string, network string, beaconNodeUrl string) keystore.SlashingProtectionCommand {
+	return keystore.SlashingProtectionCommand{
+		Args: []string{
+			"validator", "slashing-protection", "export",
+			"--file", file,
+			"--dataDir", filepath.Join(validatorsPath, KeystoreDir),
+			"--network", network,
+			"--beaconNodes", beaconNodeUrl,
+		},
+	}
+}
+
+// Get the command that imports an EIP-3076 interchange file into Lodestar's slashing protection database
+func GetSlashingProtectionImportCommand(validatorsPath string, file string, network string, beaconNodeUrl string) keystore.SlashingProtectionCommand {
+	return keystore.SlashingProtectionCommand{
+		Args: []string{
+			"validator", "slashing-protection", "import",
+			"--file", file,
+			"--dataDir", filepath.Join(validatorsPath, KeystoreDir),
+			"--network", network,
+			"--beaconNodes", beaconNodeUrl,
+		},
+	}
+}
@@ -0,0 +1,29 @@
+package nimbus
+
+import (
+	"path/filepath"
+
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+)
+
+// Get the command that exports Nimbus's slashing protection database to an EIP-3076 interchange file.
+// The validator client image doesn't include the slashing database tooling, so this must be run in the Beacon Node image.
+func GetSlashingProtectionExportCommand(validatorsPath string, file string, network string, beaconNodeUrl string) keystore.SlashingProtectionCommand {
+	return keystore.SlashingProtectionCommand{
+		Args: []string{
+			"slashingdb", "export", file,
+			"--data-dir=" + filepath.Join(validatorsPath, KeystoreDir),
+		},
+	}
+}
+
+// Get the command that imports an EIP-3076 interchange file into Nimbus's slashing protection database.
+// The validator client image doesn't include the slashing database tooling, so this must be run in the Beacon Node image.
+func GetSlashingProtectionImportCommand(validatorsPath string, file string, network string, beaconNodeUrl string) keystore.SlashingProtectionCommand {
+	return keystore.SlashingProtectionCommand{
+		Args: []string{
+			"slashingdb", "import", file,
+			"--data-dir=" + filepath.Join(validatorsPath, KeystoreDir),
+		},
+	}
+}
@@ -235,6 +235,30 @@ func (ks *Keystore) initialize() error {
 
 }
 
+// Check if the account store has a key for a validator.
+// Prysm keeps every key in one file, so this decrypts the account store once and caches it.
+func (ks *Keystore) HasValidatorKey(pubkey types.ValidatorPubkey) (bool, error) {
+
+	// Don't create an account store if there isn't one
+	exists, err := rpkeystore.FilesExist(filepath.Join(ks.keystorePath, KeystoreDir, WalletDir, AccountsDir, KeystoreFileName))
+	if err != nil || !exists {
+		return false, err
+	}
+
+	// Initialize the account store
+	err = ks.initialize()
+	if err != nil {
+		return false, err
+	}
+	for _, publicKey := range ks.as.PublicKeys {
+		if bytes.Equal(pubkey.Bytes(), publicKey) {
+			return true, nil
+		}
+	}
+	return false, nil
+
+}
+
 // Load a private key
 func (ks *Keystore) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSPrivateKey, error) {
 
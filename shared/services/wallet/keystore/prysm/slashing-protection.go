@@ -0,0 +1,36 @@
+package prysm
+
+import (
+	"path/filepath"
+
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+)
+
+// Get the command that exports Prysm's slashing protection database to an EIP-3076 interchange file.
+// Prysm always names the file it exports slashing_protection.json, so file must use that name.
+func GetSlashingProtectionExportCommand(validatorsPath string, file string, network string, beaconNodeUrl string) keystore.SlashingProtectionCommand {
+	return keystore.SlashingProtectionCommand{
+		Args: []string{
+			"slashing-protection-history", "export",
+			"--datadir=" + filepath.Join(validatorsPath, KeystoreDir, WalletDir),
+			"--slashing-protection-export-dir=" + filepath.Dir(file),
+			"--accept-terms-of-use",
+		},
+	}
+}
+
+// Get the command that imports an EIP-3076 interchange file into Prysm's slashing protection database
+func GetSlashingProtectionImportCommand(validatorsPath string, file string, network string, beaconNodeUrl string) keystore.SlashingProtectionCommand {
+	args := []string{
+		"slashing-protection-history", "import",
+		"--datadir=" + filepath.Join(validatorsPath, KeystoreDir, WalletDir),
+		"--slashing-protection-json-file=" + file,
+		"--accept-terms-of-use",
+	}
+	if network != "mainnet" {
+		args = append(args, "--"+network)
+	}
+	return keystore.SlashingProtectionCommand{
+		Args: args,
+	}
+}
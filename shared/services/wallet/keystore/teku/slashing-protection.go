@@ -0,0 +1,29 @@
+package teku
+
+import (
+	"path/filepath"
+
+	keystore "github.com/rocket-pool/smartnode/shared/services/wallet/keystore"
+)
+
+// Get the command that exports Teku's slashing protection database to an EIP-3076 interchange file
+func GetSlashingProtectionExportCommand(validatorsPath string, file string, network string, beaconNodeUrl string) keystore.SlashingProtectionCommand {
+	return keystore.SlashingProtectionCommand{
+		Args: []string{
+			"slashing-protection", "export",
+			"--data-path=" + filepath.Join(validatorsPath, KeystoreDir),
+			"--to=" + file,
+		},
+	}
+}
+
+// Get the command that imports an EIP-3076 interchange file into Teku's slashing protection database
+func GetSlashingProtectionImportCommand(validatorsPath string, file string, network string, beaconNodeUrl string) keystore.SlashingProtectionCommand {
+	return keystore.SlashingProtectionCommand{
+		Args: []string{
+			"slashing-protection", "import",
+			"--data-path=" + filepath.Join(validatorsPath, KeystoreDir),
+			"--from=" + file,
+		},
+	}
+}
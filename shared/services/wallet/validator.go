@@ -136,6 +136,15 @@ func (w *Wallet) LoadValidatorKey(pubkey types.ValidatorPubkey) (*eth2types.BLSP
 
 }
 
+// Checks if the key for a validator is stored in one of the wallet's keystores, without decrypting it
+func (w *Wallet) HasValidatorKey(keystoreName string, pubkey types.ValidatorPubkey) (bool, error) {
+	ks, exists := w.keystores[keystoreName]
+	if !exists {
+		return false, fmt.Errorf("the wallet doesn't have a %s keystore", keystoreName)
+	}
+	return ks.HasValidatorKey(pubkey)
+}
+
 // Deletes all of the keystore directories and persistent VC storage
 func (w *Wallet) DeleteValidatorStores() error {
 
@@ -253,6 +253,40 @@ func (w *Wallet) Save() error {
 
 }
 
+// Import a serialized wallet store from another machine and save it to disk.
+// The wallet password must already be set to the one that encrypted the store.
+func (w *Wallet) Import(walletJson string) error {
+
+	// Check wallet is not initialized
+	if w.IsInitialized() {
+		return errors.New("Wallet is already initialized")
+	}
+
+	// Check the wallet store can be decoded
+	ws := new(walletStore)
+	if err := json.Unmarshal([]byte(walletJson), ws); err != nil {
+		return fmt.Errorf("Could not decode wallet: %w", err)
+	}
+
+	// Write wallet store to disk
+	if err := os.WriteFile(w.walletPath, []byte(walletJson), FileMode); err != nil {
+		return fmt.Errorf("Could not write wallet to disk: %w", err)
+	}
+
+	// Load it, removing it again if it can't be decrypted
+	if _, err := w.loadStore(); err != nil {
+		w.ws = nil
+		w.seed = nil
+		w.mk = nil
+		_ = os.Remove(w.walletPath)
+		return err
+	}
+
+	// Return
+	return nil
+
+}
+
 // Delete the wallet store from disk
 func (w *Wallet) Delete() error {
 
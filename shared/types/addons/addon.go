@@ -13,3 +13,8 @@ type SmartnodeAddon interface {
 	GetContainerTag() string
 	GetEnabledParameter() *cfgtypes.Parameter
 }
+
+// Interface for addons that add flags to the Validator Client's command line while they're enabled
+type ValidatorFlagsProvider interface {
+	GetValidatorFlags(client cfgtypes.ConsensusClient) (string, error)
+}
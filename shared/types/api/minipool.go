@@ -11,6 +11,7 @@ import (
 	"github.com/rocket-pool/rocketpool-go/tokens"
 	"github.com/rocket-pool/rocketpool-go/types"
 	"github.com/rocket-pool/smartnode/shared/services/beacon"
+	"github.com/rocket-pool/smartnode/shared/services/performance"
 )
 
 type MinipoolStatusResponse struct {
@@ -351,3 +352,49 @@ type RescueDissolvedMinipoolResponse struct {
 	Error  string      `json:"error"`
 	TxHash common.Hash `json:"txHash"`
 }
+
+type MinipoolHistoryEvent struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	IsBeacon    bool           `json:"isBeacon"`
+	BlockNumber uint64         `json:"blockNumber"`
+	Time        time.Time      `json:"time"`
+	TxHash      common.Hash    `json:"txHash"`
+	From        common.Address `json:"from"`
+	GasUsed     uint64         `json:"gasUsed"`
+	GasCost     *big.Int       `json:"gasCost"`
+}
+type MinipoolHistoryResponse struct {
+	Status          string                 `json:"status"`
+	Error           string                 `json:"error"`
+	Address         common.Address         `json:"address"`
+	ValidatorPubkey types.ValidatorPubkey  `json:"validatorPubkey"`
+	ValidatorIndex  string                 `json:"validatorIndex"`
+	CurrentStatus   types.MinipoolStatus   `json:"currentStatus"`
+	Finalised       bool                   `json:"finalised"`
+	StartBlock      uint64                 `json:"startBlock"`
+	Events          []MinipoolHistoryEvent `json:"events"`
+	TotalGasCost    *big.Int               `json:"totalGasCost"`
+}
+
+type ValidatorPerformanceDetails struct {
+	MinipoolAddress common.Address                  `json:"minipoolAddress"`
+	ValidatorIndex  string                          `json:"validatorIndex"`
+	Counters        performance.PerformanceCounters `json:"counters"`
+}
+type MinipoolPerformanceResponse struct {
+	Status             string                        `json:"status"`
+	Error              string                        `json:"error"`
+	TrackingEnabled    bool                          `json:"trackingEnabled"`
+	LastProcessedEpoch uint64                        `json:"lastProcessedEpoch"`
+	Window             string                        `json:"window"`
+	Validators         []ValidatorPerformanceDetails `json:"validators"`
+}
+
+type MinipoolProposalsResponse struct {
+	Status             string                      `json:"status"`
+	Error              string                      `json:"error"`
+	AuditEnabled       bool                        `json:"auditEnabled"`
+	LastProcessedEpoch uint64                      `json:"lastProcessedEpoch"`
+	Proposals          []performance.ProposalAudit `json:"proposals"`
+}
@@ -116,3 +116,10 @@ type GetLatestDelegateResponse struct {
 	Error   string         `json:"error"`
 	Address common.Address `json:"address"`
 }
+
+type BeaconHeadResponse struct {
+	Status         string `json:"status"`
+	Error          string `json:"error"`
+	Epoch          uint64 `json:"epoch"`
+	FinalizedEpoch uint64 `json:"finalizedEpoch"`
+}
@@ -596,9 +596,11 @@ type GetSmoothingPoolRegistrationStatusResponse struct {
 	TimeLeftUntilChangeable time.Duration `json:"timeLeftUntilChangeable"`
 }
 type CanSetSmoothingPoolRegistrationStatusResponse struct {
-	Status  string             `json:"status"`
-	Error   string             `json:"error"`
-	GasInfo rocketpool.GasInfo `json:"gasInfo"`
+	Status               string             `json:"status"`
+	Error                string             `json:"error"`
+	CanChange            bool               `json:"canChange"`
+	UndistributedBalance *big.Int           `json:"undistributedBalance"`
+	GasInfo              rocketpool.GasInfo `json:"gasInfo"`
 }
 type SetSmoothingPoolRegistrationStatusResponse struct {
 	Status string      `json:"status"`
@@ -682,3 +684,21 @@ type NodeAlertsResponse struct {
 	// TODO: change to GettableAlerts
 	Message string `json:"message"`
 }
+
+type AutoTxDelayDetails struct {
+	Action        string    `json:"action"`
+	Subject       string    `json:"subject"`
+	FirstDelayed  time.Time `json:"firstDelayed"`
+	LastDelayed   time.Time `json:"lastDelayed"`
+	DelayCount    uint64    `json:"delayCount"`
+	MaxFeeGwei    float64   `json:"maxFeeGwei"`
+	ThresholdGwei float64   `json:"thresholdGwei"`
+	Deadline      time.Time `json:"deadline,omitempty"`
+	Reason        string    `json:"reason"`
+}
+type NodeAutoTxDelaysResponse struct {
+	Status        string               `json:"status"`
+	Error         string               `json:"error"`
+	ThresholdGwei float64              `json:"thresholdGwei"`
+	Delays        []AutoTxDelayDetails `json:"delays"`
+}
@@ -2,11 +2,14 @@ package api
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/rocket-pool/rocketpool-go/dao"
 	tn "github.com/rocket-pool/rocketpool-go/dao/trustednode"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
+
+	"github.com/rocket-pool/smartnode/shared/services/duties"
 )
 
 type TNDAOStatusResponse struct {
@@ -314,3 +317,12 @@ type GetTNDAOMinipoolSettingsResponse struct {
 	BondReductionWindowStart  uint64 `json:"bondReductionWindowStart"`
 	BondReductionWindowLength uint64 `json:"bondReductionWindowLength"`
 }
+
+type TNDAODutiesResponse struct {
+	Status         string               `json:"status"`
+	Error          string               `json:"error"`
+	IsMember       bool                 `json:"isMember"`
+	LastUpdateTime time.Time            `json:"lastUpdateTime"`
+	Summaries      []duties.DutySummary `json:"summaries"`
+	Rounds         []duties.DutyRound   `json:"rounds"`
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/rocket-pool/rocketpool-go/dao/protocol"
 	"github.com/rocket-pool/rocketpool-go/rocketpool"
 	"github.com/rocket-pool/rocketpool-go/types"
+
+	"github.com/rocket-pool/smartnode/shared/services/proposals"
 )
 
 type PDAOProposalWithNodeVoteDirection struct {
@@ -465,3 +467,79 @@ type PDAOClearSignallingAddressResponse struct {
 	Error  string      `json:"error"`
 	TxHash common.Hash `json:"txHash"`
 }
+
+type PDAOVotePolicyStatusResponse struct {
+	Status         string                         `json:"status"`
+	Error          string                         `json:"error"`
+	Enabled        bool                           `json:"enabled"`
+	PolicyPath     string                         `json:"policyPath"`
+	PolicyExists   bool                           `json:"policyExists"`
+	PolicyError    string                         `json:"policyError"`
+	PolicyHash     string                         `json:"policyHash"`
+	RuleCount      int                            `json:"ruleCount"`
+	LeadTime       time.Duration                  `json:"leadTime"`
+	ForcedDryRun   bool                           `json:"forcedDryRun"`
+	IsConfirmed    bool                           `json:"isConfirmed"`
+	ConfirmedHash  string                         `json:"confirmedHash"`
+	AutoTxDisabled bool                           `json:"autoTxDisabled"`
+	Decisions      []proposals.VotePolicyDecision `json:"decisions"`
+}
+
+type PDAOConfirmVotePolicyResponse struct {
+	Status     string `json:"status"`
+	Error      string `json:"error"`
+	HashMatch  bool   `json:"hashMatch"`
+	PolicyHash string `json:"policyHash"`
+}
+
+type PDAODigestResponse struct {
+	Status       string                     `json:"status"`
+	Error        string                     `json:"error"`
+	Digest       proposals.GovernanceDigest `json:"digest"`
+	Events       []proposals.DigestEvent    `json:"events"`
+	LastSentTime time.Time                  `json:"lastSentTime"`
+}
+
+type PDAOSimulateProposalResponse struct {
+	Status     string                         `json:"status"`
+	Error      string                         `json:"error"`
+	ProposalID uint64                         `json:"proposalId"`
+	Message    string                         `json:"message"`
+	State      types.ProtocolDaoProposalState `json:"state"`
+	Method     string                         `json:"method"`
+	Simulation proposals.ProposalSimulation   `json:"simulation"`
+}
+
+type PDAOChallengesResponse struct {
+	Status         string                        `json:"status"`
+	Error          string                        `json:"error"`
+	VerifyEnabled  bool                          `json:"verifyEnabled"`
+	LastUpdateTime time.Time                     `json:"lastUpdateTime"`
+	Summary        proposals.ChallengeSummary    `json:"summary"`
+	Proposals      []proposals.MonitoredProposal `json:"proposals"`
+	ClaimableBonds []BondClaimResult             `json:"claimableBonds"`
+}
+
+type PDAODelegatesResponse struct {
+	Status       string                      `json:"status"`
+	Error        string                      `json:"error"`
+	NodeDelegate common.Address              `json:"nodeDelegate"`
+	Insights     *proposals.DelegateInsights `json:"insights"`
+}
+
+type PDAOExportVotingArtifactsResponse struct {
+	Status      string               `json:"status"`
+	Error       string               `json:"error"`
+	BlockNumber uint32               `json:"blockNumber"`
+	NetworkRoot types.VotingTreeNode `json:"networkRoot"`
+	NodeCount   int                  `json:"nodeCount"`
+}
+
+type PDAOImportVotingArtifactsResponse struct {
+	Status            string               `json:"status"`
+	Error             string               `json:"error"`
+	BlockNumber       uint32               `json:"blockNumber"`
+	NetworkRoot       types.VotingTreeNode `json:"networkRoot"`
+	NodeCount         int                  `json:"nodeCount"`
+	VerifiedProposals []uint64             `json:"verifiedProposals"`
+}
@@ -1,6 +1,10 @@
 package api
 
-import "github.com/ethereum/go-ethereum/common"
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/rocket-pool/smartnode/shared/services/backup"
+)
 
 type TerminateDataFolderResponse struct {
 	Status        string `json:"status"`
@@ -41,3 +45,50 @@ type RestartVcResponse struct {
 	Status string `json:"status"`
 	Error  string `json:"error"`
 }
+
+// The result of a service doctor check
+type DoctorCheckResult string
+
+const (
+	DoctorCheckResult_Pass DoctorCheckResult = "pass"
+	DoctorCheckResult_Warn DoctorCheckResult = "warn"
+	DoctorCheckResult_Fail DoctorCheckResult = "fail"
+)
+
+// A single check run by the service doctor
+type DoctorCheck struct {
+	Name        string            `json:"name"`
+	Result      DoctorCheckResult `json:"result"`
+	Message     string            `json:"message"`
+	Remediation string            `json:"remediation,omitempty"`
+}
+
+type ServiceDoctorResponse struct {
+	Status string        `json:"status"`
+	Error  string        `json:"error"`
+	Checks []DoctorCheck `json:"checks"`
+}
+
+type CreateBackupResponse struct {
+	Status      string      `json:"status"`
+	Error       string      `json:"error"`
+	Destination string      `json:"destination"`
+	Backup      backup.Info `json:"backup"`
+	Deleted     []string    `json:"deleted"`
+}
+
+type ListBackupsResponse struct {
+	Status      string        `json:"status"`
+	Error       string        `json:"error"`
+	Destination string        `json:"destination"`
+	Backups     []backup.Info `json:"backups"`
+}
+
+type RestoreBackupResponse struct {
+	Status             string            `json:"status"`
+	Error              string            `json:"error"`
+	Manifest           backup.Manifest   `json:"manifest"`
+	Settings           string            `json:"settings"`
+	SlashingProtection string            `json:"slashingProtection"`
+	MovedAside         map[string]string `json:"movedAside"`
+}
@@ -68,6 +68,12 @@ type ExportWalletResponse struct {
 	AccountPrivateKey string `json:"accountPrivateKey"`
 }
 
+type ImportMigrationWalletResponse struct {
+	Status         string         `json:"status"`
+	Error          string         `json:"error"`
+	AccountAddress common.Address `json:"accountAddress"`
+}
+
 type SetEnsNameResponse struct {
 	Status  string             `json:"status"`
 	Error   string             `json:"error"`
@@ -7,6 +7,27 @@ import (
 	"strconv"
 )
 
+// The placeholder for the value of a sensitive parameter in redacted settings
+const RedactedValue string = "<redacted>"
+
+// The largest magnitude a serialized int or uint can have; keeping them to 18 and 19 digits means anything that
+// matches their format also fits in 64 bits
+const (
+	maxSerializedInt  int64  = 999999999999999999
+	maxSerializedUint uint64 = 9999999999999999999
+)
+
+// The formats of serialized numeric and boolean values, which are the ones the Smartnode writes to the settings file.
+// These are shared by ValidateSerializedValue and the JSON Schema so the two always accept the same values; the only
+// exception is floats too large for 64 bits, which can't be described by a pattern and are only caught when parsed.
+var serializedValuePatterns = map[ParameterType]*regexp.Regexp{
+	ParameterType_Int:    regexp.MustCompile(`^-?(0|[1-9][0-9]{0,17})$`),
+	ParameterType_Uint:   regexp.MustCompile(`^(0|[1-9][0-9]{0,18})$`),
+	ParameterType_Uint16: regexp.MustCompile(`^(0|[1-9][0-9]{0,3}|[1-5][0-9]{4}|6[0-4][0-9]{3}|65[0-4][0-9]{2}|655[0-2][0-9]|6553[0-5])$`),
+	ParameterType_Float:  regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?([eE][-+]?[0-9]+)?$`),
+	ParameterType_Bool:   regexp.MustCompile(`^(true|false)$`),
+}
+
 // A parameter that can be configured by the user
 type Parameter struct {
 	ID                    string                  `yaml:"id,omitempty"`
@@ -20,6 +41,7 @@ type Parameter struct {
 	AffectsContainers     []ContainerID           `yaml:"affectsContainers,omitempty"`
 	CanBeBlank            bool                    `yaml:"canBeBlank,omitempty"`
 	OverwriteOnUpgrade    bool                    `yaml:"overwriteOnUpgrade,omitempty"`
+	Sensitive             bool                    `yaml:"sensitive,omitempty"`
 	Options               []ParameterOption       `yaml:"options,omitempty"`
 	Value                 interface{}             `yaml:"-"`
 	DescriptionsByNetwork map[Network]string      `yaml:"-"`
@@ -67,6 +89,14 @@ func (param *Parameter) Serialize(serializedParams map[string]string) {
 	serializedParams[param.ID] = value
 }
 
+// Serializes the parameter's value into a string, masking it if the parameter is sensitive and isn't blank
+func (param *Parameter) SerializeRedacted(serializedParams map[string]string) {
+	param.Serialize(serializedParams)
+	if param.Sensitive && serializedParams[param.ID] != "" {
+		serializedParams[param.ID] = RedactedValue
+	}
+}
+
 // Deserializes a map of settings into this parameter
 func (param *Parameter) Deserialize(serializedParams map[string]string, network Network) error {
 	// Update the description, if applicable
@@ -130,6 +160,56 @@ func (param *Parameter) Deserialize(serializedParams map[string]string, network
 	return nil
 }
 
+// Checks a serialized value against the parameter's type, format, length, and options without applying it.
+// Unlike Deserialize, this reports problems that would otherwise be silently replaced with a default. It's also
+// stricter about formats: Deserialize accepts anything strconv can parse (like 0x10 or TRUE) for backwards
+// compatibility, but this only accepts the formats in serializedValuePatterns, which the JSON Schema describes.
+func (param *Parameter) ValidateSerializedValue(value string) error {
+	if pattern, exists := serializedValuePatterns[param.Type]; exists && !pattern.MatchString(value) {
+		return fmt.Errorf("[%s] is not a valid %s", value, param.Type)
+	}
+
+	var err error
+	switch param.Type {
+	case ParameterType_Int:
+		_, err = strconv.ParseInt(value, 0, 0)
+	case ParameterType_Uint:
+		_, err = strconv.ParseUint(value, 0, 0)
+	case ParameterType_Uint16:
+		_, err = strconv.ParseUint(value, 0, 16)
+	case ParameterType_Bool:
+		_, err = strconv.ParseBool(value)
+	case ParameterType_Float:
+		_, err = strconv.ParseFloat(value, 64)
+	case ParameterType_String:
+		if param.Regex != "" && value != "" {
+			regex := regexp.MustCompile(param.Regex)
+			if !regex.MatchString(value) {
+				return fmt.Errorf("[%s] does not match the expected format", value)
+			}
+		}
+		if param.MaxLength > 0 && len(value) > param.MaxLength {
+			return fmt.Errorf("[%s] is longer than the max length of [%d]", value, param.MaxLength)
+		}
+	case ParameterType_Choice:
+		for _, option := range param.Options {
+			if fmt.Sprint(option.Value) == value {
+				return nil
+			}
+		}
+		options := make([]string, len(param.Options))
+		for i, option := range param.Options {
+			options[i] = fmt.Sprint(option.Value)
+		}
+		return fmt.Errorf("[%s] is not one of the valid options %v", value, options)
+	}
+
+	if err != nil {
+		return fmt.Errorf("[%s] is not a valid %s", value, param.Type)
+	}
+	return nil
+}
+
 // Set the value to the default for the provided config's network
 func (param *Parameter) SetToDefault(network Network) error {
 	defaultSetting, err := param.GetDefault(network)
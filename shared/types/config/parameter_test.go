@@ -0,0 +1,51 @@
+package config
+
+import "testing"
+
+func TestValidateSerializedValue(t *testing.T) {
+	choice := Parameter{ID: "choice", Type: ParameterType_Choice, Options: []ParameterOption{
+		{Name: "Geth", Value: ExecutionClient_Geth},
+		{Name: "Nethermind", Value: ExecutionClient_Nethermind},
+	}}
+	tests := []struct {
+		name  string
+		param Parameter
+		value string
+		valid bool
+	}{
+		{name: "int", param: Parameter{Type: ParameterType_Int}, value: "42", valid: true},
+		{name: "negative int", param: Parameter{Type: ParameterType_Int}, value: "-42", valid: true},
+		{name: "int with a fraction", param: Parameter{Type: ParameterType_Int}, value: "1.5"},
+		{name: "int that isn't a number", param: Parameter{Type: ParameterType_Int}, value: "abc"},
+		{name: "blank int", param: Parameter{Type: ParameterType_Int}, value: ""},
+		{name: "uint", param: Parameter{Type: ParameterType_Uint}, value: "42", valid: true},
+		{name: "negative uint", param: Parameter{Type: ParameterType_Uint}, value: "-1"},
+		{name: "uint16", param: Parameter{Type: ParameterType_Uint16}, value: "65535", valid: true},
+		{name: "uint16 that's too large", param: Parameter{Type: ParameterType_Uint16}, value: "65536"},
+		{name: "bool", param: Parameter{Type: ParameterType_Bool}, value: "false", valid: true},
+		{name: "bool that isn't a bool", param: Parameter{Type: ParameterType_Bool}, value: "yes"},
+		{name: "float", param: Parameter{Type: ParameterType_Float}, value: "1.5", valid: true},
+		{name: "float that isn't a number", param: Parameter{Type: ParameterType_Float}, value: "abc"},
+		{name: "string", param: Parameter{Type: ParameterType_String}, value: "anything", valid: true},
+		{name: "string matching the regex", param: Parameter{Type: ParameterType_String, Regex: "^[a-z]+$"}, value: "abc", valid: true},
+		{name: "string not matching the regex", param: Parameter{Type: ParameterType_String, Regex: "^[a-z]+$"}, value: "ABC"},
+		{name: "blank string with a regex", param: Parameter{Type: ParameterType_String, Regex: "^[a-z]+$"}, value: "", valid: true},
+		{name: "string at the max length", param: Parameter{Type: ParameterType_String, MaxLength: 3}, value: "abc", valid: true},
+		{name: "string over the max length", param: Parameter{Type: ParameterType_String, MaxLength: 3}, value: "abcd"},
+		{name: "choice", param: choice, value: "nethermind", valid: true},
+		{name: "choice that isn't an option", param: choice, value: "besu"},
+		{name: "choice with the wrong case", param: choice, value: "Geth"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.param.ValidateSerializedValue(test.value)
+			if test.valid && err != nil {
+				t.Fatalf("expected [%s] to be valid, got %s", test.value, err.Error())
+			}
+			if !test.valid && err == nil {
+				t.Fatalf("expected [%s] to be invalid", test.value)
+			}
+		})
+	}
+}
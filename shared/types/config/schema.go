@@ -0,0 +1,99 @@
+package config
+
+import (
+	"fmt"
+	"math"
+)
+
+// The JSON Schema dialect used to describe settings files
+const JsonSchemaDialect string = "https://json-schema.org/draft/2020-12/schema"
+
+// Get a JSON Schema describing the serialized value of this parameter in a settings file.
+// Settings files store every value as a string, but hand-written ones may use plain YAML scalars, so numeric and
+// boolean parameters accept both. Strings have to match the same formats ValidateSerializedValue checks; the minimum
+// and maximum only apply to plain numbers, since the formats already bound the strings. Defaults are given for mainnet, along with any network-specific overrides.
+func (param *Parameter) GetJsonSchema(networks []Network) map[string]interface{} {
+	schema := map[string]interface{}{
+		"title":       param.Name,
+		"description": param.Description,
+	}
+
+	switch param.Type {
+	case ParameterType_Bool:
+		schema["type"] = []string{"boolean", "string"}
+		schema["pattern"] = serializedValuePatterns[param.Type].String()
+	case ParameterType_Int:
+		schema["type"] = []string{"integer", "string"}
+		schema["minimum"] = -maxSerializedInt
+		schema["maximum"] = maxSerializedInt
+		schema["pattern"] = serializedValuePatterns[param.Type].String()
+	case ParameterType_Uint:
+		schema["type"] = []string{"integer", "string"}
+		schema["minimum"] = 0
+		schema["maximum"] = maxSerializedUint
+		schema["pattern"] = serializedValuePatterns[param.Type].String()
+	case ParameterType_Uint16:
+		schema["type"] = []string{"integer", "string"}
+		schema["minimum"] = 0
+		schema["maximum"] = math.MaxUint16
+		schema["pattern"] = serializedValuePatterns[param.Type].String()
+	case ParameterType_Float:
+		schema["type"] = []string{"number", "string"}
+		schema["pattern"] = serializedValuePatterns[param.Type].String()
+	case ParameterType_String:
+		schema["type"] = "string"
+		if param.MaxLength > 0 {
+			schema["maxLength"] = param.MaxLength
+		}
+		if param.Regex != "" {
+			// Blank values fall back to the default, so they're always allowed
+			schema["pattern"] = fmt.Sprintf("^$|%s", param.Regex)
+		}
+	case ParameterType_Choice:
+		schema["type"] = "string"
+		options := make([]interface{}, len(param.Options))
+		for i, option := range param.Options {
+			options[i] = map[string]interface{}{
+				"const":       fmt.Sprint(option.Value),
+				"title":       option.Name,
+				"description": option.Description,
+			}
+		}
+		schema["oneOf"] = options
+	}
+
+	// Add the defaults
+	if defaultValue, err := param.GetDefault(Network_Mainnet); err == nil {
+		schema["default"] = param.getJsonSchemaValue(defaultValue)
+	}
+	_, hasSharedDefault := param.Default[Network_All]
+	if !hasSharedDefault || len(param.Default) > 1 {
+		networkDefaults := map[string]interface{}{}
+		for _, network := range networks {
+			if defaultValue, err := param.GetDefault(network); err == nil {
+				networkDefaults[string(network)] = param.getJsonSchemaValue(defaultValue)
+			}
+		}
+		schema["x-networkDefaults"] = networkDefaults
+	}
+
+	// Add the containers that have to be restarted when it changes
+	if len(param.AffectsContainers) > 0 {
+		schema["x-affectsContainers"] = param.AffectsContainers
+	}
+	if param.Advanced {
+		schema["x-advanced"] = true
+	}
+
+	return schema
+}
+
+// Get a value the way it should appear in a JSON Schema for this parameter
+func (param *Parameter) getJsonSchemaValue(value interface{}) interface{} {
+	switch param.Type {
+	case ParameterType_Choice:
+		return fmt.Sprint(value)
+	default:
+		return value
+	}
+}
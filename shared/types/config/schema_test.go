@@ -0,0 +1,173 @@
+package config
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"testing"
+	"unicode/utf8"
+)
+
+// Check a serialized string value against the parts of a parameter's JSON Schema that apply to strings
+func schemaAcceptsString(t *testing.T, schema map[string]interface{}, value string) bool {
+	switch types := schema["type"].(type) {
+	case string:
+		if types != "string" {
+			return false
+		}
+	case []string:
+		isString := false
+		for _, schemaType := range types {
+			isString = isString || schemaType == "string"
+		}
+		if !isString {
+			return false
+		}
+	}
+
+	if pattern, exists := schema["pattern"]; exists {
+		regex, err := regexp.Compile(pattern.(string))
+		if err != nil {
+			t.Fatalf("schema has an invalid pattern: %s", err.Error())
+		}
+		if !regex.MatchString(value) {
+			return false
+		}
+	}
+	if maxLength, exists := schema["maxLength"]; exists && utf8.RuneCountInString(value) > maxLength.(int) {
+		return false
+	}
+	if options, exists := schema["oneOf"]; exists {
+		for _, option := range options.([]interface{}) {
+			if option.(map[string]interface{})["const"] == value {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// Check a plain YAML integer against the bounds of a parameter's JSON Schema
+func schemaAcceptsInteger(schema map[string]interface{}, value string) bool {
+	number, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return false
+	}
+	if minimum, exists := schema["minimum"]; exists {
+		bound, _ := new(big.Int).SetString(fmt.Sprint(minimum), 10)
+		if number.Cmp(bound) < 0 {
+			return false
+		}
+	}
+	if maximum, exists := schema["maximum"]; exists {
+		bound, _ := new(big.Int).SetString(fmt.Sprint(maximum), 10)
+		if number.Cmp(bound) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func TestJsonSchemaMatchesValidation(t *testing.T) {
+	tests := []struct {
+		param  Parameter
+		values []string
+	}{
+		{
+			param:  Parameter{ID: "bool", Type: ParameterType_Bool},
+			values: []string{"true", "false", "", "1", "0", "t", "TRUE", "False", "yes"},
+		},
+		{
+			param: Parameter{ID: "int", Type: ParameterType_Int},
+			values: []string{"0", "-0", "42", "-42", "007", "+5", "0x10", "1_000", "1.5", "", "abc",
+				"999999999999999999", "-999999999999999999", "1000000000000000000", "9223372036854775808"},
+		},
+		{
+			param: Parameter{ID: "uint", Type: ParameterType_Uint},
+			values: []string{"0", "42", "-1", "007", "0x10", "0b11", "1_000", "", "abc",
+				"9999999999999999999", "10000000000000000000", "18446744073709551616"},
+		},
+		{
+			param:  Parameter{ID: "uint16", Type: ParameterType_Uint16},
+			values: []string{"0", "9000", "65535", "65536", "70000", "99999", "-1", "0xffff", "08080", ""},
+		},
+		{
+			param:  Parameter{ID: "float", Type: ParameterType_Float},
+			values: []string{"0", "1.5", "-1.5", "1e-07", "2.5E+10", ".5", "5.", "0x1p-2", "inf", "NaN", "1_000.5", ""},
+		},
+		{
+			param:  Parameter{ID: "string", Type: ParameterType_String, Regex: "^[a-z]+$", MaxLength: 5},
+			values: []string{"", "abc", "abcde", "abcdef", "ABC", "ab1"},
+		},
+		{
+			param: Parameter{ID: "choice", Type: ParameterType_Choice, Options: []ParameterOption{
+				{Name: "Geth", Value: ExecutionClient_Geth},
+				{Name: "Nethermind", Value: ExecutionClient_Nethermind},
+			}},
+			values: []string{"geth", "nethermind", "Geth", "besu", ""},
+		},
+	}
+
+	for _, test := range tests {
+		schema := test.param.GetJsonSchema([]Network{Network_Mainnet})
+		for _, value := range test.values {
+			t.Run(fmt.Sprintf("%s %q", test.param.ID, value), func(t *testing.T) {
+				validationErr := test.param.ValidateSerializedValue(value)
+				accepted := schemaAcceptsString(t, schema, value)
+				if accepted && validationErr != nil {
+					t.Fatalf("the schema accepts the value but validation rejects it: %s", validationErr.Error())
+				}
+				if !accepted && validationErr == nil {
+					t.Fatalf("validation accepts the value but the schema rejects it")
+				}
+			})
+		}
+	}
+}
+
+func TestJsonSchemaBoundsMatchValidation(t *testing.T) {
+	// Plain YAML numbers are checked with the schema's bounds instead of its pattern, so they have to line up with
+	// the largest and smallest values the patterns allow
+	tests := []struct {
+		param    Parameter
+		accepted []string
+		rejected []string
+	}{
+		{
+			param:    Parameter{ID: "int", Type: ParameterType_Int},
+			accepted: []string{"-999999999999999999", "999999999999999999"},
+			rejected: []string{"-1000000000000000000", "1000000000000000000"},
+		},
+		{
+			param:    Parameter{ID: "uint", Type: ParameterType_Uint},
+			accepted: []string{"0", "9999999999999999999"},
+			rejected: []string{"-1", "100000000000000000000"},
+		},
+		{
+			param:    Parameter{ID: "uint16", Type: ParameterType_Uint16},
+			accepted: []string{"0", "65535"},
+			rejected: []string{"-1", "65536"},
+		},
+	}
+
+	for _, test := range tests {
+		schema := test.param.GetJsonSchema([]Network{Network_Mainnet})
+		for _, value := range test.accepted {
+			if !schemaAcceptsInteger(schema, value) {
+				t.Fatalf("expected the %s schema to accept %s", test.param.ID, value)
+			}
+			if err := test.param.ValidateSerializedValue(value); err != nil {
+				t.Fatalf("expected %s to be a valid %s: %s", value, test.param.ID, err.Error())
+			}
+		}
+		for _, value := range test.rejected {
+			if schemaAcceptsInteger(schema, value) {
+				t.Fatalf("expected the %s schema to reject %s", test.param.ID, value)
+			}
+			if err := test.param.ValidateSerializedValue(value); err == nil {
+				t.Fatalf("expected %s to be an invalid %s", value, test.param.ID)
+			}
+		}
+	}
+}
@@ -138,6 +138,7 @@ type ExternalConsensusConfig interface {
 
 // A setting that has changed
 type ChangedSetting struct {
+	ID                 string
 	Name               string
 	OldValue           string
 	NewValue           string
@@ -88,19 +88,31 @@ func PrintAndWaitForTransaction(cfg *config.RocketPoolConfig, hash common.Hash,
 // True if a transaction is due and needs to bypass the gas threshold
 func IsTransactionDue(rp *rocketpool.RocketPool, startTime time.Time) (bool, time.Duration, error) {
 
-	// Get the dissolve timeout
-	timeout, err := protocol.GetMinipoolLaunchTimeout(rp, nil)
+	// Get the due time
+	dueTime, err := GetTransactionDueTime(rp, startTime)
 	if err != nil {
 		return false, 0, err
 	}
 
-	dueTime := timeout / time.Duration(TimeoutSafetyFactor)
-	isDue := time.Since(startTime) > dueTime
-	timeUntilDue := time.Until(startTime.Add(dueTime))
+	isDue := time.Now().After(dueTime)
+	timeUntilDue := time.Until(dueTime)
 	return isDue, timeUntilDue, nil
 
 }
 
+// Get the time after which a transaction is due and needs to bypass the gas threshold
+func GetTransactionDueTime(rp *rocketpool.RocketPool, startTime time.Time) (time.Time, error) {
+
+	// Get the dissolve timeout
+	timeout, err := protocol.GetMinipoolLaunchTimeout(rp, nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return startTime.Add(timeout / time.Duration(TimeoutSafetyFactor)), nil
+
+}
+
 //  Expects a 129 byte 0x-prefixed EIP-712 signature and returns v/r/s as v uint8 and r, s [32]byte
 
 func ParseEIP712(signature string) (*EIP712Components, error) {
@@ -0,0 +1,67 @@
+package atomicfile
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/goccy/go-json"
+)
+
+// The permissions of the files written by SaveJson
+const FileMode fs.FileMode = 0644
+
+// Write a file by writing a temporary file next to it and renaming that into place, so readers never see a partial file.
+// The folder is created if it doesn't exist yet.
+func Write(path string, data []byte, mode fs.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("error creating folder %s: %w", dir, err)
+	}
+
+	file, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("error creating temporary file for %s: %w", path, err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+	if _, err := file.Write(data); err != nil {
+		return fmt.Errorf("error writing %s: %w", file.Name(), err)
+	}
+	if err := file.Chmod(mode); err != nil {
+		return fmt.Errorf("error setting permissions of %s: %w", file.Name(), err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error writing %s: %w", file.Name(), err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return fmt.Errorf("error moving %s to %s: %w", file.Name(), path, err)
+	}
+	return nil
+}
+
+// Serialize a value to JSON and write it to a file with Write
+func SaveJson(path string, value interface{}) error {
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("error serializing %s: %w", path, err)
+	}
+	return Write(path, bytes, FileMode)
+}
+
+// Deserialize a JSON file into a value. Returns false, leaving the value untouched, if the file doesn't exist.
+func LoadJson(path string, value interface{}) (bool, error) {
+	bytes, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error reading %s: %w", path, err)
+	}
+	if err := json.Unmarshal(bytes, value); err != nil {
+		return false, fmt.Errorf("error deserializing %s: %w", path, err)
+	}
+	return true, nil
+}
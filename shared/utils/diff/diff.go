@@ -0,0 +1,140 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A single line of a diff
+type line struct {
+	kind    byte
+	text    string
+	oldLine int
+	newLine int
+}
+
+// Get a unified diff between two texts with the given number of context lines around each change, or an empty string
+// if they're the same
+func Unified(oldName string, newName string, oldText string, newText string, context int) string {
+	if oldText == newText {
+		return ""
+	}
+	lines := getLines(splitLines(oldText), splitLines(newText))
+
+	builder := &strings.Builder{}
+	fmt.Fprintf(builder, "--- %s\n+++ %s\n", oldName, newName)
+
+	// Group the changes into hunks, merging the ones whose context overlaps
+	for start := 0; start < len(lines); {
+		if lines[start].kind == ' ' {
+			start++
+			continue
+		}
+		hunkStart := start - context
+		if hunkStart < 0 {
+			hunkStart = 0
+		}
+		hunkEnd := start
+		for i := start; i < len(lines) && i <= hunkEnd+2*context+1; i++ {
+			if lines[i].kind != ' ' {
+				hunkEnd = i
+			}
+		}
+		hunkEnd += context
+		if hunkEnd >= len(lines) {
+			hunkEnd = len(lines) - 1
+		}
+		writeHunk(builder, lines[hunkStart:hunkEnd+1])
+		start = hunkEnd + 1
+	}
+	return builder.String()
+}
+
+// Write a hunk of a unified diff
+func writeHunk(builder *strings.Builder, lines []line) {
+	oldStart, oldCount, newStart, newCount := 0, 0, 0, 0
+	for _, hunkLine := range lines {
+		if hunkLine.kind != '+' {
+			if oldCount == 0 {
+				oldStart = hunkLine.oldLine
+			}
+			oldCount++
+		}
+		if hunkLine.kind != '-' {
+			if newCount == 0 {
+				newStart = hunkLine.newLine
+			}
+			newCount++
+		}
+	}
+	fmt.Fprintf(builder, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+	for _, hunkLine := range lines {
+		fmt.Fprintf(builder, "%c%s\n", hunkLine.kind, hunkLine.text)
+	}
+}
+
+// Get the lines of both texts marked as kept, removed, or added, using their longest common subsequence
+func getLines(oldLines []string, newLines []string) []line {
+
+	// Skip the common prefix and suffix so the table only covers the lines that changed
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix && oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+	oldMiddle := oldLines[prefix : len(oldLines)-suffix]
+	newMiddle := newLines[prefix : len(newLines)-suffix]
+
+	// lengths[i][j] is the length of the longest common subsequence of oldMiddle[i:] and newMiddle[j:]
+	lengths := make([][]int, len(oldMiddle)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(newMiddle)+1)
+	}
+	for i := len(oldMiddle) - 1; i >= 0; i-- {
+		for j := len(newMiddle) - 1; j >= 0; j-- {
+			if oldMiddle[i] == newMiddle[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	lines := make([]line, 0, len(oldLines)+len(newLines))
+	for i := 0; i < prefix; i++ {
+		lines = append(lines, line{kind: ' ', text: oldLines[i], oldLine: i + 1, newLine: i + 1})
+	}
+	i, j := 0, 0
+	for i < len(oldMiddle) || j < len(newMiddle) {
+		switch {
+		case i < len(oldMiddle) && j < len(newMiddle) && oldMiddle[i] == newMiddle[j]:
+			lines = append(lines, line{kind: ' ', text: oldMiddle[i], oldLine: prefix + i + 1, newLine: prefix + j + 1})
+			i++
+			j++
+		case j == len(newMiddle) || (i < len(oldMiddle) && lengths[i+1][j] >= lengths[i][j+1]):
+			lines = append(lines, line{kind: '-', text: oldMiddle[i], oldLine: prefix + i + 1, newLine: prefix + j + 1})
+			i++
+		default:
+			lines = append(lines, line{kind: '+', text: newMiddle[j], oldLine: prefix + i + 1, newLine: prefix + j + 1})
+			j++
+		}
+	}
+	for k := 0; k < suffix; k++ {
+		lines = append(lines, line{kind: ' ', text: oldLines[len(oldLines)-suffix+k], oldLine: len(oldLines) - suffix + k + 1, newLine: len(newLines) - suffix + k + 1})
+	}
+	return lines
+
+}
+
+// Split a text into lines, ignoring the newline at the end of the last one
+func splitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
@@ -0,0 +1,88 @@
+package diff
+
+import (
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldText  string
+		newText  string
+		context  int
+		expected string
+	}{
+		{
+			name:     "identical",
+			oldText:  "a\nb\nc\n",
+			newText:  "a\nb\nc\n",
+			context:  3,
+			expected: "",
+		},
+		{
+			name:    "changed line",
+			oldText: "a\nb\nc\n",
+			newText: "a\nB\nc\n",
+			context: 1,
+			expected: "--- old\n+++ new\n" +
+				"@@ -1,3 +1,3 @@\n" +
+				" a\n-b\n+B\n c\n",
+		},
+		{
+			name:    "added lines",
+			oldText: "a\nb\n",
+			newText: "a\nb\nc\nd\n",
+			context: 1,
+			expected: "--- old\n+++ new\n" +
+				"@@ -2,1 +2,3 @@\n" +
+				" b\n+c\n+d\n",
+		},
+		{
+			name:    "removed line at the start",
+			oldText: "a\nb\nc\n",
+			newText: "b\nc\n",
+			context: 1,
+			expected: "--- old\n+++ new\n" +
+				"@@ -1,2 +1,1 @@\n" +
+				"-a\n b\n",
+		},
+		{
+			name:    "from empty",
+			oldText: "",
+			newText: "a\n",
+			context: 3,
+			expected: "--- old\n+++ new\n" +
+				"@@ -0,0 +1,1 @@\n" +
+				"+a\n",
+		},
+		{
+			name:    "separate hunks",
+			oldText: "1\n2\n3\n4\n5\n6\n7\n8\n9\n",
+			newText: "one\n2\n3\n4\n5\n6\n7\n8\nnine\n",
+			context: 1,
+			expected: "--- old\n+++ new\n" +
+				"@@ -1,2 +1,2 @@\n" +
+				"-1\n+one\n 2\n" +
+				"@@ -8,2 +8,2 @@\n" +
+				" 8\n-9\n+nine\n",
+		},
+		{
+			name:    "overlapping context is merged",
+			oldText: "1\n2\n3\n4\n5\n",
+			newText: "one\n2\n3\nfour\n5\n",
+			context: 1,
+			expected: "--- old\n+++ new\n" +
+				"@@ -1,5 +1,5 @@\n" +
+				"-1\n+one\n 2\n 3\n-4\n+four\n 5\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := Unified("old", "new", test.oldText, test.newText, test.context)
+			if result != test.expected {
+				t.Fatalf("expected:\n%s\ngot:\n%s", test.expected, result)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package eth2
 
 import (
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -32,6 +33,11 @@ func EpochAt(config beacon.Eth2Config, time uint64) uint64 {
 	return config.GenesisEpoch + (time-config.GenesisTime)/config.SecondsPerEpoch
 }
 
+// Get the time at the start of an eth2 epoch
+func TimeAt(config beacon.Eth2Config, epoch uint64) time.Time {
+	return time.Unix(int64(config.GenesisTime+(epoch-config.GenesisEpoch)*config.SecondsPerEpoch), 0)
+}
+
 // Get the balances of the minipools on the beacon chain
 func GetBeaconBalances(rp *rocketpool.RocketPool, bc beacon.Client, addresses []common.Address, beaconHead beacon.BeaconHead, opts *bind.CallOpts) ([]minipoolBalanceDetails, error) {
 
@@ -6,6 +6,9 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/alessio/shellescape"
 	"github.com/rocket-pool/smartnode/shared/services/config"
@@ -13,9 +16,25 @@ import (
 )
 
 const (
-	upgradeFlagFile string = ".firstrun"
+	upgradeFlagFile   string = ".firstrun"
+	settingsBackupDir string = "settings-backups"
+	rootConfigName    string = "root"
+
+	// The format of the timestamp in settings backup filenames
+	settingsBackupTimeFormat string = "20060102-150405"
+
+	// The number of settings backups to keep; older ones are deleted when a new one is made
+	settingsBackupRetention int = 10
 )
 
+// A versioned backup of a settings file
+type SettingsBackup struct {
+	Name    string
+	Path    string
+	Version string
+	Time    time.Time
+}
+
 // Loads a config without updating it if it exists
 func LoadConfigFromFile(path string) (*config.RocketPoolConfig, error) {
 	_, err := os.Stat(path)
@@ -33,14 +52,36 @@ func LoadConfigFromFile(path string) (*config.RocketPoolConfig, error) {
 
 // Saves a config and removes the upgrade flag file
 func SaveConfig(cfg *config.RocketPoolConfig, directory, filename string) error {
+	return SaveSettings(cfg.Serialize(), directory, filename)
+}
+
+// Saves serialized settings. If they're for a different Smartnode version than the settings on disk, the old settings
+// are backed up first so the upgrade (or downgrade) can be rolled back.
+func SaveSettings(settings map[string]map[string]string, directory, filename string) error {
 	path := filepath.Join(directory, filename)
 
-	settings := cfg.Serialize()
 	configBytes, err := yaml.Marshal(settings)
 	if err != nil {
 		return fmt.Errorf("could not serialize settings file: %w", err)
 	}
 
+	// Back up the existing settings if this changes their version; unreadable settings are always backed up
+	_, err = os.Stat(path)
+	oldVersion, versionErr := getSettingsFileVersion(path)
+	if err == nil && (versionErr != nil || oldVersion != settings[rootConfigName]["version"]) {
+		_, err = BackupSettingsFile(directory, filename)
+		if err != nil {
+			return err
+		}
+	}
+
+	return writeSettingsFile(configBytes, directory, filename)
+}
+
+// Writes a settings file atomically
+func writeSettingsFile(configBytes []byte, directory, filename string) error {
+	path := filepath.Join(directory, filename)
+
 	// Make a tmp file
 	// The empty string directs CreateTemp to use the OS's $TMPDIR (or GetTempPath) on windows
 	// The * in the second string is replaced with random characters by CreateTemp
@@ -127,3 +168,125 @@ func RemoveUpgradeFlagFile(configDir string) error {
 	return nil
 
 }
+
+// Copies a settings file into the backup folder, tagged with the Smartnode version it was made by and the current time.
+// Returns the path of the backup, or an empty string if there was no settings file to back up.
+func BackupSettingsFile(directory, filename string) (string, error) {
+	path := filepath.Join(directory, filename)
+	configBytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading settings file %s: %w", shellescape.Quote(path), err)
+	}
+	version, err := getSettingsFileVersion(path)
+	if err != nil || version == "" {
+		version = "unknown"
+	}
+
+	backupDir := filepath.Join(directory, settingsBackupDir)
+	err = os.MkdirAll(backupDir, 0700)
+	if err != nil {
+		return "", fmt.Errorf("error creating settings backup folder %s: %w", shellescape.Quote(backupDir), err)
+	}
+	extension := filepath.Ext(filename)
+	backupName := fmt.Sprintf("%s-%s-%s%s", strings.TrimSuffix(filename, extension), version, time.Now().Format(settingsBackupTimeFormat), extension)
+	backupPath := filepath.Join(backupDir, backupName)
+	err = os.WriteFile(backupPath, configBytes, 0600)
+	if err != nil {
+		return "", fmt.Errorf("error writing settings backup %s: %w", shellescape.Quote(backupPath), err)
+	}
+
+	// Delete the oldest backups
+	backups, err := GetSettingsBackups(directory, filename)
+	if err != nil {
+		return "", err
+	}
+	for i := settingsBackupRetention; i < len(backups); i++ {
+		err = os.Remove(backups[i].Path)
+		if err != nil {
+			return "", fmt.Errorf("error deleting old settings backup %s: %w", shellescape.Quote(backups[i].Path), err)
+		}
+	}
+	return backupPath, nil
+}
+
+// Gets the versioned backups of a settings file, newest first
+func GetSettingsBackups(directory, filename string) ([]SettingsBackup, error) {
+	backupDir := filepath.Join(directory, settingsBackupDir)
+	entries, err := os.ReadDir(backupDir)
+	if os.IsNotExist(err) {
+		return []SettingsBackup{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading settings backup folder %s: %w", shellescape.Quote(backupDir), err)
+	}
+
+	// Backups are named <name>-<version>-<time><extension>
+	extension := filepath.Ext(filename)
+	prefix := strings.TrimSuffix(filename, extension) + "-"
+	backups := []SettingsBackup{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, extension) {
+			continue
+		}
+		tag := strings.TrimSuffix(strings.TrimPrefix(name, prefix), extension)
+		if len(tag) < len(settingsBackupTimeFormat)+2 {
+			continue
+		}
+		timestamp := tag[len(tag)-len(settingsBackupTimeFormat):]
+		backupTime, err := time.ParseInLocation(settingsBackupTimeFormat, timestamp, time.Local)
+		if err != nil {
+			continue
+		}
+		backups = append(backups, SettingsBackup{
+			Name:    name,
+			Path:    filepath.Join(backupDir, name),
+			Version: strings.TrimSuffix(tag[:len(tag)-len(settingsBackupTimeFormat)], "-"),
+			Time:    backupTime,
+		})
+	}
+
+	sort.SliceStable(backups, func(i, j int) bool {
+		return backups[i].Time.After(backups[j].Time)
+	})
+	return backups, nil
+}
+
+// Replaces a settings file with one of its backups, byte for byte. The current settings are backed up first.
+func RestoreSettingsBackup(directory, filename, backupName string) error {
+	if backupName != filepath.Base(backupName) {
+		return fmt.Errorf("%s is not the name of a settings backup", shellescape.Quote(backupName))
+	}
+	backupPath := filepath.Join(directory, settingsBackupDir, backupName)
+	configBytes, err := os.ReadFile(backupPath)
+	if err != nil {
+		return fmt.Errorf("error reading settings backup %s: %w", shellescape.Quote(backupPath), err)
+	}
+
+	_, err = BackupSettingsFile(directory, filename)
+	if err != nil {
+		return err
+	}
+	return writeSettingsFile(configBytes, directory, filename)
+}
+
+// Gets the Smartnode version recorded in a settings file, or an empty string if the file doesn't exist
+func getSettingsFileVersion(path string) (string, error) {
+	configBytes, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("error reading settings file %s: %w", shellescape.Quote(path), err)
+	}
+
+	settings := map[string]map[string]string{}
+	err = yaml.Unmarshal(configBytes, &settings)
+	if err != nil {
+		return "", fmt.Errorf("could not parse settings file %s: %w", shellescape.Quote(path), err)
+	}
+	return settings[rootConfigName]["version"], nil
+}
@@ -0,0 +1,57 @@
+package rp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestBackupSettingsFileRetention(t *testing.T) {
+	directory := t.TempDir()
+	filename := "user-settings.yml"
+	if err := os.WriteFile(filepath.Join(directory, filename), []byte("root:\n  version: v1.13.0\n"), 0664); err != nil {
+		t.Fatal(err)
+	}
+
+	// Add more old backups than are kept
+	backupDir := filepath.Join(directory, settingsBackupDir)
+	if err := os.MkdirAll(backupDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now().Add(-24 * time.Hour)
+	for i := 0; i < settingsBackupRetention+2; i++ {
+		name := fmt.Sprintf("user-settings-v1.12.0-%s.yml", start.Add(time.Duration(i)*time.Minute).Format(settingsBackupTimeFormat))
+		if err := os.WriteFile(filepath.Join(backupDir, name), []byte{}, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	backupPath, err := BackupSettingsFile(directory, filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(backupPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Fatalf("expected the backup to be written with mode 0600, got %o", info.Mode().Perm())
+	}
+
+	backups, err := GetSettingsBackups(directory, filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != settingsBackupRetention {
+		t.Fatalf("expected %d backups to be kept, got %d", settingsBackupRetention, len(backups))
+	}
+	if backups[0].Path != backupPath || backups[0].Version != "v1.13.0" {
+		t.Fatalf("expected the new backup to be the newest, got %s", backups[0].Name)
+	}
+	oldest := start.Add(3 * time.Minute).Format(settingsBackupTimeFormat)
+	if backups[len(backups)-1].Time.Format(settingsBackupTimeFormat) != oldest {
+		t.Fatalf("expected the oldest kept backup to be from %s, got %s", oldest, backups[len(backups)-1].Name)
+	}
+}